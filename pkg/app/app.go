@@ -282,6 +282,12 @@ func (app *App) setupRepo(
 }
 
 func (app *App) Run(startArgs appTypes.StartArgs) error {
+	if app.UserConfig().RemoteControl.Enabled {
+		server := newRemoteControlServer(app.Common)
+		defer server.Close()
+		app.Gui.RemoteControlServer = server
+	}
+
 	err := app.Gui.RunAndHandleError(startArgs)
 	return err
 }
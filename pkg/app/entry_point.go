@@ -35,6 +35,8 @@ type cliArgs struct {
 	GitDir             string
 	CustomConfigFile   string
 	ScreenMode         string
+	Remote             string
+	Rebase             *rebaseCliArgs
 	PrintVersionInfo   bool
 	Debug              bool
 	TailLogs           bool
@@ -141,6 +143,13 @@ func Start(buildInfo *BuildInfo, integrationTest integrationTypes.IntegrationTes
 		log.Fatal(err.Error())
 	}
 
+	if cliArgs.Remote != "" {
+		if err := runRemoteCommand(appConfig.GetUserConfig(), cliArgs.Remote, cliArgs.GitArg, os.Stdout); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	if integrationTest != nil {
 		integrationTest.SetupConfig(appConfig)
 		// Set this to true so that integration tests don't have to explicitly deal with the hunk
@@ -164,6 +173,13 @@ func Start(buildInfo *BuildInfo, integrationTest integrationTypes.IntegrationTes
 		return
 	}
 
+	if cliArgs.Rebase != nil {
+		if err := runRebaseCommand(common, appConfig, cliArgs.Rebase, os.Stdout); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	if cliArgs.Profile {
 		go func() {
 			if err := http.ListenAndServe("localhost:6060", nil); err != nil {
@@ -186,8 +202,15 @@ func parseCliArgsAndEnvVars() *cliArgs {
 	filterPath := ""
 	flaggy.String(&filterPath, "f", "filter", "Path to filter on in `git log -- <path>`. When in filter mode, the commits, reflog, and stash are filtered based on the given path, and some operations are restricted")
 
+	// flaggy doesn't allow a subcommand at the same position as a positional
+	// value, so we only add the rebase subcommand if it's being used
 	gitArg := ""
-	flaggy.AddPositionalValue(&gitArg, "git-arg", 1, false, "Panel to focus upon opening lazygit. Accepted values (based on git terminology): status, branch, log, stash. Ignored if --filter arg is passed.")
+	var rebase *rebaseCliArgs
+	if len(os.Args) > 1 && os.Args[1] == "rebase" {
+		rebase = addRebaseSubcommands()
+	} else {
+		flaggy.AddPositionalValue(&gitArg, "git-arg", 1, false, "Panel to focus upon opening lazygit. Accepted values (based on git terminology): status, branch, log, stash. Ignored if --filter arg is passed. With --remote, the argument of the remote command. Use 'lazygit rebase --help' to see the rebase subcommands.")
+	}
 
 	printVersionInfo := false
 	flaggy.Bool(&printVersionInfo, "v", "version", "Print the current version")
@@ -222,8 +245,15 @@ func parseCliArgsAndEnvVars() *cliArgs {
 	screenMode := ""
 	flaggy.String(&screenMode, "sm", "screen-mode", "The initial screen-mode, which determines the size of the focused panel. Valid options: 'normal' (default), 'half', 'full'")
 
+	remote := ""
+	flaggy.String(&remote, "", "remote", "Send a command to the lazygit running in the current repo, e.g. 'lazygit --remote focusCommit <hash>'. Requires remoteControl.enabled in its config. Commands: "+strings.Join(remoteMethodNames(), ", "))
+
 	flaggy.Parse()
 
+	if rebase != nil {
+		rebase.resolveAction()
+	}
+
 	if os.Getenv("DEBUG") == "TRUE" {
 		debug = true
 	}
@@ -243,6 +273,8 @@ func parseCliArgsAndEnvVars() *cliArgs {
 		GitDir:             gitDir,
 		CustomConfigFile:   customConfigFile,
 		ScreenMode:         screenMode,
+		Remote:             remote,
+		Rebase:             rebase,
 	}
 }
 
@@ -0,0 +1,320 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/integrii/flaggy"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/controllers"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+	"github.com/stefanhaller/git-todo-parser/todo"
+)
+
+// The `lazygit rebase <action> <commits>` subcommands do what the
+// corresponding actions in the commits panel do, but without opening the UI,
+// so that scripts can use them. See docs/Rebase_Commands.md.
+
+type rebaseAction string
+
+const (
+	rebaseActionMoveUp       rebaseAction = "move-up"
+	rebaseActionMoveDown     rebaseAction = "move-down"
+	rebaseActionDrop         rebaseAction = "drop"
+	rebaseActionSquash       rebaseAction = "squash"
+	rebaseActionFixup        rebaseAction = "fixup"
+	rebaseActionEdit         rebaseAction = "edit"
+	rebaseActionAmendTo      rebaseAction = "amend-to"
+	rebaseActionSquashFixups rebaseAction = "squash-fixups"
+)
+
+type rebaseSubcommand struct {
+	action      rebaseAction
+	description string
+	// whether the action can be applied to a range of commits rather than just
+	// a single one
+	takesRange bool
+}
+
+var rebaseSubcommands = []rebaseSubcommand{
+	{rebaseActionMoveUp, "Move the commits up by one, i.e. swap them with the commit after them", true},
+	{rebaseActionMoveDown, "Move the commits down by one, i.e. swap them with the commit before them", true},
+	{rebaseActionDrop, "Drop the commits. A merge commit can only be dropped on its own", true},
+	{rebaseActionSquash, "Squash the commits into the commit before them, combining their messages", true},
+	{rebaseActionFixup, "Squash the commits into the commit before them, discarding their messages", true},
+	{rebaseActionEdit, "Start an interactive rebase that stops at the commits so that you can amend them", true},
+	{rebaseActionAmendTo, "Amend the commit with the staged changes", false},
+	{rebaseActionSquashFixups, "Squash all fixup! commits after the commit into the commits they fix up", false},
+}
+
+type rebaseCliArgs struct {
+	Action      rebaseAction
+	Commits     string
+	KeepMessage bool
+	AllowPushed bool
+
+	subcommands []*flaggy.Subcommand
+}
+
+// addRebaseSubcommands adds `lazygit rebase` and its subcommands to flaggy's
+// parser. Call resolveAction once flaggy has parsed the args.
+func addRebaseSubcommands() *rebaseCliArgs {
+	args := &rebaseCliArgs{}
+
+	rebaseCmd := flaggy.NewSubcommand("rebase")
+	rebaseCmd.Description = "Rewrite the commits of the checked-out branch without opening lazygit"
+
+	for _, subcommand := range rebaseSubcommands {
+		cmd := flaggy.NewSubcommand(string(subcommand.action))
+		cmd.Description = subcommand.description
+		if subcommand.takesRange {
+			cmd.AddPositionalValue(&args.Commits, "commits", 1, true, "A commit, or a range of commits like HEAD~3..HEAD~1")
+		} else {
+			cmd.AddPositionalValue(&args.Commits, "commit", 1, true, "A commit, e.g. HEAD~2")
+		}
+		cmd.Bool(&args.AllowPushed, "", "allow-pushed", "Rewrite the commits even if they have already been pushed or are on a main branch")
+		if subcommand.action == rebaseActionFixup {
+			cmd.Bool(&args.KeepMessage, "", "keep-message", "Use the message of the fixed-up commit rather than the one of the commit before it. Only works with a single commit")
+		}
+
+		rebaseCmd.AttachSubcommand(cmd, 1)
+		args.subcommands = append(args.subcommands, cmd)
+	}
+
+	flaggy.AttachSubcommand(rebaseCmd, 1)
+
+	return args
+}
+
+func (self *rebaseCliArgs) resolveAction() {
+	for i, cmd := range self.subcommands {
+		if cmd.Used {
+			self.Action = rebaseSubcommands[i].action
+			return
+		}
+	}
+
+	flaggy.ShowHelpAndExit("Missing rebase action. Run 'lazygit rebase --help' to see them")
+}
+
+func runRebaseCommand(common *common.Common, appConfig config.AppConfigurer, args *rebaseCliArgs, out io.Writer) error {
+	osCommand := oscommands.NewOSCommand(common, appConfig, oscommands.GetPlatform(), oscommands.NewNullGuiIO(common.Log))
+	gitVersion, err := git_commands.GetGitVersion(osCommand)
+	if err != nil {
+		return err
+	}
+
+	git, err := commands.NewGitCommand(
+		common,
+		gitVersion,
+		osCommand,
+		git_config.NewStdCachedGitConfig(common.Log),
+		config.NewPagerConfig(common.UserConfig),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Unlike the commits panel, we don't support editing the todos of a rebase
+	// that's in progress
+	if git.Status.WorkingTreeState().Any() {
+		return errors.New(common.Tr.AlreadyRebasing)
+	}
+
+	// Like the commits panel, we take the pushed status from the checked-out
+	// branch's upstream
+	var refForPushedStatus models.Ref
+	if branchName, err := git.Branch.CurrentBranchName(); err == nil && branchName != "" {
+		refForPushedStatus = &models.Branch{Name: branchName}
+	}
+
+	commits, err := git.Loaders.CommitLoader.GetCommits(git_commands.GetCommitsOptions{
+		Limit:              true,
+		RefName:            "HEAD",
+		RefForPushedStatus: refForPushedStatus,
+		MainBranches:       git_commands.NewMainBranches(common, osCommand.Cmd),
+		HashPool:           &utils.StringPool{},
+	})
+	if err != nil {
+		return err
+	}
+
+	hashes, err := resolveRevisionRange(osCommand.Cmd, args.Commits)
+	if err != nil {
+		return err
+	}
+
+	startIdx, endIdx, err := findCommitRange(commits, hashes)
+	if err != nil {
+		return err
+	}
+
+	if err := validateRebaseAction(common.Tr, args, commits, startIdx, endIdx); err != nil {
+		return err
+	}
+
+	// If there are conflicts, git's error already explains how to continue
+	if err := performRebaseAction(git, args, commits, startIdx, endIdx); err != nil {
+		return err
+	}
+
+	if args.Action == rebaseActionEdit {
+		fmt.Fprintln(out, "Stopped for editing. Run 'git rebase --continue' when you're done")
+	}
+	return nil
+}
+
+// resolveRevisionRange returns the hashes of the commits in the given
+// revision, which is either a single commit or a range like A..B
+func resolveRevisionRange(cmd oscommands.ICmdObjBuilder, revision string) ([]string, error) {
+	var cmdArgs []string
+	if strings.Contains(revision, "..") {
+		cmdArgs = git_commands.NewGitCmd("rev-list").Arg(revision, "--").ToArgv()
+	} else {
+		cmdArgs = git_commands.NewGitCmd("rev-parse").Arg("--verify", "--end-of-options", revision+"^{commit}").ToArgv()
+	}
+
+	output, err := cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := strings.Fields(output)
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("%s doesn't contain any commits", revision)
+	}
+	return hashes, nil
+}
+
+// findCommitRange returns the indices of the first and last of the given
+// commits in the commit list, like a range selection in the commits panel.
+func findCommitRange(commits []*models.Commit, hashes []string) (int, int, error) {
+	indices := make([]int, 0, len(hashes))
+	for _, hash := range hashes {
+		_, idx, found := lo.FindIndexOf(commits, func(commit *models.Commit) bool {
+			return commit.Hash() == hash
+		})
+		if !found {
+			return 0, 0, fmt.Errorf("commit %s is not one of the last %d commits of the checked-out branch", utils.ShortHash(hash), len(commits))
+		}
+		indices = append(indices, idx)
+	}
+
+	startIdx, endIdx := lo.Min(indices), lo.Max(indices)
+	if endIdx-startIdx+1 != len(indices) {
+		return 0, 0, errors.New("the commits must be consecutive, but there are other commits between them")
+	}
+	return startIdx, endIdx, nil
+}
+
+// validateRebaseAction does the same checks as the commits panel does before
+// enabling the corresponding action. Unlike in the commits panel, there's
+// nobody to confirm anything, so on top of that we refuse to rewrite commits
+// that were pushed or are on a main branch unless asked to.
+func validateRebaseAction(tr *i18n.TranslationSet, args *rebaseCliArgs, commits []*models.Commit, startIdx int, endIdx int) error {
+	selectedCommits := commits[startIdx : endIdx+1]
+
+	if !lo.ContainsBy(rebaseSubcommands, func(s rebaseSubcommand) bool { return s.action == args.Action && s.takesRange }) &&
+		startIdx != endIdx {
+		return fmt.Errorf("%s only works with a single commit", args.Action)
+	}
+
+	// the oldest commit that the action rewrites
+	oldestIdx := endIdx
+
+	var disabledReason *types.DisabledReason
+	switch args.Action {
+	case rebaseActionMoveUp:
+		disabledReason = firstDisabledReason(
+			controllers.CanMoveCommits(tr, selectedCommits),
+			controllers.CanMoveCommitsUp(tr, commits, startIdx, endIdx),
+		)
+	case rebaseActionMoveDown:
+		disabledReason = firstDisabledReason(
+			controllers.CanMoveCommits(tr, selectedCommits),
+			controllers.CanMoveCommitsDown(tr, commits, startIdx, endIdx),
+		)
+		oldestIdx++
+	case rebaseActionDrop:
+		disabledReason = controllers.CanDropCommits(tr, selectedCommits)
+	case rebaseActionSquash, rebaseActionFixup:
+		disabledReason = controllers.CanSquashOrFixupCommits(tr, commits, startIdx, endIdx)
+		if args.KeepMessage && startIdx != endIdx {
+			return errors.New("--keep-message only works with a single commit")
+		}
+		oldestIdx++
+	case rebaseActionAmendTo:
+		if selectedCommits[0].IsMerge() {
+			return errors.New("cannot amend a merge commit")
+		}
+	}
+	if disabledReason != nil {
+		return errors.New(disabledReason.Text)
+	}
+
+	oldestCommit := commits[oldestIdx]
+	if !args.AllowPushed {
+		switch oldestCommit.Status {
+		case models.StatusPushed:
+			return fmt.Errorf("commit %s has already been pushed. Pass --allow-pushed to rewrite it anyway", utils.ShortHash(oldestCommit.Hash()))
+		case models.StatusMerged:
+			return fmt.Errorf("commit %s is already on a main branch. Pass --allow-pushed to rewrite it anyway", utils.ShortHash(oldestCommit.Hash()))
+		}
+	}
+
+	return nil
+}
+
+func firstDisabledReason(reasons ...*types.DisabledReason) *types.DisabledReason {
+	return lo.FindOrElse(reasons, nil, func(reason *types.DisabledReason) bool { return reason != nil })
+}
+
+func performRebaseAction(git *commands.GitCommand, args *rebaseCliArgs, commits []*models.Commit, startIdx int, endIdx int) error {
+	switch args.Action {
+	case rebaseActionMoveUp:
+		return git.Rebase.MoveCommitsUp(commits, startIdx, endIdx)
+	case rebaseActionMoveDown:
+		return git.Rebase.MoveCommitsDown(commits, startIdx, endIdx)
+	case rebaseActionDrop:
+		if commits[startIdx].IsMerge() {
+			return git.Rebase.DropMergeCommit(commits, startIdx)
+		}
+		return git.Rebase.InteractiveRebase(commits, startIdx, endIdx, todo.Drop, "")
+	case rebaseActionSquash:
+		return git.Rebase.InteractiveRebase(commits, startIdx, endIdx, todo.Squash, "")
+	case rebaseActionFixup:
+		return git.Rebase.InteractiveRebase(commits, startIdx, endIdx, todo.Fixup, lo.Ternary(args.KeepMessage, "-C", ""))
+	case rebaseActionEdit:
+		if !commits[endIdx].IsMerge() {
+			return git.Rebase.InteractiveRebase(commits, startIdx, endIdx, todo.Edit, "")
+		}
+
+		// Merge commits can't be set to "edit", so like the commits panel we
+		// stop before the oldest one and then set the others to "edit"
+		if err := git.Rebase.EditRebase(commits[endIdx].Hash()); err != nil {
+			return err
+		}
+		todos := lo.Filter(commits[startIdx:endIdx], func(commit *models.Commit, _ int) bool { return !commit.IsMerge() })
+		if len(todos) == 0 {
+			return nil
+		}
+		return git.Rebase.EditRebaseTodo(todos, todo.Edit, "")
+	case rebaseActionAmendTo:
+		return git.Rebase.AmendTo(commits, startIdx)
+	case rebaseActionSquashFixups:
+		return git.Rebase.SquashAllAboveFixupCommits(commits[startIdx])
+	}
+
+	return fmt.Errorf("unknown rebase action: %s", args.Action)
+}
@@ -0,0 +1,252 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCommitRange(t *testing.T) {
+	hashPool := &utils.StringPool{}
+	commits := []*models.Commit{
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "aaa"}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "bbb"}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "ccc"}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "ddd"}),
+	}
+
+	scenarios := []struct {
+		name          string
+		hashes        []string
+		expectedStart int
+		expectedEnd   int
+		expectedErr   string
+	}{
+		{
+			name:          "single commit",
+			hashes:        []string{"ccc"},
+			expectedStart: 2,
+			expectedEnd:   2,
+		},
+		{
+			name:          "range in log order",
+			hashes:        []string{"bbb", "ccc", "ddd"},
+			expectedStart: 1,
+			expectedEnd:   3,
+		},
+		{
+			name:          "range in a different order",
+			hashes:        []string{"bbb", "aaa"},
+			expectedStart: 0,
+			expectedEnd:   1,
+		},
+		{
+			name:        "commits with a gap",
+			hashes:      []string{"aaa", "ccc"},
+			expectedErr: "the commits must be consecutive, but there are other commits between them",
+		},
+		{
+			name:        "commit that isn't loaded",
+			hashes:      []string{"eee"},
+			expectedErr: "commit eee is not one of the last 4 commits of the checked-out branch",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			startIdx, endIdx, err := findCommitRange(commits, s.hashes)
+			if s.expectedErr != "" {
+				assert.EqualError(t, err, s.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedStart, startIdx)
+			assert.Equal(t, s.expectedEnd, endIdx)
+		})
+	}
+}
+
+func TestValidateRebaseAction(t *testing.T) {
+	tr := i18n.EnglishTranslationSet()
+	hashPool := &utils.StringPool{}
+	commits := []*models.Commit{
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "aaa", Parents: []string{"bbb"}}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "bbb", Parents: []string{"ccc"}}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "ccc", Parents: []string{"ddd", "xxx"}}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "ddd"}),
+	}
+
+	scenarios := []struct {
+		name        string
+		args        rebaseCliArgs
+		startIdx    int
+		endIdx      int
+		expectedErr string
+	}{
+		{
+			name:     "move down",
+			args:     rebaseCliArgs{Action: rebaseActionMoveDown},
+			startIdx: 0,
+			endIdx:   1,
+		},
+		{
+			name:        "move up the newest commit",
+			args:        rebaseCliArgs{Action: rebaseActionMoveUp},
+			startIdx:    0,
+			endIdx:      0,
+			expectedErr: tr.CannotMoveAnyFurther,
+		},
+		{
+			name:        "move down the oldest commit",
+			args:        rebaseCliArgs{Action: rebaseActionMoveDown},
+			startIdx:    3,
+			endIdx:      3,
+			expectedErr: tr.CannotMoveAnyFurther,
+		},
+		{
+			name:        "move a merge commit",
+			args:        rebaseCliArgs{Action: rebaseActionMoveUp},
+			startIdx:    1,
+			endIdx:      2,
+			expectedErr: tr.CannotMoveMergeCommit,
+		},
+		{
+			name:     "drop a merge commit on its own",
+			args:     rebaseCliArgs{Action: rebaseActionDrop},
+			startIdx: 2,
+			endIdx:   2,
+		},
+		{
+			name:        "drop a merge commit with others",
+			args:        rebaseCliArgs{Action: rebaseActionDrop},
+			startIdx:    1,
+			endIdx:      2,
+			expectedErr: tr.DroppingMergeRequiresSingleSelection,
+		},
+		{
+			name:        "squash the oldest commit",
+			args:        rebaseCliArgs{Action: rebaseActionSquash},
+			startIdx:    3,
+			endIdx:      3,
+			expectedErr: tr.CannotSquashOrFixupFirstCommit,
+		},
+		{
+			name:        "fixup a merge commit",
+			args:        rebaseCliArgs{Action: rebaseActionFixup},
+			startIdx:    2,
+			endIdx:      2,
+			expectedErr: tr.CannotSquashOrFixupMergeCommit,
+		},
+		{
+			name:     "fixup keeping the message",
+			args:     rebaseCliArgs{Action: rebaseActionFixup, KeepMessage: true},
+			startIdx: 0,
+			endIdx:   0,
+		},
+		{
+			name:        "fixup a range keeping the message",
+			args:        rebaseCliArgs{Action: rebaseActionFixup, KeepMessage: true},
+			startIdx:    0,
+			endIdx:      1,
+			expectedErr: "--keep-message only works with a single commit",
+		},
+		{
+			name:        "amend to a range",
+			args:        rebaseCliArgs{Action: rebaseActionAmendTo},
+			startIdx:    0,
+			endIdx:      1,
+			expectedErr: "amend-to only works with a single commit",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := validateRebaseAction(tr, &s.args, commits, s.startIdx, s.endIdx)
+			if s.expectedErr != "" {
+				assert.EqualError(t, err, s.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRebaseActionOnPushedCommits(t *testing.T) {
+	tr := i18n.EnglishTranslationSet()
+	hashPool := &utils.StringPool{}
+	commits := []*models.Commit{
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "aaa", Status: models.StatusUnpushed}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "bbb", Status: models.StatusPushed}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "ccc", Status: models.StatusMerged}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "ddd", Status: models.StatusMerged}),
+	}
+
+	scenarios := []struct {
+		name        string
+		args        rebaseCliArgs
+		startIdx    int
+		endIdx      int
+		expectedErr string
+	}{
+		{
+			name:     "drop an unpushed commit",
+			args:     rebaseCliArgs{Action: rebaseActionDrop},
+			startIdx: 0,
+			endIdx:   0,
+		},
+		{
+			name:        "squash an unpushed commit into a pushed one",
+			args:        rebaseCliArgs{Action: rebaseActionSquash},
+			startIdx:    0,
+			endIdx:      0,
+			expectedErr: "commit bbb has already been pushed. Pass --allow-pushed to rewrite it anyway",
+		},
+		{
+			name:     "squash an unpushed commit into a pushed one when allowed",
+			args:     rebaseCliArgs{Action: rebaseActionSquash, AllowPushed: true},
+			startIdx: 0,
+			endIdx:   0,
+		},
+		{
+			name:        "edit a pushed commit",
+			args:        rebaseCliArgs{Action: rebaseActionEdit},
+			startIdx:    0,
+			endIdx:      1,
+			expectedErr: "commit bbb has already been pushed. Pass --allow-pushed to rewrite it anyway",
+		},
+		{
+			name:        "move down a pushed commit past a commit on the main branch",
+			args:        rebaseCliArgs{Action: rebaseActionMoveDown},
+			startIdx:    1,
+			endIdx:      1,
+			expectedErr: "commit ccc is already on a main branch. Pass --allow-pushed to rewrite it anyway",
+		},
+		{
+			name:        "amend a commit on the main branch",
+			args:        rebaseCliArgs{Action: rebaseActionAmendTo},
+			startIdx:    3,
+			endIdx:      3,
+			expectedErr: "commit ddd is already on a main branch. Pass --allow-pushed to rewrite it anyway",
+		},
+		{
+			name:     "amend a commit on the main branch when allowed",
+			args:     rebaseCliArgs{Action: rebaseActionAmendTo, AllowPushed: true},
+			startIdx: 3,
+			endIdx:   3,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := validateRebaseAction(tr, &s.args, commits, s.startIdx, s.endIdx)
+			if s.expectedErr != "" {
+				assert.EqualError(t, err, s.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
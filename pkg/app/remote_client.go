@@ -0,0 +1,84 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui"
+)
+
+// runRemoteCommand sends a single request to the remote-control server of a
+// running lazygit, and prints the result, if there is one.
+func runRemoteCommand(userConfig *config.UserConfig, methodName string, arg string, out io.Writer) error {
+	method, ok := remoteMethods[methodName]
+	if !ok {
+		return fmt.Errorf("unknown remote command '%s'. Must be one of: %s", methodName, strings.Join(remoteMethodNames(), ", "))
+	}
+
+	request := rpcRequest{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: methodName}
+	if method.param != "" {
+		if arg == "" {
+			return fmt.Errorf("%s needs an argument: the %s", methodName, method.param)
+		}
+		params, err := json.Marshal(map[string]string{method.param: arg})
+		if err != nil {
+			return err
+		}
+		request.Params = params
+	} else if arg != "" {
+		return fmt.Errorf("%s doesn't take an argument", methodName)
+	}
+
+	socketPath, err := remoteSocketPath(userConfig)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("unix", socketPath, 5*time.Second)
+	if err != nil {
+		return fmt.Errorf("could not connect to lazygit at %s. Is it running, with remoteControl.enabled set? (%w)", socketPath, err)
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(request); err != nil {
+		return err
+	}
+
+	var response rpcResponse
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+
+	if string(response.Result) != "null" {
+		fmt.Fprintln(out, string(response.Result))
+	}
+	return nil
+}
+
+func remoteSocketPath(userConfig *config.UserConfig) (string, error) {
+	// set when we're run from within lazygit, e.g. by a custom command
+	if socketPath := os.Getenv(gui.REMOTE_SOCKET_ENV_VAR); socketPath != "" {
+		return socketPath, nil
+	}
+
+	if userConfig.RemoteControl.SocketPath != "" {
+		return userConfig.RemoteControl.SocketPath, nil
+	}
+
+	output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", errors.New("not in a git repo, so there's no lazygit to send the command to")
+	}
+	return defaultRemoteSocketPath(strings.TrimSpace(string(output))), nil
+}
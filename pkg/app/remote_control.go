@@ -0,0 +1,366 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/gui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	// the request was fine, but lazygit couldn't do what was asked
+	rpcRequestFailed = -32000
+)
+
+// What the remote-control server needs from the gui; implemented by
+// gui.RemoteDriver. The context is cancelled when the client goes away or
+// lazygit exits, in which case we stop waiting for the request to finish.
+type remoteDriver interface {
+	Refresh(ctx context.Context) error
+	FocusCommit(ctx context.Context, hash string) error
+	OpenFileInStaging(ctx context.Context, path string) error
+	RunCustomCommand(ctx context.Context, description string) error
+	PressKey(ctx context.Context, key string) error
+	State(ctx context.Context) (gui.RemoteState, error)
+}
+
+type remoteMethod struct {
+	// the name of the method's only parameter, if it has one
+	param string
+	call  func(ctx context.Context, driver remoteDriver, arg string) (any, error)
+}
+
+var remoteMethods = map[string]remoteMethod{
+	"refresh": {
+		call: func(ctx context.Context, driver remoteDriver, _ string) (any, error) {
+			return nil, driver.Refresh(ctx)
+		},
+	},
+	"focusCommit": {
+		param: "hash",
+		call: func(ctx context.Context, driver remoteDriver, hash string) (any, error) {
+			return nil, driver.FocusCommit(ctx, hash)
+		},
+	},
+	"openFileInStaging": {
+		param: "path",
+		call: func(ctx context.Context, driver remoteDriver, path string) (any, error) {
+			return nil, driver.OpenFileInStaging(ctx, path)
+		},
+	},
+	"runCustomCommand": {
+		param: "description",
+		call: func(ctx context.Context, driver remoteDriver, description string) (any, error) {
+			return nil, driver.RunCustomCommand(ctx, description)
+		},
+	},
+	"pressKey": {
+		param: "key",
+		call: func(ctx context.Context, driver remoteDriver, key string) (any, error) {
+			return nil, driver.PressKey(ctx, key)
+		},
+	},
+	"getState": {
+		call: func(ctx context.Context, driver remoteDriver, _ string) (any, error) {
+			return driver.State(ctx)
+		},
+	},
+}
+
+func remoteMethodNames() []string {
+	names := lo.Keys(remoteMethods)
+	slices.Sort(names)
+	return names
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	// present (though possibly null) on success, absent on error
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (self *rpcError) Error() string {
+	return self.Message
+}
+
+type remoteControlServer struct {
+	*common.Common
+	// from the user config; if empty, each repo gets its own socket
+	socketPath string
+
+	// cancelled when we're closed, to stop waiting for requests in flight
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mutex       sync.Mutex
+	listener    net.Listener
+	listeningOn string
+	closed      bool
+}
+
+var _ gui.RemoteControlServer = &remoteControlServer{}
+
+func newRemoteControlServer(common *common.Common) *remoteControlServer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &remoteControlServer{
+		Common:     common,
+		socketPath: common.UserConfig().RemoteControl.SocketPath,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+func (self *remoteControlServer) Listen(driver *gui.RemoteDriver, worktreePath string) (string, error) {
+	return self.listen(driver, worktreePath)
+}
+
+func (self *remoteControlServer) listen(driver remoteDriver, worktreePath string) (string, error) {
+	socketPath := self.socketPath
+	if socketPath == "" {
+		socketPath = defaultRemoteSocketPath(worktreePath)
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.closed {
+		return "", errors.New("the remote control server has been closed")
+	}
+	if self.listener != nil && self.listeningOn == socketPath {
+		return socketPath, nil
+	}
+
+	// Closing the old listener removes its socket, so that nobody mistakes us
+	// for the lazygit of the repo we've switched away from
+	if self.listener != nil {
+		_ = self.listener.Close()
+		self.listener = nil
+	}
+
+	listener, err := listenOnSocket(socketPath)
+	if err != nil {
+		return "", err
+	}
+	self.listener = listener
+	self.listeningOn = socketPath
+	self.Log.Infof("Remote control server listening on %s", socketPath)
+
+	go utils.Safe(func() { self.serve(driver, listener) })
+
+	return socketPath, nil
+}
+
+func (self *remoteControlServer) serve(driver remoteDriver, listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// we've been closed, or have moved on to another socket
+			return
+		}
+		go utils.Safe(func() { self.handleConnection(driver, conn) })
+	}
+}
+
+// Close stops listening and removes the socket.
+func (self *remoteControlServer) Close() error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.closed = true
+	self.cancel()
+	if self.listener == nil {
+		return nil
+	}
+	return self.listener.Close()
+}
+
+func listenOnSocket(socketPath string) (net.Listener, error) {
+	// Anyone who can connect can run the user's custom commands. Rather than
+	// restricting the socket itself, which would leave a window between
+	// creating it and changing its permissions, we only create it in a
+	// directory that nobody else can get into.
+	if err := ensurePrivateDir(filepath.Dir(socketPath)); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err == nil {
+		return listener, nil
+	}
+
+	// The socket may have been left behind by a lazygit that didn't exit
+	// cleanly. If nobody is listening on it anymore, we can take it over.
+	conn, dialErr := net.Dial("unix", socketPath)
+	if dialErr == nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("another lazygit is already listening on %s", socketPath)
+	}
+	if removeErr := os.Remove(socketPath); removeErr != nil {
+		return nil, err
+	}
+	return net.Listen("unix", socketPath)
+}
+
+func ensurePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	// Windows doesn't have Unix permissions
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return fmt.Errorf("%s can be accessed by other users, so it's not safe to put the remote control socket there", dir)
+	}
+	return nil
+}
+
+type decodedRequest struct {
+	request rpcRequest
+	err     error
+}
+
+// Requests and responses are JSON-RPC 2.0 objects, one per line.
+func (self *remoteControlServer) handleConnection(driver remoteDriver, conn io.ReadWriteCloser) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(self.ctx)
+	defer cancel()
+
+	// We keep reading while a request is being handled, so that we notice when
+	// the client hangs up and can stop waiting for the request on its behalf
+	requests := make(chan decodedRequest)
+	go utils.Safe(func() {
+		defer close(requests)
+
+		decoder := json.NewDecoder(conn)
+		for {
+			var request rpcRequest
+			err := decoder.Decode(&request)
+			if errors.Is(err, io.EOF) {
+				cancel()
+				return
+			}
+
+			select {
+			case requests <- decodedRequest{request: request, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	})
+
+	encoder := json.NewEncoder(conn)
+	for decoded := range requests {
+		if decoded.err != nil {
+			_ = encoder.Encode(rpcResponse{
+				JSONRPC: "2.0",
+				ID:      json.RawMessage("null"),
+				Error:   &rpcError{Code: rpcParseError, Message: decoded.err.Error()},
+			})
+			return
+		}
+
+		request := decoded.request
+		result, err := handleRemoteRequest(ctx, driver, &request)
+		if request.ID == nil {
+			// a notification; the client doesn't want a response
+			continue
+		}
+
+		response := rpcResponse{JSONRPC: "2.0", ID: request.ID}
+		if err == nil {
+			response.Result, err = json.Marshal(result)
+		}
+		if err != nil {
+			var rpcErr *rpcError
+			if !errors.As(err, &rpcErr) {
+				rpcErr = &rpcError{Code: rpcRequestFailed, Message: err.Error()}
+			}
+			response.Error = rpcErr
+		}
+		if err := encoder.Encode(response); err != nil {
+			return
+		}
+	}
+}
+
+func handleRemoteRequest(ctx context.Context, driver remoteDriver, request *rpcRequest) (any, error) {
+	if request.JSONRPC != "2.0" {
+		return nil, &rpcError{Code: rpcInvalidRequest, Message: `"jsonrpc" must be "2.0"`}
+	}
+
+	method, ok := remoteMethods[request.Method]
+	if !ok {
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: "unknown method: " + request.Method}
+	}
+
+	arg := ""
+	if method.param != "" {
+		var params map[string]string
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			return nil, &rpcError{
+				Code:    rpcInvalidParams,
+				Message: fmt.Sprintf("%s takes an object with a %q string", request.Method, method.param),
+			}
+		}
+		arg = params[method.param]
+		if arg == "" {
+			return nil, &rpcError{
+				Code:    rpcInvalidParams,
+				Message: fmt.Sprintf("%s requires the %q parameter", request.Method, method.param),
+			}
+		}
+	}
+
+	return method.call(ctx, driver, arg)
+}
+
+// Each repo gets its own socket, so that `lazygit --remote` run from inside a
+// repo talks to the lazygit that's open in that repo.
+func defaultRemoteSocketPath(worktreePath string) string {
+	hash := sha256.Sum256([]byte(worktreePath))
+	return filepath.Join(getTempDirBase(), "remote", hex.EncodeToString(hash[:8])+".sock")
+}
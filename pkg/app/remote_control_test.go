@@ -0,0 +1,275 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRemoteDriver struct {
+	calls []string
+}
+
+func (self *fakeRemoteDriver) Refresh(ctx context.Context) error {
+	self.calls = append(self.calls, "refresh")
+	return nil
+}
+
+func (self *fakeRemoteDriver) FocusCommit(ctx context.Context, hash string) error {
+	if hash == "unknown" {
+		return errors.New("commit unknown is not in the commits view")
+	}
+	self.calls = append(self.calls, "focusCommit "+hash)
+	return nil
+}
+
+func (self *fakeRemoteDriver) OpenFileInStaging(ctx context.Context, path string) error {
+	self.calls = append(self.calls, "openFileInStaging "+path)
+	return nil
+}
+
+func (self *fakeRemoteDriver) RunCustomCommand(ctx context.Context, description string) error {
+	self.calls = append(self.calls, "runCustomCommand "+description)
+	return nil
+}
+
+func (self *fakeRemoteDriver) PressKey(ctx context.Context, key string) error {
+	self.calls = append(self.calls, "pressKey "+key)
+	return nil
+}
+
+func (self *fakeRemoteDriver) State(ctx context.Context) (gui.RemoteState, error) {
+	return gui.RemoteState{RepoPath: "/repo", CheckedOutBranch: "main", CurrentContext: "files"}, nil
+}
+
+func startRemoteControlServer(t *testing.T, driver remoteDriver) string {
+	t.Helper()
+
+	// the server sets this, and the client prefers it to the configured path
+	t.Setenv(gui.REMOTE_SOCKET_ENV_VAR, "")
+
+	server := newRemoteControlServer(common.NewDummyCommon())
+	server.socketPath = filepath.Join(t.TempDir(), "sockets", "lazygit.sock")
+	t.Cleanup(func() { _ = server.Close() })
+
+	socketPath, err := server.listen(driver, "/repo")
+	require.NoError(t, err)
+
+	return socketPath
+}
+
+func TestRunRemoteCommand(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		method         string
+		arg            string
+		expectedCalls  []string
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:          "refresh",
+			method:        "refresh",
+			expectedCalls: []string{"refresh"},
+		},
+		{
+			name:          "method with an argument",
+			method:        "focusCommit",
+			arg:           "abc123",
+			expectedCalls: []string{"focusCommit abc123"},
+		},
+		{
+			name:           "method with a result",
+			method:         "getState",
+			expectedOutput: `{"repoPath":"/repo","checkedOutBranch":"main","currentContext":"files"}` + "\n",
+		},
+		{
+			name:        "failing request",
+			method:      "focusCommit",
+			arg:         "unknown",
+			expectedErr: "commit unknown is not in the commits view",
+		},
+		{
+			name:        "unknown method",
+			method:      "fly",
+			expectedErr: "unknown remote command 'fly'. Must be one of: focusCommit, getState, openFileInStaging, pressKey, refresh, runCustomCommand",
+		},
+		{
+			name:        "missing argument",
+			method:      "openFileInStaging",
+			expectedErr: "openFileInStaging needs an argument: the path",
+		},
+		{
+			name:        "unexpected argument",
+			method:      "refresh",
+			arg:         "now",
+			expectedErr: "refresh doesn't take an argument",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			driver := &fakeRemoteDriver{}
+			socketPath := startRemoteControlServer(t, driver)
+
+			userConfig := config.GetDefaultConfig()
+			userConfig.RemoteControl.SocketPath = socketPath
+
+			out := &bytes.Buffer{}
+			err := runRemoteCommand(userConfig, s.method, s.arg, out)
+			if s.expectedErr != "" {
+				assert.EqualError(t, err, s.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, s.expectedCalls, driver.calls)
+			assert.Equal(t, s.expectedOutput, out.String())
+		})
+	}
+}
+
+func TestRemoteControlProtocol(t *testing.T) {
+	driver := &fakeRemoteDriver{}
+	socketPath := startRemoteControlServer(t, driver)
+
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	scenarios := []struct {
+		request          string
+		expectedResponse string
+	}{
+		{
+			`{"jsonrpc":"2.0","id":1,"method":"pressKey","params":{"key":"<c-r>"}}`,
+			`{"jsonrpc":"2.0","id":1,"result":null}`,
+		},
+		{
+			`{"jsonrpc":"2.0","id":"a","method":"getState"}`,
+			`{"jsonrpc":"2.0","id":"a","result":{"repoPath":"/repo","checkedOutBranch":"main","currentContext":"files"}}`,
+		},
+		{
+			`{"jsonrpc":"2.0","id":2,"method":"fly"}`,
+			`{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"unknown method: fly"}}`,
+		},
+		{
+			`{"jsonrpc":"2.0","id":3,"method":"focusCommit","params":["abc"]}`,
+			`{"jsonrpc":"2.0","id":3,"error":{"code":-32602,"message":"focusCommit takes an object with a \"hash\" string"}}`,
+		},
+		{
+			`{"jsonrpc":"2.0","id":4,"method":"focusCommit","params":{}}`,
+			`{"jsonrpc":"2.0","id":4,"error":{"code":-32602,"message":"focusCommit requires the \"hash\" parameter"}}`,
+		},
+		{
+			`{"id":5,"method":"refresh"}`,
+			`{"jsonrpc":"2.0","id":5,"error":{"code":-32600,"message":"\"jsonrpc\" must be \"2.0\""}}`,
+		},
+		{
+			`{"jsonrpc":"2.0","id":6,"method":"focusCommit","params":{"hash":"unknown"}}`,
+			`{"jsonrpc":"2.0","id":6,"error":{"code":-32000,"message":"commit unknown is not in the commits view"}}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		_, err := conn.Write([]byte(s.request + "\n"))
+		require.NoError(t, err)
+		response, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, s.expectedResponse+"\n", response, s.request)
+	}
+
+	// notifications (requests without an id) don't get a response, so the
+	// next response we read is for the request after it
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","method":"runCustomCommand","params":{"description":"Deploy"}}` + "\n" +
+		`{"jsonrpc":"2.0","id":7,"method":"refresh"}` + "\n"))
+	require.NoError(t, err)
+	response, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, `{"jsonrpc":"2.0","id":7,"result":null}`+"\n", response)
+
+	assert.Equal(t, []string{"pressKey <c-r>", "runCustomCommand Deploy", "refresh"}, driver.calls)
+}
+
+// blocks in RunCustomCommand until the request is cancelled
+type blockingRemoteDriver struct {
+	fakeRemoteDriver
+	started   chan struct{}
+	cancelled chan struct{}
+}
+
+func (self *blockingRemoteDriver) RunCustomCommand(ctx context.Context, description string) error {
+	close(self.started)
+	<-ctx.Done()
+	close(self.cancelled)
+	return ctx.Err()
+}
+
+func TestRemoteControlCancelsRequestWhenClientHangsUp(t *testing.T) {
+	driver := &blockingRemoteDriver{started: make(chan struct{}), cancelled: make(chan struct{})}
+	socketPath := startRemoteControlServer(t, driver)
+
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"runCustomCommand","params":{"description":"Deploy"}}` + "\n"))
+	require.NoError(t, err)
+
+	<-driver.started
+	require.NoError(t, conn.Close())
+
+	select {
+	case <-driver.cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request wasn't cancelled")
+	}
+}
+
+func TestRemoteControlMovesSocketWhenSwitchingRepos(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	server := newRemoteControlServer(common.NewDummyCommon())
+	server.socketPath = ""
+	t.Cleanup(func() { _ = server.Close() })
+
+	firstSocketPath, err := server.listen(&fakeRemoteDriver{}, "/first-repo")
+	require.NoError(t, err)
+	assert.Equal(t, defaultRemoteSocketPath("/first-repo"), firstSocketPath)
+
+	secondSocketPath, err := server.listen(&fakeRemoteDriver{}, "/second-repo")
+	require.NoError(t, err)
+	assert.Equal(t, defaultRemoteSocketPath("/second-repo"), secondSocketPath)
+
+	assert.NoFileExists(t, firstSocketPath)
+	conn, err := net.Dial("unix", secondSocketPath)
+	require.NoError(t, err)
+	_ = conn.Close()
+
+	info, err := os.Stat(filepath.Dir(secondSocketPath))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+}
+
+func TestRemoteControlRefusesSharedDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0o755))
+
+	server := newRemoteControlServer(common.NewDummyCommon())
+	server.socketPath = filepath.Join(dir, "lazygit.sock")
+	t.Cleanup(func() { _ = server.Close() })
+
+	_, err := server.listen(&fakeRemoteDriver{}, "/repo")
+	assert.ErrorContains(t, err, "can be accessed by other users")
+	assert.NoFileExists(t, server.socketPath)
+}
@@ -99,36 +99,39 @@ func writeString(file *os.File, str string) {
 
 func localisedTitle(tr *i18n.TranslationSet, str string) string {
 	contextTitleMap := map[string]string{
-		"global":            tr.GlobalTitle,
-		"navigation":        tr.NavigationTitle,
-		"branches":          tr.BranchesTitle,
-		"localBranches":     tr.LocalBranchesTitle,
-		"files":             tr.FilesTitle,
-		"status":            tr.StatusTitle,
-		"submodules":        tr.SubmodulesTitle,
-		"subCommits":        tr.SubCommitsTitle,
-		"remoteBranches":    tr.RemoteBranchesTitle,
-		"remotes":           tr.RemotesTitle,
-		"reflogCommits":     tr.ReflogCommitsTitle,
-		"tags":              tr.TagsTitle,
-		"commitFiles":       tr.CommitFilesTitle,
-		"commitMessage":     tr.CommitSummaryTitle,
-		"commitDescription": tr.CommitDescriptionTitle,
-		"commits":           tr.CommitsTitle,
-		"confirmation":      tr.ConfirmationTitle,
-		"prompt":            tr.PromptTitle,
-		"information":       tr.InformationTitle,
-		"main":              tr.NormalTitle,
-		"patchBuilding":     tr.PatchBuildingTitle,
-		"mergeConflicts":    tr.MergingTitle,
-		"staging":           tr.StagingTitle,
-		"menu":              tr.MenuTitle,
-		"search":            tr.SearchTitle,
-		"secondary":         tr.SecondaryTitle,
-		"stash":             tr.StashTitle,
-		"suggestions":       tr.SuggestionsCheatsheetTitle,
-		"extras":            tr.ExtrasTitle,
-		"worktrees":         tr.WorktreesTitle,
+		"global":              tr.GlobalTitle,
+		"navigation":          tr.NavigationTitle,
+		"branches":            tr.BranchesTitle,
+		"localBranches":       tr.LocalBranchesTitle,
+		"files":               tr.FilesTitle,
+		"status":              tr.StatusTitle,
+		"submodules":          tr.SubmodulesTitle,
+		"subCommits":          tr.SubCommitsTitle,
+		"remoteBranches":      tr.RemoteBranchesTitle,
+		"remotes":             tr.RemotesTitle,
+		"reflogCommits":       tr.ReflogCommitsTitle,
+		"tags":                tr.TagsTitle,
+		"commitFiles":         tr.CommitFilesTitle,
+		"commitMessage":       tr.CommitSummaryTitle,
+		"commitDescription":   tr.CommitDescriptionTitle,
+		"commits":             tr.CommitsTitle,
+		"confirmation":        tr.ConfirmationTitle,
+		"prompt":              tr.PromptTitle,
+		"information":         tr.InformationTitle,
+		"main":                tr.NormalTitle,
+		"patchBuilding":       tr.PatchBuildingTitle,
+		"mergeConflicts":      tr.MergingTitle,
+		"mergeConflictEditor": tr.ThreeWayMergeTitle,
+		"blame":               tr.BlameTitle,
+		"staging":             tr.StagingTitle,
+		"menu":                tr.MenuTitle,
+		"search":              tr.SearchTitle,
+		"secondary":           tr.SecondaryTitle,
+		"stash":               tr.StashTitle,
+		"suggestions":         tr.SuggestionsCheatsheetTitle,
+		"extras":              tr.ExtrasTitle,
+		"worktrees":           tr.WorktreesTitle,
+		"pullRequests":        tr.PullRequestsTitle,
 	}
 
 	title, ok := contextTitleMap[str]
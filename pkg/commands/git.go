@@ -24,6 +24,7 @@ type GitCommand struct {
 	Diff           *git_commands.DiffCommands
 	File           *git_commands.FileCommands
 	Flow           *git_commands.FlowCommands
+	Journal        *git_commands.JournalCommands
 	Patch          *git_commands.PatchCommands
 	Rebase         *git_commands.RebaseCommands
 	Remote         *git_commands.RemoteCommands
@@ -32,12 +33,19 @@ type GitCommand struct {
 	Submodule      *git_commands.SubmoduleCommands
 	Sync           *git_commands.SyncCommands
 	Tag            *git_commands.TagCommands
+	Notes          *git_commands.NotesCommands
+	SparseCheckout *git_commands.SparseCheckoutCommands
+	Rerere         *git_commands.RerereCommands
+	Lfs            *git_commands.LfsCommands
 	WorkingTree    *git_commands.WorkingTreeCommands
 	Bisect         *git_commands.BisectCommands
 	Worktree       *git_commands.WorktreeCommands
 	Version        *git_commands.GitVersion
 	RepoPaths      *git_commands.RepoPaths
 	GitHub         *git_commands.GitHubCommands
+	GitLab         *git_commands.GitLabCommands
+	Gitea          *git_commands.GiteaCommands
+	Bitbucket      *git_commands.BitbucketCommands
 	HostingService *git_commands.HostingService
 
 	Loaders Loaders
@@ -48,6 +56,7 @@ type Loaders struct {
 	CommitFileLoader   *git_commands.CommitFileLoader
 	CommitLoader       *git_commands.CommitLoader
 	FileLoader         *git_commands.FileLoader
+	RangeDiffLoader    *git_commands.RangeDiffLoader
 	ReflogCommitLoader *git_commands.ReflogCommitLoader
 	RemoteLoader       *git_commands.RemoteLoader
 	StashLoader        *git_commands.StashLoader
@@ -101,13 +110,17 @@ func NewGitCommandAux(
 
 	gitCommon := git_commands.NewGitCommon(cmn, version, cmd, osCommand, repoPaths, configCommands, pagerConfig)
 
-	fileLoader := git_commands.NewFileLoader(gitCommon, cmd, configCommands)
+	lfsCommands := git_commands.NewLfsCommands(gitCommon)
+	fileLoader := git_commands.NewFileLoader(gitCommon, cmd, configCommands, lfsCommands.TrackedPaths)
 	statusCommands := git_commands.NewStatusCommands(gitCommon)
 	flowCommands := git_commands.NewFlowCommands(gitCommon)
 	remoteCommands := git_commands.NewRemoteCommands(gitCommon)
 	branchCommands := git_commands.NewBranchCommands(gitCommon)
 	syncCommands := git_commands.NewSyncCommands(gitCommon)
 	tagCommands := git_commands.NewTagCommands(gitCommon)
+	notesCommands := git_commands.NewNotesCommands(gitCommon)
+	sparseCheckoutCommands := git_commands.NewSparseCheckoutCommands(gitCommon)
+	rerereCommands := git_commands.NewRerereCommands(gitCommon)
 	commitCommands := git_commands.NewCommitCommands(gitCommon)
 	customCommands := git_commands.NewCustomCommands(gitCommon)
 	diffCommands := git_commands.NewDiffCommands(gitCommon)
@@ -116,6 +129,7 @@ func NewGitCommandAux(
 	workingTreeCommands := git_commands.NewWorkingTreeCommands(gitCommon, submoduleCommands, fileLoader)
 	rebaseCommands := git_commands.NewRebaseCommands(gitCommon, commitCommands, workingTreeCommands)
 	stashCommands := git_commands.NewStashCommands(gitCommon, fileLoader, workingTreeCommands)
+	journalCommands := git_commands.NewJournalCommands(gitCommon, stashCommands)
 	patchBuilder := patch.NewPatchBuilder(cmn.Log,
 		func(from string, to string, reverse bool, filename string, plain bool) (string, error) {
 			return workingTreeCommands.ShowFileDiff(from, to, reverse, filename, plain)
@@ -125,11 +139,15 @@ func NewGitCommandAux(
 	worktreeCommands := git_commands.NewWorktreeCommands(gitCommon)
 	blameCommands := git_commands.NewBlameCommands(gitCommon)
 	gitHubCommands := git_commands.NewGitHubCommands(gitCommon)
+	gitLabCommands := git_commands.NewGitLabCommands(gitCommon)
+	giteaCommands := git_commands.NewGiteaCommands(gitCommon)
+	bitbucketCommands := git_commands.NewBitbucketCommands(gitCommon)
 	hostingServiceCommands := git_commands.NewHostingServiceCommand(gitCommon)
 
 	branchLoader := git_commands.NewBranchLoader(cmn, gitCommon, cmd, branchCommands.CurrentBranchInfo, configCommands)
-	commitFileLoader := git_commands.NewCommitFileLoader(cmn, cmd)
+	commitFileLoader := git_commands.NewCommitFileLoader(cmn, cmd, lfsCommands.TrackedPaths)
 	commitLoader := git_commands.NewCommitLoader(cmn, cmd, statusCommands.WorkingTreeState, gitCommon)
+	rangeDiffLoader := git_commands.NewRangeDiffLoader(cmn, cmd)
 	reflogCommitLoader := git_commands.NewReflogCommitLoader(cmn, cmd)
 	remoteLoader := git_commands.NewRemoteLoader(cmn, cmd)
 	worktreeLoader := git_commands.NewWorktreeLoader(gitCommon)
@@ -145,6 +163,7 @@ func NewGitCommandAux(
 		Diff:           diffCommands,
 		File:           fileCommands,
 		Flow:           flowCommands,
+		Journal:        journalCommands,
 		Patch:          patchCommands,
 		Rebase:         rebaseCommands,
 		Remote:         remoteCommands,
@@ -153,17 +172,25 @@ func NewGitCommandAux(
 		Submodule:      submoduleCommands,
 		Sync:           syncCommands,
 		Tag:            tagCommands,
+		Notes:          notesCommands,
+		SparseCheckout: sparseCheckoutCommands,
+		Rerere:         rerereCommands,
+		Lfs:            lfsCommands,
 		Bisect:         bisectCommands,
 		WorkingTree:    workingTreeCommands,
 		Worktree:       worktreeCommands,
 		Version:        version,
 		GitHub:         gitHubCommands,
+		GitLab:         gitLabCommands,
+		Gitea:          giteaCommands,
+		Bitbucket:      bitbucketCommands,
 		HostingService: hostingServiceCommands,
 		Loaders: Loaders{
 			BranchLoader:       branchLoader,
 			CommitFileLoader:   commitFileLoader,
 			CommitLoader:       commitLoader,
 			FileLoader:         fileLoader,
+			RangeDiffLoader:    rangeDiffLoader,
 			ReflogCommitLoader: reflogCommitLoader,
 			RemoteLoader:       remoteLoader,
 			Worktrees:          worktreeLoader,
@@ -0,0 +1,122 @@
+package git_commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+)
+
+// BitbucketCommands loads pull requests from Bitbucket Cloud. Bitbucket
+// Server/Data Center has a different API and isn't supported.
+type BitbucketCommands struct {
+	*GitCommon
+	// returns the base URL of the REST API for the given host; tests point
+	// this at a local server
+	getAPIURL func(host string) string
+}
+
+func NewBitbucketCommands(gitCommon *GitCommon) *BitbucketCommands {
+	return &BitbucketCommands{
+		GitCommon: gitCommon,
+		getAPIURL: bitbucketAPIURL,
+	}
+}
+
+type bitbucketPullRequests struct {
+	Values []bitbucketPullRequest `json:"values"`
+}
+
+type bitbucketPullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	State       string `json:"state"` // "OPEN", "MERGED", "DECLINED" or "SUPERSEDED"
+	Draft       bool   `json:"draft"`
+	Description string `json:"description"`
+	Links       struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+	Source      bitbucketPullRequestEndpoint `json:"source"`
+	Destination bitbucketPullRequestEndpoint `json:"destination"`
+	Author      struct {
+		Nickname string `json:"nickname"`
+	} `json:"author"`
+}
+
+type bitbucketPullRequestEndpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	// nil if the fork the pull request came from has been deleted
+	Repository *struct {
+		FullName string `json:"full_name"` // e.g. "owner/repo"
+	} `json:"repository"`
+}
+
+// We only support Bitbucket Cloud, so the token is only for bitbucket.org
+func (self *BitbucketCommands) GetAuthToken(host string) string {
+	if !isSameHost(host, "bitbucket.org") {
+		return ""
+	}
+
+	return authTokenFromEnv("BITBUCKET_TOKEN")
+}
+
+func (self *BitbucketCommands) FetchRecentPRs(branches []string, serviceInfo *hosting_service.ServiceInfo, token string) ([]*models.PullRequest, error) {
+	// Bitbucket only returns open pull requests unless asked for the other
+	// states explicitly, and caps the page length at 50
+	pullRequestsURL := fmt.Sprintf(
+		"%s/repositories/%s/%s/pullrequests?state=OPEN&state=MERGED&state=DECLINED&state=SUPERSEDED&sort=-updated_on&pagelen=%d",
+		self.getAPIURL(serviceInfo.WebDomain), serviceInfo.Owner, serviceInfo.Repository, min(recentPullRequestsLimit, 50))
+
+	var result bitbucketPullRequests
+	if err := getJSON(pullRequestsURL, map[string]string{"Authorization": "Bearer " + token}, &result); err != nil {
+		return nil, err
+	}
+
+	prs := lo.FilterMap(result.Values, func(pull bitbucketPullRequest, _ int) (*models.PullRequest, bool) {
+		if pull.Source.Repository == nil || !lo.Contains(branches, pull.Source.Branch.Name) {
+			return nil, false
+		}
+
+		owner, _, _ := strings.Cut(pull.Source.Repository.FullName, "/")
+		isCrossRepository := pull.Destination.Repository == nil ||
+			pull.Destination.Repository.FullName != pull.Source.Repository.FullName
+
+		return &models.PullRequest{
+			HeadRefName:         pull.Source.Branch.Name,
+			Number:              pull.ID,
+			Title:               pull.Title,
+			State:               bitbucketPullRequestState(pull.State, pull.Draft),
+			Url:                 pull.Links.HTML.Href,
+			HeadRepositoryOwner: models.RepositoryOwner{Login: owner},
+			BaseRefName:         pull.Destination.Branch.Name,
+			Body:                pull.Description,
+			Author:              pull.Author.Nickname,
+			IsCrossRepository:   isCrossRepository,
+		}, true
+	})
+
+	return prs, nil
+}
+
+func bitbucketPullRequestState(state string, draft bool) string {
+	switch state {
+	case "MERGED":
+		return "MERGED"
+	case "DECLINED", "SUPERSEDED":
+		return "CLOSED"
+	default:
+		return lo.Ternary(draft, "DRAFT", "OPEN")
+	}
+}
+
+// Bitbucket Cloud is only available at bitbucket.org, whose API lives on a
+// separate host
+func bitbucketAPIURL(host string) string {
+	return "https://api.bitbucket.org/2.0"
+}
@@ -0,0 +1,86 @@
+package git_commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitbucketFetchRecentPRs(t *testing.T) {
+	response := `{"values": [
+		{"id": 8, "title": "Draft", "state": "OPEN", "draft": true,
+		 "links": {"html": {"href": "https://bitbucket.org/workspace/repo/pull-requests/8"}},
+		 "source": {"branch": {"name": "wip"}, "repository": {"full_name": "workspace/repo"}},
+		 "destination": {"branch": {"name": "main"}, "repository": {"full_name": "workspace/repo"}},
+		 "author": {"nickname": "owner"}},
+		{"id": 7, "title": "Declined fork", "state": "DECLINED", "draft": false,
+		 "links": {"html": {"href": "https://bitbucket.org/workspace/repo/pull-requests/7"}},
+		 "source": {"branch": {"name": "feature"}, "repository": {"full_name": "contributor/repo"}},
+		 "destination": {"branch": {"name": "main"}, "repository": {"full_name": "workspace/repo"}},
+		 "author": {"nickname": "contributor"}},
+		{"id": 6, "title": "Not one of ours", "state": "MERGED", "draft": false,
+		 "links": {"html": {"href": "https://bitbucket.org/workspace/repo/pull-requests/6"}},
+		 "source": {"branch": {"name": "other"}, "repository": {"full_name": "workspace/repo"}},
+		 "destination": {"branch": {"name": "main"}, "repository": {"full_name": "workspace/repo"}},
+		 "author": {"nickname": "owner"}}
+	]}`
+
+	var requestedURI string
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURI = r.RequestURI
+		receivedAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	instance := buildBitbucketCommands(commonDeps{})
+	instance.getAPIURL = func(string) string { return server.URL }
+
+	prs, err := instance.FetchRecentPRs([]string{"wip", "feature"}, &hosting_service.ServiceInfo{
+		Provider:   "bitbucket",
+		WebDomain:  "bitbucket.org",
+		Owner:      "workspace",
+		Repository: "repo",
+	}, "secret")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/repositories/workspace/repo/pullrequests?state=OPEN&state=MERGED&state=DECLINED&state=SUPERSEDED&sort=-updated_on&pagelen=50", requestedURI)
+	assert.Equal(t, "Bearer secret", receivedAuth)
+
+	assert.Equal(t, []*models.PullRequest{
+		{
+			HeadRefName:         "wip",
+			Number:              8,
+			Title:               "Draft",
+			State:               "DRAFT",
+			Url:                 "https://bitbucket.org/workspace/repo/pull-requests/8",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "workspace"},
+			BaseRefName:         "main",
+			Author:              "owner",
+		},
+		{
+			HeadRefName:         "feature",
+			Number:              7,
+			Title:               "Declined fork",
+			State:               "CLOSED",
+			Url:                 "https://bitbucket.org/workspace/repo/pull-requests/7",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
+			BaseRefName:         "main",
+			Author:              "contributor",
+			IsCrossRepository:   true,
+		},
+	}, prs)
+}
+
+func TestBitbucketGetAuthToken(t *testing.T) {
+	instance := buildBitbucketCommands(commonDeps{})
+
+	t.Setenv("BITBUCKET_TOKEN", "token")
+	assert.Equal(t, "token", instance.GetAuthToken("bitbucket.org"))
+	assert.Equal(t, "", instance.GetAuthToken("bitbucket.example.com"))
+}
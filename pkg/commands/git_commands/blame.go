@@ -2,6 +2,9 @@ package git_commands
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 )
 
 type BlameCommands struct {
@@ -31,3 +34,74 @@ func (self *BlameCommands) BlameLineRange(filename string, commit string, firstL
 
 	return self.cmd.New(cmdArgs.ToArgv()).RunWithOutput()
 }
+
+// Blame a whole file as of the given commit, or the working tree if commit is
+// empty. Each line of the output starts with the abbreviated hash of the
+// commit that last changed the line, followed by the author, date and line
+// number in parentheses, and finally the line itself. If the file has been
+// renamed, the file's name in each commit is shown after the hash. For
+// example:
+//
+//	ac90ebac (Stefan Haller 2023-08-01 11) func NewBlameCommands(gitCommon *GitCommon) *BlameCommands {
+//	ac90ebac (Stefan Haller 2023-08-01 12) 	return &BlameCommands{
+func (self *BlameCommands) BlameFileCmdObj(filename string, commit string) *oscommands.CmdObj {
+	cmdArgs := NewGitCmd("blame").
+		Arg("--root", "--date=short").
+		ArgIf(commit != "", commit).
+		Arg("--").
+		Arg(filename).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog()
+}
+
+// For the given (1-based) line of the file as of the given commit (or the
+// working tree if commit is empty), returns the parent of the commit that last
+// changed the line, together with the file's name in that parent, so that we
+// can continue blaming from there. ok is false if the line was added in a
+// commit without any earlier version of the file.
+func (self *BlameCommands) GetPreviousVersion(filename string, commit string, lineNumber int) (hash string, previousFilename string, ok bool, err error) {
+	cmdArgs := NewGitCmd("blame").
+		Arg("--root", "--porcelain").
+		Arg(fmt.Sprintf("-L%d,%d", lineNumber, lineNumber)).
+		ArgIf(commit != "", commit).
+		Arg("--").
+		Arg(filename).
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return "", "", false, err
+	}
+
+	hash, previousFilename, ok = parsePreviousVersion(output)
+	return hash, previousFilename, ok, nil
+}
+
+func parsePreviousVersion(porcelainOutput string) (string, string, bool) {
+	for _, line := range strings.Split(porcelainOutput, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			// the content of the line; the headers are done
+			break
+		}
+
+		if rest, found := strings.CutPrefix(line, "previous "); found {
+			hash, filename, found := strings.Cut(rest, " ")
+			return hash, filename, found
+		}
+	}
+
+	return "", "", false
+}
+
+// Returns the hash of the commit that last changed a line of the output of
+// BlameFileCmdObj. ok is false if the line couldn't be parsed, or if the line
+// hasn't been committed yet.
+func ParseBlameLine(line string) (hash string, ok bool) {
+	hash, _, found := strings.Cut(strings.TrimPrefix(line, "^"), " ")
+	if !found || hash == "" || strings.Trim(hash, "0123456789abcdef") != "" || strings.Trim(hash, "0") == "" {
+		return "", false
+	}
+
+	return hash, true
+}
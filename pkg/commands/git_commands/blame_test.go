@@ -0,0 +1,162 @@
+package git_commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlameFileCmdObj(t *testing.T) {
+	type scenario struct {
+		testName string
+		commit   string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "working tree",
+			commit:   "",
+			expected: []string{"blame", "--root", "--date=short", "--", "file.txt"},
+		},
+		{
+			testName: "commit",
+			commit:   "abc1234^",
+			expected: []string{"blame", "--root", "--date=short", "abc1234^", "--", "file.txt"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).ExpectGitArgs(s.expected, "", nil)
+			instance := buildBlameCommands(commonDeps{runner: runner})
+
+			assert.NoError(t, instance.BlameFileCmdObj("file.txt", s.commit).Run())
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestBlameGetPreviousVersion(t *testing.T) {
+	type scenario struct {
+		testName         string
+		commit           string
+		expectedArgs     []string
+		output           string
+		runErr           error
+		expectedHash     string
+		expectedFilename string
+		expectedOk       bool
+		expectedErr      bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:     "renamed file",
+			commit:       "",
+			expectedArgs: []string{"blame", "--root", "--porcelain", "-L2,2", "--", "file.txt"},
+			output: `803de2a95370e832bb84541743fd46b63c294d6f 2 2 1
+author A B
+summary second
+previous 8b5286d09566ed194fde20b2331434d468cc7aff old name.txt
+filename file.txt
+	previous 123 fake
+`,
+			expectedHash:     "8b5286d09566ed194fde20b2331434d468cc7aff",
+			expectedFilename: "old name.txt",
+			expectedOk:       true,
+		},
+		{
+			testName:     "line added in root commit",
+			commit:       "803de2a9",
+			expectedArgs: []string{"blame", "--root", "--porcelain", "-L2,2", "803de2a9", "--", "file.txt"},
+			output: `8b5286d09566ed194fde20b2331434d468cc7aff 2 2 1
+author A B
+summary first
+filename file.txt
+	previous 123 fake
+`,
+			expectedOk: false,
+		},
+		{
+			testName:     "error",
+			commit:       "",
+			expectedArgs: []string{"blame", "--root", "--porcelain", "-L2,2", "--", "file.txt"},
+			runErr:       errors.New("fatal: file has only 1 line"),
+			expectedErr:  true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).ExpectGitArgs(s.expectedArgs, s.output, s.runErr)
+			instance := buildBlameCommands(commonDeps{runner: runner})
+
+			hash, filename, ok, err := instance.GetPreviousVersion("file.txt", s.commit, 2)
+			if s.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, s.expectedHash, hash)
+			assert.Equal(t, s.expectedFilename, filename)
+			assert.Equal(t, s.expectedOk, ok)
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestParseBlameLine(t *testing.T) {
+	type scenario struct {
+		testName     string
+		line         string
+		expectedHash string
+		expectedOk   bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:     "plain line",
+			line:         "ac90ebac (Stefan Haller 2023-08-01 11) func main() {",
+			expectedHash: "ac90ebac",
+			expectedOk:   true,
+		},
+		{
+			testName:     "line from a renamed file",
+			line:         "ac90ebac old dir/old name.go (Stefan Haller 2023-08-01 11) foo(bar)",
+			expectedHash: "ac90ebac",
+			expectedOk:   true,
+		},
+		{
+			testName:     "boundary commit",
+			line:         "^ac90eba (Stefan Haller 2023-08-01 1) package main",
+			expectedHash: "ac90eba",
+			expectedOk:   true,
+		},
+		{
+			testName:   "not committed yet",
+			line:       "00000000 (Not Committed Yet 2023-08-01 3) wip",
+			expectedOk: false,
+		},
+		{
+			testName:   "garbage",
+			line:       "fatal: no such path 'file.go' in HEAD (oops)",
+			expectedOk: false,
+		},
+		{
+			testName:   "empty line",
+			line:       "",
+			expectedOk: false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			hash, ok := ParseBlameLine(s.line)
+			assert.Equal(t, s.expectedOk, ok)
+			assert.Equal(t, s.expectedHash, hash)
+		})
+	}
+}
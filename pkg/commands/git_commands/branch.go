@@ -295,6 +295,17 @@ func (self *BranchCommands) CanDoFastForwardMerge(refName string) bool {
 	return err == nil
 }
 
+// Returns whether ancestor is reachable from descendant, i.e. whether
+// descendant can be fast-forwarded to from ancestor
+func (self *BranchCommands) IsAncestor(ancestor string, descendant string) bool {
+	cmdArgs := NewGitCmd("merge-base").
+		Arg("--is-ancestor").
+		Arg(ancestor, descendant).
+		ToArgv()
+	err := self.cmd.New(cmdArgs).DontLog().Run()
+	return err == nil
+}
+
 // Only choose between non-empty, non-identical commands
 func (self *BranchCommands) allBranchesLogCandidates() []string {
 	return lo.Uniq(lo.WithoutEmpty(self.UserConfig().Git.AllBranchesLogCmds))
@@ -241,7 +241,7 @@ func TestBranchGetBranchGraph(t *testing.T) {
 
 func TestBranchGetAllBranchGraph(t *testing.T) {
 	runner := oscommands.NewFakeRunner(t).ExpectGitArgs([]string{
-		"log", "--graph", "--all", "--color=always", "--abbrev-commit", "--decorate", "--date=relative", "--pretty=medium",
+		"log", "--graph", "--exclude=refs/lazygit/*", "--all", "--color=always", "--abbrev-commit", "--decorate", "--date=relative", "--pretty=medium",
 	}, "", nil)
 	instance := buildBranchCommands(commonDeps{runner: runner})
 	err := instance.AllBranchesLogCmdObj().Run()
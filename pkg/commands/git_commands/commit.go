@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/samber/lo"
 )
 
 var ErrInvalidCommitIndex = errors.New("invalid commit index")
@@ -164,6 +165,26 @@ func (self *CommitCommands) GetCommitMessage(commitHash string) (string, error)
 	return strings.ReplaceAll(strings.TrimSpace(message), "\r\n", "\n"), err
 }
 
+// GetCommitMessagesInRange returns the messages of the commits that are
+// reachable from `to` but not from `from`, oldest first
+func (self *CommitCommands) GetCommitMessagesInRange(from string, to string) ([]string, error) {
+	cmdArgs := NewGitCmd("log").
+		Arg("-z", "--reverse", "--format=%B", from+".."+to).
+		Config("log.showsignature=false").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := lo.FilterMap(strings.Split(output, "\x00"), func(message string, _ int) (string, bool) {
+		message = strings.ReplaceAll(strings.TrimSpace(message), "\r\n", "\n")
+		return message, message != ""
+	})
+	return messages, nil
+}
+
 func (self *CommitCommands) GetCommitSubject(commitHash string) (string, error) {
 	cmdArgs := NewGitCmd("log").
 		Arg("--format=%s", "--max-count=1", commitHash).
@@ -240,7 +261,8 @@ func (self *CommitCommands) AmendHeadCmdObj() *oscommands.CmdObj {
 	return self.cmd.New(cmdArgs)
 }
 
-func (self *CommitCommands) ShowCmdObj(hash string, filterPaths []string) *oscommands.CmdObj {
+// If notesRef is empty, git's default notes are shown (if any)
+func (self *CommitCommands) ShowCmdObj(hash string, filterPaths []string, notesRef string) *oscommands.CmdObj {
 	contextSize := self.UserConfig().Git.DiffContextSize
 
 	extDiffCmd := self.pagerConfig.GetExternalDiffCommand()
@@ -254,6 +276,8 @@ func (self *CommitCommands) ShowCmdObj(hash string, filterPaths []string) *oscom
 		Arg(fmt.Sprintf("--unified=%d", contextSize)).
 		Arg("--stat").
 		Arg("--decorate").
+		ArgIf(self.UserConfig().Git.Log.ShowSignatureStatus, "--show-signature").
+		ArgIf(notesRef != "", "--notes="+notesRef).
 		Arg("-p").
 		Arg(hash).
 		ArgIf(self.UserConfig().Git.IgnoreWhitespaceInDiffView, "--ignore-all-space").
@@ -266,6 +290,32 @@ func (self *CommitCommands) ShowCmdObj(hash string, filterPaths []string) *oscom
 	return self.cmd.New(cmdArgs).DontLog()
 }
 
+// Shows the changes to the given line range made by the skip'th commit of
+// `git log -L` starting at ref. We go by index rather than by hash because the
+// line numbers of the range are only valid as of ref, so git needs to trace the
+// range through all the commits in between; the skip'th commit is the one at
+// that index in the list returned by CommitLoader.GetCommits for the same range.
+func (self *CommitCommands) ShowLineRangeCmdObj(ref string, lineRange *LineRange, skip int) *oscommands.CmdObj {
+	gitLogOrder := self.UserConfig().Git.Log.Order
+	extDiffCmd := self.pagerConfig.GetExternalDiffCommand()
+	useExtDiffGitConfig := self.pagerConfig.GetUseExternalDiffGitConfig()
+	cmdArgs := NewGitCmd("log").
+		Config("diff.noprefix=false").
+		ConfigIf(extDiffCmd != "", "diff.external="+extDiffCmd).
+		ArgIfElse(extDiffCmd != "" || useExtDiffGitConfig, "--ext-diff", "--no-ext-diff").
+		Arg(ref).
+		ArgIf(gitLogOrder != "default", "--"+gitLogOrder).
+		Arg("--color="+self.pagerConfig.GetColorArg()).
+		Arg("--decorate").
+		Arg(lineRange.logArg()).
+		Arg(fmt.Sprintf("--skip=%d", skip), "-1").
+		Arg("--no-show-signature").
+		Dir(self.repoPaths.worktreePath).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog()
+}
+
 func (self *CommitCommands) ShowFileContentCmdObj(hash string, filePath string) *oscommands.CmdObj {
 	cmdArgs := NewGitCmd("show").
 		Arg(fmt.Sprintf("%s:%s", hash, filePath)).
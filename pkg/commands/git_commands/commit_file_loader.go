@@ -11,13 +11,19 @@ import (
 
 type CommitFileLoader struct {
 	*common.Common
-	cmd oscommands.ICmdObjBuilder
+	cmd                oscommands.ICmdObjBuilder
+	getLfsTrackedPaths func([]string) (map[string]bool, error)
 }
 
-func NewCommitFileLoader(common *common.Common, cmd oscommands.ICmdObjBuilder) *CommitFileLoader {
+func NewCommitFileLoader(
+	common *common.Common,
+	cmd oscommands.ICmdObjBuilder,
+	getLfsTrackedPaths func([]string) (map[string]bool, error),
+) *CommitFileLoader {
 	return &CommitFileLoader{
-		Common: common,
-		cmd:    cmd,
+		Common:             common,
+		cmd:                cmd,
+		getLfsTrackedPaths: getLfsTrackedPaths,
 	}
 }
 
@@ -40,7 +46,17 @@ func (self *CommitFileLoader) GetFilesInDiff(from string, to string, reverse boo
 		return nil, err
 	}
 
-	return getCommitFilesFromFilenames(filenames), nil
+	files := getCommitFilesFromFilenames(filenames)
+
+	lfsTrackedPaths, err := self.getLfsTrackedPaths(lo.Map(files, func(file *models.CommitFile, _ int) string { return file.Path }))
+	if err != nil {
+		self.Log.Error(err)
+	}
+	for _, file := range files {
+		file.IsLfs = lfsTrackedPaths[file.Path]
+	}
+
+	return files, nil
 }
 
 // filenames string is something like "MM\x00file1\x00MU\x00file2\x00AA\x00file3\x00"
@@ -59,6 +59,7 @@ type GetCommitsOptions struct {
 	Limit                bool
 	FilterPath           string
 	FilterAuthor         string
+	ContentFilter        ContentFilter
 	IncludeRebaseCommits bool
 	RefName              string     // e.g. "HEAD" or "my_branch"
 	RefForPushedStatus   models.Ref // the ref to use for determining pushed/unpushed status
@@ -66,15 +67,86 @@ type GetCommitsOptions struct {
 	All bool
 	// If non-empty, show divergence from this ref (left-right log)
 	RefToShowDivergenceFrom string
-	MainBranches            *MainBranches
-	HashPool                *utils.StringPool
+	// If true, mark the commits that have a note attached in NotesRef (or in
+	// git's default notes ref if NotesRef is empty)
+	LoadNotes    bool
+	NotesRef     string
+	MainBranches *MainBranches
+	HashPool     *utils.StringPool
+	// If true, have git verify each commit's signature. This can be slow, so
+	// it's opt-in.
+	LoadSignatures bool
+	// If non-nil, only show the commits that touched this range of lines. This
+	// takes the place of FilterPath, FilterAuthor and ContentFilter, which are
+	// ignored.
+	LineRange *LineRange
+}
+
+// Filters commits by what they change or by their message. Can be combined
+// with filtering by path and author, in which case commits have to match all
+// of them.
+type ContentFilter struct {
+	// Only show commits that change the number of occurrences of this string
+	// (git log -S), or, if PickaxeIsRegex is true, whose added or removed lines
+	// match this regex (git log -G)
+	Pickaxe        string
+	PickaxeIsRegex bool
+	// Only show commits whose message matches this regex (git log --grep)
+	Message string
+}
+
+func (self ContentFilter) IsEmpty() bool {
+	return self.Pickaxe == "" && self.Message == ""
+}
+
+func (self ContentFilter) logArgs() []string {
+	args := []string{}
+	if self.Pickaxe != "" {
+		if self.PickaxeIsRegex {
+			args = append(args, "-G"+self.Pickaxe)
+		} else {
+			args = append(args, "-S"+self.Pickaxe)
+		}
+	}
+	if self.Message != "" {
+		args = append(args, "--grep="+self.Message)
+	}
+	return args
+}
+
+// A range of lines in a file, as of some commit, whose history we want to
+// trace with `git log -L`. Start and End are one-based and inclusive.
+type LineRange struct {
+	Path  string
+	Start int
+	End   int
+}
+
+func (self *LineRange) logArg() string {
+	return fmt.Sprintf("-L%d,%d:%s", self.Start, self.End, self.Path)
+}
+
+func (self *LineRange) String() string {
+	if self.Start == self.End {
+		return fmt.Sprintf("%s:%d", self.Path, self.Start)
+	}
+	return fmt.Sprintf("%s:%d-%d", self.Path, self.Start, self.End)
 }
 
 // GetCommits obtains the commits of the current branch
 func (self *CommitLoader) GetCommits(opts GetCommitsOptions) ([]*models.Commit, error) {
 	commits := []*models.Commit{}
 
-	if opts.IncludeRebaseCommits && opts.FilterPath == "" {
+	if opts.LineRange != nil {
+		// git log -L can't be combined with a pathspec, and already follows
+		// renames of the file
+		opts.FilterPath, opts.FilterAuthor = "", ""
+		opts.ContentFilter = ContentFilter{}
+	}
+
+	// We can't tell which of the rebase todo commits match a path or content
+	// filter, so leave them out in that case
+	if opts.IncludeRebaseCommits && opts.FilterPath == "" && opts.ContentFilter.IsEmpty() {
 		var err error
 		commits, err = self.MergeRebasingCommits(opts.HashPool, commits)
 		if err != nil {
@@ -92,7 +164,7 @@ func (self *CommitLoader) GetCommits(opts GetCommitsOptions) ([]*models.Commit,
 
 		var realCommits []*models.Commit
 		realCommits, logErr = loadCommits(self.getLogCmd(opts), opts.FilterPath, func(line string) (*models.Commit, bool) {
-			return self.extractCommitFromLine(opts.HashPool, line, opts.RefToShowDivergenceFrom != ""), false
+			return self.extractCommitFromLine(opts.HashPool, line, opts.RefToShowDivergenceFrom != "", opts.LoadSignatures), false
 		})
 		if logErr == nil {
 			commits = append(commits, realCommits...)
@@ -114,6 +186,16 @@ func (self *CommitLoader) GetCommits(opts GetCommitsOptions) ([]*models.Commit,
 		}
 	})
 
+	var annotatedCommitHashes *set.Set[string]
+	if opts.LoadNotes {
+		wg.Add(1)
+		go utils.Safe(func() {
+			defer wg.Done()
+
+			annotatedCommitHashes = self.getAnnotatedHashes(opts.NotesRef)
+		})
+	}
+
 	var unpushedCommitHashes *set.Set[string]
 	if opts.RefForPushedStatus != nil {
 		unpushedCommitHashes = self.getReachableHashes(opts.RefForPushedStatus.FullRefName(),
@@ -149,6 +231,12 @@ func (self *CommitLoader) GetCommits(opts GetCommitsOptions) ([]*models.Commit,
 		setCommitStatuses(unpushedCommitHashes, unmergedCommitHashes, commits)
 	}
 
+	if annotatedCommitHashes != nil {
+		for _, commit := range commits {
+			commit.HasNotes = annotatedCommitHashes.Includes(commit.Hash())
+		}
+	}
+
 	return commits, nil
 }
 
@@ -189,8 +277,8 @@ func (self *CommitLoader) MergeRebasingCommits(hashPool *utils.StringPool, commi
 // then puts them into a commit object
 // example input:
 // 8ad01fe32fcc20f07bc6693f87aa4977c327f1e1|10 hours ago|Jesse Duffield| (HEAD -> master, tag: v0.15.2)|refresh commits when adding a tag
-func (self *CommitLoader) extractCommitFromLine(hashPool *utils.StringPool, line string, showDivergence bool) *models.Commit {
-	split := strings.SplitN(line, "\x00", 8)
+func (self *CommitLoader) extractCommitFromLine(hashPool *utils.StringPool, line string, showDivergence bool, withSignature bool) *models.Commit {
+	split := strings.SplitN(line, "\x00", lo.Ternary(withSignature, 11, 8))
 
 	// Ensure we have the minimum required fields (at least 7 for basic functionality)
 	if len(split) < 7 {
@@ -209,6 +297,16 @@ func (self *CommitLoader) extractCommitFromLine(hashPool *utils.StringPool, line
 	}
 	extraInfo := strings.TrimSpace(split[6])
 
+	// the signature fields come between extraInfo and the message (see
+	// prettyFormatWithSignature)
+	signature := models.SignatureNotLoaded
+	signer := ""
+	if withSignature && len(split) > 9 {
+		signature = models.ParseSignatureStatus(split[7])
+		signer = lo.Ternary(split[8] != "", split[8], split[9])
+		split = append(split[:7], split[10:]...)
+	}
+
 	// message (and the \x00 before it) might not be present if extraInfo is extremely long
 	message := ""
 	if len(split) > 7 {
@@ -248,6 +346,8 @@ func (self *CommitLoader) extractCommitFromLine(hashPool *utils.StringPool, line
 		AuthorEmail:   authorEmail,
 		Parents:       parents,
 		Divergence:    divergence,
+		Signature:     signature,
+		Signer:        signer,
 	})
 }
 
@@ -298,7 +398,7 @@ func (self *CommitLoader) getHydratedTodoCommits(hashPool *utils.StringPool, tod
 		if line == "" || line[0] != '+' {
 			return false, nil
 		}
-		commit := self.extractCommitFromLine(hashPool, line[1:], false)
+		commit := self.extractCommitFromLine(hashPool, line[1:], false, false)
 		fullCommits[commit.Hash()] = commit
 		return false, nil
 	})
@@ -577,6 +677,27 @@ func (self *CommitLoader) getReachableHashes(refName string, notRefNames []strin
 	return set.NewFromSlice(utils.SplitLines(output))
 }
 
+// getAnnotatedHashes returns the hashes of all commits that have a note in the
+// given notes ref
+func (self *CommitLoader) getAnnotatedHashes(notesRef string) *set.Set[string] {
+	output, err := self.cmd.New(
+		notesCmd(notesRef).
+			Arg("list").
+			ToArgv(),
+	).
+		DontLog().
+		RunWithOutput()
+	if err != nil {
+		return set.New[string]()
+	}
+
+	// Each line is of the form "<note object hash> <annotated object hash>"
+	return set.NewFromSlice(lo.FilterMap(utils.SplitLines(output), func(line string, _ int) (string, bool) {
+		_, hash, found := strings.Cut(line, " ")
+		return hash, found
+	}))
+}
+
 // getLogCmd gets the git log.
 func (self *CommitLoader) getLogCmd(opts GetCommitsOptions) *oscommands.CmdObj {
 	gitLogOrder := self.UserConfig().Git.Log.Order
@@ -586,16 +707,24 @@ func (self *CommitLoader) getLogCmd(opts GetCommitsOptions) *oscommands.CmdObj {
 		refSpec += "..." + opts.RefToShowDivergenceFrom
 	}
 
+	lineRangeArgs := []string{}
+	if opts.LineRange != nil {
+		lineRangeArgs = []string{opts.LineRange.logArg(), "--no-patch"}
+	}
+
 	cmdArgs := NewGitCmd("log").
 		Arg(refSpec).
 		ArgIf(gitLogOrder != "default", "--"+gitLogOrder).
-		ArgIf(opts.All, "--all").
+		// the journal keeps its objects alive with commits under refs/lazygit/
+		ArgIf(opts.All, "--exclude=refs/lazygit/*", "--all").
 		Arg("--oneline").
-		Arg(prettyFormat).
+		Arg(lo.Ternary(opts.LoadSignatures, prettyFormatWithSignature, prettyFormat)).
 		Arg("--abbrev=40").
 		ArgIf(opts.FilterAuthor != "", "--author="+opts.FilterAuthor).
+		Arg(opts.ContentFilter.logArgs()...).
 		ArgIf(opts.Limit, "-300").
 		ArgIf(opts.FilterPath != "", "--follow", "--name-status").
+		Arg(lineRangeArgs...).
 		Arg("--no-show-signature").
 		ArgIf(opts.RefToShowDivergenceFrom != "", "--left-right").
 		Arg("--").
@@ -606,3 +735,7 @@ func (self *CommitLoader) getLogCmd(opts GetCommitsOptions) *oscommands.CmdObj {
 }
 
 const prettyFormat = `--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%s`
+
+// Same as prettyFormat, plus the signature status, signer and signing key
+// before the subject
+const prettyFormatWithSignature = `--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%G?%x00%GS%x00%GK%x00%s`
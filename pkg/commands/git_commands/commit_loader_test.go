@@ -294,6 +294,87 @@ func TestGetCommits(t *testing.T) {
 			expectedCommitOpts: []models.NewCommitOpts{},
 			expectedError:      nil,
 		},
+		{
+			testName: "should trace a line range instead of filtering by path or author",
+			logOrder: "default",
+			opts: GetCommitsOptions{
+				RefName: "HEAD", RefForPushedStatus: &models.Branch{Name: "mybranch"}, FilterPath: "src", FilterAuthor: "Jesse",
+				LineRange: &LineRange{Path: "src/main.go", Start: 3, End: 7},
+			},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"rev-list", "refs/heads/mybranch", "^mybranch@{u}"}, "", nil).
+				ExpectGitArgs([]string{"log", "HEAD", "--oneline", "--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%s", "--abbrev=40", "-L3,7:src/main.go", "--no-patch", "--no-show-signature", "--"}, "", nil),
+
+			expectedCommitOpts: []models.NewCommitOpts{},
+			expectedError:      nil,
+		},
+		{
+			testName: "should combine content filters with filtering by path and author",
+			logOrder: "default",
+			opts: GetCommitsOptions{
+				RefName: "HEAD", RefForPushedStatus: &models.Branch{Name: "mybranch"}, FilterPath: "src", FilterAuthor: "Jesse",
+				ContentFilter: ContentFilter{Pickaxe: "func main", Message: "^fix"},
+			},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"rev-list", "refs/heads/mybranch", "^mybranch@{u}"}, "", nil).
+				ExpectGitArgs([]string{"log", "HEAD", "--oneline", "--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%s", "--abbrev=40", "--author=Jesse", "-Sfunc main", "--grep=^fix", "--follow", "--name-status", "--no-show-signature", "--", "src"}, "", nil),
+
+			expectedCommitOpts: []models.NewCommitOpts{},
+			expectedError:      nil,
+		},
+		{
+			testName: "should use a regex pickaxe and leave out rebase todo commits",
+			logOrder: "default",
+			opts: GetCommitsOptions{
+				RefName: "HEAD", RefForPushedStatus: &models.Branch{Name: "mybranch"}, IncludeRebaseCommits: true,
+				ContentFilter: ContentFilter{Pickaxe: "ma[iy]n", PickaxeIsRegex: true},
+			},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"rev-list", "refs/heads/mybranch", "^mybranch@{u}"}, "", nil).
+				ExpectGitArgs([]string{"log", "HEAD", "--oneline", "--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%s", "--abbrev=40", "-Gma[iy]n", "--no-show-signature", "--"}, "", nil),
+
+			expectedCommitOpts: []models.NewCommitOpts{},
+			expectedError:      nil,
+		},
+		{
+			testName: "should verify signatures if asked to",
+			logOrder: "default",
+			opts:     GetCommitsOptions{RefName: "HEAD", RefForPushedStatus: &models.Branch{Name: "mybranch"}, LoadSignatures: true},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"rev-list", "refs/heads/mybranch", "^mybranch@{u}"}, "", nil).
+				ExpectGitArgs([]string{"log", "HEAD", "--oneline", "--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%G?%x00%GS%x00%GK%x00%s", "--abbrev=40", "--no-show-signature", "--"}, "", nil),
+
+			expectedCommitOpts: []models.NewCommitOpts{},
+			expectedError:      nil,
+		},
+		{
+			testName: "should mark commits that have notes",
+			logOrder: "default",
+			opts:     GetCommitsOptions{RefName: "HEAD", RefForPushedStatus: &models.Branch{Name: "mybranch"}, LoadNotes: true, NotesRef: "refs/notes/review"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"rev-list", "refs/heads/mybranch", "^mybranch@{u}"}, "", nil).
+				ExpectGitArgs([]string{"log", "HEAD", "--oneline", "--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%s", "--abbrev=40", "--no-show-signature", "--"}, singleCommitOutput, nil).
+				ExpectGitArgs([]string{"notes", "--ref=refs/notes/review", "list"}, "45b983be36b73c0788dc9cbcb76cbb80fc7bb057 0eea75e8c631fba6b58135697835d58ba4c18dbc\n", nil),
+
+			expectedCommitOpts: []models.NewCommitOpts{
+				{
+					Hash:          "0eea75e8c631fba6b58135697835d58ba4c18dbc",
+					Name:          "better typing for rebase mode",
+					Status:        models.StatusPushed,
+					Action:        models.ActionNone,
+					Tags:          nil,
+					ExtraInfo:     "(HEAD -> better-tests)",
+					AuthorName:    "Jesse Duffield",
+					AuthorEmail:   "jessedduffield@gmail.com",
+					UnixTimestamp: 1640826609,
+					Parents: []string{
+						"b21997d6b4cbdf84b149",
+					},
+					HasNotes: true,
+				},
+			},
+			expectedError: nil,
+		},
 	}
 
 	for _, scenario := range scenarios {
@@ -605,6 +686,7 @@ func TestCommitLoader_extractCommitFromLine(t *testing.T) {
 		testName       string
 		line           string
 		showDivergence bool
+		withSignature  bool
 		expectedCommit *models.Commit
 	}{
 		{
@@ -781,11 +863,56 @@ func TestCommitLoader_extractCommitFromLine(t *testing.T) {
 				Divergence:    models.DivergenceRight,
 			}),
 		},
+		{
+			testName:      "commit line with a good signature",
+			line:          "hash123\x001234567890\x00John Doe\x00john@example.com\x00parent1\x00>\x00HEAD -> main\x00G\x00john@example.com\x00SHA256:abc\x00signed commit",
+			withSignature: true,
+			expectedCommit: models.NewCommit(hashPool, models.NewCommitOpts{
+				Hash:          "hash123",
+				Name:          "signed commit",
+				ExtraInfo:     "(HEAD -> main)",
+				UnixTimestamp: 1234567890,
+				AuthorName:    "John Doe",
+				AuthorEmail:   "john@example.com",
+				Parents:       []string{"parent1"},
+				Signature:     models.SignatureGood,
+				Signer:        "john@example.com",
+			}),
+		},
+		{
+			testName:      "commit line with an unverifiable signature falls back to the key as signer",
+			line:          "hash123\x001234567890\x00John Doe\x00john@example.com\x00parent1\x00>\x00\x00E\x00\x00SHA256:abc\x00message with \x00 null byte",
+			withSignature: true,
+			expectedCommit: models.NewCommit(hashPool, models.NewCommitOpts{
+				Hash:          "hash123",
+				Name:          "message with \x00 null byte",
+				UnixTimestamp: 1234567890,
+				AuthorName:    "John Doe",
+				AuthorEmail:   "john@example.com",
+				Parents:       []string{"parent1"},
+				Signature:     models.SignatureUnverifiable,
+				Signer:        "SHA256:abc",
+			}),
+		},
+		{
+			testName:      "unsigned commit line",
+			line:          "hash123\x001234567890\x00John Doe\x00john@example.com\x00parent1\x00>\x00\x00N\x00\x00\x00unsigned commit",
+			withSignature: true,
+			expectedCommit: models.NewCommit(hashPool, models.NewCommitOpts{
+				Hash:          "hash123",
+				Name:          "unsigned commit",
+				UnixTimestamp: 1234567890,
+				AuthorName:    "John Doe",
+				AuthorEmail:   "john@example.com",
+				Parents:       []string{"parent1"},
+				Signature:     models.SignatureNone,
+			}),
+		},
 	}
 
 	for _, scenario := range scenarios {
 		t.Run(scenario.testName, func(t *testing.T) {
-			result := loader.extractCommitFromLine(hashPool, scenario.line, scenario.showDivergence)
+			result := loader.extractCommitFromLine(hashPool, scenario.line, scenario.showDivergence, scenario.withSignature)
 			if scenario.expectedCommit == nil {
 				assert.Nil(t, result)
 			} else {
@@ -252,9 +252,11 @@ func TestCommitShowCmdObj(t *testing.T) {
 	type scenario struct {
 		testName            string
 		filterPaths         []string
+		notesRef            string
 		contextSize         uint64
 		similarityThreshold int
 		ignoreWhitespace    bool
+		showSignature       bool
 		pagerConfig         *config.PagingConfig
 		expected            []string
 	}
@@ -278,6 +280,25 @@ func TestCommitShowCmdObj(t *testing.T) {
 			pagerConfig:         nil,
 			expected:            []string{"-C", "/path/to/worktree", "-c", "diff.noprefix=false", "show", "--no-ext-diff", "--submodule", "--color=always", "--unified=3", "--stat", "--decorate", "-p", "1234567890", "--find-renames=50%", "--", "file.txt"},
 		},
+		{
+			testName:            "Show notes from a custom notes ref",
+			filterPaths:         []string{},
+			notesRef:            "refs/notes/review",
+			contextSize:         3,
+			similarityThreshold: 50,
+			ignoreWhitespace:    false,
+			pagerConfig:         nil,
+			expected:            []string{"-C", "/path/to/worktree", "-c", "diff.noprefix=false", "show", "--no-ext-diff", "--submodule", "--color=always", "--unified=3", "--stat", "--decorate", "--notes=refs/notes/review", "-p", "1234567890", "--find-renames=50%", "--"},
+		},
+		{
+			testName:            "Show signature details",
+			filterPaths:         []string{},
+			contextSize:         3,
+			similarityThreshold: 50,
+			showSignature:       true,
+			pagerConfig:         nil,
+			expected:            []string{"-C", "/path/to/worktree", "-c", "diff.noprefix=false", "show", "--no-ext-diff", "--submodule", "--color=always", "--unified=3", "--stat", "--decorate", "--show-signature", "-p", "1234567890", "--find-renames=50%", "--"},
+		},
 		{
 			testName:            "Show diff with custom context size",
 			filterPaths:         []string{},
@@ -334,6 +355,50 @@ func TestCommitShowCmdObj(t *testing.T) {
 			userConfig.Git.IgnoreWhitespaceInDiffView = s.ignoreWhitespace
 			userConfig.Git.DiffContextSize = s.contextSize
 			userConfig.Git.RenameSimilarityThreshold = s.similarityThreshold
+			userConfig.Git.Log.ShowSignatureStatus = s.showSignature
+
+			runner := oscommands.NewFakeRunner(t).ExpectGitArgs(s.expected, "", nil)
+			repoPaths := RepoPaths{
+				worktreePath: "/path/to/worktree",
+			}
+			instance := buildCommitCommands(commonDeps{userConfig: userConfig, appState: &config.AppState{}, runner: runner, repoPaths: &repoPaths})
+
+			assert.NoError(t, instance.ShowCmdObj("1234567890", s.filterPaths, s.notesRef).Run())
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestCommitShowLineRangeCmdObj(t *testing.T) {
+	type scenario struct {
+		testName    string
+		logOrder    string
+		pagerConfig *config.PagingConfig
+		expected    []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:    "Default case",
+			logOrder:    "topo-order",
+			pagerConfig: nil,
+			expected:    []string{"-C", "/path/to/worktree", "-c", "diff.noprefix=false", "log", "--no-ext-diff", "HEAD", "--topo-order", "--color=always", "--decorate", "-L3,7:src/main.go", "--skip=2", "-1", "--no-show-signature"},
+		},
+		{
+			testName:    "Default log order and external diff command",
+			logOrder:    "default",
+			pagerConfig: &config.PagingConfig{ExternalDiffCommand: "difft --color=always"},
+			expected:    []string{"-C", "/path/to/worktree", "-c", "diff.external=difft --color=always", "-c", "diff.noprefix=false", "log", "--ext-diff", "HEAD", "--color=always", "--decorate", "-L3,7:src/main.go", "--skip=2", "-1", "--no-show-signature"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			userConfig := config.GetDefaultConfig()
+			if s.pagerConfig != nil {
+				userConfig.Git.Pagers = []config.PagingConfig{*s.pagerConfig}
+			}
+			userConfig.Git.Log.Order = s.logOrder
 
 			runner := oscommands.NewFakeRunner(t).ExpectGitArgs(s.expected, "", nil)
 			repoPaths := RepoPaths{
@@ -341,7 +406,8 @@ func TestCommitShowCmdObj(t *testing.T) {
 			}
 			instance := buildCommitCommands(commonDeps{userConfig: userConfig, appState: &config.AppState{}, runner: runner, repoPaths: &repoPaths})
 
-			assert.NoError(t, instance.ShowCmdObj("1234567890", s.filterPaths).Run())
+			lineRange := &LineRange{Path: "src/main.go", Start: 3, End: 7}
+			assert.NoError(t, instance.ShowLineRangeCmdObj("HEAD", lineRange, 2).Run())
 			runner.CheckForMissingCalls()
 		})
 	}
@@ -496,3 +562,15 @@ func TestAddCoAuthorToDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCommitMessagesInRange(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"-c", "log.showsignature=false", "log", "-z", "--reverse", "--format=%B", "main..feature"},
+			"first commit\n\nwith a body\n\x00second commit\r\n\x00", nil)
+	instance := buildCommitCommands(commonDeps{runner: runner})
+
+	messages, err := instance.GetCommitMessagesInRange("main", "feature")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first commit\n\nwith a body", "second commit"}, messages)
+	runner.CheckForMissingCalls()
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/samber/lo"
 )
 
 // BranchConfig holds the tracking configuration for a branch.
@@ -67,10 +68,36 @@ func (self *ConfigCommands) GetRemoteURL() string {
 	return self.gitConfig.Get("remote.origin.url")
 }
 
+func (self *ConfigCommands) GetSparseCheckout() bool {
+	return self.gitConfig.GetBool("core.sparseCheckout")
+}
+
+func (self *ConfigCommands) GetSparseCheckoutCone() bool {
+	return self.gitConfig.GetBool("core.sparseCheckoutCone")
+}
+
 func (self *ConfigCommands) GetShowUntrackedFiles() string {
 	return self.gitConfig.Get("status.showUntrackedFiles")
 }
 
+// UsesFsmonitor tells whether git status uses a file system monitor, either
+// git's built-in one or a hook. The config is either a boolean or the path of
+// the hook.
+func (self *ConfigCommands) UsesFsmonitor() bool {
+	value := strings.ToLower(self.gitConfig.Get("core.fsmonitor"))
+	return value != "" && !lo.Contains([]string{"false", "no", "off", "0"}, value)
+}
+
+// UsesUntrackedCache tells whether git status caches the untracked files of
+// each directory. feature.manyFiles turns the cache on unless it's configured
+// explicitly.
+func (self *ConfigCommands) UsesUntrackedCache() bool {
+	if self.gitConfig.Get("core.untrackedCache") == "" {
+		return self.gitConfig.GetBool("feature.manyFiles")
+	}
+	return self.gitConfig.GetBool("core.untrackedCache")
+}
+
 // this determines whether the user has configured to push to the remote branch of the same name as the current or not
 func (self *ConfigCommands) GetPushToCurrent() bool {
 	return self.gitConfig.Get("push.default") == "current"
@@ -183,6 +210,12 @@ func (self *ConfigCommands) GetCoreCommentChar() byte {
 	return '#'
 }
 
+// The second return value is false if rerere.enabled isn't set at all, which
+// git treats differently from an explicit false
+func (self *ConfigCommands) GetRerereEnabled() (bool, bool) {
+	return self.gitConfig.GetBool("rerere.enabled"), self.gitConfig.Get("rerere.enabled") != ""
+}
+
 func (self *ConfigCommands) GetRebaseUpdateRefs() bool {
 	return self.gitConfig.GetBool("rebase.updateRefs")
 }
@@ -125,3 +125,44 @@ func TestGetGitFlowPrefixMap(t *testing.T) {
 		})
 	}
 }
+
+func TestUsesFsmonitor(t *testing.T) {
+	scenarios := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"true", true},
+		{"false", false},
+		{"Off", false},
+		{".git/hooks/query-watchman", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			config := NewConfigCommands(common.NewDummyCommon(), git_config.NewFakeGitConfig(map[string]string{"core.fsmonitor": s.value}))
+			assert.Equal(t, s.expected, config.UsesFsmonitor())
+		})
+	}
+}
+
+func TestUsesUntrackedCache(t *testing.T) {
+	scenarios := []struct {
+		testName string
+		config   map[string]string
+		expected bool
+	}{
+		{"not configured", nil, false},
+		{"enabled", map[string]string{"core.untrackedCache": "true"}, true},
+		{"keep", map[string]string{"core.untrackedCache": "keep"}, false},
+		{"enabled by feature.manyFiles", map[string]string{"feature.manyFiles": "true"}, true},
+		{"disabled despite feature.manyFiles", map[string]string{"feature.manyFiles": "true", "core.untrackedCache": "false"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			config := NewConfigCommands(common.NewDummyCommon(), git_config.NewFakeGitConfig(s.config))
+			assert.Equal(t, s.expected, config.UsesUntrackedCache())
+		})
+	}
+}
@@ -112,7 +112,7 @@ func buildGitCommon(deps commonDeps) *GitCommon {
 }
 
 func buildFileLoader(gitCommon *GitCommon) *FileLoader {
-	return NewFileLoader(gitCommon, gitCommon.cmd, gitCommon.config)
+	return NewFileLoader(gitCommon, gitCommon.cmd, gitCommon.config, func([]string) (map[string]bool, error) { return nil, nil })
 }
 
 func buildSubmoduleCommands(deps commonDeps) *SubmoduleCommands {
@@ -142,6 +142,13 @@ func buildStashCommands(deps commonDeps) *StashCommands {
 	return NewStashCommands(gitCommon, fileLoader, workingTreeCommands)
 }
 
+func buildJournalCommands(deps commonDeps) *JournalCommands {
+	gitCommon := buildGitCommon(deps)
+	stashCommands := buildStashCommands(deps)
+
+	return NewJournalCommands(gitCommon, stashCommands)
+}
+
 func buildRebaseCommands(deps commonDeps) *RebaseCommands {
 	gitCommon := buildGitCommon(deps)
 	workingTreeCommands := buildWorkingTreeCommands(deps)
@@ -173,3 +180,65 @@ func buildFlowCommands(deps commonDeps) *FlowCommands {
 
 	return NewFlowCommands(gitCommon)
 }
+
+func buildSparseCheckoutCommands(deps commonDeps) *SparseCheckoutCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewSparseCheckoutCommands(gitCommon)
+}
+
+func buildLfsCommands(deps commonDeps) *LfsCommands {
+	gitCommon := buildGitCommon(deps)
+
+	lfsCommands := NewLfsCommands(gitCommon)
+	lfsCommands.isInstalled = func() bool { return true }
+	return lfsCommands
+}
+
+func buildBlameCommands(deps commonDeps) *BlameCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewBlameCommands(gitCommon)
+}
+
+func buildWorktreeCommands(deps commonDeps) *WorktreeCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewWorktreeCommands(gitCommon)
+}
+
+func buildPatchCommands(deps commonDeps) *PatchCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewPatchCommands(gitCommon, nil, nil, nil, nil, nil)
+}
+
+func buildRerereCommands(deps commonDeps) *RerereCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewRerereCommands(gitCommon)
+}
+
+func buildGitHubCommands(deps commonDeps) *GitHubCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewGitHubCommands(gitCommon)
+}
+
+func buildGitLabCommands(deps commonDeps) *GitLabCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewGitLabCommands(gitCommon)
+}
+
+func buildGiteaCommands(deps commonDeps) *GiteaCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewGiteaCommands(gitCommon)
+}
+
+func buildBitbucketCommands(deps commonDeps) *BitbucketCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewBitbucketCommands(gitCommon)
+}
@@ -39,6 +39,20 @@ func (self *DiffCommands) DiffCmdObj(diffArgs []string) *oscommands.CmdObj {
 	)
 }
 
+// Shows the interdiff between two versions of the same commit, the way it
+// appears in the output of `git range-diff`. We force the two commits to be
+// paired up, because the caller has already decided that they belong together.
+func (self *DiffCommands) RangeDiffCmdObj(oldHash string, newHash string) *oscommands.CmdObj {
+	return self.cmd.New(
+		NewGitCmd("range-diff").
+			Arg(fmt.Sprintf("--color=%s", self.pagerConfig.GetColorArg())).
+			Arg("--creation-factor=100").
+			Arg(oldHash+"^!", newHash+"^!").
+			Dir(self.repoPaths.worktreePath).
+			ToArgv(),
+	).DontLog()
+}
+
 // This is a basic generic diff command that can be used for any diff operation
 // (e.g. copying a diff to the clipboard). It will not use a custom pager, and
 // does not use user configs such as ignore whitespace.
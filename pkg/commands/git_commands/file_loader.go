@@ -5,13 +5,18 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/samber/lo"
 )
 
 type FileLoaderConfig interface {
 	GetShowUntrackedFiles() string
+	UsesFsmonitor() bool
+	UsesUntrackedCache() bool
 }
 
 type FileLoader struct {
@@ -19,14 +24,24 @@ type FileLoader struct {
 	cmd         oscommands.ICmdObjBuilder
 	config      FileLoaderConfig
 	getFileType func(string) string
+	// returns the subset of the given paths that are tracked by git-lfs
+	getLfsTrackedPaths func([]string) (map[string]bool, error)
+	// how long the last `git status` call took, in nanoseconds
+	lastStatusDuration atomic.Int64
 }
 
-func NewFileLoader(gitCommon *GitCommon, cmd oscommands.ICmdObjBuilder, config FileLoaderConfig) *FileLoader {
+func NewFileLoader(
+	gitCommon *GitCommon,
+	cmd oscommands.ICmdObjBuilder,
+	config FileLoaderConfig,
+	getLfsTrackedPaths func([]string) (map[string]bool, error),
+) *FileLoader {
 	return &FileLoader{
-		GitCommon:   gitCommon,
-		cmd:         cmd,
-		getFileType: oscommands.FileType,
-		config:      config,
+		GitCommon:          gitCommon,
+		cmd:                cmd,
+		getFileType:        oscommands.FileType,
+		config:             config,
+		getLfsTrackedPaths: getLfsTrackedPaths,
 	}
 }
 
@@ -36,21 +51,65 @@ type GetStatusFileOptions struct {
 	// This is useful for users with bare repos for dotfiles who default to hiding untracked files,
 	// but want to occasionally see them to `git add` a new file.
 	ForceShowUntracked bool
+	// Untracked directories whose files to list when we list untracked files
+	// lazily (see ListsUntrackedFilesLazily); other untracked directories show
+	// up as a single file with IsCollapsedUntrackedDir set. Paths are relative
+	// to the worktree, without a trailing slash.
+	ExpandedUntrackedDirs []string
+	// Called with those of ExpandedUntrackedDirs that aren't untracked
+	// directories anymore, e.g. because they were deleted or some of their
+	// files were added, so that the caller can forget about them
+	OnStaleExpandedUntrackedDirs func(dirs []string)
+}
+
+// ListsUntrackedFilesLazily tells whether we show untracked directories as a
+// whole until the user expands them, rather than listing all their files. Git
+// only uses its untracked cache when it doesn't list all untracked files, so
+// this is a lot faster in big repos.
+func (self *FileLoader) ListsUntrackedFilesLazily() bool {
+	switch self.UserConfig().Git.LazyUntrackedFiles {
+	case "always":
+		return true
+	case "never":
+		return false
+	default:
+		return self.config.UsesUntrackedCache() || self.config.UsesFsmonitor()
+	}
+}
+
+// LastStatusDuration returns how long the last `git status` call took, or 0
+// if we haven't called it yet
+func (self *FileLoader) LastStatusDuration() time.Duration {
+	return time.Duration(self.lastStatusDuration.Load())
 }
 
 func (self *FileLoader) GetStatusFiles(opts GetStatusFileOptions) []*models.File {
+	lazyUntrackedFiles := self.ListsUntrackedFilesLazily()
+
 	// check if config wants us ignoring untracked files
 	untrackedFilesSetting := self.config.GetShowUntrackedFiles()
 
 	if opts.ForceShowUntracked || untrackedFilesSetting == "" {
 		untrackedFilesSetting = "all"
 	}
+	if lazyUntrackedFiles && untrackedFilesSetting != "no" {
+		untrackedFilesSetting = "normal"
+	}
 	untrackedFilesArg := fmt.Sprintf("--untracked-files=%s", untrackedFilesSetting)
 
+	startTime := time.Now()
 	statuses, err := self.gitStatus(GitStatusOptions{NoRenames: opts.NoRenames, UntrackedFilesArg: untrackedFilesArg})
+	self.lastStatusDuration.Store(int64(time.Since(startTime)))
 	if err != nil {
 		self.Log.Error(err)
 	}
+	if lazyUntrackedFiles && err == nil {
+		var staleDirs []string
+		statuses, staleDirs = self.expandUntrackedDirs(statuses, opts.ExpandedUntrackedDirs)
+		if len(staleDirs) > 0 && opts.OnStaleExpandedUntrackedDirs != nil {
+			opts.OnStaleExpandedUntrackedDirs(staleDirs)
+		}
+	}
 	files := []*models.File{}
 
 	fileDiffs := map[string]FileDiff{}
@@ -102,9 +161,58 @@ func (self *FileLoader) GetStatusFiles(opts GetStatusFileOptions) []*models.File
 		}
 	}
 
+	if lazyUntrackedFiles {
+		for _, file := range files {
+			if !file.IsWorktree && file.ShortStatus == "??" && strings.HasSuffix(file.Path, "/") {
+				file.IsCollapsedUntrackedDir = true
+				file.Path = strings.TrimSuffix(file.Path, "/")
+			}
+		}
+	}
+
+	lfsTrackedPaths, err := self.getLfsTrackedPaths(lo.Map(files, func(file *models.File, _ int) string { return file.Path }))
+	if err != nil {
+		self.Log.Error(err)
+	}
+	for _, file := range files {
+		file.IsLfs = lfsTrackedPaths[file.Path]
+	}
+
 	return files
 }
 
+// expandUntrackedDirs replaces the given untracked directories, as listed by
+// `git status --untracked-files=normal`, with the untracked files in them. It
+// also returns the given directories that git doesn't list as untracked
+// anymore.
+func (self *FileLoader) expandUntrackedDirs(statuses []FileStatus, expandedDirs []string) ([]FileStatus, []string) {
+	dirsToExpand, staleDirs := lo.FilterReject(expandedDirs, func(dir string, _ int) bool {
+		return lo.ContainsBy(statuses, func(status FileStatus) bool {
+			return status.Change == "??" && status.Path == dir+"/"
+		})
+	})
+	if len(dirsToExpand) == 0 {
+		return statuses, staleDirs
+	}
+
+	untrackedStatuses, err := self.gitStatus(GitStatusOptions{
+		NoRenames:         true,
+		UntrackedFilesArg: "--untracked-files=all",
+		Paths:             lo.Map(dirsToExpand, func(dir string, _ int) string { return dir + "/" }),
+	})
+	if err != nil {
+		self.Log.Error(err)
+		return statuses, staleDirs
+	}
+
+	result := lo.Filter(statuses, func(status FileStatus, _ int) bool {
+		return !(status.Change == "??" && lo.Contains(dirsToExpand, strings.TrimSuffix(status.Path, "/")))
+	})
+	return append(result, lo.Filter(untrackedStatuses, func(status FileStatus, _ int) bool {
+		return status.Change == "??"
+	})...), staleDirs
+}
+
 type FileDiff struct {
 	LinesAdded   int
 	LinesDeleted int
@@ -148,6 +256,8 @@ func (self *FileLoader) getFileDiffs() (map[string]FileDiff, error) {
 type GitStatusOptions struct {
 	NoRenames         bool
 	UntrackedFilesArg string
+	// if non-empty, only list the status of these paths
+	Paths []string
 }
 
 type FileStatus struct {
@@ -177,6 +287,8 @@ func (self *FileLoader) gitStatus(opts GitStatusOptions) ([]FileStatus, error) {
 			"--no-renames",
 			fmt.Sprintf("--find-renames=%d%%", self.UserConfig().Git.RenameSimilarityThreshold),
 		).
+		ArgIf(len(opts.Paths) > 0, "--").
+		Arg(opts.Paths...).
 		ToArgv()
 
 	statusLines, _, err := self.cmd.New(cmdArgs).DontLog().RunWithOutputs()
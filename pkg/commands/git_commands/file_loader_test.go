@@ -6,6 +6,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -244,6 +245,9 @@ func TestFileGetStatusFiles(t *testing.T) {
 				cmd:         cmd,
 				config:      &FakeFileLoaderConfig{showUntrackedFiles: "yes"},
 				getFileType: func(string) string { return "file" },
+				getLfsTrackedPaths: func([]string) (map[string]bool, error) {
+					return nil, nil
+				},
 			}
 
 			assert.EqualValues(t, s.expectedFiles, loader.GetStatusFiles(GetStatusFileOptions{}))
@@ -251,10 +255,130 @@ func TestFileGetStatusFiles(t *testing.T) {
 	}
 }
 
+func TestFileGetStatusFilesLazyUntracked(t *testing.T) {
+	type scenario struct {
+		testName              string
+		lazyUntrackedFiles    string
+		config                *FakeFileLoaderConfig
+		expandedUntrackedDirs []string
+		runner                *oscommands.FakeCmdObjRunner
+		expectedPaths         []string
+		expectedCollapsedDirs []string
+		expectedStaleDirs     []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:           "auto without untracked cache or fsmonitor lists all untracked files",
+			lazyUntrackedFiles: "auto",
+			config:             &FakeFileLoaderConfig{},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"status", "--untracked-files=all", "--porcelain", "-z", "--find-renames=50%"},
+					"?? build/out.o\x00", nil),
+			expectedPaths: []string{"build/out.o"},
+		},
+		{
+			testName:           "auto with untracked cache collapses untracked dirs",
+			lazyUntrackedFiles: "auto",
+			config:             &FakeFileLoaderConfig{usesUntrackedCache: true},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"status", "--untracked-files=normal", "--porcelain", "-z", "--find-renames=50%"},
+					"M  main.go\x00?? build/\x00?? notes.txt\x00", nil),
+			expectedPaths:         []string{"main.go", "build", "notes.txt"},
+			expectedCollapsedDirs: []string{"build"},
+		},
+		{
+			testName:              "expanded dirs are listed",
+			lazyUntrackedFiles:    "always",
+			config:                &FakeFileLoaderConfig{},
+			expandedUntrackedDirs: []string{"build", "gone"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"status", "--untracked-files=normal", "--porcelain", "-z", "--find-renames=50%"},
+					"?? build/\x00?? docs/\x00", nil).
+				ExpectGitArgs([]string{"status", "--untracked-files=all", "--porcelain", "-z", "--no-renames", "--", "build/"},
+					"?? build/a.o\x00?? build/sub/b.o\x00", nil),
+			expectedPaths:         []string{"docs", "build/a.o", "build/sub/b.o"},
+			expectedCollapsedDirs: []string{"docs"},
+			expectedStaleDirs:     []string{"gone"},
+		},
+		{
+			testName:              "expanded dirs that are partly tracked now are stale",
+			lazyUntrackedFiles:    "always",
+			config:                &FakeFileLoaderConfig{},
+			expandedUntrackedDirs: []string{"build"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"status", "--untracked-files=normal", "--porcelain", "-z", "--find-renames=50%"},
+					"A  build/a.o\x00?? build/sub/\x00", nil),
+			expectedPaths:         []string{"build/a.o", "build/sub"},
+			expectedCollapsedDirs: []string{"build/sub"},
+			expectedStaleDirs:     []string{"build"},
+		},
+		{
+			testName:           "never lists all untracked files even with fsmonitor",
+			lazyUntrackedFiles: "never",
+			config:             &FakeFileLoaderConfig{usesFsmonitor: true},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"status", "--untracked-files=all", "--porcelain", "-z", "--find-renames=50%"},
+					"?? build/out.o\x00", nil),
+			expectedPaths: []string{"build/out.o"},
+		},
+		{
+			testName:           "hidden untracked files stay hidden",
+			lazyUntrackedFiles: "always",
+			config:             &FakeFileLoaderConfig{showUntrackedFiles: "no"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"status", "--untracked-files=no", "--porcelain", "-z", "--find-renames=50%"},
+					"", nil),
+			expectedPaths: []string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			userConfig := &config.UserConfig{}
+			userConfig.Git.RenameSimilarityThreshold = 50
+			userConfig.Git.LazyUntrackedFiles = s.lazyUntrackedFiles
+
+			loader := &FileLoader{
+				GitCommon:   buildGitCommon(commonDeps{appState: &config.AppState{}, userConfig: userConfig}),
+				cmd:         oscommands.NewDummyCmdObjBuilder(s.runner),
+				config:      s.config,
+				getFileType: func(string) string { return "file" },
+				getLfsTrackedPaths: func([]string) (map[string]bool, error) {
+					return nil, nil
+				},
+			}
+
+			var staleDirs []string
+			files := loader.GetStatusFiles(GetStatusFileOptions{
+				ExpandedUntrackedDirs:        s.expandedUntrackedDirs,
+				OnStaleExpandedUntrackedDirs: func(dirs []string) { staleDirs = dirs },
+			})
+			assert.Equal(t, s.expectedPaths, lo.Map(files, func(file *models.File, _ int) string { return file.Path }))
+			collapsedDirs := lo.FilterMap(files, func(file *models.File, _ int) (string, bool) {
+				return file.Path, file.IsCollapsedUntrackedDir
+			})
+			assert.Equal(t, lo.Ternary(s.expectedCollapsedDirs == nil, []string{}, s.expectedCollapsedDirs), collapsedDirs)
+			assert.Equal(t, s.expectedStaleDirs, staleDirs)
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
+
 type FakeFileLoaderConfig struct {
 	showUntrackedFiles string
+	usesFsmonitor      bool
+	usesUntrackedCache bool
 }
 
 func (self *FakeFileLoaderConfig) GetShowUntrackedFiles() string {
 	return self.showUntrackedFiles
 }
+
+func (self *FakeFileLoaderConfig) UsesFsmonitor() bool {
+	return self.usesFsmonitor
+}
+
+func (self *FakeFileLoaderConfig) UsesUntrackedCache() bool {
+	return self.usesUntrackedCache
+}
@@ -0,0 +1,104 @@
+package git_commands
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+)
+
+// GiteaCommands loads pull requests from Gitea or Forgejo instances (the
+// latter including codeberg.org), which share the same REST API
+type GiteaCommands struct {
+	*GitCommon
+	// returns the base URL of the REST API for the given host; tests point
+	// this at a local server
+	getAPIURL func(host string) string
+}
+
+func NewGiteaCommands(gitCommon *GitCommon) *GiteaCommands {
+	return &GiteaCommands{
+		GitCommon: gitCommon,
+		getAPIURL: giteaAPIURL,
+	}
+}
+
+type giteaPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"` // "open" or "closed"
+	Merged  bool   `json:"merged"`
+	Draft   bool   `json:"draft"`
+	HTMLURL string `json:"html_url"`
+	Body    string `json:"body"`
+	Head    struct {
+		Ref string `json:"ref"`
+		// nil if the fork the pull request came from has been deleted
+		Repo *struct {
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"repo"`
+		RepoID int `json:"repo_id"`
+	} `json:"head"`
+	Base struct {
+		Ref    string `json:"ref"`
+		RepoID int    `json:"repo_id"`
+	} `json:"base"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// GITEA_SERVER_URL is what the tea CLI uses to say which instance the token
+// is for; without it we only use the token for Codeberg
+func (self *GiteaCommands) GetAuthToken(host string) string {
+	return authTokenForHost(host, "codeberg.org", "GITEA_SERVER_URL", "GITEA_TOKEN", "FORGEJO_TOKEN")
+}
+
+func (self *GiteaCommands) FetchRecentPRs(branches []string, serviceInfo *hosting_service.ServiceInfo, token string) ([]*models.PullRequest, error) {
+	pullsURL := fmt.Sprintf("%s/repos/%s/%s/pulls?state=all&sort=recentupdate&limit=%d",
+		self.getAPIURL(serviceInfo.WebDomain), serviceInfo.Owner, serviceInfo.Repository, recentPullRequestsLimit)
+
+	var pulls []giteaPullRequest
+	if err := getJSON(pullsURL, map[string]string{"Authorization": "token " + token}, &pulls); err != nil {
+		return nil, err
+	}
+
+	prs := lo.FilterMap(pulls, func(pull giteaPullRequest, _ int) (*models.PullRequest, bool) {
+		if pull.Head.Repo == nil || !lo.Contains(branches, pull.Head.Ref) {
+			return nil, false
+		}
+
+		return &models.PullRequest{
+			HeadRefName:         pull.Head.Ref,
+			Number:              pull.Number,
+			Title:               pull.Title,
+			State:               giteaPullRequestState(pull.State, pull.Merged, pull.Draft),
+			Url:                 pull.HTMLURL,
+			HeadRepositoryOwner: models.RepositoryOwner{Login: pull.Head.Repo.Owner.Login},
+			BaseRefName:         pull.Base.Ref,
+			Body:                pull.Body,
+			Author:              pull.User.Login,
+			IsCrossRepository:   pull.Head.RepoID != pull.Base.RepoID,
+		}, true
+	})
+
+	return prs, nil
+}
+
+func giteaPullRequestState(state string, merged bool, draft bool) string {
+	switch {
+	case merged:
+		return "MERGED"
+	case state == "closed":
+		return "CLOSED"
+	default:
+		return lo.Ternary(draft, "DRAFT", "OPEN")
+	}
+}
+
+func giteaAPIURL(host string) string {
+	return "https://" + host + "/api/v1"
+}
@@ -0,0 +1,97 @@
+package git_commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGiteaFetchRecentPRs(t *testing.T) {
+	pulls := `[
+		{"number": 3, "title": "From a fork", "state": "open", "merged": false, "draft": false,
+		 "html_url": "https://codeberg.org/owner/repo/pulls/3",
+		 "head": {"ref": "feature", "repo_id": 9, "repo": {"owner": {"login": "contributor"}}},
+		 "base": {"ref": "main", "repo_id": 1}, "user": {"login": "contributor"}},
+		{"number": 2, "title": "Merged", "state": "closed", "merged": true, "draft": false,
+		 "html_url": "https://codeberg.org/owner/repo/pulls/2",
+		 "head": {"ref": "done", "repo_id": 1, "repo": {"owner": {"login": "owner"}}},
+		 "base": {"ref": "main", "repo_id": 1}, "user": {"login": "owner"}},
+		{"number": 1, "title": "Fork was deleted", "state": "closed", "merged": false, "draft": false,
+		 "html_url": "https://codeberg.org/owner/repo/pulls/1",
+		 "head": {"ref": "feature", "repo_id": 0, "repo": null},
+		 "base": {"ref": "main", "repo_id": 1}, "user": {"login": "ghost"}}
+	]`
+
+	var requestedURI string
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURI = r.RequestURI
+		receivedAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(pulls))
+	}))
+	defer server.Close()
+
+	instance := buildGiteaCommands(commonDeps{})
+	instance.getAPIURL = func(host string) string {
+		assert.Equal(t, "codeberg.org", host)
+		return server.URL
+	}
+
+	prs, err := instance.FetchRecentPRs([]string{"feature", "done"}, &hosting_service.ServiceInfo{
+		Provider:   "codeberg",
+		WebDomain:  "codeberg.org",
+		Owner:      "owner",
+		Repository: "repo",
+	}, "secret")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/repos/owner/repo/pulls?state=all&sort=recentupdate&limit=100", requestedURI)
+	assert.Equal(t, "token secret", receivedAuth)
+
+	assert.Equal(t, []*models.PullRequest{
+		{
+			HeadRefName:         "feature",
+			Number:              3,
+			Title:               "From a fork",
+			State:               "OPEN",
+			Url:                 "https://codeberg.org/owner/repo/pulls/3",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
+			BaseRefName:         "main",
+			Author:              "contributor",
+			IsCrossRepository:   true,
+		},
+		{
+			HeadRefName:         "done",
+			Number:              2,
+			Title:               "Merged",
+			State:               "MERGED",
+			Url:                 "https://codeberg.org/owner/repo/pulls/2",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "owner"},
+			BaseRefName:         "main",
+			Author:              "owner",
+		},
+	}, prs)
+}
+
+func TestGiteaPullRequestState(t *testing.T) {
+	assert.Equal(t, "OPEN", giteaPullRequestState("open", false, false))
+	assert.Equal(t, "DRAFT", giteaPullRequestState("open", false, true))
+	assert.Equal(t, "CLOSED", giteaPullRequestState("closed", false, true))
+	assert.Equal(t, "MERGED", giteaPullRequestState("closed", true, false))
+}
+
+func TestGiteaGetAuthToken(t *testing.T) {
+	instance := buildGiteaCommands(commonDeps{})
+
+	t.Setenv("GITEA_TOKEN", "token")
+	t.Setenv("GITEA_SERVER_URL", "")
+	assert.Equal(t, "token", instance.GetAuthToken("codeberg.org"))
+	assert.Equal(t, "", instance.GetAuthToken("gitea.example.com"))
+
+	t.Setenv("GITEA_SERVER_URL", "https://gitea.example.com")
+	assert.Equal(t, "token", instance.GetAuthToken("gitea.example.com"))
+}
@@ -13,21 +13,35 @@ import (
 	"github.com/cli/go-gh/v2/pkg/auth"
 	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gocui"
 	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 )
 
 type GitHubCommands struct {
 	*GitCommon
+	// returns the URL of the GraphQL API for the given host; tests point this
+	// at a local server
+	getGraphQLEndpoint func(host string) string
+	// same for the base URL of the REST API, which we need for mutations
+	getAPIURL func(host string) string
 }
 
 func NewGitHubCommands(gitCommon *GitCommon) *GitHubCommands {
 	return &GitHubCommands{
-		GitCommon: gitCommon,
+		GitCommon:          gitCommon,
+		getGraphQLEndpoint: graphQLEndpoint,
+		getAPIURL:          gitHubAPIURL,
 	}
 }
 
-// https://github.com/cli/cli/issues/2300
+// OverrideAPIURL sends all API requests to the given URL, whatever the host.
+// Integration tests use this to talk to a fake API server.
+func (self *GitHubCommands) OverrideAPIURL(apiURL string) {
+	self.getGraphQLEndpoint = func(string) string { return apiURL + "/graphql" }
+	self.getAPIURL = func(string) string { return apiURL }
+}
+
 func (self *GitHubCommands) ConfiguredBaseRemoteName() string {
 	// TODO: we only support the (common) case where the value of the config is "base", meaning that
 	// the remote's URL determines the GitHub repo. Since `gh repo set-default` on the command line
@@ -85,12 +99,45 @@ type PullRequestNode struct {
 	HeadRepositoryOwner GithubRepositoryOwner `json:"headRepositoryOwner"`
 	State               string                `json:"state"`
 	IsDraft             bool                  `json:"isDraft"`
+
+	// The fields below are only queried for the pull requests panel
+	BaseRefName       string                `json:"baseRefName"`
+	Body              string                `json:"body"`
+	IsCrossRepository bool                  `json:"isCrossRepository"`
+	ReviewDecision    string                `json:"reviewDecision"`
+	Author            GithubRepositoryOwner `json:"author"`
+	Commits           PullRequestCommits    `json:"commits"`
 }
 
 type GithubRepositoryOwner struct {
 	Login string `json:"login"`
 }
 
+type PullRequestCommits struct {
+	Nodes []PullRequestCommitNode `json:"nodes"`
+}
+
+type PullRequestCommitNode struct {
+	Commit struct {
+		StatusCheckRollup *struct {
+			State string `json:"state"`
+		} `json:"statusCheckRollup"`
+	} `json:"commit"`
+}
+
+// the state of the checks of the pull request's head commit, or "" if it has
+// none
+func (self *PullRequestNode) checksStatus() string {
+	if len(self.Commits.Nodes) == 0 {
+		return ""
+	}
+	rollup := self.Commits.Nodes[0].Commit.StatusCheckRollup
+	if rollup == nil {
+		return ""
+	}
+	return rollup.State
+}
+
 type graphQLRequest struct {
 	Query     string            `json:"query"`
 	Variables map[string]string `json:"variables"`
@@ -138,6 +185,42 @@ func fetchPullRequestsQuery(branches []string, owner string, repo string) (strin
 	return queryString, variables
 }
 
+const openPullRequestsQuery = `query($owner: String!, $repo: String!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequests(first: 100, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      edges {
+        node {
+          title
+          headRefName
+          baseRefName
+          state
+          number
+          url
+          isDraft
+          body
+          isCrossRepository
+          reviewDecision
+          author {
+            login
+          }
+          headRepositoryOwner {
+            login
+          }
+          commits(last: 1) {
+            nodes {
+              commit {
+                statusCheckRollup {
+                  state
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
 func (self *GitHubCommands) GetAuthToken(host string) string {
 	token, _ := auth.TokenForHost(host)
 	return token
@@ -146,8 +229,8 @@ func (self *GitHubCommands) GetAuthToken(host string) string {
 // FetchRecentPRs fetches recent pull requests using GraphQL. serviceInfo
 // identifies the GitHub instance (github.com or a GitHub Enterprise Server)
 // and the owner/repo to query against.
-func (self *GitHubCommands) FetchRecentPRs(branches []string, serviceInfo *hosting_service.ServiceInfo, token string) ([]*models.GithubPullRequest, error) {
-	endpoint := graphQLEndpoint(serviceInfo.WebDomain)
+func (self *GitHubCommands) FetchRecentPRs(branches []string, serviceInfo *hosting_service.ServiceInfo, token string) ([]*models.PullRequest, error) {
+	endpoint := self.getGraphQLEndpoint(serviceInfo.WebDomain)
 	t := time.Now()
 
 	var g errgroup.Group
@@ -157,7 +240,7 @@ func (self *GitHubCommands) FetchRecentPRs(branches []string, serviceInfo *hosti
 	minBranchesPerRequest := 10
 	branchesPerRequest := max(len(branches)/concurrency, minBranchesPerRequest)
 	numChunks := (len(branches) + branchesPerRequest - 1) / branchesPerRequest
-	results := make(chan []*models.GithubPullRequest, numChunks)
+	results := make(chan []*models.PullRequest, numChunks)
 
 	for i := 0; i < len(branches); i += branchesPerRequest {
 		end := i + branchesPerRequest
@@ -185,7 +268,7 @@ func (self *GitHubCommands) FetchRecentPRs(branches []string, serviceInfo *hosti
 	}
 
 	// Collect results from all goroutines
-	var allPRs []*models.GithubPullRequest
+	var allPRs []*models.PullRequest
 	for prs := range results {
 		allPRs = append(allPRs, prs...)
 	}
@@ -195,9 +278,45 @@ func (self *GitHubCommands) FetchRecentPRs(branches []string, serviceInfo *hosti
 	return allPRs, nil
 }
 
-func (self *GitHubCommands) fetchRecentPRsAux(endpoint string, repoOwner string, repoName string, branches []string, token string) ([]*models.GithubPullRequest, error) {
+func (self *GitHubCommands) fetchRecentPRsAux(endpoint string, repoOwner string, repoName string, branches []string, token string) ([]*models.PullRequest, error) {
 	queryString, variables := fetchPullRequestsQuery(branches, repoOwner, repoName)
 
+	result, err := runGraphQLQuery(endpoint, queryString, variables, token)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := []*models.PullRequest{}
+	for _, repoQuery := range result.Data.Repository {
+		for _, edge := range repoQuery.Edges {
+			prs = append(prs, pullRequestFromNode(edge.Node))
+		}
+	}
+
+	return prs, nil
+}
+
+// FetchOpenPRs fetches the open pull requests of the repo identified by
+// serviceInfo, most recently updated first. Unlike FetchRecentPRs, this
+// includes pull requests that none of our branches are associated with.
+func (self *GitHubCommands) FetchOpenPRs(serviceInfo *hosting_service.ServiceInfo, token string) ([]*models.PullRequest, error) {
+	endpoint := self.getGraphQLEndpoint(serviceInfo.WebDomain)
+	variables := map[string]string{
+		"owner": serviceInfo.Owner,
+		"repo":  serviceInfo.Repository,
+	}
+
+	result, err := runGraphQLQuery(endpoint, openPullRequestsQuery, variables, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(result.Data.Repository["pullRequests"].Edges, func(edge PullRequestEdge, _ int) *models.PullRequest {
+		return pullRequestFromNode(edge.Node)
+	}), nil
+}
+
+func runGraphQLQuery(endpoint string, queryString string, variables map[string]string, token string) (*Response, error) {
 	bodyBytes, err := json.Marshal(graphQLRequest{Query: queryString, Variables: variables})
 	if err != nil {
 		return nil, err
@@ -234,103 +353,195 @@ func (self *GitHubCommands) fetchRecentPRsAux(endpoint string, repoOwner string,
 		return nil, err
 	}
 
-	prs := []*models.GithubPullRequest{}
-	for _, repoQuery := range result.Data.Repository {
-		for _, edge := range repoQuery.Edges {
-			node := edge.Node
-			pr := &models.GithubPullRequest{
-				HeadRefName: node.HeadRefName,
-				Number:      node.Number,
-				Title:       node.Title,
-				State:       lo.Ternary(node.IsDraft && node.State != "CLOSED", "DRAFT", node.State),
-				Url:         node.Url,
-				HeadRepositoryOwner: models.GithubRepositoryOwner{
-					Login: node.HeadRepositoryOwner.Login,
-				},
-			}
-			prs = append(prs, pr)
-		}
+	return &result, nil
+}
+
+func pullRequestFromNode(node PullRequestNode) *models.PullRequest {
+	return &models.PullRequest{
+		HeadRefName: node.HeadRefName,
+		Number:      node.Number,
+		Title:       node.Title,
+		State:       lo.Ternary(node.IsDraft && node.State != "CLOSED", "DRAFT", node.State),
+		Url:         node.Url,
+		HeadRepositoryOwner: models.RepositoryOwner{
+			Login: node.HeadRepositoryOwner.Login,
+		},
+		BaseRefName:       node.BaseRefName,
+		Body:              node.Body,
+		Author:            node.Author.Login,
+		IsCrossRepository: node.IsCrossRepository,
+		ReviewDecision:    node.ReviewDecision,
+		ChecksStatus:      node.checksStatus(),
 	}
+}
 
-	return prs, nil
+// FetchPullRequestHead fetches the head commit of the given pull request from
+// the remote of the base repo into the given local branch. GitHub keeps a
+// pull/<number>/head ref for every pull request, which is the only way to get
+// at the commits of pull requests from forks without adding a remote for the
+// fork.
+// The local branch is created if it doesn't exist, and fast-forwarded
+// otherwise; git refuses to update it if it has diverged from the pull
+// request, or if it is checked out, in which case use PullPullRequestHead
+// instead.
+func (self *GitHubCommands) FetchPullRequestHead(task gocui.Task, remoteName string, number int, localBranchName string) error {
+	cmdArgs := NewGitCmd("fetch").
+		Arg("--no-write-fetch-head").
+		Arg(remoteName).
+		Arg(fmt.Sprintf("%s:refs/heads/%s", pullRequestHeadRef(number), localBranchName)).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
 }
 
-// returns a map from branch name to pull request
-func GenerateGithubPullRequestMap(
-	prs []*models.GithubPullRequest,
-	branches []*models.Branch,
-	remotes []*models.Remote,
-) map[string]*models.GithubPullRequest {
-	res := map[string]*models.GithubPullRequest{}
+// FetchPullRequestHeadCommit fetches the head commit of the given pull request
+// without updating any branch, and returns its hash. This lets us check
+// whether an existing local branch can be fast-forwarded to it.
+func (self *GitHubCommands) FetchPullRequestHeadCommit(task gocui.Task, remoteName string, number int) (string, error) {
+	cmdArgs := NewGitCmd("fetch").
+		Arg(remoteName).
+		Arg(pullRequestHeadRef(number)).
+		ToArgv()
 
-	if len(prs) == 0 {
-		return res
+	if err := self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run(); err != nil {
+		return "", err
 	}
 
-	remotesToOwnersMap := getRemotesToOwnersMap(remotes)
+	revParseArgs := NewGitCmd("rev-parse").
+		Arg("FETCH_HEAD").
+		ToArgv()
 
-	// A PR can be identified by two things: the owner e.g. 'jesseduffield' and the
-	// branch name e.g. 'feature/my-feature'. The owner might be different
-	// to the owner of the repo if the PR is from a fork of that repo.
-	type prKey struct {
-		owner      string
-		branchName string
+	output, err := self.cmd.New(revParseArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return "", err
 	}
 
-	prByKey := map[prKey]models.GithubPullRequest{}
+	return strings.TrimSpace(output), nil
+}
 
-	for _, pr := range prs {
-		key := prKey{owner: strings.ToLower(pr.UserName()), branchName: pr.BranchName()}
-		// PRs are returned newest-first from the API, so the first one we
-		// see for each key is the most recent and therefore the most relevant.
-		if _, exists := prByKey[key]; !exists {
-			prByKey[key] = *pr
-		}
-	}
+// PullPullRequestHead fast-forwards the checked-out branch to the head commit
+// of the given pull request. It fails if the branch has diverged from it.
+func (self *GitHubCommands) PullPullRequestHead(task gocui.Task, remoteName string, number int) error {
+	cmdArgs := NewGitCmd("pull").
+		Arg("--no-edit", "--ff-only").
+		Arg(remoteName).
+		Arg(pullRequestHeadRef(number)).
+		ToArgv()
 
-	for _, branch := range branches {
-		if !branch.IsTrackingRemote() {
-			continue
-		}
+	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
+}
 
-		owner, foundRemoteOwner := remotesToOwnersMap[branch.UpstreamRemote]
-		if !foundRemoteOwner {
-			// UpstreamRemote may be a full URL rather than a remote name;
-			// try parsing the owner directly from it.
-			repoInfo, err := hosting_service.GetRepoInfoFromURL(branch.UpstreamRemote)
-			if err != nil {
-				continue
-			}
-			owner = repoInfo.Owner
-		}
+func pullRequestHeadRef(number int) string {
+	return fmt.Sprintf("refs/pull/%d/head", number)
+}
+
+type CreatePullRequestOpts struct {
+	Title string
+	Body  string
+	// the branch to merge, prefixed with "owner:" if it lives in a fork
+	Head string
+	// the branch to merge into; empty for the repo's default branch
+	Base  string
+	Draft bool
+	// user logins, or "org/team-slug" for teams
+	Reviewers []string
+}
 
-		pr, hasPr := prByKey[prKey{owner: strings.ToLower(owner), branchName: branch.UpstreamBranch}]
+type restPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"` // "open" or "closed"
+	Draft   bool   `json:"draft"`
+	HTMLURL string `json:"html_url"`
+	Body    string `json:"body"`
+	Head    struct {
+		Ref  string `json:"ref"`
+		Repo *struct {
+			Owner GithubRepositoryOwner `json:"owner"`
+		} `json:"repo"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	User GithubRepositoryOwner `json:"user"`
+}
+
+// CreatePullRequest opens a pull request in the repo identified by
+// serviceInfo. If requesting the reviewers fails, the pull request has still
+// been created, so it's returned along with the error.
+func (self *GitHubCommands) CreatePullRequest(serviceInfo *hosting_service.ServiceInfo, token string, opts CreatePullRequestOpts) (*models.PullRequest, error) {
+	repoURL := fmt.Sprintf("%s/repos/%s/%s", self.getAPIURL(serviceInfo.WebDomain), serviceInfo.Owner, serviceInfo.Repository)
+	headers := map[string]string{
+		"Authorization": "token " + token,
+		"Accept":        "application/vnd.github+json",
+	}
 
-		if !hasPr {
-			continue
+	base := opts.Base
+	if base == "" {
+		var repo struct {
+			DefaultBranch string `json:"default_branch"`
 		}
+		if err := getJSON(repoURL, headers, &repo); err != nil {
+			return nil, err
+		}
+		base = repo.DefaultBranch
+	}
 
-		res[branch.Name] = &pr
+	request := map[string]any{
+		"title": opts.Title,
+		"body":  opts.Body,
+		"head":  opts.Head,
+		"base":  base,
+		"draft": opts.Draft,
+	}
+	var created restPullRequest
+	if err := sendJSONRequest("POST", repoURL+"/pulls", headers, request, &created); err != nil {
+		return nil, err
 	}
 
-	return res
-}
+	pr := &models.PullRequest{
+		HeadRefName:       created.Head.Ref,
+		Number:            created.Number,
+		Title:             created.Title,
+		State:             lo.Ternary(created.Draft, "DRAFT", strings.ToUpper(created.State)),
+		Url:               created.HTMLURL,
+		BaseRefName:       created.Base.Ref,
+		Body:              created.Body,
+		Author:            created.User.Login,
+		IsCrossRepository: strings.Contains(opts.Head, ":"),
+	}
+	if created.Head.Repo != nil {
+		pr.HeadRepositoryOwner = models.RepositoryOwner{Login: created.Head.Repo.Owner.Login}
+	}
 
-func getRemotesToOwnersMap(remotes []*models.Remote) map[string]string {
-	res := map[string]string{}
-	for _, remote := range remotes {
-		if len(remote.Urls) == 0 {
-			continue
+	if len(opts.Reviewers) > 0 {
+		teams, users := lo.FilterReject(opts.Reviewers, func(reviewer string, _ int) bool {
+			return strings.Contains(reviewer, "/")
+		})
+		teamSlugs := lo.Map(teams, func(team string, _ int) string {
+			_, slug, _ := strings.Cut(team, "/")
+			return slug
+		})
+		reviewersRequest := map[string][]string{
+			"reviewers":      users,
+			"team_reviewers": teamSlugs,
 		}
-
-		repoInfo, err := hosting_service.GetRepoInfoFromURL(remote.Urls[0])
-		if err != nil {
-			continue
+		reviewersURL := fmt.Sprintf("%s/pulls/%d/requested_reviewers", repoURL, created.Number)
+		if err := sendJSONRequest("POST", reviewersURL, headers, reviewersRequest, nil); err != nil {
+			return pr, err
 		}
+	}
 
-		res[remote.Name] = repoInfo.Owner
+	return pr, nil
+}
+
+// gitHubAPIURL returns the base URL of the REST API for a GitHub host, which
+// is laid out like the GraphQL one (see below)
+func gitHubAPIURL(host string) string {
+	if auth.NormalizeHostname(host) == "github.com" {
+		return "https://api.github.com"
 	}
-	return res
+	return "https://" + host + "/api/v3"
 }
 
 // graphQLEndpoint returns the GraphQL API URL for a GitHub host. github.com
@@ -1,10 +1,15 @@
 package git_commands
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -76,31 +81,31 @@ func TestGraphQLEndpoint(t *testing.T) {
 	}
 }
 
-func TestGenerateGithubPullRequestMap(t *testing.T) {
+func TestGeneratePullRequestMap(t *testing.T) {
 	cases := []struct {
 		name     string
-		prs      []*models.GithubPullRequest
+		prs      []*models.PullRequest
 		branches []*models.Branch
 		remotes  []*models.Remote
-		expected map[string]*models.GithubPullRequest
+		expected map[string]*models.PullRequest
 	}{
 		{
 			name:     "empty inputs",
-			prs:      []*models.GithubPullRequest{},
+			prs:      []*models.PullRequest{},
 			branches: []*models.Branch{},
 			remotes:  []*models.Remote{},
-			expected: map[string]*models.GithubPullRequest{},
+			expected: map[string]*models.PullRequest{},
 		},
 		{
 			name: "matches PR to branch tracking origin",
-			prs: []*models.GithubPullRequest{
+			prs: []*models.PullRequest{
 				{
 					HeadRefName:         "feature-branch",
 					Number:              42,
 					Title:               "Add feature",
 					State:               "OPEN",
 					Url:                 "https://github.com/jesseduffield/lazygit/pull/42",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 			},
 			branches: []*models.Branch{
@@ -116,26 +121,26 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Urls: []string{"git@github.com:jesseduffield/lazygit.git"},
 				},
 			},
-			expected: map[string]*models.GithubPullRequest{
+			expected: map[string]*models.PullRequest{
 				"feature-branch": {
 					HeadRefName:         "feature-branch",
 					Number:              42,
 					Title:               "Add feature",
 					State:               "OPEN",
 					Url:                 "https://github.com/jesseduffield/lazygit/pull/42",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 			},
 		},
 		{
 			name: "does not match branch without upstream",
-			prs: []*models.GithubPullRequest{
+			prs: []*models.PullRequest{
 				{
 					HeadRefName:         "feature-branch",
 					Number:              42,
 					Title:               "Add feature",
 					State:               "OPEN",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 			},
 			branches: []*models.Branch{
@@ -150,17 +155,17 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Urls: []string{"git@github.com:jesseduffield/lazygit.git"},
 				},
 			},
-			expected: map[string]*models.GithubPullRequest{},
+			expected: map[string]*models.PullRequest{},
 		},
 		{
 			name: "matches fork PR to branch tracking fork remote",
-			prs: []*models.GithubPullRequest{
+			prs: []*models.PullRequest{
 				{
 					HeadRefName:         "fix-bug",
 					Number:              99,
 					Title:               "Fix bug",
 					State:               "OPEN",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "contributor"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
 				},
 			},
 			branches: []*models.Branch{
@@ -180,25 +185,25 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Urls: []string{"git@github.com:contributor/lazygit.git"},
 				},
 			},
-			expected: map[string]*models.GithubPullRequest{
+			expected: map[string]*models.PullRequest{
 				"fix-bug": {
 					HeadRefName:         "fix-bug",
 					Number:              99,
 					Title:               "Fix bug",
 					State:               "OPEN",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "contributor"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
 				},
 			},
 		},
 		{
 			name: "does not match when owner differs",
-			prs: []*models.GithubPullRequest{
+			prs: []*models.PullRequest{
 				{
 					HeadRefName:         "feature-branch",
 					Number:              42,
 					Title:               "Add feature",
 					State:               "OPEN",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "someone-else"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "someone-else"},
 				},
 			},
 			branches: []*models.Branch{
@@ -214,17 +219,17 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Urls: []string{"git@github.com:jesseduffield/lazygit.git"},
 				},
 			},
-			expected: map[string]*models.GithubPullRequest{},
+			expected: map[string]*models.PullRequest{},
 		},
 		{
 			name: "matches when UpstreamRemote is a full URL",
-			prs: []*models.GithubPullRequest{
+			prs: []*models.PullRequest{
 				{
 					HeadRefName:         "my-branch",
 					Number:              55,
 					Title:               "Full URL upstream",
 					State:               "OPEN",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "contributor"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
 				},
 			},
 			branches: []*models.Branch{
@@ -240,19 +245,19 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Urls: []string{"git@github.com:jesseduffield/lazygit.git"},
 				},
 			},
-			expected: map[string]*models.GithubPullRequest{
+			expected: map[string]*models.PullRequest{
 				"my-branch": {
 					HeadRefName:         "my-branch",
 					Number:              55,
 					Title:               "Full URL upstream",
 					State:               "OPEN",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "contributor"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
 				},
 			},
 		},
 		{
 			name: "uses first PR when branch name is reused (API returns newest first)",
-			prs: []*models.GithubPullRequest{
+			prs: []*models.PullRequest{
 				// API returns newest first (CREATED_AT DESC)
 				{
 					HeadRefName:         "update-sponsors",
@@ -260,7 +265,7 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Title:               "Newest PR",
 					State:               "CLOSED",
 					Url:                 "https://github.com/jesseduffield/lazygit/pull/50",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 				{
 					HeadRefName:         "update-sponsors",
@@ -268,7 +273,7 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Title:               "Middle PR",
 					State:               "OPEN",
 					Url:                 "https://github.com/jesseduffield/lazygit/pull/30",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 				{
 					HeadRefName:         "update-sponsors",
@@ -276,7 +281,7 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Title:               "Oldest PR",
 					State:               "CLOSED",
 					Url:                 "https://github.com/jesseduffield/lazygit/pull/10",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 			},
 			branches: []*models.Branch{
@@ -292,26 +297,26 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Urls: []string{"git@github.com:jesseduffield/lazygit.git"},
 				},
 			},
-			expected: map[string]*models.GithubPullRequest{
+			expected: map[string]*models.PullRequest{
 				"update-sponsors": {
 					HeadRefName:         "update-sponsors",
 					Number:              50,
 					Title:               "Newest PR",
 					State:               "CLOSED",
 					Url:                 "https://github.com/jesseduffield/lazygit/pull/50",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 			},
 		},
 		{
 			name: "matches with HTTPS remote URL",
-			prs: []*models.GithubPullRequest{
+			prs: []*models.PullRequest{
 				{
 					HeadRefName:         "my-pr",
 					Number:              10,
 					Title:               "My PR",
 					State:               "MERGED",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 			},
 			branches: []*models.Branch{
@@ -327,25 +332,25 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Urls: []string{"https://github.com/jesseduffield/lazygit.git"},
 				},
 			},
-			expected: map[string]*models.GithubPullRequest{
+			expected: map[string]*models.PullRequest{
 				"my-pr": {
 					HeadRefName:         "my-pr",
 					Number:              10,
 					Title:               "My PR",
 					State:               "MERGED",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "jesseduffield"},
 				},
 			},
 		},
 		{
 			name: "matches when owner casing differs",
-			prs: []*models.GithubPullRequest{
+			prs: []*models.PullRequest{
 				{
 					HeadRefName:         "fix-case-insensitive",
 					Number:              42,
 					Title:               "Fix case insensitive",
 					State:               "OPEN",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "Jesseduffield"}, // Uppercase J
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "Jesseduffield"}, // Uppercase J
 				},
 			},
 			branches: []*models.Branch{
@@ -361,13 +366,13 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 					Urls: []string{"git@github.com:jesseduffield/lazygit.git"}, // Lowercase j
 				},
 			},
-			expected: map[string]*models.GithubPullRequest{
+			expected: map[string]*models.PullRequest{
 				"fix-case-insensitive": {
 					HeadRefName:         "fix-case-insensitive",
 					Number:              42,
 					Title:               "Fix case insensitive",
 					State:               "OPEN",
-					HeadRepositoryOwner: models.GithubRepositoryOwner{Login: "Jesseduffield"},
+					HeadRepositoryOwner: models.RepositoryOwner{Login: "Jesseduffield"},
 				},
 			},
 		},
@@ -375,8 +380,253 @@ func TestGenerateGithubPullRequestMap(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			result := GenerateGithubPullRequestMap(c.prs, c.branches, c.remotes)
+			result := GeneratePullRequestMap(c.prs, c.branches, c.remotes)
 			assert.Equal(t, c.expected, result)
 		})
 	}
 }
+
+func TestFetchOpenPRs(t *testing.T) {
+	response := `{"data": {"repository": {"pullRequests": {"edges": [
+		{"node": {
+			"title": "Add feature",
+			"headRefName": "feature",
+			"baseRefName": "main",
+			"state": "OPEN",
+			"number": 12,
+			"url": "https://github.com/owner/repo/pull/12",
+			"isDraft": false,
+			"body": "Adds a feature",
+			"isCrossRepository": true,
+			"reviewDecision": "APPROVED",
+			"author": {"login": "contributor"},
+			"headRepositoryOwner": {"login": "contributor"},
+			"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "FAILURE"}}}]}
+		}},
+		{"node": {
+			"title": "Work in progress",
+			"headRefName": "wip",
+			"baseRefName": "main",
+			"state": "OPEN",
+			"number": 11,
+			"url": "https://github.com/owner/repo/pull/11",
+			"isDraft": true,
+			"body": "",
+			"isCrossRepository": false,
+			"reviewDecision": null,
+			"author": {"login": "owner"},
+			"headRepositoryOwner": {"login": "owner"},
+			"commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}
+		}}
+	]}}}}`
+
+	var receivedRequest graphQLRequest
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &receivedRequest)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	instance := buildGitHubCommands(commonDeps{})
+	instance.getGraphQLEndpoint = func(host string) string {
+		assert.Equal(t, "github.com", host)
+		return server.URL
+	}
+
+	prs, err := instance.FetchOpenPRs(&hosting_service.ServiceInfo{
+		WebDomain:  "github.com",
+		Owner:      "owner",
+		Repository: "repo",
+	}, "secret")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "token secret", receivedAuth)
+	assert.Equal(t, map[string]string{"owner": "owner", "repo": "repo"}, receivedRequest.Variables)
+	assert.Contains(t, receivedRequest.Query, "states: OPEN")
+
+	assert.Equal(t, []*models.PullRequest{
+		{
+			HeadRefName:         "feature",
+			Number:              12,
+			Title:               "Add feature",
+			State:               "OPEN",
+			Url:                 "https://github.com/owner/repo/pull/12",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
+			BaseRefName:         "main",
+			Body:                "Adds a feature",
+			Author:              "contributor",
+			IsCrossRepository:   true,
+			ReviewDecision:      "APPROVED",
+			ChecksStatus:        "FAILURE",
+		},
+		{
+			HeadRefName:         "wip",
+			Number:              11,
+			Title:               "Work in progress",
+			State:               "DRAFT",
+			Url:                 "https://github.com/owner/repo/pull/11",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "owner"},
+			BaseRefName:         "main",
+			Author:              "owner",
+		},
+	}, prs)
+}
+
+func TestFetchOpenPRsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Bad credentials"))
+	}))
+	defer server.Close()
+
+	instance := buildGitHubCommands(commonDeps{})
+	instance.getGraphQLEndpoint = func(string) string { return server.URL }
+
+	_, err := instance.FetchOpenPRs(&hosting_service.ServiceInfo{WebDomain: "github.com", Owner: "owner", Repository: "repo"}, "secret")
+	assert.ErrorContains(t, err, "Bad credentials")
+}
+
+func TestFetchPullRequestHead(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"fetch", "--no-write-fetch-head", "origin", "refs/pull/12/head:refs/heads/pr/12"}, "", nil)
+	instance := buildGitHubCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.FetchPullRequestHead(nil, "origin", 12, "pr/12"))
+	runner.CheckForMissingCalls()
+}
+
+func TestFetchPullRequestHeadCommit(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"fetch", "origin", "refs/pull/12/head"}, "", nil).
+		ExpectGitArgs([]string{"rev-parse", "FETCH_HEAD"}, "abc123\n", nil)
+	instance := buildGitHubCommands(commonDeps{runner: runner})
+
+	hash, err := instance.FetchPullRequestHeadCommit(nil, "origin", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", hash)
+	runner.CheckForMissingCalls()
+}
+
+func TestPullPullRequestHead(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"pull", "--no-edit", "--ff-only", "origin", "refs/pull/12/head"}, "", nil)
+	instance := buildGitHubCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.PullPullRequestHead(nil, "origin", 12))
+	runner.CheckForMissingCalls()
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	type receivedRequest struct {
+		method string
+		path   string
+		body   map[string]any
+	}
+	var requests []receivedRequest
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		var body map[string]any
+		bodyBytes, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(bodyBytes, &body)
+		requests = append(requests, receivedRequest{method: r.Method, path: r.URL.Path, body: body})
+
+		switch r.URL.Path {
+		case "/repos/owner/repo":
+			_, _ = w.Write([]byte(`{"default_branch": "main"}`))
+		case "/repos/owner/repo/pulls":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{
+				"number": 42,
+				"title": "Add feature",
+				"state": "open",
+				"draft": true,
+				"html_url": "https://github.com/owner/repo/pull/42",
+				"body": "Details",
+				"head": {"ref": "feature", "repo": {"owner": {"login": "contributor"}}},
+				"base": {"ref": "main"},
+				"user": {"login": "contributor"}
+			}`))
+		case "/repos/owner/repo/pulls/42/requested_reviewers":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	instance := buildGitHubCommands(commonDeps{})
+	instance.getAPIURL = func(host string) string {
+		assert.Equal(t, "github.com", host)
+		return server.URL
+	}
+
+	pr, err := instance.CreatePullRequest(&hosting_service.ServiceInfo{
+		WebDomain:  "github.com",
+		Owner:      "owner",
+		Repository: "repo",
+	}, "secret", CreatePullRequestOpts{
+		Title:     "Add feature",
+		Body:      "Details",
+		Head:      "contributor:feature",
+		Draft:     true,
+		Reviewers: []string{"alice", "org/core-team"},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "token secret", receivedAuth)
+	assert.Equal(t, []receivedRequest{
+		{method: "GET", path: "/repos/owner/repo"},
+		{method: "POST", path: "/repos/owner/repo/pulls", body: map[string]any{
+			"title": "Add feature",
+			"body":  "Details",
+			"head":  "contributor:feature",
+			"base":  "main",
+			"draft": true,
+		}},
+		{method: "POST", path: "/repos/owner/repo/pulls/42/requested_reviewers", body: map[string]any{
+			"reviewers":      []any{"alice"},
+			"team_reviewers": []any{"core-team"},
+		}},
+	}, requests)
+
+	assert.Equal(t, &models.PullRequest{
+		HeadRefName:         "feature",
+		Number:              42,
+		Title:               "Add feature",
+		State:               "DRAFT",
+		Url:                 "https://github.com/owner/repo/pull/42",
+		HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
+		BaseRefName:         "main",
+		Body:                "Details",
+		Author:              "contributor",
+		IsCrossRepository:   true,
+	}, pr)
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+	}))
+	defer server.Close()
+
+	instance := buildGitHubCommands(commonDeps{})
+	instance.getAPIURL = func(string) string { return server.URL }
+
+	pr, err := instance.CreatePullRequest(&hosting_service.ServiceInfo{
+		Owner:      "owner",
+		Repository: "repo",
+	}, "secret", CreatePullRequestOpts{Title: "Title", Head: "feature", Base: "main"})
+	assert.Nil(t, pr)
+	assert.ErrorContains(t, err, "Validation Failed")
+}
+
+func TestGitHubAPIURL(t *testing.T) {
+	assert.Equal(t, "https://api.github.com", gitHubAPIURL("github.com"))
+	assert.Equal(t, "https://ghe.example.com/api/v3", gitHubAPIURL("ghe.example.com"))
+}
@@ -0,0 +1,133 @@
+package git_commands
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+)
+
+// GitLabCommands loads merge requests from gitlab.com or a self-hosted GitLab
+// instance
+type GitLabCommands struct {
+	*GitCommon
+	// returns the base URL of the REST API for the given host; tests point
+	// this at a local server
+	getAPIURL func(host string) string
+}
+
+func NewGitLabCommands(gitCommon *GitCommon) *GitLabCommands {
+	return &GitLabCommands{
+		GitCommon: gitCommon,
+		getAPIURL: gitLabAPIURL,
+	}
+}
+
+type gitLabMergeRequest struct {
+	IID             int    `json:"iid"`
+	Title           string `json:"title"`
+	State           string `json:"state"` // "opened", "closed", "locked" or "merged"
+	Draft           bool   `json:"draft"`
+	WebURL          string `json:"web_url"`
+	SourceBranch    string `json:"source_branch"`
+	TargetBranch    string `json:"target_branch"`
+	SourceProjectID int    `json:"source_project_id"`
+	ProjectID       int    `json:"project_id"`
+	Description     string `json:"description"`
+	Author          struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+type gitLabProject struct {
+	Namespace struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+}
+
+// uses the same environment variables as the glab CLI, which also uses
+// GITLAB_HOST to say which self-hosted instance the token is for
+func (self *GitLabCommands) GetAuthToken(host string) string {
+	return authTokenForHost(host, "gitlab.com", "GITLAB_HOST", "GITLAB_TOKEN", "GITLAB_ACCESS_TOKEN")
+}
+
+func (self *GitLabCommands) FetchRecentPRs(branches []string, serviceInfo *hosting_service.ServiceInfo, token string) ([]*models.PullRequest, error) {
+	apiURL := self.getAPIURL(serviceInfo.WebDomain)
+	headers := map[string]string{"PRIVATE-TOKEN": token}
+
+	// GitLab projects can be nested in subgroups, so the owner may contain
+	// slashes; the API wants the whole path as a single escaped segment
+	projectPath := url.PathEscape(serviceInfo.Owner + "/" + serviceInfo.Repository)
+	mergeRequestsURL := fmt.Sprintf("%s/projects/%s/merge_requests?state=all&order_by=updated_at&sort=desc&per_page=%d",
+		apiURL, projectPath, recentPullRequestsLimit)
+
+	var mergeRequests []gitLabMergeRequest
+	if err := getJSON(mergeRequestsURL, headers, &mergeRequests); err != nil {
+		return nil, err
+	}
+
+	prs := []*models.PullRequest{}
+	// the merge requests don't tell us the namespace of the project they come
+	// from, so we need to look that up for merge requests from forks. Empty if
+	// the lookup failed.
+	namespacesByProjectID := map[int]string{}
+	for _, mr := range mergeRequests {
+		if !lo.Contains(branches, mr.SourceBranch) {
+			continue
+		}
+
+		owner := serviceInfo.Owner
+		if mr.SourceProjectID != mr.ProjectID {
+			namespace, ok := namespacesByProjectID[mr.SourceProjectID]
+			if !ok {
+				var project gitLabProject
+				projectURL := apiURL + "/projects/" + strconv.Itoa(mr.SourceProjectID)
+				if err := getJSON(projectURL, headers, &project); err != nil {
+					// the fork may have been deleted, or we may not have
+					// access to it
+					self.Log.Warnf("failed to look up the source project of merge request !%d: %v", mr.IID, err)
+				}
+				namespace = project.Namespace.FullPath
+				namespacesByProjectID[mr.SourceProjectID] = namespace
+			}
+			if namespace == "" {
+				// we can't tell which branch the merge request belongs to
+				continue
+			}
+			owner = namespace
+		}
+
+		prs = append(prs, &models.PullRequest{
+			HeadRefName:         mr.SourceBranch,
+			Number:              mr.IID,
+			Title:               mr.Title,
+			State:               gitLabPullRequestState(mr.State, mr.Draft),
+			Url:                 mr.WebURL,
+			HeadRepositoryOwner: models.RepositoryOwner{Login: owner},
+			BaseRefName:         mr.TargetBranch,
+			Body:                mr.Description,
+			Author:              mr.Author.Username,
+			IsCrossRepository:   mr.SourceProjectID != mr.ProjectID,
+		})
+	}
+
+	return prs, nil
+}
+
+func gitLabPullRequestState(state string, draft bool) string {
+	switch state {
+	case "merged":
+		return "MERGED"
+	case "closed", "locked":
+		return "CLOSED"
+	default:
+		return lo.Ternary(draft, "DRAFT", "OPEN")
+	}
+}
+
+func gitLabAPIURL(host string) string {
+	return "https://" + host + "/api/v4"
+}
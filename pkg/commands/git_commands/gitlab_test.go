@@ -0,0 +1,147 @@
+package git_commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitLabFetchRecentPRs(t *testing.T) {
+	mergeRequests := `[
+		{"iid": 8, "title": "From a deleted fork", "state": "opened", "draft": false,
+		 "web_url": "https://git.example.com/group/sub/repo/-/merge_requests/8",
+		 "source_branch": "feature", "target_branch": "main",
+		 "source_project_id": 43, "project_id": 1, "author": {"username": "someone"}},
+		{"iid": 7, "title": "From a fork", "state": "opened", "draft": false,
+		 "web_url": "https://git.example.com/group/sub/repo/-/merge_requests/7",
+		 "source_branch": "feature", "target_branch": "main",
+		 "source_project_id": 42, "project_id": 1, "author": {"username": "contributor"}},
+		{"iid": 6, "title": "Draft: wip", "state": "opened", "draft": true,
+		 "web_url": "https://git.example.com/group/sub/repo/-/merge_requests/6",
+		 "source_branch": "wip", "target_branch": "main",
+		 "source_project_id": 1, "project_id": 1, "author": {"username": "owner"}},
+		{"iid": 5, "title": "Merged", "state": "merged", "draft": false,
+		 "web_url": "https://git.example.com/group/sub/repo/-/merge_requests/5",
+		 "source_branch": "done", "target_branch": "main",
+		 "source_project_id": 1, "project_id": 1, "author": {"username": "owner"}},
+		{"iid": 4, "title": "Not one of ours", "state": "closed", "draft": false,
+		 "web_url": "https://git.example.com/group/sub/repo/-/merge_requests/4",
+		 "source_branch": "other", "target_branch": "main",
+		 "source_project_id": 1, "project_id": 1, "author": {"username": "owner"}}
+	]`
+
+	var requestedURIs []string
+	var receivedTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURIs = append(requestedURIs, r.RequestURI)
+		receivedTokens = append(receivedTokens, r.Header.Get("PRIVATE-TOKEN"))
+		switch r.URL.Path {
+		case "/projects/group/sub/repo/merge_requests":
+			_, _ = w.Write([]byte(mergeRequests))
+		case "/projects/42":
+			_, _ = w.Write([]byte(`{"namespace": {"full_path": "contributor"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	instance := buildGitLabCommands(commonDeps{})
+	instance.getAPIURL = func(host string) string {
+		assert.Equal(t, "git.example.com", host)
+		return server.URL
+	}
+
+	prs, err := instance.FetchRecentPRs([]string{"feature", "wip", "done"}, &hosting_service.ServiceInfo{
+		Provider:   "gitlab",
+		WebDomain:  "git.example.com",
+		Owner:      "group/sub",
+		Repository: "repo",
+	}, "secret")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/projects/group%2Fsub%2Frepo/merge_requests?state=all&order_by=updated_at&sort=desc&per_page=100",
+		"/projects/43",
+		"/projects/42",
+	}, requestedURIs)
+	assert.Equal(t, []string{"secret", "secret", "secret"}, receivedTokens)
+
+	assert.Equal(t, []*models.PullRequest{
+		{
+			HeadRefName:         "feature",
+			Number:              7,
+			Title:               "From a fork",
+			State:               "OPEN",
+			Url:                 "https://git.example.com/group/sub/repo/-/merge_requests/7",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "contributor"},
+			BaseRefName:         "main",
+			Author:              "contributor",
+			IsCrossRepository:   true,
+		},
+		{
+			HeadRefName:         "wip",
+			Number:              6,
+			Title:               "Draft: wip",
+			State:               "DRAFT",
+			Url:                 "https://git.example.com/group/sub/repo/-/merge_requests/6",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "group/sub"},
+			BaseRefName:         "main",
+			Author:              "owner",
+		},
+		{
+			HeadRefName:         "done",
+			Number:              5,
+			Title:               "Merged",
+			State:               "MERGED",
+			Url:                 "https://git.example.com/group/sub/repo/-/merge_requests/5",
+			HeadRepositoryOwner: models.RepositoryOwner{Login: "group/sub"},
+			BaseRefName:         "main",
+			Author:              "owner",
+		},
+	}, prs)
+}
+
+func TestGitLabFetchRecentPRsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "401 Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	instance := buildGitLabCommands(commonDeps{})
+	instance.getAPIURL = func(string) string { return server.URL }
+
+	_, err := instance.FetchRecentPRs([]string{"feature"}, &hosting_service.ServiceInfo{
+		Owner:      "owner",
+		Repository: "repo",
+	}, "bad-token")
+	assert.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestGitLabGetAuthToken(t *testing.T) {
+	instance := buildGitLabCommands(commonDeps{})
+
+	t.Setenv("GITLAB_TOKEN", "")
+	t.Setenv("GITLAB_ACCESS_TOKEN", "")
+	assert.Equal(t, "", instance.GetAuthToken("gitlab.com"))
+
+	t.Setenv("GITLAB_ACCESS_TOKEN", "access-token")
+	assert.Equal(t, "access-token", instance.GetAuthToken("gitlab.com"))
+
+	t.Setenv("GITLAB_TOKEN", "token")
+	assert.Equal(t, "token", instance.GetAuthToken("gitlab.com"))
+
+	// the token isn't sent to self-hosted instances unless it's for them
+	t.Setenv("GITLAB_HOST", "")
+	assert.Equal(t, "", instance.GetAuthToken("gitlab.example.com"))
+
+	t.Setenv("GITLAB_HOST", "https://GitLab.example.com/")
+	assert.Equal(t, "token", instance.GetAuthToken("gitlab.example.com"))
+	assert.Equal(t, "token", instance.GetAuthToken("gitlab.com"))
+	assert.Equal(t, "", instance.GetAuthToken("evil.example.com"))
+}
@@ -0,0 +1,623 @@
+package git_commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+)
+
+// The operation journal keeps track of destructive operations that don't show
+// up in the reflog (discarding changes, unstaging lines, dropping stashes), so
+// that the undo/redo keys can revert them. For each operation we store the
+// state of the index and of the touched files before and after the operation;
+// file contents are written as blobs to the object database, and kept alive
+// (together with dropped stashes) by a ref that only we use, see pin.
+
+type JournalEntryKind string
+
+const (
+	JOURNAL_OPERATION JournalEntryKind = "operation"
+	// Markers written when undoing/redoing an operation, playing the same role
+	// as the "[lazygit undo]"/"[lazygit redo]" reflog entries
+	JOURNAL_UNDO JournalEntryKind = "undo"
+	JOURNAL_REDO JournalEntryKind = "redo"
+)
+
+// The maximum number of entries we keep; older ones are dropped. New entries
+// are appended to the file, and we only trim it once it has grown to twice
+// this size.
+const maxJournalEntries = 100
+
+// Limits on how much we are willing to copy into the object database for a
+// single operation (e.g. nuking a working tree with large untracked
+// directories); beyond that, the operation is performed without recording it
+const (
+	maxSnapshotFiles = 10000
+	maxSnapshotBytes = 100 * 1024 * 1024
+)
+
+var (
+	errSnapshotTooLarge   = errors.New("too many or too large files to record in the journal")
+	errJournalObjectsGone = errors.New("the recorded state is no longer available in the repository")
+)
+
+// The message of the commits we create to keep the journal's objects alive
+const journalPinMessage = "lazygit journal"
+
+type JournalFile struct {
+	// Empty if the file didn't exist
+	Blob string      `json:"blob,omitempty"`
+	Mode os.FileMode `json:"mode,omitempty"`
+}
+
+type JournalSnapshot struct {
+	// Empty if the index couldn't be written as a tree, e.g. because of
+	// merge conflicts; in that case we leave the index alone when restoring
+	IndexTree string                 `json:"indexTree,omitempty"`
+	Paths     []string               `json:"paths"`
+	Files     map[string]JournalFile `json:"files"`
+}
+
+type JournalStash struct {
+	Hash    string `json:"hash"`
+	Message string `json:"message"`
+}
+
+type JournalEntry struct {
+	Kind        JournalEntryKind `json:"kind"`
+	Description string           `json:"description,omitempty"`
+	// The number of entries in the HEAD reflog at the time the entry was
+	// written, and the newest of those entries (the raw line from the reflog
+	// file); used to interleave journal entries with reflog entries
+	ReflogLength   int              `json:"reflogLength"`
+	ReflogAnchor   string           `json:"reflogAnchor,omitempty"`
+	Before         *JournalSnapshot `json:"before,omitempty"`
+	After          *JournalSnapshot `json:"after,omitempty"`
+	DroppedStashes []JournalStash   `json:"droppedStashes,omitempty"`
+
+	// The number of HEAD reflog entries that were written after this entry.
+	// Computed when loading the journal.
+	NewerReflogEntries int `json:"-"`
+}
+
+type JournalCommands struct {
+	*GitCommon
+	stash *StashCommands
+}
+
+func NewJournalCommands(gitCommon *GitCommon, stash *StashCommands) *JournalCommands {
+	return &JournalCommands{
+		GitCommon: gitCommon,
+		stash:     stash,
+	}
+}
+
+func (self *JournalCommands) journalPath() string {
+	return filepath.Join(self.repoPaths.WorktreeGitDirPath(), "lazygit", "journal.jsonl")
+}
+
+// Record snapshots the given paths (relative to the worktree root; directories
+// are expanded) and the index, runs f, and writes a journal entry so that the
+// operation can be undone. Failing to record the entry doesn't fail the
+// operation itself.
+func (self *JournalCommands) Record(description string, paths []string, f func() error) error {
+	before, err := self.Snapshot(paths)
+	if err != nil {
+		self.Log.Errorf("failed to snapshot files for the journal: %v", err)
+		return f()
+	}
+
+	if err := f(); err != nil {
+		return err
+	}
+
+	after, err := self.Snapshot(before.Paths)
+	if err != nil {
+		self.Log.Errorf("failed to snapshot files for the journal: %v", err)
+		return nil
+	}
+
+	if err := self.Append(&JournalEntry{
+		Kind:        JOURNAL_OPERATION,
+		Description: description,
+		Before:      before,
+		After:       after,
+	}); err != nil {
+		self.Log.Errorf("failed to write journal entry: %v", err)
+	}
+	return nil
+}
+
+// RecordStashDrops remembers the stash entries at the given indices, runs f
+// (which is expected to drop them), and writes a journal entry so that the
+// entries can be restored.
+func (self *JournalCommands) RecordStashDrops(description string, indices []int, f func() error) error {
+	stashes := make([]JournalStash, 0, len(indices))
+	for _, index := range indices {
+		stash, err := self.stashAt(index)
+		if err != nil {
+			self.Log.Errorf("failed to read stash entry for the journal: %v", err)
+			return f()
+		}
+		stashes = append(stashes, stash)
+	}
+
+	if err := f(); err != nil {
+		return err
+	}
+
+	if err := self.Append(&JournalEntry{
+		Kind:           JOURNAL_OPERATION,
+		Description:    description,
+		DroppedStashes: stashes,
+	}); err != nil {
+		self.Log.Errorf("failed to write journal entry: %v", err)
+	}
+	return nil
+}
+
+func (self *JournalCommands) stashAt(index int) (JournalStash, error) {
+	hash, err := self.stash.Hash(index)
+	if err != nil {
+		return JournalStash{}, err
+	}
+
+	cmdArgs := NewGitCmd("log").Arg("-g", "-1", "--format=%gs", fmt.Sprintf("refs/stash@{%d}", index)).ToArgv()
+	message, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return JournalStash{}, err
+	}
+
+	return JournalStash{Hash: hash, Message: strings.TrimRight(message, "\r\n")}, nil
+}
+
+// Undo reverts the effect of the given operation entry
+func (self *JournalCommands) Undo(entry *JournalEntry) error {
+	// check this up front so that we don't restore half of the state
+	stashHashes := lo.Map(entry.DroppedStashes, func(stash JournalStash, _ int) string { return stash.Hash })
+	if err := self.checkObjectsExist(append(entry.Before.objects(), stashHashes...)); err != nil {
+		return err
+	}
+
+	if entry.Before != nil {
+		if err := self.Restore(entry.Before); err != nil {
+			return err
+		}
+	}
+
+	// stashes are dropped from the highest index down; storing them back in
+	// the same order keeps their relative order
+	for _, stash := range entry.DroppedStashes {
+		if err := self.stash.Store(stash.Hash, stash.Message); err != nil {
+			return err
+		}
+	}
+
+	return self.Append(&JournalEntry{Kind: JOURNAL_UNDO, Description: entry.Description})
+}
+
+// Redo re-applies the effect of the given operation entry after it was undone
+func (self *JournalCommands) Redo(entry *JournalEntry) error {
+	if err := self.checkObjectsExist(entry.After.objects()); err != nil {
+		return err
+	}
+
+	if entry.After != nil {
+		if err := self.Restore(entry.After); err != nil {
+			return err
+		}
+	}
+
+	for _, stash := range entry.DroppedStashes {
+		if err := self.dropStashByHash(stash.Hash); err != nil {
+			return err
+		}
+	}
+
+	return self.Append(&JournalEntry{Kind: JOURNAL_REDO, Description: entry.Description})
+}
+
+func (self *JournalCommands) dropStashByHash(hash string) error {
+	cmdArgs := NewGitCmd("log").Arg("-g", "--format=%H", "refs/stash").ToArgv()
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return err
+	}
+
+	index := slices.Index(strings.Split(strings.TrimSpace(output), "\n"), hash)
+	if index == -1 {
+		return errors.Errorf("stash entry %s no longer exists", hash)
+	}
+	return self.stash.Drop(index)
+}
+
+// objects returns the hashes of the index tree and blobs of the snapshot
+func (self *JournalSnapshot) objects() []string {
+	if self == nil {
+		return nil
+	}
+
+	var hashes []string
+	if self.IndexTree != "" {
+		hashes = append(hashes, self.IndexTree)
+	}
+	for _, file := range self.Files {
+		if file.Blob != "" {
+			hashes = append(hashes, file.Blob)
+		}
+	}
+	return hashes
+}
+
+// checkObjectsExist returns errJournalObjectsGone if any of the given objects
+// is missing from the object database
+func (self *JournalCommands) checkObjectsExist(hashes []string) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	cmdArgs := NewGitCmd("cat-file").Arg("--batch-check").ToArgv()
+	output, err := self.cmd.New(cmdArgs).
+		SetStdin(strings.Join(hashes, "\n") + "\n").
+		DontLog().RunWithOutput()
+	if err != nil {
+		return err
+	}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		if strings.HasSuffix(line, " missing") {
+			return errJournalObjectsGone
+		}
+	}
+	return nil
+}
+
+// Snapshot records the current index tree and the contents of the given
+// files, writing the contents to the object database
+func (self *JournalCommands) Snapshot(paths []string) (*JournalSnapshot, error) {
+	snapshot := &JournalSnapshot{Files: map[string]JournalFile{}}
+
+	// an unmerged index can't be written as a tree; that's fine, we just won't
+	// be able to restore it
+	if indexTree, err := self.cmd.New(NewGitCmd("write-tree").ToArgv()).DontLog().RunWithOutput(); err == nil {
+		snapshot.IndexTree = strings.TrimSpace(indexTree)
+	}
+
+	var filesToHash, absPathsToHash []string
+	var totalBytes int64
+	for _, path := range paths {
+		err := afero.Walk(self.Fs, self.absPath(path), func(absPath string, info fs.FileInfo, err error) error {
+			relPath := self.relPath(absPath)
+			if errors.Is(err, fs.ErrNotExist) {
+				snapshot.Paths = append(snapshot.Paths, relPath)
+				snapshot.Files[relPath] = JournalFile{}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if info.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				// don't descend into submodules; we only restore their index entry
+				if _, err := self.Fs.Stat(filepath.Join(absPath, ".git")); err == nil {
+					snapshot.Paths = append(snapshot.Paths, relPath)
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			snapshot.Paths = append(snapshot.Paths, relPath)
+			// We only keep the contents of regular files; for anything else
+			// (symlinks, submodules) we only restore the index entry
+			if info.Mode().IsRegular() {
+				totalBytes += info.Size()
+				if len(filesToHash) >= maxSnapshotFiles || totalBytes > maxSnapshotBytes {
+					return errSnapshotTooLarge
+				}
+				snapshot.Files[relPath] = JournalFile{Mode: info.Mode().Perm()}
+				filesToHash = append(filesToHash, relPath)
+				absPathsToHash = append(absPathsToHash, absPath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(filesToHash) > 0 {
+		cmdArgs := NewGitCmd("hash-object").Arg("-w", "--no-filters", "--stdin-paths").ToArgv()
+		output, err := self.cmd.New(cmdArgs).
+			SetStdin(strings.Join(absPathsToHash, "\n") + "\n").
+			DontLog().RunWithOutput()
+		if err != nil {
+			return nil, err
+		}
+
+		blobs := strings.Fields(output)
+		if len(blobs) != len(filesToHash) {
+			return nil, errors.Errorf("expected %d blobs from git hash-object, got %d", len(filesToHash), len(blobs))
+		}
+		for i, path := range filesToHash {
+			file := snapshot.Files[path]
+			file.Blob = blobs[i]
+			snapshot.Files[path] = file
+		}
+	}
+
+	snapshot.Paths = lo.Uniq(snapshot.Paths)
+	return snapshot, nil
+}
+
+// Restore brings the index entries and files of the snapshot back to the
+// recorded state
+func (self *JournalCommands) Restore(snapshot *JournalSnapshot) error {
+	if snapshot.IndexTree != "" && len(snapshot.Paths) > 0 {
+		cmdArgs := NewGitCmd("reset").Arg("-q", snapshot.IndexTree, "--").Arg(snapshot.Paths...).ToArgv()
+		if err := self.cmd.New(cmdArgs).Run(); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range snapshot.Paths {
+		file, ok := snapshot.Files[path]
+		if !ok {
+			continue
+		}
+
+		absPath := self.absPath(path)
+		if file.Blob == "" {
+			if err := self.Fs.Remove(absPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+
+		cmdArgs := NewGitCmd("cat-file").Arg("blob", file.Blob).ToArgv()
+		content, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+		if err != nil {
+			return err
+		}
+		if err := self.Fs.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(self.Fs, absPath, []byte(content), file.Mode); err != nil {
+			return err
+		}
+		if err := self.Fs.Chmod(absPath, file.Mode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *JournalCommands) absPath(path string) string {
+	return filepath.Join(self.repoPaths.WorktreePath(), path)
+}
+
+func (self *JournalCommands) relPath(absPath string) string {
+	relPath, err := filepath.Rel(self.repoPaths.WorktreePath(), absPath)
+	if err != nil {
+		return absPath
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// Entries returns the journal entries, oldest first
+func (self *JournalCommands) Entries() ([]*JournalEntry, error) {
+	content, err := afero.ReadFile(self.Fs, self.journalPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*JournalEntry{}
+	for line := range strings.SplitSeq(string(content), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			// skip corrupt lines rather than losing the whole journal
+			self.Log.Errorf("failed to parse journal entry: %v", err)
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+
+	if len(entries) >= 2*maxJournalEntries {
+		entries = entries[len(entries)-maxJournalEntries:]
+		if err := self.rewrite(entries); err != nil {
+			self.Log.Errorf("failed to trim journal: %v", err)
+		} else if err := self.pin(entries, ""); err != nil {
+			self.Log.Errorf("failed to update the journal's ref: %v", err)
+		}
+	} else if len(entries) > maxJournalEntries {
+		entries = entries[len(entries)-maxJournalEntries:]
+	}
+
+	reflogLines := self.reflogLines()
+	for _, entry := range entries {
+		entry.NewerReflogEntries = newerReflogEntries(entry, reflogLines)
+	}
+	return entries, nil
+}
+
+// newerReflogEntries returns how many of the given reflog lines (oldest first)
+// were written after the entry. The reflog may have shrunk since the entry was
+// written (e.g. because old entries expired), so we look for the entry's
+// anchor line at or below its recorded position; if it's gone, the entry is
+// older than everything that's left.
+func newerReflogEntries(entry *JournalEntry, reflogLines []string) int {
+	if entry.ReflogAnchor == "" {
+		// no anchor: either the reflog was empty at the time, or the entry
+		// predates anchors and all we can go by is the recorded length
+		return max(len(reflogLines)-entry.ReflogLength, 0)
+	}
+
+	for i := min(entry.ReflogLength, len(reflogLines)) - 1; i >= 0; i-- {
+		if reflogLines[i] == entry.ReflogAnchor {
+			return len(reflogLines) - i - 1
+		}
+	}
+	return len(reflogLines)
+}
+
+// Append adds an entry to the journal, stamping it with the current state of
+// the HEAD reflog
+func (self *JournalCommands) Append(entry *JournalEntry) error {
+	reflogLines := self.reflogLines()
+	entry.ReflogLength = len(reflogLines)
+	entry.ReflogAnchor = ""
+	if len(reflogLines) > 0 {
+		entry.ReflogAnchor = reflogLines[len(reflogLines)-1]
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := self.Fs.MkdirAll(filepath.Dir(self.journalPath()), 0o755); err != nil {
+		return err
+	}
+	file, err := self.Fs.OpenFile(self.journalPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if len(entry.Before.objects()) == 0 && len(entry.After.objects()) == 0 && len(entry.DroppedStashes) == 0 {
+		return nil
+	}
+	return self.pin([]*JournalEntry{entry}, self.pinnedCommit())
+}
+
+// The objects of the journal (snapshot blobs and index trees, and dropped
+// stashes) aren't referenced by anything git knows about, so `git gc` would
+// prune them. To keep them alive we point a ref at a commit whose tree lists
+// them and whose parents are the dropped stashes. Each new entry adds such a
+// commit on top of the previous one; when the journal is trimmed, we replace
+// them all with a single commit for the remaining entries, so that git can
+// prune the objects of the dropped entries.
+func (self *JournalCommands) pinRef() string {
+	// the journal is per worktree, but refs in refs/worktree/ don't keep
+	// objects alive when gc runs in another worktree
+	rel, err := filepath.Rel(self.repoPaths.RepoGitDirPath(), self.repoPaths.WorktreeGitDirPath())
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "refs/lazygit/journal"
+	}
+	return "refs/lazygit/" + filepath.ToSlash(rel) + "/journal"
+}
+
+func (self *JournalCommands) pinnedCommit() string {
+	cmdArgs := NewGitCmd("rev-parse").Arg("--verify", "-q", self.pinRef()).ToArgv()
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// pin makes the ref point to a commit that keeps the objects of the given
+// entries alive, with the given commit (if any) as its first parent
+func (self *JournalCommands) pin(entries []*JournalEntry, parent string) error {
+	var treeLines, stashHashes []string
+	for _, entry := range entries {
+		for _, snapshot := range []*JournalSnapshot{entry.Before, entry.After} {
+			if snapshot == nil {
+				continue
+			}
+			if snapshot.IndexTree != "" {
+				treeLines = append(treeLines, fmt.Sprintf("040000 tree %s\t%s", snapshot.IndexTree, snapshot.IndexTree))
+			}
+			for _, file := range snapshot.Files {
+				if file.Blob != "" {
+					treeLines = append(treeLines, fmt.Sprintf("100644 blob %s\t%s", file.Blob, file.Blob))
+				}
+			}
+		}
+		for _, stash := range entry.DroppedStashes {
+			stashHashes = append(stashHashes, stash.Hash)
+		}
+	}
+
+	if len(treeLines) == 0 && len(stashHashes) == 0 {
+		if parent != "" {
+			// nothing new to keep alive
+			return nil
+		}
+		cmdArgs := NewGitCmd("update-ref").Arg("-d", self.pinRef()).ToArgv()
+		return self.cmd.New(cmdArgs).DontLog().Run()
+	}
+
+	treeLines = lo.Uniq(treeLines)
+	slices.Sort(treeLines)
+	treeInput := ""
+	if len(treeLines) > 0 {
+		// mktree doesn't accept a lone newline for an empty tree
+		treeInput = strings.Join(treeLines, "\n") + "\n"
+	}
+	mktreeArgs := NewGitCmd("mktree").ToArgv()
+	tree, err := self.cmd.New(mktreeArgs).SetStdin(treeInput).DontLog().RunWithOutput()
+	if err != nil {
+		return err
+	}
+
+	parents := lo.Uniq(lo.Compact(append([]string{parent}, stashHashes...)))
+	commitTreeArgs := NewGitCmd("commit-tree").
+		Arg("--no-gpg-sign", "-m", journalPinMessage).
+		Arg(lo.FlatMap(parents, func(parent string, _ int) []string { return []string{"-p", parent} })...).
+		Arg(strings.TrimSpace(tree)).
+		ToArgv()
+	// the user may not have configured an identity, and it doesn't matter
+	// who authored these commits anyway
+	commit, err := self.cmd.New(commitTreeArgs).
+		AddEnvVars("GIT_AUTHOR_NAME=lazygit", "GIT_AUTHOR_EMAIL=lazygit@localhost",
+			"GIT_COMMITTER_NAME=lazygit", "GIT_COMMITTER_EMAIL=lazygit@localhost").
+		DontLog().RunWithOutput()
+	if err != nil {
+		return err
+	}
+
+	updateRefArgs := NewGitCmd("update-ref").Arg(self.pinRef(), strings.TrimSpace(commit)).ToArgv()
+	return self.cmd.New(updateRefArgs).DontLog().Run()
+}
+
+func (self *JournalCommands) rewrite(entries []*JournalEntry) error {
+	var content strings.Builder
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		content.Write(line)
+		content.WriteString("\n")
+	}
+
+	return afero.WriteFile(self.Fs, self.journalPath(), []byte(content.String()), 0o644)
+}
+
+func (self *JournalCommands) reflogLines() []string {
+	content, err := afero.ReadFile(self.Fs, filepath.Join(self.repoPaths.WorktreeGitDirPath(), "logs", "HEAD"))
+	if err != nil || len(content) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
@@ -0,0 +1,299 @@
+package git_commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalRecord(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "/repo/.git/logs/HEAD", []byte("a\nb\n"), 0o644))
+	assert.NoError(t, afero.WriteFile(fs, "/repo/dir/file.txt", []byte("content"), 0o644))
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"write-tree"}, "tree1\n", nil).
+		ExpectGitArgs([]string{"hash-object", "-w", "--no-filters", "--stdin-paths"}, "blob1\n", nil).
+		ExpectGitArgs([]string{"write-tree"}, "tree2\n", nil).
+		ExpectGitArgs([]string{"rev-parse", "--verify", "-q", "refs/lazygit/journal"}, "", errors.New("not found")).
+		ExpectGitArgs([]string{"mktree"}, "pintree\n", nil).
+		ExpectGitArgs([]string{"commit-tree", "--no-gpg-sign", "-m", "lazygit journal", "pintree"}, "pin1\n", nil).
+		ExpectGitArgs([]string{"update-ref", "refs/lazygit/journal", "pin1"}, "", nil)
+	instance := buildJournalCommands(commonDeps{runner: runner, fs: fs, repoPaths: MockRepoPaths("/repo")})
+
+	err := instance.Record("Discard", []string{"dir", "missing.txt"}, func() error {
+		return fs.Remove("/repo/dir/file.txt")
+	})
+	assert.NoError(t, err)
+	runner.CheckForMissingCalls()
+
+	entries, err := instance.Entries()
+	assert.NoError(t, err)
+	assert.Equal(t, []*JournalEntry{
+		{
+			Kind:         JOURNAL_OPERATION,
+			Description:  "Discard",
+			ReflogLength: 2,
+			ReflogAnchor: "b",
+			Before: &JournalSnapshot{
+				IndexTree: "tree1",
+				Paths:     []string{"dir/file.txt", "missing.txt"},
+				Files: map[string]JournalFile{
+					"dir/file.txt": {Blob: "blob1", Mode: 0o644},
+					"missing.txt":  {},
+				},
+			},
+			After: &JournalSnapshot{
+				IndexTree: "tree2",
+				Paths:     []string{"dir/file.txt", "missing.txt"},
+				Files: map[string]JournalFile{
+					"dir/file.txt": {},
+					"missing.txt":  {},
+				},
+			},
+		},
+	}, entries)
+}
+
+func TestJournalUndoRestoresSnapshot(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "/repo/added.txt", []byte("new"), 0o644))
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"cat-file", "--batch-check"}, "tree1 tree 33\nblob1 blob 8\n", nil).
+		ExpectGitArgs([]string{"reset", "-q", "tree1", "--", "dir/file.txt", "added.txt"}, "", nil).
+		ExpectGitArgs([]string{"cat-file", "blob", "blob1"}, "original", nil)
+	instance := buildJournalCommands(commonDeps{runner: runner, fs: fs, repoPaths: MockRepoPaths("/repo")})
+
+	entry := &JournalEntry{
+		Kind:        JOURNAL_OPERATION,
+		Description: "Discard",
+		Before: &JournalSnapshot{
+			IndexTree: "tree1",
+			Paths:     []string{"dir/file.txt", "added.txt"},
+			Files: map[string]JournalFile{
+				"dir/file.txt": {Blob: "blob1", Mode: 0o755},
+				"added.txt":    {},
+			},
+		},
+	}
+	assert.NoError(t, instance.Undo(entry))
+	runner.CheckForMissingCalls()
+
+	content, err := afero.ReadFile(fs, "/repo/dir/file.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+	info, err := fs.Stat("/repo/dir/file.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "-rwxr-xr-x", info.Mode().String())
+
+	exists, err := afero.Exists(fs, "/repo/added.txt")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	entries, err := instance.Entries()
+	assert.NoError(t, err)
+	assert.Equal(t, []*JournalEntry{{Kind: JOURNAL_UNDO, Description: "Discard"}}, entries)
+}
+
+func TestJournalStashDrops(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rev-parse", "refs/stash@{2}"}, "hash2\n", nil).
+		ExpectGitArgs([]string{"log", "-g", "-1", "--format=%gs", "refs/stash@{2}"}, "On master: two\n", nil).
+		ExpectGitArgs([]string{"rev-parse", "refs/stash@{0}"}, "hash0\n", nil).
+		ExpectGitArgs([]string{"log", "-g", "-1", "--format=%gs", "refs/stash@{0}"}, "On master: zero\n", nil).
+		ExpectGitArgs([]string{"stash", "drop", "refs/stash@{2}"}, "", nil).
+		ExpectGitArgs([]string{"stash", "drop", "refs/stash@{0}"}, "", nil).
+		ExpectGitArgs([]string{"rev-parse", "--verify", "-q", "refs/lazygit/journal"}, "pin1\n", nil).
+		ExpectGitArgs([]string{"mktree"}, "emptytree\n", nil).
+		ExpectGitArgs([]string{"commit-tree", "--no-gpg-sign", "-m", "lazygit journal", "-p", "pin1", "-p", "hash2", "-p", "hash0", "emptytree"}, "pin2\n", nil).
+		ExpectGitArgs([]string{"update-ref", "refs/lazygit/journal", "pin2"}, "", nil).
+		// undo
+		ExpectGitArgs([]string{"cat-file", "--batch-check"}, "hash2 commit 200\nhash0 commit 200\n", nil).
+		ExpectGitArgs([]string{"stash", "store", "-m", "On master: two", "hash2"}, "", nil).
+		ExpectGitArgs([]string{"stash", "store", "-m", "On master: zero", "hash0"}, "", nil).
+		// redo
+		ExpectGitArgs([]string{"log", "-g", "--format=%H", "refs/stash"}, "hash0\nhash1\nhash2\n", nil).
+		ExpectGitArgs([]string{"stash", "drop", "refs/stash@{2}"}, "", nil).
+		ExpectGitArgs([]string{"log", "-g", "--format=%H", "refs/stash"}, "hash0\nhash1\n", nil).
+		ExpectGitArgs([]string{"stash", "drop", "refs/stash@{0}"}, "", nil)
+	instance := buildJournalCommands(commonDeps{runner: runner, fs: fs, repoPaths: MockRepoPaths("/repo")})
+
+	err := instance.RecordStashDrops("Drop stash", []int{2, 0}, func() error {
+		if err := instance.stash.Drop(2); err != nil {
+			return err
+		}
+		return instance.stash.Drop(0)
+	})
+	assert.NoError(t, err)
+
+	entries, err := instance.Entries()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, []JournalStash{
+		{Hash: "hash2", Message: "On master: two"},
+		{Hash: "hash0", Message: "On master: zero"},
+	}, entries[0].DroppedStashes)
+
+	assert.NoError(t, instance.Undo(entries[0]))
+	assert.NoError(t, instance.Redo(entries[0]))
+	runner.CheckForMissingCalls()
+
+	entries, err = instance.Entries()
+	assert.NoError(t, err)
+	assert.Equal(t, []JournalEntryKind{JOURNAL_OPERATION, JOURNAL_UNDO, JOURNAL_REDO},
+		[]JournalEntryKind{entries[0].Kind, entries[1].Kind, entries[2].Kind})
+}
+
+func TestJournalIsCapped(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	// trimming the journal drops the objects of the trimmed entries; these
+	// entries don't have any, so nothing is left to keep alive
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"update-ref", "-d", "refs/lazygit/journal"}, "", nil)
+	instance := buildJournalCommands(commonDeps{runner: runner, fs: fs, repoPaths: MockRepoPaths("/repo")})
+
+	for i := range maxJournalEntries + 5 {
+		assert.NoError(t, instance.Append(&JournalEntry{Kind: JOURNAL_OPERATION, Description: string(rune('a' + i%26))}))
+	}
+
+	entries, err := instance.Entries()
+	assert.NoError(t, err)
+	assert.Len(t, entries, maxJournalEntries)
+	// the oldest five entries were dropped
+	assert.Equal(t, "f", entries[0].Description)
+
+	for range maxJournalEntries {
+		assert.NoError(t, instance.Append(&JournalEntry{Kind: JOURNAL_OPERATION}))
+	}
+
+	// once the file has grown to twice the maximum, it gets trimmed
+	_, err = instance.Entries()
+	assert.NoError(t, err)
+	content, err := afero.ReadFile(fs, "/repo/.git/lazygit/journal.jsonl")
+	assert.NoError(t, err)
+	assert.Equal(t, maxJournalEntries, strings.Count(string(content), "\n"))
+	runner.CheckForMissingCalls()
+}
+
+func TestJournalUndoFailsIfObjectsAreGone(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "/repo/file.txt", []byte("current"), 0o644))
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"cat-file", "--batch-check"}, "tree1 tree 33\nblob1 missing\n", nil)
+	instance := buildJournalCommands(commonDeps{runner: runner, fs: fs, repoPaths: MockRepoPaths("/repo")})
+
+	entry := &JournalEntry{
+		Kind:        JOURNAL_OPERATION,
+		Description: "Discard",
+		Before: &JournalSnapshot{
+			IndexTree: "tree1",
+			Paths:     []string{"file.txt"},
+			Files:     map[string]JournalFile{"file.txt": {Blob: "blob1", Mode: 0o644}},
+		},
+	}
+	assert.ErrorIs(t, instance.Undo(entry), errJournalObjectsGone)
+	runner.CheckForMissingCalls()
+
+	// nothing was touched
+	content, err := afero.ReadFile(fs, "/repo/file.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "current", string(content))
+}
+
+func TestJournalPinRef(t *testing.T) {
+	repoPaths := &RepoPaths{
+		worktreePath:       "/wt",
+		worktreeGitDirPath: "/repo/.git/worktrees/wt",
+		repoPath:           "/repo",
+		repoGitDirPath:     "/repo/.git",
+	}
+	instance := buildJournalCommands(commonDeps{repoPaths: repoPaths})
+	assert.Equal(t, "refs/lazygit/worktrees/wt/journal", instance.pinRef())
+
+	instance = buildJournalCommands(commonDeps{repoPaths: MockRepoPaths("/repo")})
+	assert.Equal(t, "refs/lazygit/journal", instance.pinRef())
+}
+
+func TestJournalNewerReflogEntries(t *testing.T) {
+	scenarios := []struct {
+		testName    string
+		entry       *JournalEntry
+		reflogLines []string
+		expected    int
+	}{
+		{
+			testName:    "reflog unchanged",
+			entry:       &JournalEntry{ReflogLength: 2, ReflogAnchor: "b"},
+			reflogLines: []string{"a", "b"},
+			expected:    0,
+		},
+		{
+			testName:    "reflog grew",
+			entry:       &JournalEntry{ReflogLength: 2, ReflogAnchor: "b"},
+			reflogLines: []string{"a", "b", "c", "d"},
+			expected:    2,
+		},
+		{
+			testName:    "old reflog entries expired",
+			entry:       &JournalEntry{ReflogLength: 3, ReflogAnchor: "c"},
+			reflogLines: []string{"c", "d"},
+			expected:    1,
+		},
+		{
+			testName:    "anchor expired",
+			entry:       &JournalEntry{ReflogLength: 3, ReflogAnchor: "c"},
+			reflogLines: []string{"d", "e"},
+			expected:    2,
+		},
+		{
+			testName:    "anchor appears again later",
+			entry:       &JournalEntry{ReflogLength: 1, ReflogAnchor: "a"},
+			reflogLines: []string{"a", "b", "a"},
+			expected:    2,
+		},
+		{
+			testName:    "no anchor, reflog shorter than recorded",
+			entry:       &JournalEntry{ReflogLength: 5},
+			reflogLines: []string{"a", "b"},
+			expected:    0,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.Equal(t, s.expected, newerReflogEntries(s.entry, s.reflogLines))
+		})
+	}
+}
+
+func TestJournalSkipsTooLargeSnapshots(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for i := range maxSnapshotFiles + 1 {
+		assert.NoError(t, afero.WriteFile(fs, fmt.Sprintf("/repo/untracked/%d", i), []byte("x"), 0o644))
+	}
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"write-tree"}, "tree1\n", nil)
+	instance := buildJournalCommands(commonDeps{runner: runner, fs: fs, repoPaths: MockRepoPaths("/repo")})
+
+	called := false
+	err := instance.Record("Nuke", []string{"untracked"}, func() error {
+		called = true
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, called)
+	runner.CheckForMissingCalls()
+
+	entries, err := instance.Entries()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
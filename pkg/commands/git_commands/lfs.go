@@ -0,0 +1,242 @@
+package git_commands
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/samber/lo"
+)
+
+type LfsCommands struct {
+	*GitCommon
+	isInstalled func() bool
+}
+
+func NewLfsCommands(gitCommon *GitCommon) *LfsCommands {
+	return &LfsCommands{
+		GitCommon: gitCommon,
+		isInstalled: sync.OnceValue(func() bool {
+			_, err := exec.LookPath("git-lfs")
+			return err == nil
+		}),
+	}
+}
+
+// IsInstalled tells whether git-lfs is on PATH. If it isn't, all LFS features
+// are skipped, so users who don't use LFS pay nothing for them.
+func (self *LfsCommands) IsInstalled() bool {
+	return self.isInstalled()
+}
+
+// TrackedPaths returns the subset of the given paths whose `filter` attribute
+// is `lfs`. Attributes are taken from the working tree, so for files of older
+// commits this reflects the current .gitattributes rather than the one at the
+// time of the commit.
+func (self *LfsCommands) TrackedPaths(paths []string) (map[string]bool, error) {
+	if !self.IsInstalled() || len(paths) == 0 {
+		return nil, nil
+	}
+
+	cmdArgs := NewGitCmd("check-attr").
+		Arg("-z", "--stdin", "filter").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).
+		SetStdin(strings.Join(paths, "\x00")).
+		DontLog().
+		RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLfsCheckAttrOutput(output), nil
+}
+
+// output looks like "path\x00filter\x00lfs\x00other\x00filter\x00unspecified\x00"
+func parseLfsCheckAttrOutput(output string) map[string]bool {
+	result := map[string]bool{}
+	fields := strings.Split(strings.TrimSuffix(output, "\x00"), "\x00")
+	for _, chunk := range lo.Chunk(fields, 3) {
+		if len(chunk) == 3 && chunk[2] == "lfs" {
+			result[chunk[0]] = true
+		}
+	}
+	return result
+}
+
+type lfsLockJson struct {
+	ID    string `json:"id"`
+	Path  string `json:"path"`
+	Owner struct {
+		Name string `json:"name"`
+	} `json:"owner"`
+	LockedAt string `json:"locked_at"`
+}
+
+// GetLocks returns the locks held on the LFS server of the current remote,
+// by anyone. We can't capture the output of commands that we answer
+// credential prompts for, so the credentials have to come from a credential
+// helper.
+func (self *LfsCommands) GetLocks() ([]*models.LfsLock, error) {
+	cmdArgs := NewGitCmd("lfs").Arg("locks", "--json").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).
+		// prevents git from prompting us for input which would freeze the program
+		AddEnvVars("GIT_TERMINAL_PROMPT=0").
+		DontLog().
+		RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLfsLocks(output)
+}
+
+func parseLfsLocks(output string) ([]*models.LfsLock, error) {
+	var locks []lfsLockJson
+	if err := json.Unmarshal([]byte(output), &locks); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(locks, func(lock lfsLockJson, _ int) *models.LfsLock {
+		// a lock without a parsable timestamp is still worth showing
+		lockedAt, _ := time.Parse(time.RFC3339, lock.LockedAt)
+		return &models.LfsLock{
+			ID:       lock.ID,
+			Path:     lock.Path,
+			Owner:    lock.Owner.Name,
+			LockedAt: lockedAt,
+		}
+	}), nil
+}
+
+func (self *LfsCommands) Lock(task gocui.Task, path string) error {
+	cmdArgs := NewGitCmd("lfs").Arg("lock", "--", path).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
+}
+
+// Unlock releases the lock with the given ID. force is needed for releasing
+// somebody else's lock.
+func (self *LfsCommands) Unlock(task gocui.Task, id string, force bool) error {
+	cmdArgs := NewGitCmd("lfs").Arg("unlock", "--id="+id).
+		ArgIf(force, "--force").
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
+}
+
+// Fetch downloads the LFS objects needed for the current checkout from the
+// default remote, without changing the working tree
+func (self *LfsCommands) Fetch(task gocui.Task) error {
+	cmdArgs := NewGitCmd("lfs").Arg("fetch").
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
+}
+
+// Pull fetches the LFS objects and replaces pointer files in the working tree
+// with their content
+func (self *LfsCommands) Pull(task gocui.Task) error {
+	cmdArgs := NewGitCmd("lfs").Arg("pull").
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
+}
+
+// Prune deletes local copies of LFS objects that are old and already pushed
+func (self *LfsCommands) Prune() error {
+	cmdArgs := NewGitCmd("lfs").Arg("prune").
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+// LfsPointer is the content of the small text file that git stores in place
+// of an LFS-tracked file
+type LfsPointer struct {
+	Oid  string
+	Size int64
+}
+
+// ParseLfsPointerDiff extracts the old and new pointer from a plain (uncolored)
+// diff of a single LFS-tracked file. The old pointer is nil if the file was
+// added, the new one is nil if it was deleted. ok is false if the diff doesn't
+// look like a pointer diff, e.g. because the file was committed before it was
+// tracked by LFS.
+func ParseLfsPointerDiff(diff string) (oldPointer *LfsPointer, newPointer *LfsPointer, ok bool) {
+	var before, after LfsPointer
+	hasOld, hasNew := false, false
+	inHunk := false
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			inHunk = true
+			continue
+		}
+		if !inHunk || line == "" {
+			continue
+		}
+
+		prefix, content := line[0], line[1:]
+		var targets []*LfsPointer
+		switch prefix {
+		case '-':
+			targets = []*LfsPointer{&before}
+			hasOld = true
+		case '+':
+			targets = []*LfsPointer{&after}
+			hasNew = true
+		case ' ':
+			targets = []*LfsPointer{&before, &after}
+			hasOld, hasNew = true, true
+		default:
+			continue
+		}
+
+		key, value, found := strings.Cut(content, " ")
+		if !found {
+			return nil, nil, false
+		}
+
+		for _, target := range targets {
+			switch key {
+			case "version":
+				if !strings.HasPrefix(value, "https://git-lfs.github.com/spec/") {
+					return nil, nil, false
+				}
+			case "oid":
+				target.Oid = value
+			case "size":
+				size, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, nil, false
+				}
+				target.Size = size
+			}
+		}
+	}
+
+	if hasOld {
+		if before.Oid == "" {
+			return nil, nil, false
+		}
+		oldPointer = &before
+	}
+	if hasNew {
+		if after.Oid == "" {
+			return nil, nil, false
+		}
+		newPointer = &after
+	}
+
+	return oldPointer, newPointer, hasOld || hasNew
+}
@@ -0,0 +1,173 @@
+package git_commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLfsTrackedPaths(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"check-attr", "-z", "--stdin", "filter"},
+			"image.png\x00filter\x00lfs\x00README.md\x00filter\x00unspecified\x00", nil)
+	instance := buildLfsCommands(commonDeps{runner: runner})
+
+	trackedPaths, err := instance.TrackedPaths([]string{"image.png", "README.md"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"image.png": true}, trackedPaths)
+	runner.CheckForMissingCalls()
+}
+
+func TestLfsTrackedPathsWhenNotInstalled(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t)
+	instance := buildLfsCommands(commonDeps{runner: runner})
+	instance.isInstalled = func() bool { return false }
+
+	trackedPaths, err := instance.TrackedPaths([]string{"image.png"})
+	assert.NoError(t, err)
+	assert.Empty(t, trackedPaths)
+	runner.CheckForMissingCalls()
+}
+
+func TestLfsGetLocks(t *testing.T) {
+	output := `[{"id":"3","path":"assets/logo.psd","owner":{"name":"Jane Doe"},"locked_at":"2024-05-01T12:30:00Z"}]`
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"lfs", "locks", "--json"}, output, nil)
+	instance := buildLfsCommands(commonDeps{runner: runner})
+
+	locks, err := instance.GetLocks()
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.LfsLock{
+		{
+			ID:       "3",
+			Path:     "assets/logo.psd",
+			Owner:    "Jane Doe",
+			LockedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		},
+	}, locks)
+	runner.CheckForMissingCalls()
+}
+
+func TestLfsUnlock(t *testing.T) {
+	type scenario struct {
+		testName string
+		force    bool
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "own lock",
+			force:    false,
+			expected: []string{"lfs", "unlock", "--id=3"},
+		},
+		{
+			testName: "somebody else's lock",
+			force:    true,
+			expected: []string{"lfs", "unlock", "--id=3", "--force"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).ExpectGitArgs(s.expected, "", nil)
+			instance := buildLfsCommands(commonDeps{runner: runner})
+
+			assert.NoError(t, instance.Unlock(nil, "3", s.force))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestParseLfsPointerDiff(t *testing.T) {
+	type scenario struct {
+		testName    string
+		diff        string
+		expectedOk  bool
+		expectedOld *LfsPointer
+		expectedNew *LfsPointer
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "modified",
+			diff: `diff --git a/image.png b/image.png
+index 1f0e3a2..5c1d2b9 100644
+--- a/image.png
++++ b/image.png
+@@ -1,3 +1,3 @@
+ version https://git-lfs.github.com/spec/v1
+-oid sha256:aaaa
+-size 1024
++oid sha256:bbbb
++size 2048
+`,
+			expectedOk:  true,
+			expectedOld: &LfsPointer{Oid: "sha256:aaaa", Size: 1024},
+			expectedNew: &LfsPointer{Oid: "sha256:bbbb", Size: 2048},
+		},
+		{
+			testName: "modified with same size",
+			diff: `@@ -1,3 +1,3 @@
+ version https://git-lfs.github.com/spec/v1
+-oid sha256:aaaa
++oid sha256:bbbb
+ size 1024
+`,
+			expectedOk:  true,
+			expectedOld: &LfsPointer{Oid: "sha256:aaaa", Size: 1024},
+			expectedNew: &LfsPointer{Oid: "sha256:bbbb", Size: 1024},
+		},
+		{
+			testName: "added",
+			diff: `diff --git a/image.png b/image.png
+new file mode 100644
+--- /dev/null
++++ b/image.png
+@@ -0,0 +1,3 @@
++version https://git-lfs.github.com/spec/v1
++oid sha256:bbbb
++size 2048
+`,
+			expectedOk:  true,
+			expectedOld: nil,
+			expectedNew: &LfsPointer{Oid: "sha256:bbbb", Size: 2048},
+		},
+		{
+			testName: "deleted",
+			diff: `@@ -1,3 +0,0 @@
+-version https://git-lfs.github.com/spec/v1
+-oid sha256:aaaa
+-size 1024
+`,
+			expectedOk:  true,
+			expectedOld: &LfsPointer{Oid: "sha256:aaaa", Size: 1024},
+			expectedNew: nil,
+		},
+		{
+			testName: "not a pointer diff",
+			diff: `@@ -1 +1 @@
+-hello
++world
+`,
+			expectedOk: false,
+		},
+		{
+			testName:   "binary diff",
+			diff:       "Binary files a/image.png and b/image.png differ\n",
+			expectedOk: false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			oldPointer, newPointer, ok := ParseLfsPointerDiff(s.diff)
+			assert.Equal(t, s.expectedOk, ok)
+			assert.Equal(t, s.expectedOld, oldPointer)
+			assert.Equal(t, s.expectedNew, newPointer)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package git_commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// All of the methods below take a notes ref; passing an empty string means
+// git's default notes ref is used (core.notesRef, or refs/notes/commits if
+// that isn't set).
+type NotesCommands struct {
+	*GitCommon
+}
+
+func NewNotesCommands(gitCommon *GitCommon) *NotesCommands {
+	return &NotesCommands{
+		GitCommon: gitCommon,
+	}
+}
+
+func notesCmd(notesRef string) *GitCommandBuilder {
+	return NewGitCmd("notes").
+		ArgIf(notesRef != "", "--ref="+notesRef)
+}
+
+// Get returns the note attached to the given commit, or an error if there is
+// none.
+func (self *NotesCommands) Get(notesRef string, hash string) (string, error) {
+	cmdArgs := notesCmd(notesRef).
+		Arg("show", hash).
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	return strings.TrimSuffix(output, "\n"), err
+}
+
+// Set creates the note for the given commit, replacing an existing one
+func (self *NotesCommands) Set(notesRef string, hash string, message string) error {
+	cmdArgs := notesCmd(notesRef).
+		Arg("add", "--force", "--allow-empty", "-m", message, hash).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+func (self *NotesCommands) EditCmdObj(notesRef string, hash string) *oscommands.CmdObj {
+	cmdArgs := notesCmd(notesRef).
+		Arg("edit", hash).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs)
+}
+
+func (self *NotesCommands) Remove(notesRef string, hash string) error {
+	cmdArgs := notesCmd(notesRef).
+		Arg("remove", "--ignore-missing", hash).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+// GetRef resolves the given notes ref to its full name, e.g. "foo" becomes
+// "refs/notes/foo", and an empty string becomes the default notes ref.
+func (self *NotesCommands) GetRef(notesRef string) (string, error) {
+	cmdArgs := notesCmd(notesRef).
+		Arg("get-ref").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	return strings.TrimSpace(output), err
+}
+
+// GetRefs returns the full names of all the notes refs that exist locally
+func (self *NotesCommands) GetRefs() ([]string, error) {
+	cmdArgs := NewGitCmd("for-each-ref").
+		Arg("--format=%(refname)", "refs/notes/").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SplitLines(output), nil
+}
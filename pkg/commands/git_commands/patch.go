@@ -3,12 +3,15 @@ package git_commands
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/app/daemon"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 	"github.com/stefanhaller/git-todo-parser/todo"
 )
 
@@ -45,6 +48,9 @@ type ApplyPatchOpts struct {
 	Cached   bool
 	Index    bool
 	Reverse  bool
+	// Needed for patches created with -U0, which have no context lines that
+	// git could use to check where the hunks go
+	UnidiffZero bool
 }
 
 func (self *PatchCommands) ApplyCustomPatch(reverse bool, turnAddedFilesIntoDiffAgainstEmptyFile bool) error {
@@ -72,6 +78,7 @@ func (self *PatchCommands) applyPatchFile(filepath string, opts ApplyPatchOpts)
 		ArgIf(opts.Cached, "--cached").
 		ArgIf(opts.Index, "--index").
 		ArgIf(opts.Reverse, "--reverse").
+		ArgIf(opts.UnidiffZero, "--unidiff-zero").
 		Arg(filepath).
 		ToArgv()
 
@@ -87,6 +94,181 @@ func (self *PatchCommands) SaveTemporaryPatch(patch string) (string, error) {
 	return filepath, nil
 }
 
+type StashPatchOpts struct {
+	Message string
+	// If true, the patch is taken from the index, so it is stored in the
+	// stash's index commit too and removed from both index and working tree
+	Staged bool
+}
+
+// StashPatch stores the changes of the given patch (which must be relative to
+// HEAD) as a new stash entry and then removes them by applying discardPatch in
+// reverse, leaving all other changes in place. The two patches differ when only
+// some lines of a block of changes are selected: discardPatch keeps the other
+// lines as context so that it applies to the current state of the file. This
+// is what `git stash push --patch` does, but without an interactive session.
+func (self *PatchCommands) StashPatch(patch string, discardPatch string, opts StashPatchOpts) error {
+	patchPath, err := self.SaveTemporaryPatch(patch)
+	if err != nil {
+		return err
+	}
+
+	discardPatchPath, err := self.SaveTemporaryPatch(discardPatch)
+	if err != nil {
+		return err
+	}
+
+	// Make sure that we'll be able to remove the changes again before we store
+	// anything, so that a failure doesn't leave a half-done stash behind
+	discards := []ApplyPatchOpts{{Reverse: true}}
+	if opts.Staged {
+		discards = append(discards, ApplyPatchOpts{Reverse: true, Cached: true})
+	}
+	for _, discard := range discards {
+		if err := self.checkPatchFile(discardPatchPath, discard); err != nil {
+			return err
+		}
+	}
+
+	headSummary, err := self.cmd.New(
+		NewGitCmd("log").Arg("-1", "--format=%h %s", "HEAD").ToArgv(),
+	).DontLog().RunWithOutput()
+	if err != nil {
+		return err
+	}
+	headSummary = strings.TrimSpace(headSummary)
+
+	branch, err := self.cmd.New(
+		NewGitCmd("rev-parse").Arg("--abbrev-ref", "HEAD").ToArgv(),
+	).DontLog().RunWithOutput()
+	if err != nil {
+		return err
+	}
+	branch = strings.TrimSpace(branch)
+	if branch == "HEAD" {
+		branch = "(no branch)"
+	}
+
+	workingTree, err := self.treeWithPatchApplied(patchPath)
+	if err != nil {
+		return err
+	}
+
+	indexTree := "HEAD^{tree}"
+	if opts.Staged {
+		indexTree = workingTree
+	}
+	indexCommit, err := self.commitTree(indexTree, fmt.Sprintf("index on %s: %s", branch, headSummary), "HEAD")
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("WIP on %s: %s", branch, headSummary)
+	if opts.Message != "" {
+		message = fmt.Sprintf("On %s: %s", branch, opts.Message)
+	}
+	stashCommit, err := self.commitTree(workingTree, message, "HEAD", indexCommit)
+	if err != nil {
+		return err
+	}
+
+	if err := self.stash.Store(stashCommit, message); err != nil {
+		return err
+	}
+
+	for _, discard := range discards {
+		if err := self.applyPatchFile(discardPatchPath, discard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (self *PatchCommands) checkPatchFile(patchPath string, opts ApplyPatchOpts) error {
+	cmdArgs := NewGitCmd("apply").
+		Arg("--check").
+		ArgIf(opts.Cached, "--cached").
+		ArgIf(opts.Reverse, "--reverse").
+		Arg(patchPath).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().Run()
+}
+
+// treeWithPatchApplied returns the hash of a tree that has the given patch
+// applied on top of HEAD. We use a temporary index file for this so that the
+// real index is left alone.
+func (self *PatchCommands) treeWithPatchApplied(patchPath string) (string, error) {
+	indexPath := patchPath + ".index"
+	defer func() { _ = self.os.Remove(indexPath) }()
+
+	env := []string{"GIT_INDEX_FILE=" + indexPath}
+	for _, cmdArgs := range [][]string{
+		NewGitCmd("read-tree").Arg("HEAD").ToArgv(),
+		NewGitCmd("apply").Arg("--cached", patchPath).ToArgv(),
+	} {
+		if err := self.cmd.New(cmdArgs).AddEnvVars(env...).DontLog().Run(); err != nil {
+			return "", err
+		}
+	}
+
+	tree, err := self.cmd.New(
+		NewGitCmd("write-tree").ToArgv(),
+	).AddEnvVars(env...).DontLog().RunWithOutput()
+	return strings.TrimSpace(tree), err
+}
+
+func (self *PatchCommands) commitTree(tree string, message string, parents ...string) (string, error) {
+	cmdArgs := NewGitCmd("commit-tree").
+		Arg(tree).
+		Arg(lo.FlatMap(parents, func(parent string, _ int) []string { return []string{"-p", parent} })...).
+		Arg("-m", message).
+		ToArgv()
+
+	hash, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	return strings.TrimSpace(hash), err
+}
+
+type FormatPatchOpts struct {
+	// The series starts after this commit; if empty, it starts with the root
+	// commit
+	Base        string
+	To          string
+	OutputDir   string
+	CoverLetter bool
+}
+
+// FormatPatch writes one patch file per commit (plus an optional cover
+// letter) to the output directory, and returns the paths of the written files
+func (self *PatchCommands) FormatPatch(opts FormatPatchOpts) ([]string, error) {
+	cmdArgs := NewGitCmd("format-patch").
+		Arg("--output-directory", opts.OutputDir).
+		ArgIf(opts.CoverLetter, "--cover-letter").
+		ArgIf(opts.Base == "", "--root").
+		ArgIfElse(opts.Base == "", opts.To, opts.Base+".."+opts.To).
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SplitLines(output), nil
+}
+
+// ApplyMailbox applies the patches in the given mbox files (or patch files) as
+// commits. We use a three-way merge so that conflicts can be resolved in the
+// same way as for a rebase, followed by `git am --continue`.
+func (self *PatchCommands) ApplyMailbox(paths []string) error {
+	cmdArgs := NewGitCmd("am").
+		Arg("--3way").
+		Arg("--").
+		Arg(paths...).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
 // DeletePatchesFromCommit applies a patch in reverse for a commit
 func (self *PatchCommands) DeletePatchesFromCommit(commits []*models.Commit, commitIndex int) error {
 	if err := self.rebase.BeginInteractiveRebaseForCommit(commits, commitIndex, false); err != nil {
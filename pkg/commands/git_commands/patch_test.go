@@ -0,0 +1,125 @@
+package git_commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchCommandsFormatPatch(t *testing.T) {
+	type scenario struct {
+		testName string
+		opts     FormatPatchOpts
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "range",
+			opts:     FormatPatchOpts{Base: "abc123^", To: "def456", OutputDir: "patches"},
+			expected: []string{"format-patch", "--output-directory", "patches", "abc123^..def456"},
+		},
+		{
+			testName: "range with cover letter",
+			opts:     FormatPatchOpts{Base: "abc123^", To: "def456", OutputDir: "patches", CoverLetter: true},
+			expected: []string{"format-patch", "--output-directory", "patches", "--cover-letter", "abc123^..def456"},
+		},
+		{
+			testName: "starting at the root commit",
+			opts:     FormatPatchOpts{To: "def456", OutputDir: "."},
+			expected: []string{"format-patch", "--output-directory", ".", "--root", "def456"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).
+				ExpectGitArgs(s.expected, "patches/0001-first.patch\npatches/0002-second.patch\n", nil)
+			instance := buildPatchCommands(commonDeps{runner: runner})
+
+			files, err := instance.FormatPatch(s.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"patches/0001-first.patch", "patches/0002-second.patch"}, files)
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestPatchCommandsApplyMailbox(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"am", "--3way", "--", "0001-first.patch", "0002-second.patch"}, "", nil)
+	instance := buildPatchCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.ApplyMailbox([]string{"0001-first.patch", "0002-second.patch"}))
+	runner.CheckForMissingCalls()
+}
+
+func TestPatchCommandsStashPatch(t *testing.T) {
+	// the temporary patch file gets a time-based name, so we only compare the
+	// arguments that come before it
+	expectWithPatchFile := func(runner *oscommands.FakeCmdObjRunner, expectedArgs []string, output string) *oscommands.FakeCmdObjRunner {
+		return runner.ExpectFunc(strings.Join(expectedArgs, " "), func(cmdObj *oscommands.CmdObj) bool {
+			args := cmdObj.GetCmd().Args[1:]
+			return len(args) == len(expectedArgs)+1 &&
+				slices.Equal(args[:len(expectedArgs)], expectedArgs) &&
+				strings.HasSuffix(args[len(expectedArgs)], ".patch")
+		}, output, nil)
+	}
+
+	type scenario struct {
+		testName string
+		opts     StashPatchOpts
+		branch   string
+		expected func(runner *oscommands.FakeCmdObjRunner)
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "unstaged lines with a message",
+			opts:     StashPatchOpts{Message: "my stash"},
+			branch:   "master",
+			expected: func(runner *oscommands.FakeCmdObjRunner) {
+				runner.ExpectGitArgs([]string{"commit-tree", "HEAD^{tree}", "-p", "HEAD", "-m", "index on master: abc123 subject"}, "index-commit\n", nil).
+					ExpectGitArgs([]string{"commit-tree", "work-tree", "-p", "HEAD", "-p", "index-commit", "-m", "On master: my stash"}, "stash-commit\n", nil).
+					ExpectGitArgs([]string{"stash", "store", "-m", "On master: my stash", "stash-commit"}, "", nil)
+				expectWithPatchFile(runner, []string{"apply", "--reverse"}, "")
+			},
+		},
+		{
+			testName: "staged lines on a detached head",
+			opts:     StashPatchOpts{Staged: true},
+			branch:   "HEAD",
+			expected: func(runner *oscommands.FakeCmdObjRunner) {
+				expectWithPatchFile(runner, []string{"apply", "--check", "--cached", "--reverse"}, "")
+				runner.ExpectGitArgs([]string{"commit-tree", "work-tree", "-p", "HEAD", "-m", "index on (no branch): abc123 subject"}, "index-commit\n", nil).
+					ExpectGitArgs([]string{"commit-tree", "work-tree", "-p", "HEAD", "-p", "index-commit", "-m", "WIP on (no branch): abc123 subject"}, "stash-commit\n", nil).
+					ExpectGitArgs([]string{"stash", "store", "-m", "WIP on (no branch): abc123 subject", "stash-commit"}, "", nil)
+				expectWithPatchFile(runner, []string{"apply", "--cached", "--reverse"}, "")
+				expectWithPatchFile(runner, []string{"apply", "--reverse"}, "")
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t)
+			expectWithPatchFile(runner, []string{"apply", "--check", "--reverse"}, "")
+			runner.ExpectGitArgs([]string{"log", "-1", "--format=%h %s", "HEAD"}, "abc123 subject\n", nil).
+				ExpectGitArgs([]string{"rev-parse", "--abbrev-ref", "HEAD"}, s.branch+"\n", nil).
+				ExpectGitArgs([]string{"read-tree", "HEAD"}, "", nil).
+				ExpectGitArgs([]string{"write-tree"}, "work-tree\n", nil)
+			expectWithPatchFile(runner, []string{"apply", "--cached"}, "")
+			s.expected(runner)
+
+			stash := buildStashCommands(commonDeps{runner: runner})
+			instance := buildPatchCommands(commonDeps{runner: runner})
+			instance.stash = stash
+
+			assert.NoError(t, instance.StashPatch("a patch", "a discard patch", s.opts))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package git_commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// PullRequestLoader fetches the pull requests of a repo from the API of a
+// hosting service. GitHub is queried via GraphQL, the other providers via
+// their REST APIs.
+type PullRequestLoader interface {
+	// returns "" if we have no token for the host, in which case we don't
+	// fetch any pull requests from it
+	GetAuthToken(host string) string
+	// serviceInfo identifies the instance (which may be self-hosted) and the
+	// owner/repo to query against. The returned pull requests are ordered
+	// newest-first.
+	FetchRecentPRs(branches []string, serviceInfo *hosting_service.ServiceInfo, token string) ([]*models.PullRequest, error)
+}
+
+var (
+	_ PullRequestLoader = &GitHubCommands{}
+	_ PullRequestLoader = &GitLabCommands{}
+	_ PullRequestLoader = &GiteaCommands{}
+	_ PullRequestLoader = &BitbucketCommands{}
+)
+
+// the number of most recently updated pull requests we ask the REST APIs for;
+// unlike GitHub's GraphQL API they can't be asked for the pull requests of
+// a list of branches in one go, so we filter these by branch instead
+const recentPullRequestsLimit = 100
+
+// returns the first of the given environment variables that is set
+func authTokenFromEnv(names ...string) string {
+	for _, name := range names {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// authTokenForHost returns the token from the given environment variables, but
+// only if host is the provider's public instance or the one named by the
+// hostEnvVar environment variable. We mustn't send the token anywhere else;
+// otherwise a `services` entry for a domain that someone else controls (which
+// a repo's remote URL may point at) would get the token sent to them.
+func authTokenForHost(host string, publicHost string, hostEnvVar string, tokenEnvVars ...string) string {
+	if !isSameHost(host, publicHost) && !isSameHost(host, os.Getenv(hostEnvVar)) {
+		return ""
+	}
+
+	return authTokenFromEnv(tokenEnvVars...)
+}
+
+// isSameHost compares host names case-insensitively. The second one may also be
+// a URL, e.g. GITLAB_HOST=https://gitlab.example.com/
+func isSameHost(host string, other string) bool {
+	if other == "" {
+		return false
+	}
+
+	if u, err := url.Parse(other); err == nil && u.Host != "" {
+		other = u.Host
+	}
+	return strings.EqualFold(host, strings.TrimSuffix(other, "/"))
+}
+
+// getJSON sends a GET request to a REST API and decodes the JSON response into
+// result
+func getJSON(url string, headers map[string]string, result any) error {
+	return sendJSONRequest("GET", url, headers, nil, result)
+}
+
+// sendJSONRequest sends a request with an optional JSON body to a REST API
+// and decodes the JSON response into result, unless result is nil
+func sendJSONRequest(method string, url string, headers map[string]string, body any, result any) error {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewBuffer(bodyBytes)
+	}
+
+	req, err := http.NewRequest(method, url, bodyReader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyStr := new(bytes.Buffer)
+		_, _ = bodyStr.ReadFrom(resp.Body)
+		return fmt.Errorf("request to %s failed with status: %s. Body: %s", url, resp.Status, bodyStr.String())
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(respBytes, result)
+}
+
+// returns a map from branch name to pull request
+func GeneratePullRequestMap(
+	prs []*models.PullRequest,
+	branches []*models.Branch,
+	remotes []*models.Remote,
+) map[string]*models.PullRequest {
+	res := map[string]*models.PullRequest{}
+
+	if len(prs) == 0 {
+		return res
+	}
+
+	remotesToOwnersMap := getRemotesToOwnersMap(remotes)
+
+	// A PR can be identified by two things: the owner e.g. 'jesseduffield' and the
+	// branch name e.g. 'feature/my-feature'. The owner might be different
+	// to the owner of the repo if the PR is from a fork of that repo.
+	type prKey struct {
+		owner      string
+		branchName string
+	}
+
+	prByKey := map[prKey]models.PullRequest{}
+
+	for _, pr := range prs {
+		key := prKey{owner: strings.ToLower(pr.UserName()), branchName: pr.BranchName()}
+		// PRs are returned newest-first from the API, so the first one we
+		// see for each key is the most recent and therefore the most relevant.
+		if _, exists := prByKey[key]; !exists {
+			prByKey[key] = *pr
+		}
+	}
+
+	for _, branch := range branches {
+		if !branch.IsTrackingRemote() {
+			continue
+		}
+
+		owner, foundRemoteOwner := remotesToOwnersMap[branch.UpstreamRemote]
+		if !foundRemoteOwner {
+			// UpstreamRemote may be a full URL rather than a remote name;
+			// try parsing the owner directly from it.
+			repoInfo, err := hosting_service.GetRepoInfoFromURL(branch.UpstreamRemote)
+			if err != nil {
+				continue
+			}
+			owner = repoInfo.Owner
+		}
+
+		pr, hasPr := prByKey[prKey{owner: strings.ToLower(owner), branchName: branch.UpstreamBranch}]
+
+		if !hasPr {
+			continue
+		}
+
+		res[branch.Name] = &pr
+	}
+
+	return res
+}
+
+func getRemotesToOwnersMap(remotes []*models.Remote) map[string]string {
+	res := map[string]string{}
+	for _, remote := range remotes {
+		if len(remote.Urls) == 0 {
+			continue
+		}
+
+		repoInfo, err := hosting_service.GetRepoInfoFromURL(remote.Urls[0])
+		if err != nil {
+			continue
+		}
+
+		res[remote.Name] = repoInfo.Owner
+	}
+	return res
+}
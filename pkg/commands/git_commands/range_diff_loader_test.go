@@ -0,0 +1,81 @@
+package git_commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRangeDiffEntries(t *testing.T) {
+	type scenario struct {
+		testName        string
+		base            string
+		runner          *oscommands.FakeCmdObjRunner
+		expectedEntries []*models.RangeDiffEntry
+		expectedError   error
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "no commits",
+			base:     "",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"range-diff", "--no-color", "--no-patch", "old...new"}, "", nil),
+			expectedEntries: []*models.RangeDiffEntry{},
+		},
+		{
+			testName: "all kinds of pairings, returned newest first",
+			base:     "",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"range-diff", "--no-color", "--no-patch", "old...new"},
+					" -:  ------- >  1:  e01aad2 added commit\n"+
+						" 1:  a1f4111 =  2:  6712391 unchanged commit\n"+
+						" 2:  aa3a8d6 !  3:  1c2cba1 changed commit: with colon\n"+
+						" 3:  0b3c2a1 <  -:  ------- removed commit\n"+
+						"10:  abcdef0 = 11:  0fedcba double digits\n",
+					nil),
+			expectedEntries: []*models.RangeDiffEntry{
+				{Status: models.RangeDiffStatusEqual, OldIndex: 10, OldHash: "abcdef0", NewIndex: 11, NewHash: "0fedcba", Subject: "double digits"},
+				{Status: models.RangeDiffStatusRemoved, OldIndex: 3, OldHash: "0b3c2a1", Subject: "removed commit"},
+				{Status: models.RangeDiffStatusChanged, OldIndex: 2, OldHash: "aa3a8d6", NewIndex: 3, NewHash: "1c2cba1", Subject: "changed commit: with colon"},
+				{Status: models.RangeDiffStatusEqual, OldIndex: 1, OldHash: "a1f4111", NewIndex: 2, NewHash: "6712391", Subject: "unchanged commit"},
+				{Status: models.RangeDiffStatusAdded, NewIndex: 1, NewHash: "e01aad2", Subject: "added commit"},
+			},
+		},
+		{
+			testName: "with explicit base",
+			base:     "main",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"range-diff", "--no-color", "--no-patch", "main", "old", "new"},
+					"1:  a1f4111 = 1:  6712391 unchanged commit\n",
+					nil),
+			expectedEntries: []*models.RangeDiffEntry{
+				{Status: models.RangeDiffStatusEqual, OldIndex: 1, OldHash: "a1f4111", NewIndex: 1, NewHash: "6712391", Subject: "unchanged commit"},
+			},
+		},
+		{
+			testName: "error",
+			base:     "",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"range-diff", "--no-color", "--no-patch", "old...new"}, "", errors.New("fatal: need two commit ranges")),
+			expectedEntries: nil,
+			expectedError:   errors.New("fatal: need two commit ranges"),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			loader := NewRangeDiffLoader(common.NewDummyCommon(), oscommands.NewDummyCmdObjBuilder(s.runner))
+
+			entries, err := loader.GetRangeDiffEntries(s.base, "old", "new")
+
+			assert.Equal(t, s.expectedError, err)
+			assert.Equal(t, s.expectedEntries, entries)
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
@@ -28,6 +28,7 @@ func TestGetReflogCommits(t *testing.T) {
 		lastReflogCommit        *models.Commit
 		filterPath              string
 		filterAuthor            string
+		contentFilter           ContentFilter
 		expectedCommitOpts      []models.NewCommitOpts
 		expectedOnlyObtainedNew bool
 		expectedError           error
@@ -166,6 +167,53 @@ func TestGetReflogCommits(t *testing.T) {
 			expectedOnlyObtainedNew: true,
 			expectedError:           nil,
 		},
+		{
+			testName: "when passing contentFilter",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"-c", "log.showSignature=false", "log", "-g", "--format=+%H%x00%ct%x00%gs%x00%P", "-GTODO", "--grep=wip"}, reflogOutput, nil),
+
+			lastReflogCommit: nil,
+			contentFilter:    ContentFilter{Pickaxe: "TODO", PickaxeIsRegex: true, Message: "wip"},
+			expectedCommitOpts: []models.NewCommitOpts{
+				{
+					Hash:          "c3c4b66b64c97ffeecde",
+					Name:          "checkout: moving from A to B",
+					Status:        models.StatusReflog,
+					UnixTimestamp: 1643150483,
+					Parents:       []string{"51baa8c1"},
+				},
+				{
+					Hash:          "c3c4b66b64c97ffeecde",
+					Name:          "checkout: moving from B to A",
+					Status:        models.StatusReflog,
+					UnixTimestamp: 1643150483,
+					Parents:       []string{"51baa8c1"},
+				},
+				{
+					Hash:          "c3c4b66b64c97ffeecde",
+					Name:          "checkout: moving from A to B",
+					Status:        models.StatusReflog,
+					UnixTimestamp: 1643150483,
+					Parents:       []string{"51baa8c1"},
+				},
+				{
+					Hash:          "c3c4b66b64c97ffeecde",
+					Name:          "checkout: moving from master to A",
+					Status:        models.StatusReflog,
+					UnixTimestamp: 1643150483,
+					Parents:       []string{"51baa8c1"},
+				},
+				{
+					Hash:          "f4ddf2f0d4be4ccc7efa",
+					Name:          "checkout: moving from A to master",
+					Status:        models.StatusReflog,
+					UnixTimestamp: 1643149435,
+					Parents:       []string{"51baa8c1"},
+				},
+			},
+			expectedOnlyObtainedNew: false,
+			expectedError:           nil,
+		},
 		{
 			testName: "when command returns error",
 			runner: oscommands.NewFakeRunner(t).
@@ -186,7 +234,7 @@ func TestGetReflogCommits(t *testing.T) {
 				cmd:    oscommands.NewDummyCmdObjBuilder(scenario.runner),
 			}
 
-			commits, onlyObtainednew, err := builder.GetReflogCommits(hashPool, scenario.lastReflogCommit, scenario.filterPath, scenario.filterAuthor)
+			commits, onlyObtainednew, err := builder.GetReflogCommits(hashPool, scenario.lastReflogCommit, scenario.filterPath, scenario.filterAuthor, scenario.contentFilter)
 			assert.Equal(t, scenario.expectedOnlyObtainedNew, onlyObtainednew)
 			assert.Equal(t, scenario.expectedError, err)
 			t.Logf("actual commits: \n%s", litter.Sdump(commits))
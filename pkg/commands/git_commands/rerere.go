@@ -0,0 +1,202 @@
+package git_commands
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+)
+
+// Commands for git's "reuse recorded resolution" feature, which replays
+// conflict resolutions that were recorded for an earlier, identical conflict
+type RerereCommands struct {
+	*GitCommon
+}
+
+func NewRerereCommands(gitCommon *GitCommon) *RerereCommands {
+	return &RerereCommands{
+		GitCommon: gitCommon,
+	}
+}
+
+// IsEnabled mirrors git's own rule: an explicit rerere.enabled setting wins,
+// and if there is none, rerere is on as soon as the rr-cache directory exists
+func (self *RerereCommands) IsEnabled() bool {
+	if enabled, isSet := self.config.GetRerereEnabled(); isSet {
+		return enabled
+	}
+
+	exists, err := afero.DirExists(self.Fs, filepath.Join(self.repoPaths.RepoGitDirPath(), "rr-cache"))
+	return err == nil && exists
+}
+
+// Remaining returns the conflicted paths that rerere did not resolve, either
+// because it had no recorded resolution or because it can't handle them (e.g.
+// submodules). Any other conflicted path was resolved by rerere.
+func (self *RerereCommands) Remaining() ([]string, error) {
+	cmdArgs := NewGitCmd("rerere").Arg("remaining").ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SplitLines(output), nil
+}
+
+// Forget drops the recorded resolution for the given paths and recreates the
+// conflict markers, so that the conflict can be resolved again (and the new
+// resolution gets recorded instead)
+func (self *RerereCommands) Forget(paths []string) error {
+	if err := self.cmd.New(
+		NewGitCmd("rerere").Arg("forget", "--").Arg(paths...).ToArgv(),
+	).Run(); err != nil {
+		return err
+	}
+
+	return self.cmd.New(
+		NewGitCmd("checkout").Arg("--merge", "--").Arg(paths...).ToArgv(),
+	).Run()
+}
+
+// ResolutionDiffCmdObj shows the resolution that rerere recorded under the
+// given id (see ConflictID), as a diff between the conflict as rerere saw it
+// and the resolution.
+func (self *RerereCommands) ResolutionDiffCmdObj(id string) *oscommands.CmdObj {
+	hash, preimage, postimage := rrCacheFileNames(id)
+
+	cmdArgs := NewGitCmd("diff").
+		Arg("--no-index", "--no-ext-diff", "--no-prefix").
+		Arg(fmt.Sprintf("--unified=%d", self.UserConfig().Git.DiffContextSize)).
+		Arg(fmt.Sprintf("--color=%s", self.pagerConfig.GetColorArg())).
+		Arg("--", preimage, postimage).
+		Dir(filepath.Join(self.repoPaths.RepoGitDirPath(), "rr-cache", hash)).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog()
+}
+
+// ConflictID returns the id under which rerere recorded the resolution it
+// replayed for the given file. Git forgets about conflicts once rerere has
+// resolved them, so we recreate the conflict from the index stages and hash it
+// the way rerere does. The id is the hash, followed by the number of the
+// variant if there are several resolutions for conflicts with that hash.
+func (self *RerereCommands) ConflictID(path string, conflictMarkerSize int) (string, error) {
+	if conflictMarkerSize == 0 {
+		conflictMarkerSize = DEFAULT_CONFLICT_MARKER_SIZE
+	}
+
+	// recreate the conflict the way git merge did, i.e. with the configured
+	// conflict style
+	conflict, err := self.mergeStages(path, MergeStagesOpts{ConflictMarkerSize: conflictMarkerSize})
+	if err != nil {
+		return "", err
+	}
+
+	hash, preimage := normalizeRerereConflict(conflict, conflictMarkerSize)
+
+	// Rerere tries each variant until one of them resolves the conflict. We
+	// go for the one whose conflict is exactly ours, if there is one.
+	entries, err := afero.ReadDir(self.Fs, filepath.Join(self.repoPaths.RepoGitDirPath(), "rr-cache", hash))
+	if err != nil {
+		return "", fmt.Errorf("rerere hasn't recorded a resolution for %s", path)
+	}
+	ids := lo.FilterMap(entries, func(entry os.FileInfo, _ int) (string, bool) {
+		variant, ok := strings.CutPrefix(entry.Name(), "postimage")
+		if !ok {
+			return "", false
+		}
+		return hash + variant, variant == "" || strings.HasPrefix(variant, ".")
+	})
+	if len(ids) == 0 {
+		return "", fmt.Errorf("rerere hasn't recorded a resolution for %s", path)
+	}
+
+	id, found := lo.Find(ids, func(id string) bool {
+		_, preimageName, _ := rrCacheFileNames(id)
+		recordedPreimage, err := afero.ReadFile(self.Fs, filepath.Join(self.repoPaths.RepoGitDirPath(), "rr-cache", hash, preimageName))
+		return err == nil && string(recordedPreimage) == preimage
+	})
+	if !found {
+		id = ids[0]
+	}
+	return id, nil
+}
+
+// normalizeRerereConflict does what rerere does to a file with conflicts: it
+// hashes the two sides of each conflict, in sorted order so that the hash is
+// the same no matter which side is ours, and also returns the file with the
+// conflicts normalized the same way, as rerere saves it in the preimage.
+func normalizeRerereConflict(content string, markerSize int) (string, string) {
+	isMarker := func(line string, char byte) bool {
+		if len(line) < markerSize || strings.Count(line[:markerSize], string(char)) != markerSize {
+			return false
+		}
+		rest := line[markerSize:]
+		if char == '=' {
+			return rest == "\n"
+		}
+		return rest == "\n" || strings.HasPrefix(rest, " ")
+	}
+	marker := func(char byte) string {
+		return strings.Repeat(string(char), markerSize) + "\n"
+	}
+
+	const (
+		outside = iota
+		inFirstSide
+		inBase
+		inSecondSide
+	)
+
+	hash := sha1.New()
+	normalized := &strings.Builder{}
+	state := outside
+	var one, two strings.Builder
+	for _, line := range strings.SplitAfter(content, "\n") {
+		switch {
+		case state == outside && isMarker(line, '<'):
+			state = inFirstSide
+			one.Reset()
+			two.Reset()
+		case state == inFirstSide && isMarker(line, '|'):
+			state = inBase
+		case (state == inFirstSide || state == inBase) && isMarker(line, '='):
+			state = inSecondSide
+		case state == inSecondSide && isMarker(line, '>'):
+			state = outside
+			sides := []string{one.String(), two.String()}
+			slices.Sort(sides)
+			for _, side := range sides {
+				hash.Write([]byte(side + "\x00"))
+			}
+			normalized.WriteString(marker('<') + sides[0] + marker('=') + sides[1] + marker('>'))
+		case state == inFirstSide:
+			one.WriteString(line)
+		case state == inSecondSide:
+			two.WriteString(line)
+		case state == outside:
+			normalized.WriteString(line)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), normalized.String()
+}
+
+// rrCacheFileNames returns the name of the rr-cache directory of the given
+// conflict id, and the names of the preimage and postimage files in it
+func rrCacheFileNames(id string) (string, string, string) {
+	hash, variant, hasVariant := strings.Cut(id, ".")
+	if !hasVariant || variant == "0" {
+		return hash, "preimage", "postimage"
+	}
+	return hash, "preimage." + variant, "postimage." + variant
+}
@@ -0,0 +1,145 @@
+package git_commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRerereIsEnabled(t *testing.T) {
+	type scenario struct {
+		testName       string
+		rerereEnabled  string
+		rrCacheExists  bool
+		expectedResult bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:       "enabled in config",
+			rerereEnabled:  "true",
+			expectedResult: true,
+		},
+		{
+			testName:       "disabled in config even though rr-cache exists",
+			rerereEnabled:  "false",
+			rrCacheExists:  true,
+			expectedResult: false,
+		},
+		{
+			testName:       "not configured, but rr-cache exists",
+			rrCacheExists:  true,
+			expectedResult: true,
+		},
+		{
+			testName:       "not configured and no rr-cache",
+			expectedResult: false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			if s.rrCacheExists {
+				assert.NoError(t, fs.MkdirAll("/repo/.git/rr-cache", 0o755))
+			}
+			instance := buildRerereCommands(commonDeps{
+				fs:        fs,
+				repoPaths: MockRepoPaths("/repo"),
+				gitConfig: git_config.NewFakeGitConfig(map[string]string{"rerere.enabled": s.rerereEnabled}),
+			})
+
+			assert.Equal(t, s.expectedResult, instance.IsEnabled())
+		})
+	}
+}
+
+func TestRerereRemaining(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rerere", "remaining"}, "file1\ndir/file2\n", nil)
+	instance := buildRerereCommands(commonDeps{runner: runner})
+
+	remaining, err := instance.Remaining()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"file1", "dir/file2"}, remaining)
+	runner.CheckForMissingCalls()
+}
+
+func TestRerereForget(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rerere", "forget", "--", "file1", "dir/file2"}, "", nil).
+		ExpectGitArgs([]string{"checkout", "--merge", "--", "file1", "dir/file2"}, "", nil)
+	instance := buildRerereCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Forget([]string{"file1", "dir/file2"}))
+	runner.CheckForMissingCalls()
+}
+
+func TestNormalizeRerereConflict(t *testing.T) {
+	type scenario struct {
+		testName         string
+		content          string
+		markerSize       int
+		expectedHash     string
+		expectedPreimage string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:         "sides are sorted",
+			content:          "a\n<<<<<<< ours\nmaster\n=======\nfeature\n>>>>>>> theirs\nz\n",
+			markerSize:       7,
+			expectedHash:     "a70dccbc88f2b2879ed62aa5ce6c28056a431c6a",
+			expectedPreimage: "a\n<<<<<<<\nfeature\n=======\nmaster\n>>>>>>>\nz\n",
+		},
+		{
+			testName:         "base is dropped",
+			content:          "a\n<<<<<<< ours\nfeature\n||||||| base\nbase\n=======\nmaster\n>>>>>>> theirs\nz\n",
+			markerSize:       7,
+			expectedHash:     "a70dccbc88f2b2879ed62aa5ce6c28056a431c6a",
+			expectedPreimage: "a\n<<<<<<<\nfeature\n=======\nmaster\n>>>>>>>\nz\n",
+		},
+		{
+			testName:         "custom marker size",
+			content:          "<<<<<<<<<< ours\n<<<<<<< x\n==========\nfeature\n>>>>>>>>>> theirs\n",
+			markerSize:       10,
+			expectedHash:     "7bfde82e145796b5c79e3ba6ad27ad3e16744d5a",
+			expectedPreimage: "<<<<<<<<<<\n<<<<<<< x\n==========\nfeature\n>>>>>>>>>>\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			hash, preimage := normalizeRerereConflict(s.content, s.markerSize)
+			assert.Equal(t, s.expectedHash, hash)
+			assert.Equal(t, s.expectedPreimage, preimage)
+		})
+	}
+}
+
+func TestRerereResolutionDiffCmdObj(t *testing.T) {
+	scenarios := []struct {
+		id           string
+		expectedArgs []string
+	}{
+		{
+			id:           "abc",
+			expectedArgs: []string{"git", "-C", "/repo/.git/rr-cache/abc", "diff", "--no-index", "--no-ext-diff", "--no-prefix", "--unified=3", "--color=always", "--", "preimage", "postimage"},
+		},
+		{
+			id:           "abc.2",
+			expectedArgs: []string{"git", "-C", "/repo/.git/rr-cache/abc", "diff", "--no-index", "--no-ext-diff", "--no-prefix", "--unified=3", "--color=always", "--", "preimage.2", "postimage.2"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.id, func(t *testing.T) {
+			instance := buildRerereCommands(commonDeps{repoPaths: MockRepoPaths("/repo")})
+
+			assert.Equal(t, s.expectedArgs, instance.ResolutionDiffCmdObj(s.id).Args())
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package git_commands
+
+import (
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+type SparseCheckoutCommands struct {
+	*GitCommon
+
+	// We need the cone directories on every files refresh, so like the git
+	// config we only load them again after DropCache.
+	coneDirsMutex  sync.Mutex
+	coneDirs       []string
+	coneDirsLoaded bool
+}
+
+func NewSparseCheckoutCommands(gitCommon *GitCommon) *SparseCheckoutCommands {
+	return &SparseCheckoutCommands{
+		GitCommon: gitCommon,
+	}
+}
+
+func (self *SparseCheckoutCommands) IsEnabled() bool {
+	return self.config.GetSparseCheckout()
+}
+
+// In cone mode the sparse set is a list of directories rather than a list of
+// gitignore-style patterns. We only support adding and removing directories in
+// cone mode.
+func (self *SparseCheckoutCommands) IsConeMode() bool {
+	return self.config.GetSparseCheckoutCone()
+}
+
+// List returns the directories (in cone mode) or patterns (otherwise) that make
+// up the sparse set
+func (self *SparseCheckoutCommands) List() ([]string, error) {
+	cmdArgs := NewGitCmd("sparse-checkout").Arg("list").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SplitLines(output), nil
+}
+
+// ConeDirs is like List, but caches the result until DropCache is called.
+func (self *SparseCheckoutCommands) ConeDirs() ([]string, error) {
+	self.coneDirsMutex.Lock()
+	defer self.coneDirsMutex.Unlock()
+
+	if !self.coneDirsLoaded {
+		coneDirs, err := self.List()
+		if err != nil {
+			return nil, err
+		}
+		self.coneDirs = coneDirs
+		self.coneDirsLoaded = true
+	}
+
+	return self.coneDirs, nil
+}
+
+// DropCache makes the next ConeDirs call load the directories again. Call it
+// whenever the sparse set may have changed.
+func (self *SparseCheckoutCommands) DropCache() {
+	self.coneDirsMutex.Lock()
+	defer self.coneDirsMutex.Unlock()
+
+	self.coneDirs = nil
+	self.coneDirsLoaded = false
+}
+
+// Set replaces the sparse set with the given directories, enabling sparse
+// checkout in cone mode if it isn't enabled yet. Passing no directories leaves
+// only the files at the top level of the repo checked out.
+func (self *SparseCheckoutCommands) Set(dirs []string) error {
+	cmdArgs := NewGitCmd("sparse-checkout").Arg("set", "--cone", "--").
+		Arg(dirs...).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+func (self *SparseCheckoutCommands) Add(dirs []string) error {
+	cmdArgs := NewGitCmd("sparse-checkout").Arg("add", "--").
+		Arg(dirs...).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+func (self *SparseCheckoutCommands) Reapply() error {
+	cmdArgs := NewGitCmd("sparse-checkout").Arg("reapply").
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+func (self *SparseCheckoutCommands) Disable() error {
+	cmdArgs := NewGitCmd("sparse-checkout").Arg("disable").
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+// GetHeadDirectories returns all directories in the tree of HEAD, regardless
+// of whether they are currently checked out
+func (self *SparseCheckoutCommands) GetHeadDirectories() ([]string, error) {
+	cmdArgs := NewGitCmd("ls-tree").Arg("-d", "-r", "--name-only", "HEAD").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SplitLines(output), nil
+}
+
+// IsInSparseCheckoutCone tells whether the given path (as reported by git
+// status, so directories have a trailing slash) is part of the sparse set
+// defined by the given cone directories. In cone mode git includes all files
+// at the top level, all files directly inside any parent directory of a cone
+// directory, and everything below a cone directory.
+func IsInSparseCheckoutCone(filePath string, coneDirs []string) bool {
+	isDir := strings.HasSuffix(filePath, "/")
+	filePath = strings.TrimSuffix(filePath, "/")
+
+	parentDir := path.Dir(filePath)
+	if parentDir == "." {
+		parentDir = ""
+	}
+
+	for _, coneDir := range coneDirs {
+		coneDir = strings.Trim(coneDir, "/")
+
+		if filePath == coneDir || strings.HasPrefix(filePath, coneDir+"/") {
+			return true
+		}
+
+		// an ancestor directory of a cone directory is partially included
+		if isDir && strings.HasPrefix(coneDir, filePath+"/") {
+			return true
+		}
+
+		if !isDir && parentDir != "" && strings.HasPrefix(coneDir, parentDir+"/") {
+			return true
+		}
+	}
+
+	return !isDir && parentDir == ""
+}
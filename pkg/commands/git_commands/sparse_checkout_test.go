@@ -0,0 +1,99 @@
+package git_commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparseCheckoutSet(t *testing.T) {
+	type scenario struct {
+		testName string
+		dirs     []string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "no directories",
+			dirs:     []string{},
+			expected: []string{"sparse-checkout", "set", "--cone", "--"},
+		},
+		{
+			testName: "multiple directories",
+			dirs:     []string{"pkg/gui", "docs"},
+			expected: []string{"sparse-checkout", "set", "--cone", "--", "pkg/gui", "docs"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).ExpectGitArgs(s.expected, "", nil)
+			instance := buildSparseCheckoutCommands(commonDeps{runner: runner})
+
+			assert.NoError(t, instance.Set(s.dirs))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestSparseCheckoutList(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"sparse-checkout", "list"}, "pkg/gui\ndocs\n", nil)
+	instance := buildSparseCheckoutCommands(commonDeps{runner: runner})
+
+	dirs, err := instance.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pkg/gui", "docs"}, dirs)
+	runner.CheckForMissingCalls()
+}
+
+func TestSparseCheckoutConeDirsAreCachedUntilDropped(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"sparse-checkout", "list"}, "pkg/gui\n", nil).
+		ExpectGitArgs([]string{"sparse-checkout", "list"}, "pkg/gui\ndocs\n", nil)
+	instance := buildSparseCheckoutCommands(commonDeps{runner: runner})
+
+	for range 2 {
+		dirs, err := instance.ConeDirs()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pkg/gui"}, dirs)
+	}
+
+	instance.DropCache()
+	dirs, err := instance.ConeDirs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pkg/gui", "docs"}, dirs)
+	runner.CheckForMissingCalls()
+}
+
+func TestIsInSparseCheckoutCone(t *testing.T) {
+	type scenario struct {
+		path     string
+		coneDirs []string
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{path: "README.md", coneDirs: []string{}, expected: true},
+		{path: "docs/index.md", coneDirs: []string{}, expected: false},
+		{path: "docs/", coneDirs: []string{}, expected: false},
+		{path: "pkg/gui/gui.go", coneDirs: []string{"pkg/gui"}, expected: true},
+		{path: "pkg/gui/context/setup.go", coneDirs: []string{"pkg/gui"}, expected: true},
+		// files directly in a parent directory of a cone directory are included
+		{path: "pkg/doc.go", coneDirs: []string{"pkg/gui"}, expected: true},
+		{path: "pkg/", coneDirs: []string{"pkg/gui"}, expected: true},
+		// but not files in sibling directories
+		{path: "pkg/commands/git.go", coneDirs: []string{"pkg/gui"}, expected: false},
+		{path: "pkg/commands/", coneDirs: []string{"pkg/gui"}, expected: false},
+		{path: "pkg/guide/intro.md", coneDirs: []string{"pkg/gui"}, expected: false},
+		{path: "docs/index.md", coneDirs: []string{"pkg/gui", "docs"}, expected: true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			assert.Equal(t, s.expected, IsInSparseCheckoutCone(s.path, s.coneDirs))
+		})
+	}
+}
@@ -2,9 +2,14 @@ package git_commands
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 type StashCommands struct {
@@ -199,6 +204,62 @@ func (self *StashCommands) StashIncludeUntrackedChanges(message string) error {
 	).Run()
 }
 
+// StashPaths stashes the changes of the given paths only, including untracked
+// files, leaving the rest of the working tree untouched.
+func (self *StashCommands) StashPaths(message string, paths []string) error {
+	cmdArgs := NewGitCmd("stash").Arg("push", "--include-untracked", "-m", message).
+		Arg("--").
+		Arg(paths...).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+// CountCommitsBehindHead returns the number of commits reachable from HEAD but
+// not from the given stash base
+func (self *StashCommands) CountCommitsBehindHead(baseHash string) (int, error) {
+	cmdArgs := NewGitCmd("rev-list").Arg("--count", baseHash+"..HEAD").ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(output))
+}
+
+// PredictConflicts returns the files that would conflict if the given stash
+// entry were applied on top of HEAD now, without touching the working tree or
+// index. Local modifications and the stash's untracked files are not taken
+// into account, so this can only be a hint. Returns nil if git is too old to
+// do the merge in memory.
+func (self *StashCommands) PredictConflicts(stashEntry *models.StashEntry) ([]string, error) {
+	if !self.version.IsAtLeast(2, 38, 0) || stashEntry.BaseHash == "" {
+		return nil, nil
+	}
+
+	// `git stash apply` merges with the stash's base commit as merge base.
+	// Before 2.40 we can't pass that explicitly, but git's own choice is the
+	// same as long as the stash's base is an ancestor of HEAD.
+	cmdArgs := NewGitCmd("merge-tree").
+		Arg("--write-tree", "--name-only", "--no-messages").
+		ArgIf(self.version.IsAtLeast(2, 40, 0), "--merge-base", stashEntry.BaseHash).
+		Arg("HEAD", stashEntry.Hash).
+		ToArgv()
+
+	// git exits with status 1 if there are conflicts, but still prints the
+	// conflicted files after the hash of the resulting tree
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	lines := utils.SplitLines(output)
+	if len(lines) == 0 || !treeHashRegex.MatchString(lines[0]) {
+		return nil, err
+	}
+
+	return lo.Uniq(lines[1:]), nil
+}
+
+// sha1 or sha256 object names
+var treeHashRegex = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
 func (self *StashCommands) Rename(index int, message string) error {
 	hash, err := self.Hash(index)
 	if err != nil {
@@ -32,7 +32,7 @@ func (self *StashLoader) GetStashEntries(filterPath string) []*models.StashEntry
 		return self.getUnfilteredStashEntries()
 	}
 
-	cmdArgs := NewGitCmd("stash").Arg("list", "--name-only", "--pretty=%gd:%H|%ct|%gs").ToArgv()
+	cmdArgs := NewGitCmd("stash").Arg("list", "--name-only", "--pretty=%gd:%H|%ct|%P|%gs").ToArgv()
 	rawString, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
 	if err != nil {
 		return self.getUnfilteredStashEntries()
@@ -66,7 +66,7 @@ outer:
 }
 
 func (self *StashLoader) getUnfilteredStashEntries() []*models.StashEntry {
-	cmdArgs := NewGitCmd("stash").Arg("list", "-z", "--pretty=%H|%ct|%gs").ToArgv()
+	cmdArgs := NewGitCmd("stash").Arg("list", "-z", "--pretty=%H|%ct|%P|%gs").ToArgv()
 
 	rawString, _ := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
 	return lo.Map(utils.SplitNul(rawString), func(line string, index int) *models.StashEntry {
@@ -86,7 +86,12 @@ func stashEntryFromLine(line string, index int) *models.StashEntry {
 	}
 	model.Hash = hash
 
-	tstr, msg, ok := strings.Cut(line, "|")
+	tstr, line, ok := strings.Cut(line, "|")
+	if !ok {
+		return model
+	}
+
+	parents, msg, ok := strings.Cut(line, "|")
 	if !ok {
 		return model
 	}
@@ -98,6 +103,15 @@ func stashEntryFromLine(line string, index int) *models.StashEntry {
 
 	model.Name = msg
 	model.Recency = utils.UnixToTimeAgo(t)
+	model.BaseHash, _, _ = strings.Cut(parents, " ")
+	if match := stashBranchRegex.FindStringSubmatch(msg); match != nil {
+		model.BaseBranch = match[1]
+	}
 
 	return model
 }
+
+// matches the messages git uses for stashes, e.g. "WIP on master: abc1234
+// subject" or "On master: custom message". Branch names can't contain colons,
+// so the first one ends the branch name.
+var stashBranchRegex = regexp.MustCompile(`^(?:WIP on|On) ([^:]+):`)
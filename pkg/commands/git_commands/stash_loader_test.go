@@ -15,7 +15,7 @@ func TestGetStashEntries(t *testing.T) {
 	type scenario struct {
 		testName             string
 		filterPath           string
-		runner               oscommands.ICmdObjRunner
+		runner               *oscommands.FakeCmdObjRunner
 		expectedStashEntries []*models.StashEntry
 	}
 
@@ -27,30 +27,61 @@ func TestGetStashEntries(t *testing.T) {
 			"No stash entries found",
 			"",
 			oscommands.NewFakeRunner(t).
-				ExpectGitArgs([]string{"stash", "list", "-z", "--pretty=%H|%ct|%gs"}, "", nil),
+				ExpectGitArgs([]string{"stash", "list", "-z", "--pretty=%H|%ct|%P|%gs"}, "", nil),
 			[]*models.StashEntry{},
 		},
 		{
 			"Several stash entries found",
 			"",
 			oscommands.NewFakeRunner(t).
-				ExpectGitArgs([]string{"stash", "list", "-z", "--pretty=%H|%ct|%gs"},
-					fmt.Sprintf("fa1afe1|%d|WIP on add-pkg-commands-test: 55c6af2 increase parallel build\x00deadbeef|%d|WIP on master: bb86a3f update github template\x00",
+				ExpectGitArgs([]string{"stash", "list", "-z", "--pretty=%H|%ct|%P|%gs"},
+					fmt.Sprintf("fa1afe1|%d|55c6af2 1234567|WIP on add-pkg-commands-test: 55c6af2 increase parallel build\x00deadbeef|%d|bb86a3f 89abcde|WIP on master: bb86a3f update github template\x00",
 						hoursAgo,
 						daysAgo,
 					), nil),
 			[]*models.StashEntry{
 				{
-					Index:   0,
-					Name:    "WIP on add-pkg-commands-test: 55c6af2 increase parallel build",
-					Recency: "3h",
-					Hash:    "fa1afe1",
+					Index:      0,
+					Name:       "WIP on add-pkg-commands-test: 55c6af2 increase parallel build",
+					Recency:    "3h",
+					Hash:       "fa1afe1",
+					BaseHash:   "55c6af2",
+					BaseBranch: "add-pkg-commands-test",
 				},
 				{
-					Index:   1,
-					Name:    "WIP on master: bb86a3f update github template",
-					Recency: "3d",
-					Hash:    "deadbeef",
+					Index:      1,
+					Name:       "WIP on master: bb86a3f update github template",
+					Recency:    "3d",
+					Hash:       "deadbeef",
+					BaseHash:   "bb86a3f",
+					BaseBranch: "master",
+				},
+			},
+		},
+		{
+			"Stash entry with a renamed message",
+			"",
+			oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"stash", "list", "-z", "--pretty=%H|%ct|%P|%gs"},
+					fmt.Sprintf("fa1afe1|%d|55c6af2 1234567|On feature/x: my changes\x00deadbeef|%d|55c6af2 89abcde|renamed stash\x00",
+						hoursAgo,
+						daysAgo,
+					), nil),
+			[]*models.StashEntry{
+				{
+					Index:      0,
+					Name:       "On feature/x: my changes",
+					Recency:    "3h",
+					Hash:       "fa1afe1",
+					BaseHash:   "55c6af2",
+					BaseBranch: "feature/x",
+				},
+				{
+					Index:    1,
+					Name:     "renamed stash",
+					Recency:  "3d",
+					Hash:     "deadbeef",
+					BaseHash: "55c6af2",
 				},
 			},
 		},
@@ -63,6 +94,7 @@ func TestGetStashEntries(t *testing.T) {
 			loader := NewStashLoader(common.NewDummyCommon(), cmd)
 
 			assert.EqualValues(t, s.expectedStashEntries, loader.GetStashEntries(s.filterPath))
+			s.runner.CheckForMissingCalls()
 		})
 	}
 }
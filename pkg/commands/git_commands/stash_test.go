@@ -1,8 +1,10 @@
 package git_commands
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +46,15 @@ func TestStashSave(t *testing.T) {
 	runner.CheckForMissingCalls()
 }
 
+func TestStashPaths(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"stash", "push", "--include-untracked", "-m", "A stash message", "--", "dir/file1", "file2"}, "", nil)
+	instance := buildStashCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.StashPaths("A stash message", []string{"dir/file1", "file2"}))
+	runner.CheckForMissingCalls()
+}
+
 func TestStashStore(t *testing.T) {
 	type scenario struct {
 		testName string
@@ -96,6 +107,17 @@ func TestStashHash(t *testing.T) {
 	runner.CheckForMissingCalls()
 }
 
+func TestStashCountCommitsBehindHead(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rev-list", "--count", "55c6af2..HEAD"}, "12\n", nil)
+	instance := buildStashCommands(commonDeps{runner: runner})
+
+	count, err := instance.CountCommitsBehindHead("55c6af2")
+	assert.NoError(t, err)
+	assert.Equal(t, 12, count)
+	runner.CheckForMissingCalls()
+}
+
 func TestStashStashEntryCmdObj(t *testing.T) {
 	type scenario struct {
 		testName            string
@@ -225,3 +247,76 @@ func TestStashRename(t *testing.T) {
 		})
 	}
 }
+
+func TestStashPredictConflicts(t *testing.T) {
+	type scenario struct {
+		testName          string
+		gitVersion        *GitVersion
+		stashEntry        *models.StashEntry
+		runner            *oscommands.FakeCmdObjRunner
+		expectedConflicts []string
+		expectedError     string
+	}
+
+	treeHash := "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+	stashEntry := &models.StashEntry{Hash: "fa1afe1", BaseHash: "55c6af2"}
+
+	scenarios := []scenario{
+		{
+			testName:   "no conflicts",
+			gitVersion: &GitVersion{2, 40, 0, ""},
+			stashEntry: stashEntry,
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"merge-tree", "--write-tree", "--name-only", "--no-messages", "--merge-base", "55c6af2", "HEAD", "fa1afe1"}, treeHash+"\n", nil),
+			expectedConflicts: []string{},
+		},
+		{
+			testName:   "conflicts",
+			gitVersion: &GitVersion{2, 40, 0, ""},
+			stashEntry: stashEntry,
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"merge-tree", "--write-tree", "--name-only", "--no-messages", "--merge-base", "55c6af2", "HEAD", "fa1afe1"},
+					treeHash+"\nfile1\ndir/file2\n", errors.New(treeHash+"\nfile1\ndir/file2\n")),
+			expectedConflicts: []string{"file1", "dir/file2"},
+		},
+		{
+			testName:   "git can't take an explicit merge base",
+			gitVersion: &GitVersion{2, 38, 0, ""},
+			stashEntry: stashEntry,
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"merge-tree", "--write-tree", "--name-only", "--no-messages", "HEAD", "fa1afe1"}, treeHash+"\nfile1\n", errors.New(treeHash+"\nfile1\n")),
+			expectedConflicts: []string{"file1"},
+		},
+		{
+			testName:          "git can't merge in memory",
+			gitVersion:        &GitVersion{2, 37, 0, ""},
+			stashEntry:        stashEntry,
+			runner:            oscommands.NewFakeRunner(t),
+			expectedConflicts: nil,
+		},
+		{
+			testName:   "merge fails",
+			gitVersion: &GitVersion{2, 40, 0, ""},
+			stashEntry: stashEntry,
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"merge-tree", "--write-tree", "--name-only", "--no-messages", "--merge-base", "55c6af2", "HEAD", "fa1afe1"}, "fatal: bad object\n", errors.New("fatal: bad object")),
+			expectedConflicts: nil,
+			expectedError:     "fatal: bad object",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			instance := buildStashCommands(commonDeps{runner: s.runner, gitVersion: s.gitVersion})
+
+			conflicts, err := instance.PredictConflicts(s.stashEntry)
+			if s.expectedError != "" {
+				assert.EqualError(t, err, s.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, s.expectedConflicts, conflicts)
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
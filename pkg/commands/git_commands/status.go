@@ -26,6 +26,7 @@ func (self *StatusCommands) WorkingTreeState() models.WorkingTreeState {
 	result.Merging, _ = self.IsInMergeState()
 	result.CherryPicking, _ = self.IsInCherryPick()
 	result.Reverting, _ = self.IsInRevert()
+	result.ApplyingPatches, _ = self.IsInApplyingPatches()
 	return result
 }
 
@@ -38,7 +39,18 @@ func (self *StatusCommands) IsInRebase() (bool, error) {
 	if err == nil && exists {
 		return true, nil
 	}
-	return self.os.FileExists(filepath.Join(self.repoPaths.WorktreeGitDirPath(), "rebase-apply"))
+	exists, err = self.os.FileExists(filepath.Join(self.repoPaths.WorktreeGitDirPath(), "rebase-apply"))
+	if err != nil || !exists {
+		return exists, err
+	}
+	// `git am` uses the rebase-apply directory too
+	isApplyingPatches, err := self.IsInApplyingPatches()
+	return !isApplyingPatches, err
+}
+
+// IsInApplyingPatches states whether we are in the middle of a `git am`
+func (self *StatusCommands) IsInApplyingPatches() (bool, error) {
+	return self.os.FileExists(filepath.Join(self.repoPaths.WorktreeGitDirPath(), "rebase-apply", "applying"))
 }
 
 // IsInMergeState states whether we are still mid-merge
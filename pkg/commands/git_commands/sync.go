@@ -131,3 +131,24 @@ func (self *SyncCommands) FetchRemote(task gocui.Task, remoteName string) error
 
 	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
 }
+
+// FetchNotes fetches the given notes ref (e.g. "refs/notes/commits") from the
+// remote into the local ref of the same name. This fails if the local notes
+// have diverged from the remote ones; git has no way of merging notes refs as
+// part of a fetch.
+func (self *SyncCommands) FetchNotes(task gocui.Task, remoteName string, notesRef string) error {
+	cmdArgs := self.fetchCommandBuilder(false).
+		Arg(remoteName).
+		Arg(notesRef + ":" + notesRef).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
+}
+
+func (self *SyncCommands) PushNotes(task gocui.Task, remoteName string, notesRef string) error {
+	cmdArgs := NewGitCmd("push").
+		Arg(remoteName, notesRef).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).PromptOnCredentialRequest(task).Run()
+}
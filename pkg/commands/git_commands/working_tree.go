@@ -3,15 +3,18 @@ package git_commands
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/generics/set"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/samber/lo"
 )
 
@@ -549,6 +552,23 @@ func (self *WorkingTreeCommands) ResetMixed(ref string) error {
 	return self.cmd.New(cmdArgs).Run()
 }
 
+// WriteIndexTree stores the current index as a tree object and returns its
+// hash, so that the index can be restored later with ReadIndexTree
+func (self *WorkingTreeCommands) WriteIndexTree() (string, error) {
+	cmdArgs := NewGitCmd("write-tree").ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	return strings.TrimSpace(output), err
+}
+
+// ReadIndexTree replaces the index with the given tree without touching the
+// working tree
+func (self *WorkingTreeCommands) ReadIndexTree(treeish string) error {
+	cmdArgs := NewGitCmd("read-tree").Arg(treeish).ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
 func (self *WorkingTreeCommands) ShowFileAtStage(path string, stage int) (string, error) {
 	cmdArgs := NewGitCmd("show").
 		Arg(fmt.Sprintf(":%d:%s", stage, path)).
@@ -557,6 +577,32 @@ func (self *WorkingTreeCommands) ShowFileAtStage(path string, stage int) (string
 	return self.cmd.New(cmdArgs).RunWithOutput()
 }
 
+// UnmergedStages returns which of the index stages (1 for the common ancestor,
+// 2 for ours and 3 for theirs) exist for the given conflicted file. Files that
+// were added on both sides have no common ancestor, and files that were
+// deleted on one side have no stage for that side.
+func (self *WorkingTreeCommands) UnmergedStages(path string) ([]int, error) {
+	cmdArgs := NewGitCmd("ls-files").
+		Arg("--unmerged", "-z", "--", path).
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	// each entry looks like "<mode> <object> <stage>\t<path>"
+	return lo.FilterMap(utils.SplitNul(output), func(entry string, _ int) (int, bool) {
+		info, _, _ := strings.Cut(entry, "\t")
+		fields := strings.Fields(info)
+		if len(fields) != 3 {
+			return 0, false
+		}
+		stage, err := strconv.Atoi(fields[2])
+		return stage, err == nil
+	}), nil
+}
+
 func (self *WorkingTreeCommands) ObjectIDAtStage(path string, stage int) (string, error) {
 	cmdArgs := NewGitCmd("rev-parse").
 		Arg(fmt.Sprintf(":%d:%s", stage, path)).
@@ -570,26 +616,185 @@ func (self *WorkingTreeCommands) ObjectIDAtStage(path string, stage int) (string
 	return strings.TrimSpace(output), nil
 }
 
-func (self *WorkingTreeCommands) MergeFileForFiles(strategy string, oursFilepath string, baseFilepath string, theirsFilepath string) (string, error) {
+// OIDs mode (Git 2.43+)
+func (self *WorkingTreeCommands) MergeFileForObjectIDs(strategy string, oursID string, baseID string, theirsID string) (string, error) {
 	cmdArgs := NewGitCmd("merge-file").
 		Arg(strategy).
 		Arg("--stdout").
-		Arg(oursFilepath, baseFilepath, theirsFilepath).
+		Arg("--object-id").
+		Arg(oursID, baseID, theirsID).
 		ToArgv()
 
 	return self.cmd.New(cmdArgs).RunWithOutput()
 }
 
-// OIDs mode (Git 2.43+)
-func (self *WorkingTreeCommands) MergeFileForObjectIDs(strategy string, oursID string, baseID string, theirsID string) (string, error) {
+type MergeStagesOpts struct {
+	// e.g. "--ours", to resolve all conflicts in favour of one side; empty to
+	// leave conflict markers in the result
+	Strategy string
+	// Write the common ancestor into the conflict markers no matter which
+	// conflict style the repo is configured with, and label the sides
+	WithBase bool
+	// 0 for git's default
+	ConflictMarkerSize int
+}
+
+// MergeStages re-merges the index stages of a conflicted file. See mergeStages.
+func (self *WorkingTreeCommands) MergeStages(path string, opts MergeStagesOpts) (string, error) {
+	return self.mergeStages(path, opts)
+}
+
+// mergeStages merges the index stages of a conflicted file with git
+// merge-file, which needs them as files. Files that were added on both sides
+// have no base stage; they are merged against an empty base. Unlike with git
+// merge, conflicts in the result are not an error. It lives here so that
+// RerereCommands can use it too.
+func (self *GitCommon) mergeStages(path string, opts MergeStagesOpts) (string, error) {
+	stagePaths := make([]string, 0, 3)
+	defer func() {
+		for _, stagePath := range stagePaths {
+			_ = os.Remove(stagePath)
+		}
+	}()
+
+	// merge-file takes ours, base, theirs
+	for _, stage := range []int{2, 1, 3} {
+		content := ""
+		if stage != 1 || self.hasStage(path, 1) {
+			var err error
+			content, err = self.cmd.New(
+				NewGitCmd("show").Arg(fmt.Sprintf(":%d:%s", stage, path)).ToArgv(),
+			).DontLog().RunWithOutput()
+			if err != nil {
+				return "", err
+			}
+		}
+
+		stagePath, err := writeTempFile(self.os.GetTempDir(), "mergefile-stage-*", content)
+		if err != nil {
+			return "", err
+		}
+		stagePaths = append(stagePaths, stagePath)
+	}
+
 	cmdArgs := NewGitCmd("merge-file").
-		Arg(strategy).
-		Arg("--stdout").
-		Arg("--object-id").
-		Arg(oursID, baseID, theirsID).
+		ArgIf(opts.Strategy != "", opts.Strategy).
+		ArgIf(opts.WithBase, lo.Ternary(self.version.IsAtLeast(2, 35, 0), "--zdiff3", "--diff3")).
+		Arg("--stdout", "--quiet").
+		ArgIf(opts.ConflictMarkerSize > 0, fmt.Sprintf("--marker-size=%d", opts.ConflictMarkerSize)).
+		ArgIf(opts.WithBase, "-L", "ours", "-L", "base", "-L", "theirs").
+		Arg(stagePaths...).
 		ToArgv()
 
-	return self.cmd.New(cmdArgs).RunWithOutput()
+	output, _, err := self.cmd.New(cmdArgs).DontLog().RunWithOutputs()
+	// merge-file exits with the number of conflicts it found
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) && exitError.ExitCode() > 0 && exitError.ExitCode() < 128 {
+		return output, nil
+	}
+	return output, err
+}
+
+func (self *GitCommon) hasStage(path string, stage int) bool {
+	cmdArgs := NewGitCmd("rev-parse").Arg("--verify", "--quiet", fmt.Sprintf(":%d:%s", stage, path)).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().Run() == nil
+}
+
+func writeTempFile(dir string, pattern string, content string) (string, error) {
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(content)
+	return f.Name(), err
+}
+
+// DEFAULT_CONFLICT_MARKER_SIZE is the length of conflict markers unless a file
+// sets the conflict-marker-size attribute
+const DEFAULT_CONFLICT_MARKER_SIZE = 7
+
+// ConflictMarkerSizes returns the value of the conflict-marker-size attribute
+// for those of the given paths that set it. Git writes the conflict markers of
+// all other files with the default size.
+func (self *WorkingTreeCommands) ConflictMarkerSizes(paths []string) (map[string]int, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	cmdArgs := NewGitCmd("check-attr").
+		Arg("-z", "--stdin", "conflict-marker-size").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).
+		SetStdin(strings.Join(paths, "\x00")).
+		DontLog().
+		RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseConflictMarkerSizes(output), nil
+}
+
+// output looks like "path\x00conflict-marker-size\x0032\x00other\x00conflict-marker-size\x00unspecified\x00"
+func parseConflictMarkerSizes(output string) map[string]int {
+	result := map[string]int{}
+	fields := strings.Split(strings.TrimSuffix(output, "\x00"), "\x00")
+	for _, chunk := range lo.Chunk(fields, 3) {
+		if len(chunk) != 3 {
+			continue
+		}
+		// values like "unspecified" or "set" make git fall back to the default
+		if size, err := strconv.Atoi(chunk[2]); err == nil && size > 0 {
+			result[chunk[0]] = size
+		}
+	}
+	return result
+}
+
+// IgnoredPaths returns those of the given paths (relative to the worktree)
+// that git ignores, because of a .gitignore file or any of the other places
+// ignore rules can come from.
+func (self *WorkingTreeCommands) IgnoredPaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	// With --non-matching git exits with 0 even when none of the paths are
+	// ignored, and --verbose lets us tell the ignored paths from the others.
+	cmdArgs := NewGitCmd("check-ignore").
+		Arg("-z", "--stdin", "--verbose", "--non-matching").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).
+		SetStdin(strings.Join(paths, "\x00")).
+		DontLog().
+		RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIgnoredPaths(output), nil
+}
+
+// output looks like "source\x00linenum\x00pattern\x00path\x00", where source,
+// linenum and pattern are empty for paths that no pattern matches
+func parseIgnoredPaths(output string) []string {
+	result := []string{}
+	fields := strings.Split(strings.TrimSuffix(output, "\x00"), "\x00")
+	for _, chunk := range lo.Chunk(fields, 4) {
+		if len(chunk) != 4 {
+			continue
+		}
+		// a negated pattern matching means the path is explicitly not ignored
+		if chunk[2] != "" && !strings.HasPrefix(chunk[2], "!") {
+			result = append(result, chunk[3])
+		}
+	}
+	return result
 }
 
 // Returns all tracked files in the repo (not in the working tree). The returned entries are
@@ -1,6 +1,7 @@
 package git_commands
 
 import (
+	"os"
 	"testing"
 
 	"github.com/go-errors/errors"
@@ -891,3 +892,141 @@ func TestWorkingTreeCommands_AllRepoFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkingTreeWriteAndReadIndexTree(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"write-tree"}, "4b825dc642cb6eb9a060e54bf8d69288fbee4904\n", nil).
+		ExpectGitArgs([]string{"read-tree", "4b825dc642cb6eb9a060e54bf8d69288fbee4904"}, "", nil)
+	instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+	tree, err := instance.WriteIndexTree()
+	assert.NoError(t, err)
+	assert.Equal(t, "4b825dc642cb6eb9a060e54bf8d69288fbee4904", tree)
+
+	assert.NoError(t, instance.ReadIndexTree(tree))
+	runner.CheckForMissingCalls()
+}
+
+func TestWorkingTreeMergeStages(t *testing.T) {
+	scenarios := []struct {
+		testName     string
+		gitVersion   *GitVersion
+		hasBase      bool
+		opts         MergeStagesOpts
+		expectedArgs []string
+		expectedBase string
+	}{
+		{
+			testName:     "zdiff3 when supported",
+			gitVersion:   &GitVersion{2, 35, 0, ""},
+			hasBase:      true,
+			opts:         MergeStagesOpts{WithBase: true},
+			expectedArgs: []string{"merge-file", "--zdiff3", "--stdout", "--quiet", "-L", "ours", "-L", "base", "-L", "theirs"},
+			expectedBase: "base\n",
+		},
+		{
+			testName:     "diff3 on older git versions",
+			gitVersion:   &GitVersion{2, 34, 0, ""},
+			hasBase:      true,
+			opts:         MergeStagesOpts{WithBase: true},
+			expectedArgs: []string{"merge-file", "--diff3", "--stdout", "--quiet", "-L", "ours", "-L", "base", "-L", "theirs"},
+			expectedBase: "base\n",
+		},
+		{
+			testName:     "custom conflict marker size",
+			gitVersion:   &GitVersion{2, 35, 0, ""},
+			hasBase:      true,
+			opts:         MergeStagesOpts{WithBase: true, ConflictMarkerSize: 10},
+			expectedArgs: []string{"merge-file", "--zdiff3", "--stdout", "--quiet", "--marker-size=10", "-L", "ours", "-L", "base", "-L", "theirs"},
+			expectedBase: "base\n",
+		},
+		{
+			testName:     "strategy",
+			gitVersion:   &GitVersion{2, 35, 0, ""},
+			hasBase:      true,
+			opts:         MergeStagesOpts{Strategy: "--ours"},
+			expectedArgs: []string{"merge-file", "--ours", "--stdout", "--quiet"},
+			expectedBase: "base\n",
+		},
+		{
+			testName:     "added on both sides",
+			gitVersion:   &GitVersion{2, 35, 0, ""},
+			hasBase:      false,
+			opts:         MergeStagesOpts{WithBase: true},
+			expectedArgs: []string{"merge-file", "--zdiff3", "--stdout", "--quiet", "-L", "ours", "-L", "base", "-L", "theirs"},
+			expectedBase: "",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			var hasBaseErr error
+			if !s.hasBase {
+				hasBaseErr = errors.New("no stage 1")
+			}
+			runner := oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"show", ":2:file"}, "ours\n", nil).
+				ExpectGitArgs([]string{"rev-parse", "--verify", "--quiet", ":1:file"}, "", hasBaseErr)
+			if s.hasBase {
+				runner.ExpectGitArgs([]string{"show", ":1:file"}, "base\n", nil)
+			}
+			runner.
+				ExpectGitArgs([]string{"show", ":3:file"}, "theirs\n", nil).
+				ExpectFunc("merge-file of the stages", func(cmdObj *oscommands.CmdObj) bool {
+					args := cmdObj.GetCmd().Args
+					if len(args) != len(s.expectedArgs)+4 || !assert.Equal(t, s.expectedArgs, args[1:len(args)-3]) {
+						return false
+					}
+					// the stages are written to files in the order ours, base, theirs
+					contents := lo.Map(args[len(args)-3:], func(path string, _ int) string {
+						content, err := os.ReadFile(path)
+						assert.NoError(t, err)
+						return string(content)
+					})
+					return assert.Equal(t, []string{"ours\n", s.expectedBase, "theirs\n"}, contents)
+				}, "merged\n", nil)
+
+			instance := buildWorkingTreeCommands(commonDeps{runner: runner, gitVersion: s.gitVersion})
+			output, err := instance.MergeStages("file", s.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, "merged\n", output)
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestWorkingTreeConflictMarkerSizes(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"check-attr", "-z", "--stdin", "conflict-marker-size"},
+			"big.txt\x00conflict-marker-size\x0032\x00README.md\x00conflict-marker-size\x00unspecified\x00odd.txt\x00conflict-marker-size\x00set\x00", nil)
+	instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+	sizes, err := instance.ConflictMarkerSizes([]string{"big.txt", "README.md", "odd.txt"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"big.txt": 32}, sizes)
+	runner.CheckForMissingCalls()
+}
+
+func TestWorkingTreeUnmergedStages(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"ls-files", "--unmerged", "-z", "--", "file"},
+			"100644 1f1c2a0b4e0f4a3f5d1c6b7a8e9f0a1b2c3d4e5f 2\tfile\x00100644 2a2c2a0b4e0f4a3f5d1c6b7a8e9f0a1b2c3d4e5f 3\tfile\x00", nil)
+	instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+	stages, err := instance.UnmergedStages("file")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, stages)
+	runner.CheckForMissingCalls()
+}
+
+func TestWorkingTreeIgnoredPaths(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"check-ignore", "-z", "--stdin", "--verbose", "--non-matching"},
+			".gitignore\x001\x00build/\x00build\x00\x00\x00\x00src\x00.gitignore\x003\x00!keep.log\x00keep.log\x00.git/info/exclude\x007\x00*.log\x00debug.log\x00", nil)
+	instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+	ignored, err := instance.IgnoredPaths([]string{"build", "src", "keep.log", "debug.log"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"build", "debug.log"}, ignored)
+	runner.CheckForMissingCalls()
+}
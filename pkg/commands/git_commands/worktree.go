@@ -54,6 +54,46 @@ func (self *WorktreeCommands) Detach(worktreePath string) error {
 	return self.cmd.New(cmdArgs).Run()
 }
 
+// Lock prevents the worktree's administrative files from being pruned, e.g.
+// when it lives on a removable drive that isn't always mounted
+func (self *WorktreeCommands) Lock(worktreePath string, reason string) error {
+	cmdArgs := NewGitCmd("worktree").Arg("lock").
+		ArgIf(reason != "", "--reason", reason).
+		Arg(worktreePath).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+func (self *WorktreeCommands) Unlock(worktreePath string) error {
+	cmdArgs := NewGitCmd("worktree").Arg("unlock", worktreePath).ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+func (self *WorktreeCommands) Move(worktreePath string, newPath string) error {
+	cmdArgs := NewGitCmd("worktree").Arg("move", worktreePath, newPath).ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+// Prune removes the administrative files of worktrees whose directories no
+// longer exist (unless they are locked)
+func (self *WorktreeCommands) Prune() error {
+	cmdArgs := NewGitCmd("worktree").Arg("prune").ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+// Repair fixes up the links between the repo and its worktrees, e.g. after a
+// worktree was moved without using `git worktree move`. Passing the new path
+// of a moved worktree lets git reconnect it.
+func (self *WorktreeCommands) Repair(worktreePaths ...string) error {
+	cmdArgs := NewGitCmd("worktree").Arg("repair").Arg(worktreePaths...).ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
 func WorktreeForBranch(branch *models.Branch, worktrees []*models.Worktree) (*models.Worktree, bool) {
 	for _, worktree := range worktrees {
 		if worktree.Branch == branch.Name {
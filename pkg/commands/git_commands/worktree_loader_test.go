@@ -234,6 +234,77 @@ detached
 			},
 			expectedErr: "",
 		},
+		{
+			testName: "Locked and prunable worktrees",
+			repoPaths: &RepoPaths{
+				repoPath:     "/path/to/repo",
+				worktreePath: "/path/to/repo",
+			},
+			before: func(runner *oscommands.FakeCmdObjRunner, fs afero.Fs, getRevParseArgs argFn) {
+				runner.ExpectGitArgs([]string{"worktree", "list", "--porcelain"},
+					`worktree /path/to/repo
+HEAD d85cc9d281fa6ae1665c68365fc70e75e82a042d
+branch refs/heads/mybranch
+
+worktree /media/usb/repo-locked
+HEAD 775955775e79b8f5b4c4b56f82fbf657e2d5e4de
+branch refs/heads/locked-with-reason
+locked on my usb drive
+
+worktree /media/usb/repo-locked-no-reason
+HEAD 775955775e79b8f5b4c4b56f82fbf657e2d5e4de
+branch refs/heads/locked-without-reason
+locked
+
+worktree /path/to/repo-gone
+HEAD 775955775e79b8f5b4c4b56f82fbf657e2d5e4de
+branch refs/heads/gone
+prunable gitdir file points to non-existent location
+`,
+					nil)
+				gitArgsMainWorktree := append(append([]string{"-C", "/path/to/repo"}, getRevParseArgs()...), "--absolute-git-dir")
+				runner.ExpectGitArgs(gitArgsMainWorktree, "/path/to/repo/.git", nil)
+
+				_ = fs.MkdirAll("/path/to/repo/.git", 0o755)
+			},
+			expectedWorktrees: []*models.Worktree{
+				{
+					IsMain:    true,
+					IsCurrent: true,
+					Path:      "/path/to/repo",
+					GitDir:    "/path/to/repo/.git",
+					Branch:    "mybranch",
+					Head:      "d85cc9d281fa6ae1665c68365fc70e75e82a042d",
+					Name:      "repo",
+				},
+				{
+					Path:          "/media/usb/repo-locked",
+					IsPathMissing: true,
+					IsLocked:      true,
+					LockReason:    "on my usb drive",
+					Branch:        "locked-with-reason",
+					Head:          "775955775e79b8f5b4c4b56f82fbf657e2d5e4de",
+					Name:          "repo-locked",
+				},
+				{
+					Path:          "/media/usb/repo-locked-no-reason",
+					IsPathMissing: true,
+					IsLocked:      true,
+					Branch:        "locked-without-reason",
+					Head:          "775955775e79b8f5b4c4b56f82fbf657e2d5e4de",
+					Name:          "repo-locked-no-reason",
+				},
+				{
+					Path:          "/path/to/repo-gone",
+					IsPathMissing: true,
+					IsPrunable:    true,
+					Branch:        "gone",
+					Head:          "775955775e79b8f5b4c4b56f82fbf657e2d5e4de",
+					Name:          "repo-gone",
+				},
+			},
+			expectedErr: "",
+		},
 	}
 
 	for _, s := range scenarios {
@@ -0,0 +1,77 @@
+package git_commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorktreeLock(t *testing.T) {
+	type scenario struct {
+		testName string
+		reason   string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "With reason",
+			reason:   "on usb drive",
+			expected: []string{"worktree", "lock", "--reason", "on usb drive", "/path/to/worktree"},
+		},
+		{
+			testName: "Without reason",
+			reason:   "",
+			expected: []string{"worktree", "lock", "/path/to/worktree"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).ExpectGitArgs(s.expected, "", nil)
+			instance := buildWorktreeCommands(commonDeps{runner: runner})
+
+			assert.NoError(t, instance.Lock("/path/to/worktree", s.reason))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestWorktreeUnlock(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"worktree", "unlock", "/path/to/worktree"}, "", nil)
+	instance := buildWorktreeCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Unlock("/path/to/worktree"))
+	runner.CheckForMissingCalls()
+}
+
+func TestWorktreeMove(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"worktree", "move", "/path/to/worktree", "/new/path"}, "", nil)
+	instance := buildWorktreeCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Move("/path/to/worktree", "/new/path"))
+	runner.CheckForMissingCalls()
+}
+
+func TestWorktreePrune(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"worktree", "prune"}, "", nil)
+	instance := buildWorktreeCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Prune())
+	runner.CheckForMissingCalls()
+}
+
+func TestWorktreeRepair(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"worktree", "repair"}, "", nil).
+		ExpectGitArgs([]string{"worktree", "repair", "/new/path"}, "", nil)
+	instance := buildWorktreeCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Repair())
+	assert.NoError(t, instance.Repair("/new/path"))
+	runner.CheckForMissingCalls()
+}
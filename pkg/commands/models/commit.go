@@ -41,6 +41,50 @@ const (
 	DivergenceRight
 )
 
+// The result of verifying a commit's signature, as reported by git's %G?
+// placeholder. Works the same for gpg, ssh and x509 signatures.
+type SignatureStatus uint8
+
+const (
+	// We didn't ask git to verify the signature
+	SignatureNotLoaded SignatureStatus = iota
+	SignatureNone
+	SignatureGood
+	// A good signature, but we can't tell whether the key belongs to who it
+	// claims to (e.g. an untrusted gpg key)
+	SignatureGoodUnknownValidity
+	// A good signature that has expired, or was made by a key that has since
+	// expired
+	SignatureExpired
+	// A good signature made by a key that has since been revoked
+	SignatureRevoked
+	SignatureBad
+	// The signature couldn't be checked, e.g. because the key is missing, or
+	// gpg.ssh.allowedSignersFile isn't set up
+	SignatureUnverifiable
+)
+
+// Parses the output of git's %G? placeholder
+func ParseSignatureStatus(code string) SignatureStatus {
+	switch code {
+	case "G":
+		return SignatureGood
+	case "U":
+		return SignatureGoodUnknownValidity
+	case "X", "Y":
+		return SignatureExpired
+	case "R":
+		return SignatureRevoked
+	case "B":
+		return SignatureBad
+	case "E":
+		return SignatureUnverifiable
+	case "N":
+		return SignatureNone
+	}
+	return SignatureNotLoaded
+}
+
 // Commit : A git commit
 type Commit struct {
 	hash          *string
@@ -62,6 +106,16 @@ type Commit struct {
 	Action     todo.TodoCommand
 	ActionFlag string     // e.g. "-C" for fixup -C
 	Divergence Divergence // set to DivergenceNone unless we are showing the divergence view
+
+	// Whether the commit has a note attached in the notes ref that we are
+	// currently showing
+	HasNotes bool
+
+	// Only loaded if git.log.showSignatureStatus is on
+	Signature SignatureStatus
+	// The signer's name (gpg) or principal (ssh) if known, otherwise the
+	// fingerprint of the signing key
+	Signer string
 }
 
 type NewCommitOpts struct {
@@ -77,6 +131,9 @@ type NewCommitOpts struct {
 	UnixTimestamp int64
 	Divergence    Divergence
 	Parents       []string
+	HasNotes      bool
+	Signature     SignatureStatus
+	Signer        string
 }
 
 func NewCommit(hashPool *utils.StringPool, opts NewCommitOpts) *Commit {
@@ -92,6 +149,9 @@ func NewCommit(hashPool *utils.StringPool, opts NewCommitOpts) *Commit {
 		AuthorEmail:   opts.AuthorEmail,
 		UnixTimestamp: opts.UnixTimestamp,
 		Divergence:    opts.Divergence,
+		HasNotes:      opts.HasNotes,
+		Signature:     opts.Signature,
+		Signer:        opts.Signer,
 		parents:       lo.Map(opts.Parents, func(s string, _ int) *string { return hashPool.Add(s) }),
 	}
 }
@@ -5,6 +5,9 @@ type CommitFile struct {
 	Path string
 
 	ChangeStatus string // e.g. 'A' for added or 'M' for modified. This is based on the result from git diff --name-status
+
+	// If true, the file is tracked by git-lfs
+	IsLfs bool
 }
 
 func (f *CommitFile) ID() string {
@@ -25,6 +25,30 @@ type File struct {
 
 	// If true, this must be a worktree folder
 	IsWorktree bool
+
+	// If true, this is an untracked directory whose files we haven't listed
+	// because we list untracked files lazily; Path has no trailing slash
+	IsCollapsedUntrackedDir bool
+
+	// If true, the repo uses a sparse checkout and this file lies outside of
+	// the sparse set
+	OutsideSparseCheckout bool
+
+	// If true, the file is still unmerged but git's rerere already resolved
+	// its conflicts using a previously recorded resolution
+	ResolvedByRerere bool
+
+	// The id under which rerere recorded the resolution it replayed, if
+	// ResolvedByRerere is true and we could work it out
+	RerereID string
+
+	// The size of the file's conflict markers as set by the
+	// conflict-marker-size attribute, or 0 if the file uses the default size
+	ConflictMarkerSize int
+
+	// If true, the file is tracked by git-lfs, so its diff is a diff of
+	// pointer files rather than of the actual content
+	IsLfs bool
 }
 
 // sometimes we need to deal with either a node (which contains a file) or an actual file
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// LfsLock : A lock on an LFS-tracked file, held by Owner
+type LfsLock struct {
+	ID       string
+	Path     string
+	Owner    string
+	LockedAt time.Time
+}
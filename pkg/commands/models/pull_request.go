@@ -0,0 +1,44 @@
+package models
+
+import "strconv"
+
+// A pull request (or merge request, as GitLab calls it) on any of the hosting
+// services we know how to load them from. The loaders map their provider's
+// states onto the GitHub names below.
+type PullRequest struct {
+	HeadRefName         string          `json:"headRefName"`
+	Number              int             `json:"number"`
+	Title               string          `json:"title"`
+	State               string          `json:"state"` // "MERGED", "OPEN", "CLOSED", "DRAFT"
+	Url                 string          `json:"url"`
+	HeadRepositoryOwner RepositoryOwner `json:"headRepositoryOwner"`
+
+	// These are only filled in for the open pull requests shown in the pull
+	// requests panel, not for the ones we fetch for decorating branches
+	BaseRefName       string `json:"baseRefName"`
+	Body              string `json:"body"`
+	Author            string `json:"author"`
+	IsCrossRepository bool   `json:"isCrossRepository"` // true if the PR comes from a fork
+	ReviewDecision    string `json:"reviewDecision"`    // "APPROVED", "CHANGES_REQUESTED", "REVIEW_REQUIRED", or ""
+	ChecksStatus      string `json:"checksStatus"`      // "SUCCESS", "FAILURE", "ERROR", "PENDING", "EXPECTED", or ""
+}
+
+func (pr *PullRequest) ID() string {
+	return strconv.Itoa(pr.Number)
+}
+
+func (pr *PullRequest) UserName() string {
+	// e.g. 'jesseduffield'
+	return pr.HeadRepositoryOwner.Login
+}
+
+func (pr *PullRequest) BranchName() string {
+	// e.g. 'feature/my-feature'
+	return pr.HeadRefName
+}
+
+// The owner of the repo a pull request's head branch lives in. For GitLab this
+// is the namespace of the source project, e.g. 'group/subgroup'.
+type RepositoryOwner struct {
+	Login string `json:"login"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "fmt"
+
+// The pairing status of a commit in the output of `git range-diff`
+type RangeDiffStatus int
+
+const (
+	// the commit is identical in both ranges (shown as '=' by git)
+	RangeDiffStatusEqual RangeDiffStatus = iota
+	// the commit exists in both ranges but its patch changed ('!')
+	RangeDiffStatusChanged
+	// the commit only exists in the old range ('<')
+	RangeDiffStatusRemoved
+	// the commit only exists in the new range ('>')
+	RangeDiffStatusAdded
+)
+
+// A single line of `git range-diff` output, pairing a commit of the old range
+// with a commit of the new range
+type RangeDiffEntry struct {
+	Status RangeDiffStatus
+	// 1-based position in the old range; 0 if the commit only exists in the new range
+	OldIndex int
+	OldHash  string
+	// 1-based position in the new range; 0 if the commit only exists in the old range
+	NewIndex int
+	NewHash  string
+	Subject  string
+}
+
+func (e *RangeDiffEntry) HasOld() bool {
+	return e.OldIndex != 0
+}
+
+func (e *RangeDiffEntry) HasNew() bool {
+	return e.NewIndex != 0
+}
+
+func (e *RangeDiffEntry) ID() string {
+	return fmt.Sprintf("%d:%s-%d:%s", e.OldIndex, e.OldHash, e.NewIndex, e.NewHash)
+}
+
+func (e *RangeDiffEntry) Description() string {
+	return e.Subject
+}
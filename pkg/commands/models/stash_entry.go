@@ -8,6 +8,15 @@ type StashEntry struct {
 	Recency string
 	Name    string
 	Hash    string
+	// the commit the stash was created on top of, i.e. its first parent
+	BaseHash string
+	// the branch that was checked out when the stash was created, taken from
+	// the default stash message; empty if the message was customised away
+	BaseBranch string
+	// number of commits reachable from HEAD but not from BaseHash; only
+	// counted once the entry gets selected
+	CommitsBehindHead       int
+	CommitsBehindHeadLoaded bool
 }
 
 func (s *StashEntry) FullRefName() string {
@@ -10,6 +10,13 @@ type Worktree struct {
 	Path string
 	// if true, the path is not found
 	IsPathMissing bool
+	// if true, the worktree is locked and won't be pruned or moved
+	IsLocked bool
+	// the reason given when locking the worktree, if any
+	LockReason string
+	// if true, git considers the worktree's administrative files stale, so
+	// `git worktree prune` would remove them
+	IsPrunable bool
 	// path of the git directory for this worktree. The equivalent of the .git directory
 	// in the main worktree. For linked worktrees this would be <repo_path>/.git/worktrees/<name>
 	GitDir string
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/mgutz/str"
 )
@@ -23,16 +25,54 @@ type ICmdObjBuilder interface {
 type CmdObjBuilder struct {
 	runner   ICmdObjRunner
 	platform *Platform
+	// added to the environment of every command; shared with the builders
+	// cloned from this one
+	envVars *envVars
+}
+
+type envVars struct {
+	mutex sync.Mutex
+	vars  []string
 }
 
 // poor man's version of explicitly saying that struct X implements interface Y
 var _ ICmdObjBuilder = &CmdObjBuilder{}
 
 func (self *CmdObjBuilder) New(args []string) *CmdObj {
-	cmdObj := self.NewWithEnviron(args, os.Environ())
+	cmdObj := self.NewWithEnviron(args, append(os.Environ(), self.envVars.get()...))
 	return cmdObj
 }
 
+// SetEnvVar sets an env var for all commands created from now on, e.g. to tell
+// the programs we start how to talk back to lazygit.
+func (self *CmdObjBuilder) SetEnvVar(key string, value string) {
+	self.envVars.set(key, key+"="+value)
+}
+
+// UnsetEnvVar undoes SetEnvVar; commands get the var from lazygit's own
+// environment again, if it's set there.
+func (self *CmdObjBuilder) UnsetEnvVar(key string) {
+	self.envVars.set(key, "")
+}
+
+func (self *envVars) get() []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return slices.Clone(self.vars)
+}
+
+// an empty envVar removes the var
+func (self *envVars) set(key string, envVar string) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.vars = slices.DeleteFunc(self.vars, func(v string) bool { return strings.HasPrefix(v, key+"=") })
+	if envVar != "" {
+		self.vars = append(self.vars, envVar)
+	}
+}
+
 // A command with explicit environment from env
 func (self *CmdObjBuilder) NewWithEnviron(args []string, env []string) *CmdObj {
 	cmd := exec.Command(args[0], args[1:]...)
@@ -76,6 +116,7 @@ func (self *CmdObjBuilder) CloneWithNewRunner(decorate func(ICmdObjRunner) ICmdO
 	return &CmdObjBuilder{
 		runner:   decoratedRunner,
 		platform: self.platform,
+		envVars:  self.envVars,
 	}
 }
 
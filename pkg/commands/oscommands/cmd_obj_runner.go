@@ -111,6 +111,7 @@ func (self *cmdObjRunner) RunWithOutputAux(cmdObj *CmdObj) (string, error) {
 	}
 
 	self.log.Infof("%s (%s)", cmdObj.ToString(), time.Since(t))
+	self.recordCmdObj(cmdObj, t, func() string { return output })
 
 	return output, err
 }
@@ -130,6 +131,7 @@ func (self *cmdObjRunner) RunWithOutputsAux(cmdObj *CmdObj) (string, string, err
 	err := cmd.Run()
 
 	self.log.Infof("%s (%s)", cmdObj.ToString(), time.Since(t))
+	self.recordCmdObj(cmdObj, t, func() string { return outBuffer.String() + errBuffer.String() })
 
 	stdout := outBuffer.String()
 	stderr, err := sanitisedCommandOutput(errBuffer.Bytes(), err)
@@ -154,6 +156,8 @@ func (self *cmdObjRunner) RunAndProcessLines(cmdObj *CmdObj, onLine func(line st
 		self.logCmdObj(cmdObj)
 	}
 	t := time.Now()
+	// we don't record the output because we don't keep the lines around
+	defer func() { self.recordCmdObj(cmdObj, t, func() string { return "" }) }()
 
 	cmd := cmdObj.GetCmd()
 	stdoutPipe, err := cmd.StdoutPipe()
@@ -196,6 +200,21 @@ func (self *cmdObjRunner) logCmdObj(cmdObj *CmdObj) {
 	self.guiIO.logCommandFn(cmdObj.ToString(), true)
 }
 
+// getOutput is only called if the output is going to be recorded, because
+// copying the output of e.g. `git status` in a big repo isn't free
+func (self *cmdObjRunner) recordCmdObj(cmdObj *CmdObj, startTime time.Time, getOutput func() string) {
+	recording := self.guiIO.cmdRecordingFn()
+	if recording == CMD_RECORDING_OFF {
+		return
+	}
+
+	output := ""
+	if recording == CMD_RECORDING_WITH_OUTPUT {
+		output = getOutput()
+	}
+	self.guiIO.recordCmdFn(NewCmdRecord(cmdObj, startTime, output))
+}
+
 func sanitisedCommandOutput(output []byte, err error) (string, error) {
 	outputString := string(output)
 	if err != nil {
@@ -271,6 +290,9 @@ func (self *cmdObjRunner) runAndStreamAux(
 	err = cmd.Wait()
 
 	self.log.Infof("%s (%s)", cmdObj.ToString(), time.Since(t))
+	// stdout may still be being copied to the cmdWriter on another goroutine,
+	// so we can only safely record stderr
+	self.recordCmdObj(cmdObj, t, stderr.String)
 
 	if err != nil {
 		if cmdObj.suppressOutputUnlessError {
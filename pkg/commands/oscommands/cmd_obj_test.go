@@ -2,6 +2,7 @@ package oscommands
 
 import (
 	"os/exec"
+	"slices"
 	"testing"
 
 	"github.com/jesseduffield/lazygit/pkg/gocui"
@@ -52,3 +53,21 @@ func TestClone(t *testing.T) {
 		t.Errorf("Clone should have the same task")
 	}
 }
+
+func TestEnvVarsAreSharedWithClones(t *testing.T) {
+	builder := NewDummyCmdObjBuilder(NewFakeRunner(t))
+	clone := builder.CloneWithNewRunner(func(runner ICmdObjRunner) ICmdObjRunner { return runner })
+
+	builder.SetEnvVar("LAZYGIT_TEST_VAR", "one")
+	builder.SetEnvVar("LAZYGIT_TEST_VAR", "two")
+	env := clone.New([]string{"true"}).GetEnvVars()
+	if !slices.Contains(env, "LAZYGIT_TEST_VAR=two") || slices.Contains(env, "LAZYGIT_TEST_VAR=one") {
+		t.Errorf("Expected the clone's commands to get the latest value of the env var, got %v", env)
+	}
+
+	builder.UnsetEnvVar("LAZYGIT_TEST_VAR")
+	env = clone.New([]string{"true"}).GetEnvVars()
+	if slices.Contains(env, "LAZYGIT_TEST_VAR=two") {
+		t.Errorf("Expected the env var to be unset, got %v", env)
+	}
+}
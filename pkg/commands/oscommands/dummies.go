@@ -51,6 +51,7 @@ func NewDummyCmdObjBuilder(runner ICmdObjRunner) *CmdObjBuilder {
 	return &CmdObjBuilder{
 		runner:   runner,
 		platform: dummyPlatform,
+		envVars:  &envVars{},
 	}
 }
 
@@ -27,6 +27,13 @@ type guiIO struct {
 	// that a command requests it.
 	// the 'credential' arg is something like 'username' or 'password'
 	promptForCredentialFn func(credential CredentialType) <-chan string
+	// this is called once a command has finished running, with the details
+	// that we write to the session log.
+	recordCmdFn func(record CmdRecord)
+	// tells whether recordCmdFn needs to be called at all, and whether the
+	// record needs the command's output; most users don't have the session
+	// log enabled, so we don't want to build records for nothing
+	cmdRecordingFn func() CmdRecording
 }
 
 func NewGuiIO(
@@ -34,12 +41,16 @@ func NewGuiIO(
 	logCommandFn func(string, bool),
 	newCmdWriterFn func() io.Writer,
 	promptForCredentialFn func(CredentialType) <-chan string,
+	recordCmdFn func(CmdRecord),
+	cmdRecordingFn func() CmdRecording,
 ) *guiIO {
 	return &guiIO{
 		log:                   log,
 		logCommandFn:          logCommandFn,
 		newCmdWriterFn:        newCmdWriterFn,
 		promptForCredentialFn: promptForCredentialFn,
+		recordCmdFn:           recordCmdFn,
+		cmdRecordingFn:        cmdRecordingFn,
 	}
 }
 
@@ -51,5 +62,7 @@ func NewNullGuiIO(log *logrus.Entry) *guiIO {
 		logCommandFn:          func(string, bool) {},
 		newCmdWriterFn:        func() io.Writer { return io.Discard },
 		promptForCredentialFn: failPromptFn,
+		recordCmdFn:           func(CmdRecord) {},
+		cmdRecordingFn:        func() CmdRecording { return CMD_RECORDING_OFF },
 	}
 }
@@ -57,7 +57,7 @@ func NewOSCommand(common *common.Common, config config.AppConfigurer, platform *
 	}
 
 	runner := &cmdObjRunner{log: common.Log, guiIO: guiIO}
-	c.Cmd = &CmdObjBuilder{runner: runner, platform: platform}
+	c.Cmd = &CmdObjBuilder{runner: runner, platform: platform, envVars: &envVars{}}
 
 	return c
 }
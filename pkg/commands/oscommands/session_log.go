@@ -0,0 +1,264 @@
+package oscommands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// CmdRecord describes a command that has finished running.
+type CmdRecord struct {
+	Command   string
+	Dir       string
+	StartTime time.Time
+	Duration  time.Duration
+	// -1 if the command couldn't be started or was killed by a signal
+	ExitCode int
+	// The command's output, if we captured it
+	Output string
+	// false if the command was run with DontLog(), i.e. it's not shown in the
+	// command log panel
+	Logged bool
+}
+
+// Whether and how finished commands are recorded
+type CmdRecording int
+
+const (
+	CMD_RECORDING_OFF CmdRecording = iota
+	CMD_RECORDING_WITHOUT_OUTPUT
+	CMD_RECORDING_WITH_OUTPUT
+)
+
+func NewCmdRecord(cmdObj *CmdObj, startTime time.Time, output string) CmdRecord {
+	cmd := cmdObj.GetCmd()
+
+	dir := cmd.Dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+
+	exitCode := -1
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+
+	return CmdRecord{
+		Command:   cmdObj.ToString(),
+		Dir:       dir,
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+		ExitCode:  exitCode,
+		Output:    output,
+		Logged:    cmdObj.ShouldLog(),
+	}
+}
+
+// SessionLog writes every command that lazygit runs to a JSON Lines file, one
+// per session, so that there's a complete record of what happened even after
+// the commands have scrolled out of the command log panel. See
+// docs/Session_Log.md for the format.
+type SessionLog struct {
+	*common.Common
+
+	dir         string
+	maxSessions int
+	// the number of files we've opened so far
+	sessions int
+	path     string
+	// nil once the log has been closed
+	file   *os.File
+	mutex  deadlock.Mutex
+	action string
+}
+
+type sessionLogEntry struct {
+	Time            time.Time `json:"time"`
+	DurationMs      float64   `json:"durationMs"`
+	Command         string    `json:"command"`
+	Dir             string    `json:"dir"`
+	ExitCode        int       `json:"exitCode"`
+	Action          string    `json:"action,omitempty"`
+	Output          string    `json:"output,omitempty"`
+	OutputTruncated bool      `json:"outputTruncated,omitempty"`
+}
+
+const sessionLogExtension = ".jsonl"
+
+// NewSessionLog creates a new session log file in the given directory, and
+// deletes the oldest ones so that at most maxSessions are left.
+func NewSessionLog(cmn *common.Common, dir string, maxSessions int) (*SessionLog, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	self := &SessionLog{Common: cmn, dir: dir, maxSessions: maxSessions}
+	if err := self.openNewFile(); err != nil {
+		return nil, err
+	}
+	return self, nil
+}
+
+func (self *SessionLog) openNewFile() error {
+	deleteOldSessionLogs(self.dir, self.maxSessions-1)
+
+	// the names sort chronologically, which deleteOldSessionLogs relies on
+	self.sessions++
+	name := fmt.Sprintf("%s-%d-%d%s", time.Now().Format("20060102-150405"), os.Getpid(), self.sessions, sessionLogExtension)
+	path := filepath.Join(self.dir, name)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	self.path = path
+	self.file = file
+	return nil
+}
+
+// StartNewSession closes the current file and continues in a new one, e.g.
+// when switching to a different repo.
+func (self *SessionLog) StartNewSession() error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.closeFile()
+	self.action = ""
+	return self.openNewFile()
+}
+
+// Close closes the file; commands that run afterwards aren't recorded.
+func (self *SessionLog) Close() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.closeFile()
+}
+
+func (self *SessionLog) closeFile() {
+	if self.file == nil {
+		return
+	}
+	if err := self.file.Close(); err != nil {
+		self.Log.Error(err)
+	}
+	self.file = nil
+}
+
+func deleteOldSessionLogs(dir string, keep int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), sessionLogExtension) {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+
+	for len(names) > max(keep, 0) {
+		_ = os.Remove(filepath.Join(dir, names[0]))
+		names = names[1:]
+	}
+}
+
+func (self *SessionLog) Path() string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.path
+}
+
+// SetAction sets the action (e.g. "Stage file") that subsequent commands are
+// recorded as part of, like the headings in the command log panel. Pass an
+// empty string once the action is finished.
+func (self *SessionLog) SetAction(action string) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.action = action
+}
+
+// Recording tells the command runner what Record needs
+func (self *SessionLog) Recording() CmdRecording {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.file == nil {
+		return CMD_RECORDING_OFF
+	}
+	if self.UserConfig().SessionLog.IncludeOutput {
+		return CMD_RECORDING_WITH_OUTPUT
+	}
+	return CMD_RECORDING_WITHOUT_OUTPUT
+}
+
+func (self *SessionLog) Record(record CmdRecord) {
+	entry := sessionLogEntry{
+		Time:       record.StartTime,
+		DurationMs: float64(record.Duration.Microseconds()) / 1000,
+		Command:    record.Command,
+		Dir:        record.Dir,
+		ExitCode:   record.ExitCode,
+	}
+
+	if config := self.UserConfig().SessionLog; config.IncludeOutput {
+		entry.Output = record.Output
+		if len(entry.Output) > config.MaxOutputBytes {
+			entry.Output = truncateOutput(entry.Output, config.MaxOutputBytes)
+			entry.OutputTruncated = true
+		}
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.file == nil {
+		return
+	}
+
+	// Commands that aren't shown in the command log (e.g. the ones we run to
+	// refresh the views) aren't part of the action either
+	if record.Logged {
+		entry.Action = self.action
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		self.Log.Error(err)
+		return
+	}
+	if _, err := self.file.Write(append(line, '\n')); err != nil {
+		self.Log.Error(err)
+	}
+}
+
+// truncateOutput cuts the output down to at most maxBytes bytes without
+// splitting a multi-byte character, which json.Marshal would otherwise
+// replace with U+FFFD
+func truncateOutput(output string, maxBytes int) string {
+	end := max(maxBytes, 0)
+	for end > 0 && !utf8.RuneStart(output[end]) {
+		end--
+	}
+	return output[:end]
+}
+
+// Contents returns everything that's been recorded so far.
+func (self *SessionLog) Contents() ([]byte, error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return os.ReadFile(self.path)
+}
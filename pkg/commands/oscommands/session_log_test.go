@@ -0,0 +1,167 @@
+//go:build !windows
+
+package oscommands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionLog(t *testing.T) {
+	userConfig := config.GetDefaultConfig()
+	userConfig.SessionLog.IncludeOutput = true
+	userConfig.SessionLog.MaxOutputBytes = 5
+	cmn := common.NewDummyCommonWithUserConfigAndAppState(userConfig, &config.AppState{})
+
+	sessionLog, err := NewSessionLog(cmn, t.TempDir(), 10)
+	require.NoError(t, err)
+
+	log := utils.NewDummyLog()
+	guiIO := NewGuiIO(log, func(string, bool) {}, nil, nil, sessionLog.Record, sessionLog.Recording)
+	osCommand := NewOSCommand(cmn, config.NewDummyAppConfig(), dummyPlatform, guiIO)
+
+	sessionLog.SetAction("Test")
+	_, _ = osCommand.Cmd.New([]string{"echo", "hello world"}).RunWithOutput()
+	_, _ = osCommand.Cmd.New([]string{"sh", "-c", "echo oops; exit 3"}).DontLog().RunWithOutput()
+
+	contents, err := sessionLog.Contents()
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var entries []sessionLogEntry
+	for _, line := range lines {
+		var entry sessionLogEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	assert.Equal(t, `echo "hello world"`, entries[0].Command)
+	assert.Equal(t, wd, entries[0].Dir)
+	assert.Equal(t, 0, entries[0].ExitCode)
+	assert.Equal(t, "Test", entries[0].Action)
+	assert.Equal(t, "hello", entries[0].Output)
+	assert.True(t, entries[0].OutputTruncated)
+	assert.False(t, entries[0].Time.IsZero())
+
+	// commands that aren't shown in the command log aren't part of the action
+	assert.Equal(t, "", entries[1].Action)
+	assert.Equal(t, 3, entries[1].ExitCode)
+	assert.Equal(t, "oops\n", entries[1].Output)
+	assert.False(t, entries[1].OutputTruncated)
+}
+
+func TestNewSessionLogDeletesOldLogs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"20240101-100000-1.jsonl", "20240102-100000-2.jsonl", "20240103-100000-3.jsonl", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	sessionLog, err := NewSessionLog(common.NewDummyCommon(), dir, 3)
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	assert.ElementsMatch(t, []string{
+		"20240102-100000-2.jsonl",
+		"20240103-100000-3.jsonl",
+		filepath.Base(sessionLog.Path()),
+		"notes.txt",
+	}, names)
+}
+
+func TestTruncateOutput(t *testing.T) {
+	scenarios := []struct {
+		output   string
+		maxBytes int
+		expected string
+	}{
+		{"hello", 3, "hel"},
+		{"héllo", 2, "h"},
+		{"héllo", 3, "hé"},
+		{"日本語", 5, "日"},
+		{"日本語", 0, ""},
+	}
+
+	for _, s := range scenarios {
+		assert.Equal(t, s.expected, truncateOutput(s.output, s.maxBytes))
+	}
+}
+
+func TestSessionLogStartNewSessionAndClose(t *testing.T) {
+	sessionLog, err := NewSessionLog(common.NewDummyCommon(), t.TempDir(), 10)
+	require.NoError(t, err)
+
+	sessionLog.SetAction("Test")
+	sessionLog.Record(CmdRecord{Command: "one", Logged: true})
+	firstPath := sessionLog.Path()
+
+	require.NoError(t, sessionLog.StartNewSession())
+	assert.NotEqual(t, firstPath, sessionLog.Path())
+	sessionLog.Record(CmdRecord{Command: "two", Logged: true})
+
+	sessionLog.Close()
+	// commands that run after closing are dropped
+	sessionLog.Record(CmdRecord{Command: "three", Logged: true})
+
+	first, err := os.ReadFile(firstPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(first), `"command":"one"`)
+	assert.Contains(t, string(first), `"action":"Test"`)
+
+	second, err := sessionLog.Contents()
+	require.NoError(t, err)
+	assert.Contains(t, string(second), `"command":"two"`)
+	// the action doesn't carry over into the new session
+	assert.NotContains(t, string(second), `"action"`)
+	assert.NotContains(t, string(second), `"command":"three"`)
+}
+
+func TestRecordingCommands(t *testing.T) {
+	scenarios := []struct {
+		testName       string
+		recording      CmdRecording
+		expectedOutput []string
+	}{
+		{"off", CMD_RECORDING_OFF, nil},
+		{"without output", CMD_RECORDING_WITHOUT_OUTPUT, []string{""}},
+		{"with output", CMD_RECORDING_WITH_OUTPUT, []string{"out\nerr\n"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			var outputs []string
+			guiIO := NewGuiIO(utils.NewDummyLog(), func(string, bool) {}, nil, nil,
+				func(record CmdRecord) { outputs = append(outputs, record.Output) },
+				func() CmdRecording { return s.recording })
+			osCommand := NewOSCommand(common.NewDummyCommon(), config.NewDummyAppConfig(), dummyPlatform, guiIO)
+
+			_, _, err := osCommand.Cmd.New([]string{"sh", "-c", "echo out; echo err >&2"}).RunWithOutputs()
+			require.NoError(t, err)
+			assert.Equal(t, s.expectedOutput, outputs)
+		})
+	}
+
+	sessionLog, err := NewSessionLog(common.NewDummyCommon(), t.TempDir(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, CMD_RECORDING_WITHOUT_OUTPUT, sessionLog.Recording())
+	sessionLog.Close()
+	assert.Equal(t, CMD_RECORDING_OFF, sessionLog.Recording())
+}
@@ -114,6 +114,40 @@ func (self *Patch) LineNumberOfLine(idx int) int {
 	return hunk.newStart + offset
 }
 
+// Returns the range of one-based line numbers in the old file (or in the new
+// file, if newFile is true) that the patch lines from startIdx to endIdx
+// (inclusive) correspond to. ok is false if none of those lines exist on that
+// side, e.g. when asking for the old side of a selection of pure additions.
+func (self *Patch) FileLineRange(startIdx int, endIdx int, newFile bool) (int, int, bool) {
+	first, last := -1, -1
+	idx := len(self.header)
+	for _, hunk := range self.hunks {
+		lineNumber := hunk.oldStart
+		if newFile {
+			lineNumber = hunk.newStart
+		}
+		// skip the hunk header
+		idx++
+		for _, line := range hunk.bodyLines {
+			onSide := line.Kind == CONTEXT ||
+				(newFile && line.Kind == ADDITION) ||
+				(!newFile && line.Kind == DELETION)
+			if onSide {
+				if idx >= startIdx && idx <= endIdx {
+					if first == -1 {
+						first = lineNumber
+					}
+					last = lineNumber
+				}
+				lineNumber++
+			}
+			idx++
+		}
+	}
+
+	return first, last, first != -1
+}
+
 // Returns hunk index containing the line at the given patch line index
 func (self *Patch) HunkContainingLine(idx int) int {
 	for hunkIdx, hunk := range self.hunks {
@@ -627,6 +627,98 @@ func TestLineNumberOfLine(t *testing.T) {
 	}
 }
 
+func TestFileLineRange(t *testing.T) {
+	type scenario struct {
+		testName      string
+		patchStr      string
+		startIdx      int
+		endIdx        int
+		newFile       bool
+		expectedStart int
+		expectedEnd   int
+		expectedOk    bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:      "single deletion on the old side",
+			patchStr:      twoHunks,
+			startIdx:      6,
+			endIdx:        6,
+			newFile:       false,
+			expectedStart: 2,
+			expectedEnd:   2,
+			expectedOk:    true,
+		},
+		{
+			testName:   "single deletion on the new side",
+			patchStr:   twoHunks,
+			startIdx:   6,
+			endIdx:     6,
+			newFile:    true,
+			expectedOk: false,
+		},
+		{
+			testName:      "additions on the new side",
+			patchStr:      twoHunks,
+			startIdx:      15,
+			endIdx:        16,
+			newFile:       true,
+			expectedStart: 11,
+			expectedEnd:   12,
+			expectedOk:    true,
+		},
+		{
+			testName:   "additions on the old side",
+			patchStr:   twoHunks,
+			startIdx:   15,
+			endIdx:     16,
+			newFile:    false,
+			expectedOk: false,
+		},
+		{
+			testName:      "range spanning both hunks on the new side",
+			patchStr:      twoHunks,
+			startIdx:      7,
+			endIdx:        13,
+			newFile:       true,
+			expectedStart: 2,
+			expectedEnd:   9,
+			expectedOk:    true,
+		},
+		{
+			testName:      "range spanning both hunks on the old side",
+			patchStr:      twoHunks,
+			startIdx:      5,
+			endIdx:        19,
+			newFile:       false,
+			expectedStart: 1,
+			expectedEnd:   13,
+			expectedOk:    true,
+		},
+		{
+			testName:   "hunk header only",
+			patchStr:   twoHunks,
+			startIdx:   4,
+			endIdx:     4,
+			newFile:    false,
+			expectedOk: false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			patch := Parse(s.patchStr)
+			start, end, ok := patch.FileLineRange(s.startIdx, s.endIdx, s.newFile)
+			assert.Equal(t, s.expectedOk, ok)
+			if s.expectedOk {
+				assert.Equal(t, s.expectedStart, start)
+				assert.Equal(t, s.expectedEnd, end)
+			}
+		})
+	}
+}
+
 func TestGetNextStageableLineIndex(t *testing.T) {
 	type scenario struct {
 		testName  string
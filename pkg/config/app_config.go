@@ -707,13 +707,15 @@ type AppState struct {
 
 	HideCommandLog bool
 
-	// Cache of GitHub pull requests per repo path, so that PR info can be
-	// shown instantly on startup before the async refresh completes.
-	GithubPullRequests map[string][]CachedPullRequest `yaml:"githubPullRequests"`
+	// Cache of pull requests per repo path, so that PR info can be shown
+	// instantly on startup before the async refresh completes. This used to
+	// be GitHub-only, so for backwards compatibility we keep the old name in
+	// yaml files.
+	PullRequests map[string][]CachedPullRequest `yaml:"githubPullRequests"`
 }
 
-// CachedPullRequest stores the essential fields of a GitHub pull request
-// for persisting in the app state cache.
+// CachedPullRequest stores the essential fields of a pull request for
+// persisting in the app state cache.
 type CachedPullRequest struct {
 	HeadRefName         string `yaml:"headRefName"`
 	Number              int    `yaml:"number"`
@@ -725,7 +727,7 @@ type CachedPullRequest struct {
 
 func getDefaultAppState() *AppState {
 	return &AppState{
-		GithubPullRequests: make(map[string][]CachedPullRequest),
+		PullRequests: make(map[string][]CachedPullRequest),
 	}
 }
 
@@ -736,3 +738,9 @@ func LogPath() (string, error) {
 
 	return stateFilePath("development.log")
 }
+
+// SessionLogDir returns the directory that session logs are written to (see
+// SessionLogConfig).
+func SessionLogDir() (string, error) {
+	return stateFilePath("sessions")
+}
@@ -36,6 +36,13 @@ type UserConfig struct {
 	NotARepository string `yaml:"notARepository" jsonschema:"enum=prompt,enum=create,enum=skip,enum=quit"`
 	// If true, display a confirmation when subprocess terminates. This allows you to view the output of the subprocess before returning to Lazygit.
 	PromptToReturnFromSubprocess bool `yaml:"promptToReturnFromSubprocess"`
+	// Controlling a running Lazygit from other programs, e.g. editor plugins.
+	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Remote_Control.md
+	RemoteControl RemoteControlConfig `yaml:"remoteControl"`
+	// Recording the commands that Lazygit runs in a file per session, for
+	// audits and bug reports.
+	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Session_Log.md
+	SessionLog SessionLogConfig `yaml:"sessionLog"`
 	// Keybindings.
 	// Each binding can be a single key or a list of keys; see https://github.com/jesseduffield/lazygit/blob/master/docs/keybindings/Custom_Keybindings.md for the syntax.
 	Keybinding KeybindingConfig `yaml:"keybinding"`
@@ -48,6 +55,13 @@ type RefresherConfig struct {
 	// Re-fetch interval in seconds.
 	// Auto-fetch can be disabled via option 'git.autoFetch'.
 	FetchInterval int `yaml:"fetchInterval" jsonschema:"minimum=0"`
+	// If true, refresh in response to changes in the working tree and the
+	// .git dir rather than every refreshInterval seconds, and only refresh
+	// the views affected by a change. Files ignored by git are not watched.
+	// Only supported on Linux; elsewhere, or if the watches can't be set up
+	// (e.g. because fs.inotify.max_user_watches is too low), we fall back to
+	// refreshing every refreshInterval seconds.
+	WatchFileSystem bool `yaml:"watchFileSystem"`
 }
 
 func (c *RefresherConfig) RefreshIntervalDuration() time.Duration {
@@ -296,6 +310,14 @@ type GitConfig struct {
 	AutoFetch bool `yaml:"autoFetch"`
 	// If true, periodically refresh files and submodules
 	AutoRefresh bool `yaml:"autoRefresh"`
+	// Whether to list the files in untracked directories only once you expand
+	// the directory in the files panel. This makes loading the files much
+	// faster in repos with big untracked directories, and lets git use its
+	// untracked cache.
+	// One of 'auto' | 'always' | 'never'
+	// 'auto' does this in repos that enable core.untrackedCache or core.fsmonitor.
+	// 'never' lists all untracked files straight away.
+	LazyUntrackedFiles string `yaml:"lazyUntrackedFiles" jsonschema:"enum=auto,enum=always,enum=never"`
 	// If not "none", lazygit will automatically fast-forward local branches to match their upstream after fetching. Applies to branches that are not the currently checked out branch, and only to those that are strictly behind their upstream (as opposed to diverged).
 	// Possible values: 'none' | 'onlyMainBranches' | 'allBranches'
 	AutoForwardBranches string `yaml:"autoForwardBranches" jsonschema:"enum=none,enum=onlyMainBranches,enum=allBranches"`
@@ -303,6 +325,8 @@ type GitConfig struct {
 	FetchAll bool `yaml:"fetchAll"`
 	// If true, lazygit will automatically stage files that used to have merge conflicts but no longer do; and it will also ask you if you want to continue a merge or rebase if you've resolved all conflicts. If false, it won't do either of these things.
 	AutoStageResolvedConflicts bool `yaml:"autoStageResolvedConflicts"`
+	// If true, lazygit will automatically stage files whose conflicts were resolved by git's rerere (reuse recorded resolution) feature. If false, they stay unmerged and marked as resolved by rerere so that you can review the resolution before staging them.
+	AutoStageRerereResolvedConflicts bool `yaml:"autoStageRerereResolvedConflicts"`
 	// Command used when displaying the current branch git log in the main window
 	BranchLogCmd string `yaml:"branchLogCmd"`
 	// Commands used to display git log of all branches in the main window, they will be cycled in order of appearance (array of strings)
@@ -399,6 +423,11 @@ type LogConfig struct {
 	ShowGraph string `yaml:"showGraph" jsonschema:"enum=always,enum=never,enum=when-maximised"`
 	// displays the whole git graph by default in the commits view (equivalent to passing the `--all` argument to `git log`)
 	ShowWholeGraph bool `yaml:"showWholeGraph"`
+	// If true, verify the signature of each commit and show whether it's good,
+	// bad, or missing next to the commit hash, and show the signature details
+	// in the main view. Works with gpg, ssh and x509 signatures, as configured
+	// by git's `gpg.format`. This can make loading commits noticeably slower.
+	ShowSignatureStatus bool `yaml:"showSignatureStatus"`
 }
 
 type CommitPrefixConfig struct {
@@ -415,6 +444,34 @@ type UpdateConfig struct {
 	Days int64 `yaml:"days" jsonschema:"minimum=0"`
 }
 
+type RemoteControlConfig struct {
+	// If true, listen for JSON-RPC requests on a Unix domain socket, so that
+	// other programs can drive Lazygit via `lazygit --remote <method>`.
+	// Requires a restart to take effect.
+	Enabled bool `yaml:"enabled"`
+	// Path of the socket. By default, each repo gets its own socket in the
+	// temp dir, so that `lazygit --remote` finds the Lazygit that's running
+	// in the current repo. The socket's directory must only be accessible
+	// by you.
+	SocketPath string `yaml:"socketPath"`
+}
+
+type SessionLogConfig struct {
+	// If true, write every command that Lazygit runs to a JSON Lines file in
+	// Lazygit's state directory, with one file per session. Switching to
+	// another repo starts a new session.
+	// Requires a restart to take effect.
+	Enabled bool `yaml:"enabled"`
+	// If true, include the output of each command in the session log.
+	IncludeOutput bool `yaml:"includeOutput"`
+	// If includeOutput is true, output longer than this many bytes is
+	// truncated.
+	MaxOutputBytes int `yaml:"maxOutputBytes" jsonschema:"minimum=1"`
+	// How many session logs to keep. The oldest ones are deleted when Lazygit
+	// starts.
+	MaxSessions int `yaml:"maxSessions" jsonschema:"minimum=1"`
+}
+
 type KeybindingConfig struct {
 	Universal      KeybindingUniversalConfig      `yaml:"universal"`
 	Status         KeybindingStatusConfig         `yaml:"status"`
@@ -535,28 +592,35 @@ type KeybindingStatusConfig struct {
 	RecentRepos                Keybinding `yaml:"recentRepos"`
 	AllBranchesLogGraph        Keybinding `yaml:"allBranchesLogGraph"`
 	AllBranchesLogGraphReverse Keybinding `yaml:"allBranchesLogGraphReverse"`
+	ViewSparseCheckoutOptions  Keybinding `yaml:"viewSparseCheckoutOptions"`
+	ApplyMailbox               Keybinding `yaml:"applyMailbox"`
 }
 
 type KeybindingFilesConfig struct {
-	CommitChanges            Keybinding `yaml:"commitChanges"`
-	CommitChangesWithoutHook Keybinding `yaml:"commitChangesWithoutHook"`
-	AmendLastCommit          Keybinding `yaml:"amendLastCommit"`
-	CommitChangesWithEditor  Keybinding `yaml:"commitChangesWithEditor"`
-	FindBaseCommitForFixup   Keybinding `yaml:"findBaseCommitForFixup"`
-	ConfirmDiscard           Keybinding `yaml:"confirmDiscard"`
-	IgnoreFile               Keybinding `yaml:"ignoreFile"`
-	RefreshFiles             Keybinding `yaml:"refreshFiles"`
-	StashAllChanges          Keybinding `yaml:"stashAllChanges"`
-	ViewStashOptions         Keybinding `yaml:"viewStashOptions"`
-	ToggleStagedAll          Keybinding `yaml:"toggleStagedAll"`
-	ViewResetOptions         Keybinding `yaml:"viewResetOptions"`
-	Fetch                    Keybinding `yaml:"fetch"`
-	ToggleTreeView           Keybinding `yaml:"toggleTreeView"`
-	OpenMergeOptions         Keybinding `yaml:"openMergeOptions"`
-	OpenStatusFilter         Keybinding `yaml:"openStatusFilter"`
-	CopyFileInfoToClipboard  Keybinding `yaml:"copyFileInfoToClipboard"`
-	CollapseAll              Keybinding `yaml:"collapseAll"`
-	ExpandAll                Keybinding `yaml:"expandAll"`
+	CommitChanges             Keybinding `yaml:"commitChanges"`
+	CommitChangesWithoutHook  Keybinding `yaml:"commitChangesWithoutHook"`
+	AmendLastCommit           Keybinding `yaml:"amendLastCommit"`
+	CommitChangesWithEditor   Keybinding `yaml:"commitChangesWithEditor"`
+	FindBaseCommitForFixup    Keybinding `yaml:"findBaseCommitForFixup"`
+	AbsorbStagedChanges       Keybinding `yaml:"absorbStagedChanges"`
+	ConfirmDiscard            Keybinding `yaml:"confirmDiscard"`
+	IgnoreFile                Keybinding `yaml:"ignoreFile"`
+	RefreshFiles              Keybinding `yaml:"refreshFiles"`
+	StashAllChanges           Keybinding `yaml:"stashAllChanges"`
+	ViewStashOptions          Keybinding `yaml:"viewStashOptions"`
+	ToggleStagedAll           Keybinding `yaml:"toggleStagedAll"`
+	ViewResetOptions          Keybinding `yaml:"viewResetOptions"`
+	Fetch                     Keybinding `yaml:"fetch"`
+	ToggleTreeView            Keybinding `yaml:"toggleTreeView"`
+	OpenMergeOptions          Keybinding `yaml:"openMergeOptions"`
+	OpenStatusFilter          Keybinding `yaml:"openStatusFilter"`
+	CopyFileInfoToClipboard   Keybinding `yaml:"copyFileInfoToClipboard"`
+	CollapseAll               Keybinding `yaml:"collapseAll"`
+	ExpandAll                 Keybinding `yaml:"expandAll"`
+	ViewSparseCheckoutOptions Keybinding `yaml:"viewSparseCheckoutOptions"`
+	ViewLfsOptions            Keybinding `yaml:"viewLfsOptions"`
+	ApplyMailbox              Keybinding `yaml:"applyMailbox"`
+	ViewBlame                 Keybinding `yaml:"viewBlame"`
 }
 
 type KeybindingBranchesConfig struct {
@@ -611,6 +675,8 @@ type KeybindingCommitsConfig struct {
 	OpenInBrowser                  Keybinding `yaml:"openInBrowser"`
 	OpenPullRequestInBrowser       Keybinding `yaml:"openPullRequestInBrowser"`
 	ViewBisectOptions              Keybinding `yaml:"viewBisectOptions"`
+	ViewNotesOptions               Keybinding `yaml:"viewNotesOptions"`
+	ExportPatchSeries              Keybinding `yaml:"exportPatchSeries"`
 	StartInteractiveRebase         Keybinding `yaml:"startInteractiveRebase"`
 	SelectCommitsOfCurrentBranch   Keybinding `yaml:"selectCommitsOfCurrentBranch"`
 }
@@ -628,14 +694,18 @@ type KeybindingStashConfig struct {
 
 type KeybindingCommitFilesConfig struct {
 	CheckoutCommitFile Keybinding `yaml:"checkoutCommitFile"`
+	ViewBlame          Keybinding `yaml:"viewBlame"`
 }
 
 type KeybindingMainConfig struct {
-	PrevHunk         Keybinding `yaml:"prevHunk"`
-	NextHunk         Keybinding `yaml:"nextHunk"`
-	ToggleSelectHunk Keybinding `yaml:"toggleSelectHunk"`
-	PickBothHunks    Keybinding `yaml:"pickBothHunks"`
-	EditSelectHunk   Keybinding `yaml:"editSelectHunk"`
+	PrevHunk                Keybinding `yaml:"prevHunk"`
+	NextHunk                Keybinding `yaml:"nextHunk"`
+	ToggleSelectHunk        Keybinding `yaml:"toggleSelectHunk"`
+	PickBothHunks           Keybinding `yaml:"pickBothHunks"`
+	OpenThreeWayMergeEditor Keybinding `yaml:"openThreeWayMergeEditor"`
+	EditSelectHunk          Keybinding `yaml:"editSelectHunk"`
+	BlameParent             Keybinding `yaml:"blameParent"`
+	ViewLineHistory         Keybinding `yaml:"viewLineHistory"`
 }
 
 type KeybindingSubmodulesConfig struct {
@@ -916,29 +986,32 @@ func GetDefaultConfigForPlatform(platform string) *UserConfig {
 				ShowGraph:      "always",
 				ShowWholeGraph: false,
 			},
-			LocalBranchSortOrder:         "date",
-			RemoteBranchSortOrder:        "date",
-			SkipHookPrefix:               "WIP",
-			MainBranches:                 []string{"master", "main"},
-			AutoFetch:                    true,
-			AutoRefresh:                  true,
-			AutoForwardBranches:          "onlyMainBranches",
-			FetchAll:                     true,
-			AutoStageResolvedConflicts:   true,
-			BranchLogCmd:                 "git log --graph --color=always --abbrev-commit --decorate --date=relative --pretty=medium {{branchName}} --",
-			AllBranchesLogCmds:           []string{"git log --graph --all --color=always --abbrev-commit --decorate --date=relative  --pretty=medium"},
-			IgnoreWhitespaceInDiffView:   false,
-			DiffContextSize:              3,
-			RenameSimilarityThreshold:    50,
-			DisableForcePushing:          false,
-			CommitPrefixes:               map[string][]CommitPrefixConfig(nil),
-			BranchPrefix:                 "",
-			ParseEmoji:                   false,
-			TruncateCopiedCommitHashesTo: 12,
+			LocalBranchSortOrder:             "date",
+			RemoteBranchSortOrder:            "date",
+			SkipHookPrefix:                   "WIP",
+			MainBranches:                     []string{"master", "main"},
+			AutoFetch:                        true,
+			AutoRefresh:                      true,
+			LazyUntrackedFiles:               "never",
+			AutoForwardBranches:              "onlyMainBranches",
+			FetchAll:                         true,
+			AutoStageResolvedConflicts:       true,
+			AutoStageRerereResolvedConflicts: false,
+			BranchLogCmd:                     "git log --graph --color=always --abbrev-commit --decorate --date=relative --pretty=medium {{branchName}} --",
+			AllBranchesLogCmds:               []string{"git log --graph --exclude=refs/lazygit/* --all --color=always --abbrev-commit --decorate --date=relative  --pretty=medium"},
+			IgnoreWhitespaceInDiffView:       false,
+			DiffContextSize:                  3,
+			RenameSimilarityThreshold:        50,
+			DisableForcePushing:              false,
+			CommitPrefixes:                   map[string][]CommitPrefixConfig(nil),
+			BranchPrefix:                     "",
+			ParseEmoji:                       false,
+			TruncateCopiedCommitHashesTo:     12,
 		},
 		Refresher: RefresherConfig{
 			RefreshInterval: 10,
 			FetchInterval:   60,
+			WatchFileSystem: false,
 		},
 		Update: UpdateConfig{
 			Method: "prompt",
@@ -952,6 +1025,16 @@ func GetDefaultConfigForPlatform(platform string) *UserConfig {
 		Services:                     map[string]string(nil),
 		NotARepository:               "prompt",
 		PromptToReturnFromSubprocess: true,
+		RemoteControl: RemoteControlConfig{
+			Enabled:    false,
+			SocketPath: "",
+		},
+		SessionLog: SessionLogConfig{
+			Enabled:        false,
+			IncludeOutput:  false,
+			MaxOutputBytes: 4096,
+			MaxSessions:    20,
+		},
 		Keybinding: KeybindingConfig{
 			Universal: KeybindingUniversalConfig{
 				Quit:                              Keybinding{"q"},
@@ -1041,27 +1124,34 @@ func GetDefaultConfigForPlatform(platform string) *UserConfig {
 				RecentRepos:                Keybinding{"<enter>"},
 				AllBranchesLogGraph:        Keybinding{"a"},
 				AllBranchesLogGraphReverse: Keybinding{"A"},
+				ViewSparseCheckoutOptions:  Keybinding{"s"},
+				ApplyMailbox:               Keybinding{"<ctrl+a>"},
 			},
 			Files: KeybindingFilesConfig{
-				CommitChanges:            Keybinding{"c"},
-				CommitChangesWithoutHook: Keybinding{"w"},
-				AmendLastCommit:          Keybinding{"A"},
-				CommitChangesWithEditor:  Keybinding{"C"},
-				FindBaseCommitForFixup:   Keybinding{"<ctrl+f>"},
-				IgnoreFile:               Keybinding{"i"},
-				RefreshFiles:             Keybinding{"r"},
-				StashAllChanges:          Keybinding{"s"},
-				ViewStashOptions:         Keybinding{"S"},
-				ToggleStagedAll:          Keybinding{"a"},
-				ViewResetOptions:         Keybinding{"D"},
-				Fetch:                    Keybinding{"f"},
-				ToggleTreeView:           Keybinding{"`"},
-				OpenMergeOptions:         Keybinding{"M"},
-				OpenStatusFilter:         Keybinding{"<ctrl+b>"},
-				ConfirmDiscard:           Keybinding{"x"},
-				CopyFileInfoToClipboard:  Keybinding{"y"},
-				CollapseAll:              Keybinding{"-"},
-				ExpandAll:                Keybinding{"="},
+				CommitChanges:             Keybinding{"c"},
+				CommitChangesWithoutHook:  Keybinding{"w"},
+				AmendLastCommit:           Keybinding{"A"},
+				CommitChangesWithEditor:   Keybinding{"C"},
+				FindBaseCommitForFixup:    Keybinding{"<ctrl+f>"},
+				AbsorbStagedChanges:       Keybinding{"<ctrl+x>"},
+				IgnoreFile:                Keybinding{"i"},
+				RefreshFiles:              Keybinding{"r"},
+				StashAllChanges:           Keybinding{"s"},
+				ViewStashOptions:          Keybinding{"S"},
+				ToggleStagedAll:           Keybinding{"a"},
+				ViewResetOptions:          Keybinding{"D"},
+				Fetch:                     Keybinding{"f"},
+				ToggleTreeView:            Keybinding{"`"},
+				OpenMergeOptions:          Keybinding{"M"},
+				OpenStatusFilter:          Keybinding{"<ctrl+b>"},
+				ConfirmDiscard:            Keybinding{"x"},
+				CopyFileInfoToClipboard:   Keybinding{"y"},
+				CollapseAll:               Keybinding{"-"},
+				ExpandAll:                 Keybinding{"="},
+				ViewSparseCheckoutOptions: Keybinding{"<ctrl+k>"},
+				ViewLfsOptions:            Keybinding{"F"},
+				ApplyMailbox:              Keybinding{"<ctrl+a>"},
+				ViewBlame:                 Keybinding{"b"},
 			},
 			Branches: KeybindingBranchesConfig{
 				CopyPullRequestURL:       Keybinding{"<ctrl+y>"},
@@ -1113,6 +1203,8 @@ func GetDefaultConfigForPlatform(platform string) *UserConfig {
 				OpenInBrowser:                  Keybinding{"o"},
 				OpenPullRequestInBrowser:       Keybinding{"G"},
 				ViewBisectOptions:              Keybinding{"b"},
+				ViewNotesOptions:               Keybinding{"<ctrl+n>"},
+				ExportPatchSeries:              Keybinding{"<ctrl+x>"},
 				StartInteractiveRebase:         Keybinding{"i"},
 				SelectCommitsOfCurrentBranch:   Keybinding{"*"},
 			},
@@ -1127,13 +1219,17 @@ func GetDefaultConfigForPlatform(platform string) *UserConfig {
 			},
 			CommitFiles: KeybindingCommitFilesConfig{
 				CheckoutCommitFile: Keybinding{"c"},
+				ViewBlame:          Keybinding{"b"},
 			},
 			Main: KeybindingMainConfig{
-				PrevHunk:         Keybinding{"<left>", "h"},
-				NextHunk:         Keybinding{"<right>", "l"},
-				ToggleSelectHunk: Keybinding{"a"},
-				PickBothHunks:    Keybinding{"b"},
-				EditSelectHunk:   Keybinding{"E"},
+				PrevHunk:                Keybinding{"<left>", "h"},
+				NextHunk:                Keybinding{"<right>", "l"},
+				ToggleSelectHunk:        Keybinding{"a"},
+				PickBothHunks:           Keybinding{"b"},
+				OpenThreeWayMergeEditor: Keybinding{"t"},
+				EditSelectHunk:          Keybinding{"E"},
+				BlameParent:             Keybinding{"b"},
+				ViewLineHistory:         Keybinding{"t"},
 			},
 			Submodules: KeybindingSubmodulesConfig{
 				Init:     Keybinding{"i"},
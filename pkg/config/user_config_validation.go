@@ -30,6 +30,10 @@ func (config *UserConfig) Validate() error {
 		[]string{"none", "onlyMainBranches", "allBranches"}); err != nil {
 		return err
 	}
+	if err := validateEnum("git.lazyUntrackedFiles", config.Git.LazyUntrackedFiles,
+		[]string{"auto", "always", "never"}); err != nil {
+		return err
+	}
 	if err := validateEnum("git.localBranchSortOrder", config.Git.LocalBranchSortOrder,
 		[]string{"date", "recency", "alphabetical"}); err != nil {
 		return err
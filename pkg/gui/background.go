@@ -3,11 +3,14 @@ package gui
 import (
 	"fmt"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/filewatcher"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 type BackgroundRoutineMgr struct {
@@ -20,6 +23,16 @@ type BackgroundRoutineMgr struct {
 
 	// a channel to trigger an immediate background fetch; we use this when switching repos
 	triggerFetch chan struct{}
+
+	// when refresher.watchFileSystem is on, this watches the current repo
+	fileWatcher *filewatcher.Watcher
+	// incremented whenever we switch repos, so that we can tell whether a
+	// watcher that took a while to set up is still for the current repo
+	fileWatcherGeneration int
+	// whether we've given up on watching the file system and refresh files
+	// every refreshInterval seconds instead
+	pollingFiles     bool
+	fileWatcherMutex sync.Mutex
 }
 
 func (self *BackgroundRoutineMgr) PauseBackgroundRefreshes(pause bool) {
@@ -41,13 +54,10 @@ func (self *BackgroundRoutineMgr) startBackgroundRoutines() {
 	}
 
 	if userConfig.Git.AutoRefresh {
-		refreshInterval := userConfig.Refresher.RefreshInterval
-		if refreshInterval > 0 {
-			go utils.Safe(self.startBackgroundFilesRefresh)
+		if userConfig.Refresher.WatchFileSystem {
+			go utils.Safe(self.startFileSystemWatcher)
 		} else {
-			self.gui.c.Log.Errorf(
-				"Value of config option 'refresher.refreshInterval' (%d) is invalid, disabling auto-refresh",
-				refreshInterval)
+			self.startPollingFiles()
 		}
 	}
 
@@ -106,6 +116,17 @@ func (self *BackgroundRoutineMgr) startBackgroundFetch() {
 	self.triggerFetch = self.goEvery(userConfig.Refresher.FetchIntervalDuration(), self.gui.stopChan, fetch)
 }
 
+func (self *BackgroundRoutineMgr) startPollingFiles() {
+	refreshInterval := self.gui.UserConfig().Refresher.RefreshInterval
+	if refreshInterval > 0 {
+		go utils.Safe(self.startBackgroundFilesRefresh)
+	} else {
+		self.gui.c.Log.Errorf(
+			"Value of config option 'refresher.refreshInterval' (%d) is invalid, disabling auto-refresh",
+			refreshInterval)
+	}
+}
+
 func (self *BackgroundRoutineMgr) startBackgroundFilesRefresh() {
 	self.gui.waitForIntro.Wait()
 
@@ -116,6 +137,97 @@ func (self *BackgroundRoutineMgr) startBackgroundFilesRefresh() {
 	})
 }
 
+func (self *BackgroundRoutineMgr) startFileSystemWatcher() {
+	self.gui.waitForIntro.Wait()
+
+	self.watchCurrentRepo()
+
+	<-self.gui.stopChan
+	self.fileWatcherMutex.Lock()
+	defer self.fileWatcherMutex.Unlock()
+	self.closeFileWatcher()
+}
+
+// watchCurrentRepo replaces the watcher of the previous repo, if any, with one
+// for the current repo. Setting it up walks the whole working tree, so we do
+// that in the background.
+func (self *BackgroundRoutineMgr) watchCurrentRepo() {
+	self.fileWatcherMutex.Lock()
+	defer self.fileWatcherMutex.Unlock()
+
+	if self.pollingFiles {
+		return
+	}
+
+	self.closeFileWatcher()
+	generation := self.fileWatcherGeneration
+	git := self.gui.git
+	repoPaths := git.RepoPaths
+
+	go utils.Safe(func() {
+		watcher, err := filewatcher.New(filewatcher.Opts{
+			WorktreePath:       lo.Ternary(repoPaths.IsBareRepo(), "", repoPaths.WorktreePath()),
+			WorktreeGitDirPath: repoPaths.WorktreeGitDirPath(),
+			RepoGitDirPath:     repoPaths.RepoGitDirPath(),
+			IgnoredPaths:       git.WorkingTree.IgnoredPaths,
+			OnChange:           self.refreshScopes,
+			Log:                self.gui.c.Log,
+		})
+
+		self.fileWatcherMutex.Lock()
+		defer self.fileWatcherMutex.Unlock()
+
+		if err != nil {
+			self.gui.c.Log.Errorf("Can't watch the file system, falling back to refreshing every %d seconds: %v",
+				self.gui.UserConfig().Refresher.RefreshInterval, err)
+			if !self.pollingFiles {
+				self.pollingFiles = true
+				self.startPollingFiles()
+			}
+			return
+		}
+
+		if generation != self.fileWatcherGeneration {
+			// we've switched repos (or quit) in the meantime
+			watcher.Close()
+			return
+		}
+		self.fileWatcher = watcher
+	})
+}
+
+// must be called with fileWatcherMutex held
+func (self *BackgroundRoutineMgr) closeFileWatcher() {
+	if self.fileWatcher != nil {
+		self.fileWatcher.Close()
+		self.fileWatcher = nil
+	}
+	self.fileWatcherGeneration++
+}
+
+func (self *BackgroundRoutineMgr) refreshScopes(scopes []types.RefreshableView) {
+	if self.pauseBackgroundRefreshes {
+		return
+	}
+
+	// Wait for the refresh to finish, so that we don't start another one
+	// while it's still running
+	done := make(chan struct{})
+	self.gui.c.OnWorker(func(gocui.Task) error {
+		self.gui.c.Refresh(types.RefreshOptions{Scope: scopes})
+		close(done)
+		return nil
+	})
+	<-done
+}
+
+func (self *BackgroundRoutineMgr) onSwitchToNewRepo() {
+	userConfig := self.gui.UserConfig()
+	if userConfig.Git.AutoRefresh && userConfig.Refresher.WatchFileSystem {
+		self.watchCurrentRepo()
+	}
+}
+
 // returns a channel that can be used to trigger the callback immediately
 func (self *BackgroundRoutineMgr) goEvery(interval time.Duration, stop chan struct{}, function func(bool) error) chan struct{} {
 	done := make(chan struct{})
@@ -6,9 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/constants"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // our UI command log looks like this:
@@ -23,6 +27,10 @@ import (
 // We pass logCommand to our OSCommand struct so that it can handle logging commands
 // for us.
 func (gui *Gui) LogAction(action string) {
+	if gui.sessionLog != nil {
+		gui.sessionLog.SetAction(action)
+	}
+
 	if gui.Views.Extras == nil {
 		return
 	}
@@ -51,6 +59,64 @@ func (gui *Gui) LogCommand(cmdStr string, commandLine bool) {
 	fmt.Fprint(gui.Views.Extras, "\n"+textStyle.Sprint(indentedCmdStr))
 }
 
+// Unlike the command log panel, the session log records every command we run,
+// including the ones we don't show in the panel, e.g. for refreshing
+func newSessionLog(cmn *common.Common) *oscommands.SessionLog {
+	if !cmn.UserConfig().SessionLog.Enabled {
+		return nil
+	}
+
+	dir, err := config.SessionLogDir()
+	if err != nil {
+		cmn.Log.Error(err)
+		return nil
+	}
+
+	sessionLog, err := oscommands.NewSessionLog(cmn, dir, cmn.UserConfig().SessionLog.MaxSessions)
+	if err != nil {
+		cmn.Log.Error(err)
+		return nil
+	}
+	return sessionLog
+}
+
+// An action lasts until lazygit is idle again; logged commands that run after
+// that (e.g. from a background fetch) aren't part of it. Must be called before
+// the main loop starts, because gocui doesn't expect idle listeners to be
+// added while it's running.
+func (gui *Gui) resetSessionLogActionWhenIdle() {
+	isIdleChan := make(chan struct{})
+	gui.g.AddIdleListener(isIdleChan)
+	go utils.Safe(func() {
+		for range isIdleChan {
+			gui.sessionLog.SetAction("")
+		}
+	})
+}
+
+func (gui *Gui) startNewSessionLog() {
+	if gui.sessionLog == nil {
+		return
+	}
+
+	if err := gui.sessionLog.StartNewSession(); err != nil {
+		gui.c.Log.Error(err)
+	}
+}
+
+func (gui *Gui) cmdRecording() oscommands.CmdRecording {
+	if gui.sessionLog == nil {
+		return oscommands.CMD_RECORDING_OFF
+	}
+	return gui.sessionLog.Recording()
+}
+
+func (gui *Gui) recordCommand(record oscommands.CmdRecord) {
+	if gui.sessionLog != nil {
+		gui.sessionLog.Record(record)
+	}
+}
+
 func (gui *Gui) printCommandLogHeader() {
 	introStr := fmt.Sprintf(
 		gui.c.Tr.CommandLogHeader,
@@ -0,0 +1,76 @@
+package context
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+type BlameContext struct {
+	*SimpleContext
+	*SearchTrait
+
+	state *BlameState
+}
+
+type BlameState struct {
+	// the path of the file as of Ref (files can be renamed as we walk back
+	// through history)
+	Filename string
+	// the commit we're blaming the file at; empty for the working tree
+	Ref string
+	// how to show Ref in the view's subtitle
+	TitleRef string
+	// the side context that the blame view was opened from
+	SourceContext types.Context
+}
+
+var _ types.ISearchableContext = (*BlameContext)(nil)
+
+func NewBlameContext(c *ContextCommon) *BlameContext {
+	return &BlameContext{
+		SimpleContext: NewSimpleContext(
+			NewBaseContext(NewBaseContextOpts{
+				Kind:             types.MAIN_CONTEXT,
+				View:             c.Views().Blame,
+				WindowName:       "main",
+				Key:              BLAME_CONTEXT_KEY,
+				Focusable:        true,
+				HighlightOnFocus: true,
+			})),
+		SearchTrait: NewSearchTrait(c),
+	}
+}
+
+func (self *BlameContext) GetState() *BlameState {
+	return self.state
+}
+
+func (self *BlameContext) SetState(state *BlameState) {
+	self.state = state
+}
+
+func (self *BlameContext) GetSelectedLineIdx() int {
+	return self.GetView().SelectedLineIdx()
+}
+
+func (self *BlameContext) GetSelectedLine() string {
+	line, _ := self.GetView().Line(self.GetView().CursorY())
+	return line
+}
+
+// Moves the selection to the given line, clamped to the lines that have been
+// loaded into the view so far.
+func (self *BlameContext) SelectLine(lineIdx int) {
+	view := self.GetView()
+	lineIdx = min(lineIdx, view.ViewLinesHeight()-1)
+	lineIdx = max(lineIdx, 0)
+	view.FocusPoint(0, lineIdx, true)
+}
+
+func (self *BlameContext) ModelSearchResults(searchStr string, caseSensitive bool) []gocui.SearchPosition {
+	return nil
+}
+
+func (self *BlameContext) OnSearchSelect(selectedLineIdx int) {
+	self.SelectLine(selectedLineIdx)
+}
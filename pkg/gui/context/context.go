@@ -17,9 +17,11 @@ const (
 	WORKTREES_CONTEXT_KEY                types.ContextKey = "worktrees"
 	REMOTE_BRANCHES_CONTEXT_KEY          types.ContextKey = "remoteBranches"
 	TAGS_CONTEXT_KEY                     types.ContextKey = "tags"
+	PULL_REQUESTS_CONTEXT_KEY            types.ContextKey = "pullRequests"
 	LOCAL_COMMITS_CONTEXT_KEY            types.ContextKey = "commits"
 	REFLOG_COMMITS_CONTEXT_KEY           types.ContextKey = "reflogCommits"
 	SUB_COMMITS_CONTEXT_KEY              types.ContextKey = "subCommits"
+	RANGE_DIFF_CONTEXT_KEY               types.ContextKey = "rangeDiff"
 	COMMIT_FILES_CONTEXT_KEY             types.ContextKey = "commitFiles"
 	STASH_CONTEXT_KEY                    types.ContextKey = "stash"
 	NORMAL_MAIN_CONTEXT_KEY              types.ContextKey = "normal"
@@ -29,6 +31,9 @@ const (
 	PATCH_BUILDING_MAIN_CONTEXT_KEY      types.ContextKey = "patchBuilding"
 	PATCH_BUILDING_SECONDARY_CONTEXT_KEY types.ContextKey = "patchBuildingSecondary"
 	MERGE_CONFLICTS_CONTEXT_KEY          types.ContextKey = "mergeConflicts"
+	MERGE_CONFLICT_EDITOR_CONTEXT_KEY    types.ContextKey = "mergeConflictEditor"
+	MERGE_CONFLICT_RESULT_CONTEXT_KEY    types.ContextKey = "mergeConflictResult"
+	BLAME_CONTEXT_KEY                    types.ContextKey = "blame"
 
 	// these shouldn't really be needed for anything but I'm giving them unique keys nonetheless
 	OPTIONS_CONTEXT_KEY        types.ContextKey = "options"
@@ -59,9 +64,11 @@ var AllContextKeys = []types.ContextKey{
 	WORKTREES_CONTEXT_KEY,
 	REMOTE_BRANCHES_CONTEXT_KEY,
 	TAGS_CONTEXT_KEY,
+	PULL_REQUESTS_CONTEXT_KEY,
 	LOCAL_COMMITS_CONTEXT_KEY,
 	REFLOG_COMMITS_CONTEXT_KEY,
 	SUB_COMMITS_CONTEXT_KEY,
+	RANGE_DIFF_CONTEXT_KEY,
 	COMMIT_FILES_CONTEXT_KEY,
 	STASH_CONTEXT_KEY,
 	NORMAL_MAIN_CONTEXT_KEY,
@@ -90,6 +97,7 @@ type ContextTree struct {
 	Menu                        *MenuContext
 	Branches                    *BranchesContext
 	Tags                        *TagsContext
+	PullRequests                *PullRequestsContext
 	LocalCommits                *LocalCommitsContext
 	CommitFiles                 *CommitFilesContext
 	Remotes                     *RemotesContext
@@ -98,6 +106,7 @@ type ContextTree struct {
 	RemoteBranches              *RemoteBranchesContext
 	ReflogCommits               *ReflogCommitsContext
 	SubCommits                  *SubCommitsContext
+	RangeDiff                   *RangeDiffContext
 	Stash                       *StashContext
 	Suggestions                 *SuggestionsContext
 	Normal                      *MainContext
@@ -107,6 +116,9 @@ type ContextTree struct {
 	CustomPatchBuilder          *PatchExplorerContext
 	CustomPatchBuilderSecondary types.Context
 	MergeConflicts              *MergeConflictsContext
+	MergeConflictEditor         *MergeConflictEditorContext
+	MergeConflictResult         types.Context
+	Blame                       *BlameContext
 	Confirmation                *ConfirmationContext
 	Prompt                      *PromptContext
 	CommitMessage               *CommitMessageContext
@@ -134,9 +146,11 @@ func (self *ContextTree) Flatten() []types.Context {
 		self.Worktrees,
 		self.Files,
 		self.SubCommits,
+		self.RangeDiff,
 		self.Remotes,
 		self.RemoteBranches,
 		self.Tags,
+		self.PullRequests,
 		self.Branches,
 		self.CommitFiles,
 		self.ReflogCommits,
@@ -149,6 +163,9 @@ func (self *ContextTree) Flatten() []types.Context {
 		self.CommitDescription,
 
 		self.MergeConflicts,
+		self.MergeConflictResult,
+		self.MergeConflictEditor,
+		self.Blame,
 		self.StagingSecondary,
 		self.Staging,
 		self.CustomPatchBuilderSecondary,
@@ -0,0 +1,56 @@
+package context
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// MergeConflictEditorContext shows the sides of the selected conflict of the
+// merge conflicts context next to each other, so that it can be resolved line
+// by line. The resolution built so far is shown in the secondary view.
+type MergeConflictEditorContext struct {
+	*SimpleContext
+
+	state *mergeconflicts.ThreeWayState
+
+	// the conflicts of a re-merge of the file's index stages, which is where
+	// we get the common ancestor from if the file was written without it
+	remergedPath string
+	remerged     []mergeconflicts.ConflictSides
+}
+
+func NewMergeConflictEditorContext(c *ContextCommon) *MergeConflictEditorContext {
+	return &MergeConflictEditorContext{
+		SimpleContext: NewSimpleContext(
+			NewBaseContext(NewBaseContextOpts{
+				Kind:       types.MAIN_CONTEXT,
+				View:       c.Views().MergeConflictEditor,
+				WindowName: "main",
+				Key:        MERGE_CONFLICT_EDITOR_CONTEXT_KEY,
+				Focusable:  true,
+				// we highlight the selected line of the selected pane ourselves
+				HighlightOnFocus: false,
+			})),
+	}
+}
+
+func (self *MergeConflictEditorContext) GetState() *mergeconflicts.ThreeWayState {
+	return self.state
+}
+
+func (self *MergeConflictEditorContext) SetState(state *mergeconflicts.ThreeWayState) {
+	self.state = state
+}
+
+func (self *MergeConflictEditorContext) GetRemerged(path string) ([]mergeconflicts.ConflictSides, bool) {
+	if path != self.remergedPath {
+		return nil, false
+	}
+
+	return self.remerged, true
+}
+
+func (self *MergeConflictEditorContext) SetRemerged(path string, remerged []mergeconflicts.ConflictSides) {
+	self.remergedPath = path
+	self.remerged = remerged
+}
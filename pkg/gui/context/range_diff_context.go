@@ -0,0 +1,45 @@
+package context
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+type RangeDiffContext struct {
+	*ListViewModel[*models.RangeDiffEntry]
+	*ListContextTrait
+	*DynamicTitleBuilder
+}
+
+var _ types.IListContext = (*RangeDiffContext)(nil)
+
+func NewRangeDiffContext(c *ContextCommon) *RangeDiffContext {
+	viewModel := NewListViewModel(
+		func() []*models.RangeDiffEntry { return c.Model().RangeDiffEntries },
+	)
+
+	getDisplayStrings := func(_ int, _ int) [][]string {
+		return presentation.GetRangeDiffEntryListDisplayStrings(c.Model().RangeDiffEntries)
+	}
+
+	return &RangeDiffContext{
+		ListViewModel:       viewModel,
+		DynamicTitleBuilder: NewDynamicTitleBuilder(c.Tr.RangeDiffDynamicTitle),
+		ListContextTrait: &ListContextTrait{
+			Context: NewSimpleContext(NewBaseContext(NewBaseContextOpts{
+				View:       c.Views().RangeDiff,
+				WindowName: "commits",
+				Key:        RANGE_DIFF_CONTEXT_KEY,
+				Kind:       types.SIDE_CONTEXT,
+				Focusable:  true,
+				Transient:  true,
+			})),
+			ListRenderer: ListRenderer{
+				list:              viewModel,
+				getDisplayStrings: getDisplayStrings,
+			},
+			c: c,
+		},
+	}
+}
@@ -35,8 +35,10 @@ func NewContextTree(c *ContextCommon) *ContextTree {
 		CommitFiles:     commitFilesContext,
 		ReflogCommits:   NewReflogCommitsContext(c),
 		SubCommits:      NewSubCommitsContext(c),
+		RangeDiff:       NewRangeDiffContext(c),
 		Branches:        NewBranchesContext(c),
 		Tags:            NewTagsContext(c),
+		PullRequests:    NewPullRequestsContext(c),
 		Stash:           NewStashContext(c),
 		Suggestions:     NewSuggestionsContext(c),
 		Normal:          NewMainContext(c.Views().Main, "main", NORMAL_MAIN_CONTEXT_KEY, c),
@@ -83,6 +85,17 @@ func NewContextTree(c *ContextCommon) *ContextTree {
 		MergeConflicts: NewMergeConflictsContext(
 			c,
 		),
+		MergeConflictEditor: NewMergeConflictEditorContext(c),
+		MergeConflictResult: NewSimpleContext(
+			NewBaseContext(NewBaseContextOpts{
+				Kind:       types.MAIN_CONTEXT,
+				View:       c.Views().MergeConflictResult,
+				WindowName: "secondary",
+				Key:        MERGE_CONFLICT_RESULT_CONTEXT_KEY,
+				Focusable:  false,
+			}),
+		),
+		Blame:         NewBlameContext(c),
 		Confirmation:  NewConfirmationContext(c),
 		Prompt:        NewPromptContext(c),
 		CommitMessage: NewCommitMessageContext(c),
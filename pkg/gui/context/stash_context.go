@@ -27,7 +27,7 @@ func NewStashContext(
 	)
 
 	getDisplayStrings := func(_ int, _ int) [][]string {
-		return presentation.GetStashEntryListDisplayStrings(viewModel.GetItems(), c.Modes().Diffing.Ref)
+		return presentation.GetStashEntryListDisplayStrings(viewModel.GetItems(), c.Modes().Diffing.Ref, c.Tr)
 	}
 
 	return &StashContext{
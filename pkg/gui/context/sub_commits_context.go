@@ -145,6 +145,10 @@ type SubCommitsViewModel struct {
 
 	limitCommits    bool
 	showBranchHeads bool
+
+	// if set, we're showing the history of this range of lines rather than
+	// the history of ref as a whole
+	lineRange *git_commands.LineRange
 }
 
 func (self *SubCommitsViewModel) SetRef(ref models.Ref) {
@@ -163,6 +167,14 @@ func (self *SubCommitsViewModel) GetRefToShowDivergenceFrom() string {
 	return self.refToShowDivergenceFrom
 }
 
+func (self *SubCommitsViewModel) SetLineRange(lineRange *git_commands.LineRange) {
+	self.lineRange = lineRange
+}
+
+func (self *SubCommitsViewModel) GetLineRange() *git_commands.LineRange {
+	return self.lineRange
+}
+
 func (self *SubCommitsViewModel) SetShowBranchHeads(value bool) {
 	self.showBranchHeads = value
 }
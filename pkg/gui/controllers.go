@@ -74,6 +74,7 @@ func (gui *Gui) resetHelpersAndControllers() {
 	)
 	bisectHelper := helpers.NewBisectHelper(helperCommon)
 	windowHelper := helpers.NewWindowHelper(helperCommon, viewHelper)
+	rangeDiffHelper := helpers.NewRangeDiffHelper(helperCommon, refsHelper, windowHelper)
 	modeHelper := helpers.NewModeHelper(
 		helperCommon,
 		diffHelper,
@@ -81,6 +82,7 @@ func (gui *Gui) resetHelpersAndControllers() {
 		cherryPickHelper,
 		rebaseHelper,
 		bisectHelper,
+		rangeDiffHelper,
 	)
 	appStatusHelper := helpers.NewAppStatusHelper(
 		helperCommon,
@@ -88,24 +90,34 @@ func (gui *Gui) resetHelpersAndControllers() {
 		modeHelper,
 	)
 
+	subCommitsHelper := helpers.NewSubCommitsHelper(helperCommon, refreshHelper)
+	workingTreeHelper := helpers.NewWorkingTreeHelper(helperCommon, refsHelper, commitsHelper, gpgHelper, rebaseHelper)
+
+	hostHelper := helpers.NewHostHelper(helperCommon)
+
 	gui.helpers = &helpers.Helpers{
-		Refs:            refsHelper,
-		Host:            helpers.NewHostHelper(helperCommon),
-		PatchBuilding:   patchBuildingHelper,
-		Staging:         stagingHelper,
-		Bisect:          bisectHelper,
-		Suggestions:     suggestionsHelper,
-		Files:           helpers.NewFilesHelper(helperCommon),
-		WorkingTree:     helpers.NewWorkingTreeHelper(helperCommon, refsHelper, commitsHelper, gpgHelper, rebaseHelper),
-		Tags:            helpers.NewTagsHelper(helperCommon, commitsHelper, gpgHelper),
-		BranchesHelper:  helpers.NewBranchesHelper(helperCommon, worktreeHelper),
-		GPG:             helpers.NewGpgHelper(helperCommon),
-		MergeAndRebase:  rebaseHelper,
-		MergeConflicts:  mergeConflictsHelper,
+		Refs:              refsHelper,
+		Host:              hostHelper,
+		CreatePullRequest: helpers.NewCreatePullRequestHelper(helperCommon, commitsHelper, suggestionsHelper, hostHelper),
+		PatchBuilding:     patchBuildingHelper,
+		Staging:           stagingHelper,
+		Bisect:            bisectHelper,
+		Suggestions:       suggestionsHelper,
+		Files:             helpers.NewFilesHelper(helperCommon),
+		WorkingTree:       workingTreeHelper,
+		Tags:              helpers.NewTagsHelper(helperCommon, commitsHelper, gpgHelper),
+		BranchesHelper:    helpers.NewBranchesHelper(helperCommon, worktreeHelper),
+		GPG:               helpers.NewGpgHelper(helperCommon),
+		MergeAndRebase:    rebaseHelper,
+		MergeConflicts:    mergeConflictsHelper,
+		MergeConflictEditor: helpers.NewMergeConflictEditorHelper(
+			helperCommon,
+			mergeConflictsHelper,
+		),
 		CherryPick:      cherryPickHelper,
 		Upstream:        helpers.NewUpstreamHelper(helperCommon, suggestionsHelper.GetRemoteBranchesSuggestionsFunc),
 		AmendHelper:     helpers.NewAmendHelper(helperCommon, gpgHelper),
-		FixupHelper:     helpers.NewFixupHelper(helperCommon),
+		FixupHelper:     helpers.NewFixupHelper(helperCommon, rebaseHelper),
 		Commits:         commitsHelper,
 		SuspendResume:   helpers.NewSuspendResumeHelper(helperCommon),
 		Snake:           helpers.NewSnakeHelper(helperCommon),
@@ -126,9 +138,15 @@ func (gui *Gui) resetHelpersAndControllers() {
 			modeHelper,
 			appStatusHelper,
 		),
-		Search:     searchHelper,
-		Worktree:   worktreeHelper,
-		SubCommits: helpers.NewSubCommitsHelper(helperCommon, refreshHelper),
+		Search:         searchHelper,
+		Worktree:       worktreeHelper,
+		SubCommits:     subCommitsHelper,
+		Blame:          helpers.NewBlameHelper(helperCommon, subCommitsHelper),
+		LineHistory:    helpers.NewLineHistoryHelper(helperCommon, subCommitsHelper),
+		RangeDiff:      rangeDiffHelper,
+		Notes:          helpers.NewNotesHelper(helperCommon, suggestionsHelper),
+		SparseCheckout: helpers.NewSparseCheckoutHelper(helperCommon),
+		Lfs:            helpers.NewLfsHelper(helperCommon),
 	}
 
 	gui.CustomCommandsClient = custom_commands.NewClient(
@@ -163,11 +181,14 @@ func (gui *Gui) resetHelpersAndControllers() {
 		common,
 	)
 	mergeConflictsController := controllers.NewMergeConflictsController(common)
+	mergeConflictEditorController := controllers.NewMergeConflictEditorController(common)
+	blameController := controllers.NewBlameController(common)
 	remotesController := controllers.NewRemotesController(
 		common,
 		func(branches []*models.RemoteBranch) { gui.State.Model.RemoteBranches = branches },
 	)
 	worktreesController := controllers.NewWorktreesController(common)
+	pullRequestsController := controllers.NewPullRequestsController(common)
 	undoController := controllers.NewUndoController(common)
 	globalController := controllers.NewGlobalController(common)
 	contextLinesController := controllers.NewContextLinesController(common)
@@ -188,6 +209,7 @@ func (gui *Gui) resetHelpersAndControllers() {
 	snakeController := controllers.NewSnakeController(common)
 	reflogCommitsController := controllers.NewReflogCommitsController(common)
 	subCommitsController := controllers.NewSubCommitsController(common)
+	rangeDiffController := controllers.NewRangeDiffController(common)
 	statusController := controllers.NewStatusController(common)
 	commandLogController := controllers.NewCommandLogController(common)
 	confirmationController := controllers.NewConfirmationController(common)
@@ -215,6 +237,7 @@ func (gui *Gui) resetHelpersAndControllers() {
 		gui.State.Contexts.Branches,
 		gui.State.Contexts.RemoteBranches,
 		gui.State.Contexts.Tags,
+		gui.State.Contexts.Worktrees,
 	} {
 		controllers.AttachControllers(context, controllers.NewWorktreeOptionsController(common, context))
 	}
@@ -225,6 +248,7 @@ func (gui *Gui) resetHelpersAndControllers() {
 		gui.State.Contexts.Remotes,
 		gui.State.Contexts.Worktrees,
 		gui.State.Contexts.Tags,
+		gui.State.Contexts.PullRequests,
 		gui.State.Contexts.Branches,
 		gui.State.Contexts.RemoteBranches,
 		gui.State.Contexts.Files,
@@ -292,6 +316,10 @@ func (gui *Gui) resetHelpersAndControllers() {
 		subCommitsController,
 	)
 
+	controllers.AttachControllers(gui.State.Contexts.RangeDiff,
+		rangeDiffController,
+	)
+
 	// TODO: add scroll controllers for main panels (need to bring some more functionality across for that e.g. reading more from the currently displayed git command)
 	controllers.AttachControllers(gui.State.Contexts.Staging,
 		stagingController,
@@ -319,6 +347,19 @@ func (gui *Gui) resetHelpersAndControllers() {
 		mergeConflictsController,
 	)
 
+	controllers.AttachControllers(gui.State.Contexts.MergeConflictEditor,
+		mergeConflictEditorController,
+	)
+
+	controllers.AttachControllers(gui.State.Contexts.MergeConflictResult,
+		verticalScrollControllerFactory.Create(gui.State.Contexts.MergeConflictResult),
+	)
+
+	controllers.AttachControllers(gui.State.Contexts.Blame,
+		blameController,
+		verticalScrollControllerFactory.Create(gui.State.Contexts.Blame),
+	)
+
 	controllers.AttachControllers(gui.State.Contexts.Normal,
 		mainViewController,
 		verticalScrollControllerFactory.Create(gui.State.Contexts.Normal),
@@ -365,6 +406,10 @@ func (gui *Gui) resetHelpersAndControllers() {
 		worktreesController,
 	)
 
+	controllers.AttachControllers(gui.State.Contexts.PullRequests,
+		pullRequestsController,
+	)
+
 	controllers.AttachControllers(gui.State.Contexts.Stash,
 		stashController,
 	)
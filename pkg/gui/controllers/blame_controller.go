@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+type BlameController struct {
+	baseController
+	c *ControllerCommon
+}
+
+var _ types.IController = &BlameController{}
+
+func NewBlameController(
+	c *ControllerCommon,
+) *BlameController {
+	return &BlameController{
+		baseController: baseController{},
+		c:              c,
+	}
+}
+
+func (self *BlameController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	return []*types.Binding{
+		{
+			Keys:    opts.GetKeys(opts.Config.Universal.PrevItem),
+			Handler: self.handlePrevLine,
+			Tag:     "navigation",
+		},
+		{
+			Keys:    opts.GetKeys(opts.Config.Universal.NextItem),
+			Handler: self.handleNextLine,
+			Tag:     "navigation",
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.PrevPage),
+			Handler:     self.handlePrevPage,
+			Description: self.c.Tr.PrevPage,
+			Tag:         "navigation",
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.NextPage),
+			Handler:     self.handleNextPage,
+			Description: self.c.Tr.NextPage,
+			Tag:         "navigation",
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.GotoTop),
+			Handler:     self.handleGotoTop,
+			Description: self.c.Tr.GotoTop,
+			Tag:         "navigation",
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.GotoBottom),
+			Handler:     self.handleGotoBottom,
+			Description: self.c.Tr.GotoBottom,
+			Tag:         "navigation",
+		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Universal.GoInto),
+			Handler:           self.c.Helpers().Blame.ViewCommitOfSelectedLine,
+			GetDisabledReason: self.selectedLineIsCommitted,
+			Description:       self.c.Tr.ViewBlameCommit,
+			Tooltip:           self.c.Tr.ViewBlameCommitTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Main.BlameParent),
+			Handler:           self.c.Helpers().Blame.BlameParentOfSelectedLine,
+			GetDisabledReason: self.selectedLineIsCommitted,
+			Description:       self.c.Tr.BlameParentCommit,
+			Tooltip:           self.c.Tr.BlameParentCommitTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			// overriding this because we want to read all of the task's output before we start searching
+			Keys:        opts.GetKeys(opts.Config.Universal.StartSearch),
+			Handler:     self.openSearch,
+			Description: self.c.Tr.StartSearch,
+			Tag:         "navigation",
+		},
+		{
+			Keys:            opts.GetKeys(opts.Config.Universal.Return),
+			Handler:         self.escape,
+			Description:     self.c.Tr.ExitFocusedMainView,
+			DisplayOnScreen: true,
+		},
+	}
+}
+
+func (self *BlameController) GetMouseKeybindings(opts types.KeybindingsOpts) []*gocui.ViewMouseBinding {
+	return []*gocui.ViewMouseBinding{
+		{
+			ViewName:    self.context().GetViewName(),
+			Key:         gocui.MouseLeft,
+			Handler:     self.onClick,
+			FocusedView: self.context().GetViewName(),
+		},
+	}
+}
+
+func (self *BlameController) Context() types.Context {
+	return self.context()
+}
+
+func (self *BlameController) context() *context.BlameContext {
+	return self.c.Contexts().Blame
+}
+
+func (self *BlameController) handlePrevLine() error {
+	self.moveSelection(-1)
+	return nil
+}
+
+func (self *BlameController) handleNextLine() error {
+	self.moveSelection(1)
+	return nil
+}
+
+func (self *BlameController) handlePrevPage() error {
+	self.moveSelection(-self.pageSize())
+	return nil
+}
+
+func (self *BlameController) handleNextPage() error {
+	self.moveSelection(self.pageSize())
+	return nil
+}
+
+func (self *BlameController) handleGotoTop() error {
+	self.context().SelectLine(0)
+	return nil
+}
+
+func (self *BlameController) handleGotoBottom() error {
+	if manager := self.c.GetViewBufferManagerForView(self.context().GetView()); manager != nil {
+		manager.ReadToEnd(func() {
+			self.c.OnUIThread(func() error {
+				self.context().SelectLine(self.context().GetView().ViewLinesHeight() - 1)
+				return nil
+			})
+		})
+	}
+
+	return nil
+}
+
+func (self *BlameController) moveSelection(delta int) {
+	newIdx := self.context().GetSelectedLineIdx() + delta
+	self.context().SelectLine(newIdx)
+
+	// The blame output is streamed into the view, so make sure we stay at
+	// least a page ahead of the selection.
+	if newIdx+self.pageSize() >= self.context().GetView().ViewLinesHeight() {
+		if manager := self.c.GetViewBufferManagerForView(self.context().GetView()); manager != nil {
+			manager.ReadLines(self.pageSize())
+		}
+	}
+}
+
+func (self *BlameController) pageSize() int {
+	return max(self.context().GetView().InnerHeight()-1, 1)
+}
+
+func (self *BlameController) onClick(opts gocui.ViewMouseBindingOpts) error {
+	self.context().SelectLine(opts.Y)
+	return nil
+}
+
+func (self *BlameController) openSearch() error {
+	if manager := self.c.GetViewBufferManagerForView(self.context().GetView()); manager != nil {
+		manager.ReadToEnd(func() {
+			self.c.OnUIThread(func() error {
+				return self.c.Helpers().Search.OpenSearchPrompt(self.context())
+			})
+		})
+	}
+
+	return nil
+}
+
+func (self *BlameController) escape() error {
+	self.c.Context().Pop()
+	return nil
+}
+
+func (self *BlameController) selectedLineIsCommitted() *types.DisabledReason {
+	if _, ok := self.c.Helpers().Blame.SelectedLineCommitHash(); !ok {
+		return &types.DisabledReason{Text: self.c.Tr.BlameLineNotCommitted}
+	}
+
+	return nil
+}
@@ -869,6 +869,18 @@ func (self *BranchesController) createPullRequestMenu(selectedBranch *models.Bra
 
 	menuItems = append(menuItems, menuItemsForBranch(selectedBranch)...)
 
+	menuItems = append(menuItems, &types.MenuItem{
+		Label: self.c.Tr.CreatePullRequestViaAPI,
+		OnPress: func() error {
+			if !selectedBranch.IsTrackingRemote() {
+				return errors.New(self.c.Tr.PullRequestNoUpstream)
+			}
+			return self.c.Helpers().CreatePullRequest.Create(selectedBranch)
+		},
+		DisabledReason: self.c.Helpers().CreatePullRequest.GetDisabledReason(),
+		Tooltip:        self.c.Tr.CreatePullRequestViaAPITooltip,
+	})
+
 	return self.c.Menu(types.CreateMenuOptions{Title: fmt.Sprint(self.c.Tr.CreatePullRequestOptions), Items: menuItems})
 }
 
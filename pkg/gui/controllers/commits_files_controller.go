@@ -58,6 +58,13 @@ func (self *CommitFilesController) GetKeybindings(opts types.KeybindingsOpts) []
 			Tooltip:           self.c.Tr.CheckoutCommitFileTooltip,
 			DisplayOnScreen:   true,
 		},
+		{
+			Keys:              opts.GetKeys(opts.Config.CommitFiles.ViewBlame),
+			Handler:           self.withItem(self.viewBlame),
+			GetDisabledReason: self.require(self.singleItemSelected(self.canViewBlame)),
+			Description:       self.c.Tr.ViewBlame,
+			Tooltip:           self.c.Tr.ViewBlameTooltip,
+		},
 		{
 			Keys:              opts.GetKeys(opts.Config.Universal.Remove),
 			Handler:           self.withItems(self.discard),
@@ -177,7 +184,13 @@ func (self *CommitFilesController) GetOnRenderToMain() func() {
 
 		paths := self.pathsForDiff(node)
 		cmdObj := self.c.Git().WorkingTree.ShowFileDiffCmdObj(from, to, reverse, paths, false)
-		task := types.NewRunPtyTask(cmdObj.GetCmd())
+		var task types.UpdateTask
+		if node.File != nil && node.File.IsLfs {
+			plainCmdObj := self.c.Git().WorkingTree.ShowFileDiffCmdObj(from, to, reverse, paths, true)
+			task = self.c.Helpers().Diff.GetUpdateTaskForLfsFileDiff(plainCmdObj, cmdObj)
+		} else {
+			task = types.NewRunPtyTask(cmdObj.GetCmd())
+		}
 
 		self.c.RenderToMainViews(types.RefreshMainOpts{
 			Pair: self.c.MainViewPairs().Normal,
@@ -328,6 +341,32 @@ func (self *CommitFilesController) checkout(node *filetree.CommitFileNode) error
 	return nil
 }
 
+func (self *CommitFilesController) viewBlame(node *filetree.CommitFileNode) error {
+	ref := self.context().GetRef()
+	if refRange := self.context().GetRefRange(); refRange != nil {
+		ref = refRange.To
+	}
+	self.c.Helpers().Blame.OpenBlame(context.BlameState{
+		Filename:      node.GetPath(),
+		Ref:           ref.RefName(),
+		TitleRef:      ref.ShortRefName(),
+		SourceContext: self.context(),
+	})
+	return nil
+}
+
+func (self *CommitFilesController) canViewBlame(node *filetree.CommitFileNode) *types.DisabledReason {
+	if node.File == nil {
+		return &types.DisabledReason{Text: self.c.Tr.CannotBlameDirectory}
+	}
+
+	if node.File.Deleted() {
+		return &types.DisabledReason{Text: self.c.Tr.CannotBlameDeletedFile}
+	}
+
+	return nil
+}
+
 func (self *CommitFilesController) discard(selectedNodes []*filetree.CommitFileNode) error {
 	prompt := lo.Ternary(self.c.Git().Patch.PatchBuilder.Active(),
 		self.c.Tr.DiscardFileChangesPromptResetPatch,
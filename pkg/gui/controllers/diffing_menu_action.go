@@ -5,6 +5,7 @@ import (
 
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/diffing"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 type DiffingMenuAction struct {
@@ -29,6 +30,22 @@ func (self *DiffingMenuAction) Call() error {
 		}...)
 	}
 
+	currentBranch := self.c.Helpers().Refs.GetCheckedOutRef()
+	if currentBranch != nil {
+		for _, name := range names {
+			menuItems = append(menuItems, &types.MenuItem{
+				Label: utils.ResolvePlaceholderString(self.c.Tr.RangeDiffAgainst, map[string]string{
+					"ref":           name,
+					"currentBranch": currentBranch.Name,
+				}),
+				Tooltip: self.c.Tr.RangeDiffTooltip,
+				OnPress: func() error {
+					return self.c.Helpers().RangeDiff.EnterRangeDiffMode(name, self.c.Context().CurrentSide())
+				},
+			})
+		}
+	}
+
 	menuItems = append(menuItems, []*types.MenuItem{
 		{
 			Label: self.c.Tr.EnterRefToDiff,
@@ -48,6 +65,30 @@ func (self *DiffingMenuAction) Call() error {
 		},
 	}...)
 
+	menuItems = append(menuItems, &types.MenuItem{
+		Label:   self.c.Tr.EnterRefToRangeDiff,
+		Tooltip: self.c.Tr.RangeDiffTooltip,
+		OnPress: func() error {
+			parentContext := self.c.Context().CurrentSide()
+			self.c.Prompt(types.PromptOpts{
+				Title:               self.c.Tr.EnterRefName,
+				FindSuggestionsFunc: self.c.Helpers().Suggestions.GetRefsSuggestionsFunc(),
+				HandleConfirm: func(response string) error {
+					return self.c.Helpers().RangeDiff.EnterRangeDiffMode(response, parentContext)
+				},
+			})
+
+			return nil
+		},
+	})
+
+	if self.c.Modes().RangeDiffing.Active() {
+		menuItems = append(menuItems, &types.MenuItem{
+			Label:   self.c.Tr.ExitRangeDiffMode,
+			OnPress: self.c.Helpers().RangeDiff.ExitRangeDiffMode,
+		})
+	}
+
 	if self.c.Modes().Diffing.Active() {
 		menuItems = append(menuItems, []*types.MenuItem{
 			{
@@ -88,6 +88,12 @@ func (self *FilesController) GetKeybindings(opts types.KeybindingsOpts) []*types
 			Description: self.c.Tr.FindBaseCommitForFixup,
 			Tooltip:     self.c.Tr.FindBaseCommitForFixupTooltip,
 		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Files.AbsorbStagedChanges),
+			Handler:     self.c.Helpers().FixupHelper.HandleAbsorbPress,
+			Description: self.c.Tr.AbsorbStagedChanges,
+			Tooltip:     self.c.Tr.AbsorbStagedChangesTooltip,
+		},
 		{
 			Keys:              opts.GetKeys(opts.Config.Universal.Edit),
 			Handler:           self.withItems(self.edit),
@@ -129,6 +135,27 @@ func (self *FilesController) GetKeybindings(opts types.KeybindingsOpts) []*types
 			Tooltip:     self.c.Tr.ViewStashOptionsTooltip,
 			OpensMenu:   true,
 		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Files.ViewSparseCheckoutOptions),
+			Handler:     self.c.Helpers().SparseCheckout.OpenSparseCheckoutMenu,
+			Description: self.c.Tr.ViewSparseCheckoutOptions,
+			Tooltip:     self.c.Tr.ViewSparseCheckoutOptionsTooltip,
+			OpensMenu:   true,
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Files.ApplyMailbox),
+			Handler:     self.c.Helpers().MergeAndRebase.ApplyMailbox,
+			Description: self.c.Tr.ApplyMailbox,
+			Tooltip:     self.c.Tr.ApplyMailboxTooltip,
+		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Files.ViewLfsOptions),
+			Handler:           self.openLfsMenu,
+			GetDisabledReason: self.c.Helpers().Lfs.DisabledReason,
+			Description:       self.c.Tr.ViewLfsOptions,
+			Tooltip:           self.c.Tr.ViewLfsOptionsTooltip,
+			OpensMenu:         true,
+		},
 		{
 			Keys:        opts.GetKeys(opts.Config.Files.ToggleStagedAll),
 			Handler:     self.toggleStagedAll,
@@ -177,6 +204,13 @@ func (self *FilesController) GetKeybindings(opts types.KeybindingsOpts) []*types
 			GetDisabledReason: self.require(self.singleItemSelected()),
 			Description:       self.c.Tr.OpenDiffTool,
 		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Files.ViewBlame),
+			Handler:           self.withItem(self.viewBlame),
+			GetDisabledReason: self.require(self.singleItemSelected(self.canViewBlame)),
+			Description:       self.c.Tr.ViewBlame,
+			Tooltip:           self.c.Tr.ViewBlameTooltip,
+		},
 		{
 			Keys:              opts.GetKeys(opts.Config.Files.OpenMergeOptions),
 			Handler:           self.withItems(self.openMergeConflictMenu),
@@ -315,11 +349,31 @@ func (self *FilesController) GetOnRenderToMain() func() {
 
 			self.c.Helpers().MergeConflicts.ResetMergeState()
 
+			if node.File != nil && node.File.IsCollapsedUntrackedDir {
+				self.c.RenderToMainViews(types.RefreshMainOpts{
+					Pair: self.c.MainViewPairs().Normal,
+					Main: &types.ViewUpdateOpts{
+						Title: self.c.Tr.UnstagedChanges,
+						Task: types.NewRenderStringTask(fmt.Sprintf(self.c.Tr.CollapsedUntrackedDirHint,
+							self.c.UserConfig().Keybinding.Universal.GoInto)),
+					},
+				})
+				return
+			}
+
 			split := self.c.UserConfig().Gui.SplitDiff == "always" || (node.GetHasUnstagedChanges() && node.GetHasStagedChanges())
 			mainShowsStaged := !split && node.GetHasStagedChanges()
 
 			pathOverrides := self.pathOverridesForDiff(node)
-			cmdObj := self.c.Git().WorkingTree.WorktreeFileDiffCmdObj(node, false, mainShowsStaged, pathOverrides)
+			diffTask := func(cached bool) types.UpdateTask {
+				cmdObj := self.c.Git().WorkingTree.WorktreeFileDiffCmdObj(node, false, cached, pathOverrides)
+				if node.File != nil && node.File.IsLfs {
+					plainCmdObj := self.c.Git().WorkingTree.WorktreeFileDiffCmdObj(node, true, cached, pathOverrides)
+					return self.c.Helpers().Diff.GetUpdateTaskForLfsFileDiff(plainCmdObj, cmdObj)
+				}
+				return types.NewRunPtyTask(cmdObj.GetCmd())
+			}
+
 			title := self.c.Tr.UnstagedChanges
 			if mainShowsStaged {
 				title = self.c.Tr.StagedChanges
@@ -327,15 +381,13 @@ func (self *FilesController) GetOnRenderToMain() func() {
 			refreshOpts := types.RefreshMainOpts{
 				Pair: self.c.MainViewPairs().Normal,
 				Main: &types.ViewUpdateOpts{
-					Task:     types.NewRunPtyTask(cmdObj.GetCmd()),
+					Task:     diffTask(mainShowsStaged),
 					SubTitle: self.c.Helpers().Diff.IgnoringWhitespaceSubTitle(),
 					Title:    title,
 				},
 			}
 
 			if split {
-				cmdObj := self.c.Git().WorkingTree.WorktreeFileDiffCmdObj(node, false, true, pathOverrides)
-
 				title := self.c.Tr.StagedChanges
 				if mainShowsStaged {
 					title = self.c.Tr.UnstagedChanges
@@ -344,7 +396,14 @@ func (self *FilesController) GetOnRenderToMain() func() {
 				refreshOpts.Secondary = &types.ViewUpdateOpts{
 					Title:    title,
 					SubTitle: self.c.Helpers().Diff.IgnoringWhitespaceSubTitle(),
-					Task:     types.NewRunPtyTask(cmdObj.GetCmd()),
+					Task:     diffTask(true),
+				}
+			} else if node.File != nil && node.File.ResolvedByRerere {
+				// The diff against the conflicting sides doesn't tell how the
+				// conflict was resolved, so show the recorded resolution too
+				refreshOpts.Secondary = &types.ViewUpdateOpts{
+					Title: self.c.Tr.RerereResolutionTitle,
+					Task:  self.c.Helpers().WorkingTree.RerereResolutionTask(node.File),
 				}
 			}
 
@@ -683,6 +742,10 @@ func (self *FilesController) EnterFile(opts types.OnFocusOpts) error {
 
 	file := node.File
 
+	if file.IsCollapsedUntrackedDir {
+		return self.expandUntrackedDir(file)
+	}
+
 	submoduleConfigs := self.c.Model().Submodules
 	if file.IsSubmodule(submoduleConfigs) {
 		submoduleConfig := file.SubmoduleConfig(submoduleConfigs)
@@ -1095,6 +1158,31 @@ func (self *FilesController) openDiffTool(node *filetree.FileNode) error {
 	)
 }
 
+func (self *FilesController) viewBlame(node *filetree.FileNode) error {
+	// A file that's been deleted from the working tree can still be blamed as
+	// of HEAD
+	ref := lo.Ternary(node.File.Deleted, "HEAD", "")
+	self.c.Helpers().Blame.OpenBlame(context.BlameState{
+		Filename:      node.GetPath(),
+		Ref:           ref,
+		TitleRef:      ref,
+		SourceContext: self.context(),
+	})
+	return nil
+}
+
+func (self *FilesController) canViewBlame(node *filetree.FileNode) *types.DisabledReason {
+	if node.File == nil {
+		return &types.DisabledReason{Text: self.c.Tr.CannotBlameDirectory}
+	}
+
+	if node.File.Added {
+		return &types.DisabledReason{Text: self.c.Tr.CannotBlameUncommittedFile}
+	}
+
+	return nil
+}
+
 func (self *FilesController) switchToMerge() error {
 	file := self.getSelectedFile()
 	if file == nil {
@@ -1161,10 +1249,39 @@ func (self *FilesController) createStashMenu() error {
 				},
 				Keys: menuKey('u'),
 			},
+			{
+				Label:          self.c.Tr.StashSelectedFiles,
+				Tooltip:        self.c.Tr.StashSelectedFilesTooltip,
+				DisabledReason: self.stashSelectedFilesDisabledReason(),
+				OnPress: func() error {
+					selectedNodes, _, _ := self.context().GetSelectedItems()
+					nodes := lo.Map(normalisedSelectedNodes(selectedNodes),
+						func(n *filetree.FileNode, _ int) git_commands.IFileNode { return n })
+					paths := pathsForNodes(nodes)
+					return self.handleStashSave(func(message string) error {
+						return self.c.Git().Stash.StashPaths(message, paths)
+					}, self.c.Tr.Actions.StashSelectedFiles)
+				},
+				Keys: menuKey('f'),
+			},
 		},
 	})
 }
 
+func (self *FilesController) stashSelectedFilesDisabledReason() *types.DisabledReason {
+	nodes, _, _ := self.context().GetSelectedItems()
+	if len(nodes) == 0 {
+		return &types.DisabledReason{Text: self.c.Tr.NoFilesToStash}
+	}
+
+	submodules := self.c.Model().Submodules
+	if findSubmoduleNode(nodes, submodules) != nil {
+		return &types.DisabledReason{Text: self.c.Tr.CantStashSubmoduleSelection}
+	}
+
+	return nil
+}
+
 func (self *FilesController) openMergeConflictMenu(nodes []*filetree.FileNode) error {
 	normalizedNodes := flattenSelectedNodesToFiles(nodes)
 
@@ -1313,6 +1430,15 @@ func (self *FilesController) hasPathStagedChanges(node *filetree.FileNode) bool
 	})
 }
 
+func (self *FilesController) openLfsMenu() error {
+	path := ""
+	if node := self.context().GetSelected(); node != nil && node.File != nil {
+		path = node.File.Path
+	}
+
+	return self.c.Helpers().Lfs.OpenLfsMenu(path)
+}
+
 func (self *FilesController) stash() error {
 	return self.handleStashSave(self.c.Git().Stash.Push, self.c.Tr.Actions.StashAllChanges)
 }
@@ -1334,6 +1460,17 @@ func (self *FilesController) handleToggleDirCollapsed() error {
 	return nil
 }
 
+// expandUntrackedDir lists the files of an untracked directory that we've
+// only shown as a whole so far, because we list untracked files lazily
+func (self *FilesController) expandUntrackedDir(file *models.File) error {
+	self.context().FileTreeViewModel.ExpandUntrackedDir(file.Path)
+
+	return self.c.WithWaitingStatus(self.c.Tr.ListingUntrackedFiles, func(gocui.Task) error {
+		self.c.Refresh(types.RefreshOptions{Scope: []types.RefreshableView{types.FILES}})
+		return nil
+	})
+}
+
 func (self *FilesController) toggleTreeView() error {
 	self.context().FileTreeViewModel.ToggleShowTree()
 
@@ -1573,7 +1710,9 @@ func (self *FilesController) remove(selectedNodes []*filetree.FileNode) error {
 			}
 
 			nodes := lo.Map(selectedNodes, func(n *filetree.FileNode, _ int) git_commands.IFileNode { return n })
-			if err := self.c.Git().WorkingTree.DiscardAllDirChanges(nodes); err != nil {
+			if err := self.c.Git().Journal.Record(self.c.Tr.Actions.DiscardAllChangesInFile, pathsForNodes(nodes), func() error {
+				return self.c.Git().WorkingTree.DiscardAllDirChanges(nodes)
+			}); err != nil {
 				return err
 			}
 
@@ -1599,7 +1738,9 @@ func (self *FilesController) remove(selectedNodes []*filetree.FileNode) error {
 			}
 
 			nodes := lo.Map(selectedNodes, func(n *filetree.FileNode, _ int) git_commands.IFileNode { return n })
-			if err := self.c.Git().WorkingTree.DiscardUnstagedDirChanges(nodes); err != nil {
+			if err := self.c.Git().Journal.Record(self.c.Tr.Actions.DiscardAllUnstagedChangesInFile, pathsForNodes(nodes), func() error {
+				return self.c.Git().WorkingTree.DiscardUnstagedDirChanges(nodes)
+			}); err != nil {
 				return err
 			}
 
@@ -1663,3 +1804,27 @@ func (self *FilesController) isInTreeMode() *types.DisabledReason {
 
 	return nil
 }
+
+// Returns the paths of all files within the given nodes; for renames that
+// includes the old path, so that both sides of the rename get discarded,
+// stashed or recorded in the journal together
+func pathsForNodes(nodes []git_commands.IFileNode) []string {
+	paths := []string{}
+	for _, node := range nodes {
+		_ = node.ForEachFile(func(file *models.File) error {
+			paths = append(paths, file.Names()...)
+			return nil
+		})
+	}
+	return paths
+}
+
+func journalPathsForFiles(files []*models.File, test func(*models.File) bool) []string {
+	paths := []string{}
+	for _, file := range files {
+		if test(file) {
+			paths = append(paths, file.Names()...)
+		}
+	}
+	return paths
+}
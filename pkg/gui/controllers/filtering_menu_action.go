@@ -34,9 +34,13 @@ func (self *FilteringMenuAction) Call() error {
 
 	menuItems := []*types.MenuItem{}
 	tooltip := ""
-	if self.c.Modes().Filtering.Active() {
+	if self.c.Modes().Filtering.GetPath() != "" || self.c.Modes().Filtering.GetAuthor() != "" {
 		tooltip = self.c.Tr.WillCancelExistingFilterTooltip
 	}
+	contentTooltip := ""
+	if self.c.Modes().Filtering.Active() {
+		contentTooltip = self.c.Tr.WillCombineWithExistingFilterTooltip
+	}
 
 	if fileName != "" {
 		menuItems = append(menuItems, &types.MenuItem{
@@ -90,6 +94,51 @@ func (self *FilteringMenuAction) Call() error {
 		Tooltip: tooltip,
 	})
 
+	menuItems = append(menuItems, &types.MenuItem{
+		Label: self.c.Tr.FilterContentOption,
+		OnPress: func() error {
+			self.c.Prompt(types.PromptOpts{
+				Title: self.c.Tr.EnterContent,
+				HandleConfirm: func(response string) error {
+					return self.setFilteringPickaxe(response, false)
+				},
+			})
+
+			return nil
+		},
+		Tooltip: contentTooltip,
+	})
+
+	menuItems = append(menuItems, &types.MenuItem{
+		Label: self.c.Tr.FilterContentRegexOption,
+		OnPress: func() error {
+			self.c.Prompt(types.PromptOpts{
+				Title: self.c.Tr.EnterContentRegex,
+				HandleConfirm: func(response string) error {
+					return self.setFilteringPickaxe(response, true)
+				},
+			})
+
+			return nil
+		},
+		Tooltip: contentTooltip,
+	})
+
+	menuItems = append(menuItems, &types.MenuItem{
+		Label: self.c.Tr.FilterMessageOption,
+		OnPress: func() error {
+			self.c.Prompt(types.PromptOpts{
+				Title: self.c.Tr.EnterMessagePattern,
+				HandleConfirm: func(response string) error {
+					return self.setFilteringMessage(response)
+				},
+			})
+
+			return nil
+		},
+		Tooltip: contentTooltip,
+	})
+
 	if self.c.Modes().Filtering.Active() {
 		menuItems = append(menuItems, &types.MenuItem{
 			Label:   self.c.Tr.ExitFilterMode,
@@ -100,19 +149,36 @@ func (self *FilteringMenuAction) Call() error {
 	return self.c.Menu(types.CreateMenuOptions{Title: self.c.Tr.FilteringMenuTitle, Items: menuItems})
 }
 
+// Filtering by path and by author are mutually exclusive, but either can be
+// combined with filtering by content or message
 func (self *FilteringMenuAction) setFilteringPath(path string) error {
-	self.c.Modes().Filtering.Reset()
+	self.c.Modes().Filtering.SetAuthor("")
 	self.c.Modes().Filtering.SetPath(path)
 	return self.setFiltering()
 }
 
 func (self *FilteringMenuAction) setFilteringAuthor(author string) error {
-	self.c.Modes().Filtering.Reset()
+	self.c.Modes().Filtering.SetPath("")
 	self.c.Modes().Filtering.SetAuthor(author)
 	return self.setFiltering()
 }
 
+func (self *FilteringMenuAction) setFilteringPickaxe(pickaxe string, isRegex bool) error {
+	self.c.Modes().Filtering.SetPickaxe(pickaxe, isRegex)
+	return self.setFiltering()
+}
+
+func (self *FilteringMenuAction) setFilteringMessage(message string) error {
+	self.c.Modes().Filtering.SetMessage(message)
+	return self.setFiltering()
+}
+
 func (self *FilteringMenuAction) setFiltering() error {
+	// e.g. the user confirmed an empty prompt
+	if !self.c.Modes().Filtering.Active() {
+		return self.c.Helpers().Mode.ClearFiltering()
+	}
+
 	self.c.Modes().Filtering.SetSelectedCommitHash(self.c.Contexts().LocalCommits.GetSelectedCommitHash())
 
 	repoState := self.c.State().GetRepoState()
@@ -0,0 +1,116 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+type BlameHelper struct {
+	c *HelperCommon
+
+	subCommitsHelper *SubCommitsHelper
+}
+
+func NewBlameHelper(
+	c *HelperCommon,
+	subCommitsHelper *SubCommitsHelper,
+) *BlameHelper {
+	return &BlameHelper{
+		c:                c,
+		subCommitsHelper: subCommitsHelper,
+	}
+}
+
+func (self *BlameHelper) OpenBlame(state context.BlameState) {
+	self.context().SetState(&state)
+	self.render()
+
+	self.c.Context().Push(self.context(), types.OnFocusOpts{})
+}
+
+// Blames the file as of the parent of the commit that last changed the
+// selected line, so that we can see what the line looked like before.
+func (self *BlameHelper) BlameParentOfSelectedLine() error {
+	hash, ok := self.SelectedLineCommitHash()
+	if !ok {
+		return nil
+	}
+
+	state := self.context().GetState()
+	lineNumber := self.context().GetSelectedLineIdx() + 1
+	parentHash, parentFilename, ok, err := self.c.Git().Blame.GetPreviousVersion(state.Filename, state.Ref, lineNumber)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf(self.c.Tr.NoHistoryBeforeBlameCommit, hash)
+	}
+
+	self.context().SetState(&context.BlameState{
+		Filename:      parentFilename,
+		Ref:           parentHash,
+		TitleRef:      utils.ShortHash(parentHash),
+		SourceContext: state.SourceContext,
+	})
+	self.render()
+	return nil
+}
+
+// Shows the commit that last changed the selected line in the sub-commits
+// view, in place of the side context the blame view was opened from.
+func (self *BlameHelper) ViewCommitOfSelectedLine() error {
+	hash, ok := self.SelectedLineCommitHash()
+	if !ok {
+		return nil
+	}
+
+	commit := models.NewCommit(self.c.Model().HashPool, models.NewCommitOpts{Hash: hash})
+	return self.subCommitsHelper.ViewSubCommits(ViewSubCommitsOpts{
+		Ref:      commit,
+		TitleRef: commit.ShortRefName(),
+		Context:  self.context().GetState().SourceContext,
+	})
+}
+
+// Returns the hash of the commit that last changed the selected line, or
+// ok=false if the line hasn't been committed yet (or hasn't been loaded).
+func (self *BlameHelper) SelectedLineCommitHash() (string, bool) {
+	if self.context().GetState() == nil {
+		return "", false
+	}
+
+	return git_commands.ParseBlameLine(self.context().GetSelectedLine())
+}
+
+func (self *BlameHelper) render() {
+	state := self.context().GetState()
+
+	titleRef := state.TitleRef
+	if state.Ref == "" {
+		titleRef = self.c.Tr.BlameWorkingTree
+	}
+
+	view := self.context().GetView()
+	view.SetOrigin(0, 0)
+	view.SetCursor(0, 0)
+
+	cmdObj := self.c.Git().Blame.BlameFileCmdObj(state.Filename, state.Ref)
+	self.c.RenderToMainViews(types.RefreshMainOpts{
+		Pair: self.c.MainViewPairs().Blame,
+		Main: &types.ViewUpdateOpts{
+			Title:    self.c.Tr.BlameTitle,
+			SubTitle: fmt.Sprintf("%s @ %s", state.Filename, style.FgYellow.Sprint(titleRef)),
+			Task:     types.NewRunCommandTask(cmdObj.GetCmd()),
+		},
+	})
+}
+
+func (self *BlameHelper) context() *context.BlameContext {
+	return self.c.Contexts().Blame
+}
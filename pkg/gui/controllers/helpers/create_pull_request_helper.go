@@ -0,0 +1,267 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// Creates pull requests through the GitHub API. This is only offered as a
+// separate item in the create pull request menu; the create pull request
+// keybinding keeps opening the hosting service's page in the browser.
+
+type CreatePullRequestHelper struct {
+	c           *HelperCommon
+	commits     *CommitsHelper
+	suggestions *SuggestionsHelper
+	host        *HostHelper
+}
+
+func NewCreatePullRequestHelper(
+	c *HelperCommon,
+	commits *CommitsHelper,
+	suggestions *SuggestionsHelper,
+	host *HostHelper,
+) *CreatePullRequestHelper {
+	return &CreatePullRequestHelper{
+		c:           c,
+		commits:     commits,
+		suggestions: suggestions,
+		host:        host,
+	}
+}
+
+// the repo we create pull requests in via the API
+type pullRequestTarget struct {
+	remoteName  string
+	serviceInfo hosting_service.ServiceInfo
+	authToken   string
+}
+
+// GetDisabledReason returns why we can't create pull requests via the API,
+// i.e. because there's no GitHub repo whose pull requests we show, or we don't
+// have a token for it.
+func (self *CreatePullRequestHelper) GetDisabledReason() *types.DisabledReason {
+	if self.getTarget() == nil {
+		return &types.DisabledReason{Text: self.c.Tr.CreatePullRequestViaAPIUnavailable}
+	}
+
+	return nil
+}
+
+// Create creates a pull request from the upstream of the given branch via the
+// GitHub API, prompting for its details first. Check GetDisabledReason
+// before calling this.
+func (self *CreatePullRequestHelper) Create(branch *models.Branch) error {
+	target := self.getTarget()
+	if target == nil {
+		return errors.New(self.c.Tr.CreatePullRequestViaAPIUnavailable)
+	}
+
+	self.commits.OpenCommitMessagePanel(
+		&OpenCommitMessagePanelOpts{
+			CommitIndex:      context.NoCommitIndex,
+			InitialMessage:   self.initialMessage(branch),
+			SummaryTitle:     self.c.Tr.PullRequestSummaryTitle,
+			DescriptionTitle: self.c.Tr.PullRequestDescriptionTitle,
+			PreserveMessage:  false,
+			OnConfirm: func(title string, body string) error {
+				self.promptForBase(branch, target, git_commands.CreatePullRequestOpts{
+					Title: title,
+					Body:  body,
+					Head:  self.head(branch, target),
+				})
+				return nil
+			},
+		},
+	)
+
+	return nil
+}
+
+func (self *CreatePullRequestHelper) promptForBase(branch *models.Branch, target *pullRequestTarget, opts git_commands.CreatePullRequestOpts) {
+	self.c.Prompt(types.PromptOpts{
+		Title:               self.c.Tr.PullRequestBaseBranchTitle,
+		FindSuggestionsFunc: self.suggestions.GetRemoteBranchesForRemoteSuggestionsFunc(target.remoteName),
+		HandleConfirm: func(base string) error {
+			opts.Base = strings.TrimSpace(base)
+			self.promptForReviewers(branch, target, opts)
+			return nil
+		},
+	})
+}
+
+func (self *CreatePullRequestHelper) promptForReviewers(branch *models.Branch, target *pullRequestTarget, opts git_commands.CreatePullRequestOpts) {
+	self.c.Prompt(types.PromptOpts{
+		Title: self.c.Tr.PullRequestReviewersTitle,
+		HandleConfirm: func(reviewers string) error {
+			opts.Reviewers = parseReviewers(reviewers)
+			return self.c.Menu(types.CreateMenuOptions{
+				Title: self.c.Tr.CreatePullRequest,
+				Items: []*types.MenuItem{
+					{
+						Label: self.c.Tr.CreatePullRequestReadyForReview,
+						OnPress: func() error {
+							return self.create(target, opts)
+						},
+					},
+					{
+						Label: self.c.Tr.CreatePullRequestAsDraft,
+						OnPress: func() error {
+							opts.Draft = true
+							return self.create(target, opts)
+						},
+					},
+					{
+						Label: self.c.Tr.CreatePullRequestInBrowserInstead,
+						OnPress: func() error {
+							return self.openInBrowser(branch.UpstreamBranch, opts.Base)
+						},
+					},
+				},
+			})
+		},
+	})
+}
+
+func (self *CreatePullRequestHelper) create(target *pullRequestTarget, opts git_commands.CreatePullRequestOpts) error {
+	return self.c.WithWaitingStatus(self.c.Tr.CreatingPullRequest, func(gocui.Task) error {
+		self.c.LogAction(self.c.Tr.Actions.CreatePullRequest)
+		pr, err := self.c.Git().GitHub.CreatePullRequest(&target.serviceInfo, target.authToken, opts)
+		if pr != nil {
+			self.addPullRequest(pr)
+			self.c.Toast(fmt.Sprintf(self.c.Tr.PullRequestCreated, pr.Number))
+		}
+		return err
+	})
+}
+
+// addPullRequest shows the pull request we just created right away, rather
+// than waiting for the next time we fetch pull requests
+func (self *CreatePullRequestHelper) addPullRequest(pr *models.PullRequest) {
+	self.c.Mutexes().RefreshingPullRequestsMutex.Lock()
+	self.c.Model().PullRequests = append([]*models.PullRequest{pr}, self.c.Model().PullRequests...)
+	self.c.Model().PullRequestsMap = git_commands.GeneratePullRequestMap(
+		self.c.Model().PullRequests,
+		self.c.Model().Branches,
+		self.c.Model().Remotes,
+	)
+	self.c.Model().OpenPullRequests = append([]*models.PullRequest{pr}, self.c.Model().OpenPullRequests...)
+	self.c.Mutexes().RefreshingPullRequestsMutex.Unlock()
+
+	self.c.OnUIThread(func() error {
+		self.c.PostRefreshUpdate(self.c.Contexts().Branches)
+		self.c.PostRefreshUpdate(self.c.Contexts().PullRequests)
+		return nil
+	})
+}
+
+func (self *CreatePullRequestHelper) openInBrowser(from string, to string) error {
+	url, err := self.host.GetPullRequestURL(from, to)
+	if err != nil {
+		return err
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.OpenPullRequest)
+
+	return self.c.OS().OpenLink(url)
+}
+
+// getTarget returns the GitHub repo whose pull requests we show in the pull
+// requests panel, or nil if there is none or we don't have a token for it
+func (self *CreatePullRequestHelper) getTarget() *pullRequestTarget {
+	remoteName := self.c.Model().PullRequestsRemoteName
+	if remoteName == "" {
+		return nil
+	}
+
+	serviceInfo, ok := self.getServiceInfo(remoteName)
+	if !ok || serviceInfo.Provider != "github" {
+		return nil
+	}
+
+	token := self.c.Git().GitHub.GetAuthToken(serviceInfo.WebDomain)
+	if token == "" {
+		return nil
+	}
+
+	return &pullRequestTarget{remoteName: remoteName, serviceInfo: serviceInfo, authToken: token}
+}
+
+func (self *CreatePullRequestHelper) getServiceInfo(remoteName string) (hosting_service.ServiceInfo, bool) {
+	remote, ok := lo.Find(self.c.Model().Remotes, func(remote *models.Remote) bool {
+		return remote.Name == remoteName
+	})
+	if !ok || len(remote.Urls) == 0 {
+		return hosting_service.ServiceInfo{}, false
+	}
+
+	serviceInfo, err := self.c.Git().HostingService.GetServiceInfo(remote.Urls[0])
+	return serviceInfo, err == nil
+}
+
+// head returns the head of the pull request in the form the API expects:
+// just the branch name if the branch is pushed to the target repo, and
+// prefixed with the owner of the fork otherwise
+func (self *CreatePullRequestHelper) head(branch *models.Branch, target *pullRequestTarget) string {
+	if branch.UpstreamRemote == target.remoteName {
+		return branch.UpstreamBranch
+	}
+
+	serviceInfo, ok := self.getServiceInfo(branch.UpstreamRemote)
+	if !ok || strings.EqualFold(serviceInfo.Owner, target.serviceInfo.Owner) {
+		return branch.UpstreamBranch
+	}
+
+	return serviceInfo.Owner + ":" + branch.UpstreamBranch
+}
+
+// initialMessage returns the initial title and description of the pull
+// request, based on the commits that the branch has on top of the main branch
+// that it was forked from. We don't know the base branch yet at this point,
+// since we only prompt for it afterwards.
+func (self *CreatePullRequestHelper) initialMessage(branch *models.Branch) string {
+	var commitMessages []string
+	if base := self.c.Model().MainBranches.GetMergeBase(branch.FullRefName()); base != "" {
+		messages, err := self.c.Git().Commit.GetCommitMessagesInRange(base, branch.FullRefName())
+		if err != nil {
+			self.c.Log.Error(err)
+		}
+		commitMessages = messages
+	}
+
+	return pullRequestMessage(branch.Name, commitMessages)
+}
+
+// pullRequestMessage returns the initial title and description of a pull
+// request, as a commit message. Like GitHub, we use the message of the
+// branch's commit if there's only one, and the branch name otherwise.
+func pullRequestMessage(branchName string, commitMessages []string) string {
+	if len(commitMessages) == 1 {
+		return commitMessages[0]
+	}
+
+	title := strings.NewReplacer("-", " ", "_", " ").Replace(branchName)
+	if title == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(title)
+	return string(unicode.ToUpper(r)) + title[size:]
+}
+
+func parseReviewers(reviewers string) []string {
+	return lo.FilterMap(strings.Split(reviewers, ","), func(reviewer string, _ int) (string, bool) {
+		reviewer = strings.TrimPrefix(strings.TrimSpace(reviewer), "@")
+		return reviewer, reviewer != ""
+	})
+}
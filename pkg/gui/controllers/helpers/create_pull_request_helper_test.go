@@ -0,0 +1,46 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestMessage(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		branchName     string
+		commitMessages []string
+		expected       string
+	}{
+		{
+			name:           "single commit",
+			branchName:     "fix-bug",
+			commitMessages: []string{"Fix the bug\n\nIt was annoying"},
+			expected:       "Fix the bug\n\nIt was annoying",
+		},
+		{
+			name:           "multiple commits",
+			branchName:     "add_new-feature",
+			commitMessages: []string{"one", "two"},
+			expected:       "Add new feature",
+		},
+		{
+			name:           "no commits",
+			branchName:     "feature/über-cool",
+			commitMessages: nil,
+			expected:       "Feature/über cool",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, pullRequestMessage(s.branchName, s.commitMessages))
+		})
+	}
+}
+
+func TestParseReviewers(t *testing.T) {
+	assert.Equal(t, []string{"alice", "bob", "org/team"}, parseReviewers(" alice, @bob,,org/team "))
+	assert.Equal(t, []string{}, parseReviewers(""))
+}
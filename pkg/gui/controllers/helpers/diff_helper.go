@@ -5,11 +5,13 @@ import (
 
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/diffing"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/samber/lo"
 )
 
@@ -77,10 +79,61 @@ func (self *DiffHelper) GetUpdateTaskForRenderingCommitsDiff(commit *models.Comm
 		return types.NewRunPtyTaskWithPrefix(cmdObj.GetCmd(), prefix)
 	}
 
-	cmdObj := self.c.Git().Commit.ShowCmdObj(commit.Hash(), self.FilterPathsForCommit(commit))
+	cmdObj := self.c.Git().Commit.ShowCmdObj(commit.Hash(), self.FilterPathsForCommit(commit), self.c.Model().NotesRef)
 	return types.NewRunPtyTask(cmdObj.GetCmd())
 }
 
+// GetUpdateTaskForLfsFileDiff returns a task that shows a summary of the change
+// to an LFS-tracked file, because the diff of the pointer files that git would
+// show is of little use. plainDiffCmdObj must produce an uncolored diff of just
+// that file; if its output doesn't look like a pointer diff, we render
+// diffCmdObj as usual. Both run in the background.
+func (self *DiffHelper) GetUpdateTaskForLfsFileDiff(plainDiffCmdObj *oscommands.CmdObj, diffCmdObj *oscommands.CmdObj) types.UpdateTask {
+	return types.NewRenderStringFuncTask(func() (string, bool) {
+		diff, err := plainDiffCmdObj.RunWithOutput()
+		if err != nil {
+			self.c.Log.Error(err)
+			return "", false
+		}
+
+		oldPointer, newPointer, ok := git_commands.ParseLfsPointerDiff(diff)
+		if !ok {
+			return "", false
+		}
+
+		return self.lfsDiffSummary(oldPointer, newPointer), true
+	}, diffCmdObj.GetCmd())
+}
+
+func (self *DiffHelper) lfsDiffSummary(oldPointer *git_commands.LfsPointer, newPointer *git_commands.LfsPointer) string {
+	var summary string
+	switch {
+	case oldPointer == nil:
+		summary = utils.ResolvePlaceholderString(self.c.Tr.LfsObjectAdded, map[string]string{
+			"size": utils.FormatBytes(newPointer.Size),
+		})
+	case newPointer == nil:
+		summary = utils.ResolvePlaceholderString(self.c.Tr.LfsObjectDeleted, map[string]string{
+			"size": utils.FormatBytes(oldPointer.Size),
+		})
+	default:
+		summary = utils.ResolvePlaceholderString(self.c.Tr.LfsObjectChanged, map[string]string{
+			"oldSize": utils.FormatBytes(oldPointer.Size),
+			"newSize": utils.FormatBytes(newPointer.Size),
+		})
+	}
+
+	lines := []string{style.FgYellow.Sprint(summary), ""}
+	if oldPointer != nil {
+		lines = append(lines, style.FgRed.Sprint("-"+oldPointer.Oid))
+	}
+	if newPointer != nil {
+		lines = append(lines, style.FgGreen.Sprint("+"+newPointer.Oid))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func (self *DiffHelper) FilterPathsForCommit(commit *models.Commit) []string {
 	filterPath := self.c.Modes().Filtering.GetPath()
 	if filterPath != "" {
@@ -7,22 +7,28 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 )
 
 type FixupHelper struct {
-	c *HelperCommon
+	c                    *HelperCommon
+	mergeAndRebaseHelper *MergeAndRebaseHelper
 }
 
 func NewFixupHelper(
 	c *HelperCommon,
+	mergeAndRebaseHelper *MergeAndRebaseHelper,
 ) *FixupHelper {
 	return &FixupHelper{
-		c: c,
+		c:                    c,
+		mergeAndRebaseHelper: mergeAndRebaseHelper,
 	}
 }
 
@@ -410,3 +416,433 @@ func IsFixupCommit(subject string) (string, bool) {
 
 	return subject, false
 }
+
+// absorbFile is a file section of a zero-context diff of the staged changes,
+// split into its header lines and its hunks
+type absorbFile struct {
+	filename    string
+	header      []string
+	hunks       []*absorbHunk
+	unsupported bool
+}
+
+// absorbHunk is a single hunk of a zero-context diff, together with the commit
+// that it is going to be absorbed into. If target is nil, refusal explains why
+// the hunk stays staged.
+type absorbHunk struct {
+	filename   string
+	oldStart   int
+	newStart   int
+	numDeleted int
+	numAdded   int
+	// the hunk header followed by its body, as they appear in the diff
+	lines []string
+
+	target  *models.Commit
+	refusal *types.DisabledReason
+}
+
+func (self *absorbHunk) location() string {
+	if len(self.lines) == 0 {
+		return self.filename
+	}
+	return fmt.Sprintf("%s:%d", self.filename, max(self.newStart, 1))
+}
+
+// HandleAbsorbPress finds the commit that each staged hunk belongs to and,
+// after showing the assignments, creates one fixup commit per target commit.
+// Hunks that don't belong to exactly one unpushed commit of the current branch
+// are left staged.
+func (self *FixupHelper) HandleAbsorbPress() error {
+	if self.c.Git().Status.WorkingTreeState().Any() {
+		return errors.New(self.c.Tr.AlreadyRebasing)
+	}
+
+	diff, err := self.c.Git().Diff.DiffIndexCmdObj(
+		"--cached", "-U0", "--no-renames", "--ignore-submodules=all", "HEAD", "--",
+	).DontLog().RunWithOutput()
+	if err != nil {
+		return err
+	}
+
+	files := parseAbsorbDiff(diff)
+	if len(files) == 0 {
+		return errors.New(self.c.Tr.NoStagedChangesToAbsorb)
+	}
+
+	if err := self.assignAbsorbTargets(files); err != nil {
+		return err
+	}
+
+	return self.showAbsorbPreview(files)
+}
+
+func (self *FixupHelper) assignAbsorbTargets(files []*absorbFile) error {
+	commits := self.c.Model().Commits
+	branches := self.c.Model().Branches
+	hasUpstream := len(branches) > 0 && branches[0].RemoteBranchStoredLocally()
+	errg := errgroup.Group{}
+
+	for _, file := range files {
+		for _, h := range file.hunks {
+			if file.unsupported {
+				h.refusal = &types.DisabledReason{Text: self.c.Tr.AbsorbUnsupportedFile}
+				continue
+			}
+
+			errg.Go(func() error {
+				hashes, err := self.blameAbsorbHunk(h)
+				if err != nil {
+					return err
+				}
+
+				// Each goroutine only writes to its own hunk, so no locking needed
+				h.target, h.refusal = absorbTarget(commits, hashes, hasUpstream, self.c.Tr)
+				return nil
+			})
+		}
+	}
+
+	return errg.Wait()
+}
+
+// Returns the hashes of the commits that introduced the lines deleted by the
+// hunk; for hunks with only added lines, the ones that introduced the lines
+// around it.
+func (self *FixupHelper) blameAbsorbHunk(h *absorbHunk) ([]string, error) {
+	blame := func(start int, numLines int) (string, error) {
+		return self.c.Git().Blame.BlameLineRange(h.filename, "HEAD", start, numLines)
+	}
+
+	if h.numDeleted > 0 {
+		blameOutput, err := blame(h.oldStart, h.numDeleted)
+		if err != nil {
+			return nil, err
+		}
+		return parseBlameHashes(blameOutput), nil
+	}
+
+	hashes := []string{}
+	if h.oldStart > 0 {
+		blameOutput, err := blame(h.oldStart, 1)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, parseBlameHashes(blameOutput)...)
+	}
+
+	// This fails if the hunk is at the end of the file, in which case only the
+	// line before it counts
+	if blameOutput, err := blame(h.oldStart+1, 1); err == nil {
+		hashes = append(hashes, parseBlameHashes(blameOutput)...)
+	}
+
+	return lo.Uniq(hashes), nil
+}
+
+func parseBlameHashes(blameOutput string) []string {
+	lines := strings.Split(strings.TrimSuffix(blameOutput, "\n"), "\n")
+	hashes := lo.FilterMap(lines, func(line string, _ int) (string, bool) {
+		// boundary commits (e.g. the root commit) are prefixed with a caret
+		hash := strings.TrimPrefix(strings.Split(line, " ")[0], "^")
+		return hash, hash != ""
+	})
+	return lo.Uniq(hashes)
+}
+
+// absorbTarget picks the commit that a hunk blamed on the given hashes is to be
+// absorbed into. It has to be an unpushed commit of the current branch, and
+// there must be only one (not counting fixups for it). If the branch has no
+// upstream, its commits are shown as pushed, but nothing has been pushed yet,
+// so all commits that aren't merged qualify.
+func absorbTarget(commits []*models.Commit, hashes []string, hasUpstream bool, tr *i18n.TranslationSet) (*models.Commit, *types.DisabledReason) {
+	if len(hashes) == 0 {
+		return nil, &types.DisabledReason{Text: tr.AbsorbNoBaseCommit}
+	}
+
+	fullHashes := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		// Hashes of boundary commits are abbreviated in the blame output
+		commit, ok := lo.Find(commits, func(commit *models.Commit) bool {
+			return strings.HasPrefix(commit.Hash(), hash)
+		})
+		isUnpushed := ok && (commit.Status == models.StatusUnpushed ||
+			(!hasUpstream && commit.Status == models.StatusPushed))
+		if !isUnpushed {
+			return nil, &types.DisabledReason{Text: tr.AbsorbBaseCommitNotUnpushed}
+		}
+		fullHashes = append(fullHashes, commit.Hash())
+	}
+
+	candidates := removeFixupCommits(getCommitsForHashes(commits, lo.Uniq(fullHashes)))
+	if len(candidates) > 1 {
+		return nil, &types.DisabledReason{
+			Text:             fmt.Sprintf("%s\n\n%s", tr.AbsorbAmbiguousHunk, getHashesAndSubjects(candidates)),
+			ShowErrorInPanel: true,
+		}
+	}
+
+	return candidates[0], nil
+}
+
+// absorbTargets returns the distinct target commits of the given hunks, oldest
+// first
+func absorbTargets(commits []*models.Commit, files []*absorbFile) []*models.Commit {
+	targetHashes := set.New[string]()
+	for _, file := range files {
+		for _, h := range file.hunks {
+			if h.target != nil {
+				targetHashes.Add(h.target.Hash())
+			}
+		}
+	}
+
+	targets := lo.Filter(commits, func(commit *models.Commit, _ int) bool {
+		return targetHashes.Includes(commit.Hash())
+	})
+	return lo.Reverse(targets)
+}
+
+func (self *FixupHelper) showAbsorbPreview(files []*absorbFile) error {
+	targets := absorbTargets(self.c.Model().Commits, files)
+
+	var noTargetsReason *types.DisabledReason
+	if len(targets) == 0 {
+		noTargetsReason = &types.DisabledReason{Text: self.c.Tr.NoHunksCanBeAbsorbed}
+	}
+
+	menuItems := []*types.MenuItem{
+		{
+			Label:   self.c.Tr.CreateFixupCommitsForAbsorb,
+			Tooltip: self.c.Tr.CreateFixupCommitsForAbsorbTooltip,
+			OnPress: func() error {
+				return self.absorb(files, targets, false)
+			},
+			DisabledReason: noTargetsReason,
+			Keys:           menuKey('f'),
+		},
+		{
+			Label:   self.c.Tr.CreateFixupCommitsAndSquash,
+			Tooltip: self.c.Tr.CreateFixupCommitsAndSquashTooltip,
+			OnPress: func() error {
+				return self.absorb(files, targets, true)
+			},
+			DisabledReason: noTargetsReason,
+			Keys:           menuKey('s'),
+		},
+	}
+
+	section := &types.MenuSection{Title: self.c.Tr.AbsorbHunks}
+	for _, file := range files {
+		for _, h := range file.hunks {
+			menuItems = append(menuItems, self.absorbHunkMenuItem(h, section))
+		}
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: self.c.Tr.AbsorbStagedChanges,
+		Items: menuItems,
+	})
+}
+
+func (self *FixupHelper) absorbHunkMenuItem(h *absorbHunk, section *types.MenuSection) *types.MenuItem {
+	if h.target == nil {
+		return &types.MenuItem{
+			LabelColumns:   []string{h.location(), style.FgRed.Sprint(self.c.Tr.NotAbsorbed)},
+			OnPress:        func() error { return nil },
+			DisabledReason: h.refusal,
+			Section:        section,
+		}
+	}
+
+	return &types.MenuItem{
+		LabelColumns: []string{
+			h.location(),
+			style.FgYellow.Sprint(h.target.ShortHash()),
+			h.target.Name,
+		},
+		Tooltip: self.c.Tr.AbsorbHunkTooltip,
+		OnPress: func() error {
+			_, index, ok := self.findCommit(self.c.Model().Commits, h.target.Hash())
+			if !ok {
+				return nil
+			}
+			self.c.Contexts().LocalCommits.SetSelection(index)
+			self.c.Contexts().LocalCommits.FocusLine(true)
+			self.c.Context().Push(self.c.Contexts().LocalCommits, types.OnFocusOpts{})
+			return nil
+		},
+		Section: section,
+	}
+}
+
+func (self *FixupHelper) absorb(files []*absorbFile, targets []*models.Commit, squash bool) error {
+	return self.c.WithWaitingStatusSync(self.c.Tr.AbsorbingStatus, func() error {
+		self.c.LogAction(self.c.Tr.Actions.AbsorbStagedChanges)
+		if err := self.createFixupCommits(files, targets); err != nil {
+			self.c.Refresh(types.RefreshOptions{Mode: types.SYNC})
+			return err
+		}
+
+		if !squash {
+			self.c.Refresh(types.RefreshOptions{Mode: types.SYNC})
+			return nil
+		}
+
+		err := self.c.Git().Rebase.SquashAllAboveFixupCommits(targets[0])
+		return self.mergeAndRebaseHelper.CheckMergeOrRebaseWithRefreshOptions(
+			err, types.RefreshOptions{Mode: types.SYNC})
+	})
+}
+
+// createFixupCommits commits the hunks of each target separately. To do this
+// without having to adjust the line numbers of the zero-context hunks as HEAD
+// moves along, each fixup commit is made from an index that has all hunks up
+// to and including its target applied to the original HEAD.
+func (self *FixupHelper) createFixupCommits(files []*absorbFile, targets []*models.Commit) error {
+	workingTree := self.c.Git().WorkingTree
+
+	stagedTree, err := workingTree.WriteIndexTree()
+	if err != nil {
+		return err
+	}
+
+	err = self.createFixupCommitsFromTree(files, targets)
+
+	// Put back the original index, whether we succeeded or not. Compared to the
+	// new HEAD, it only contains the hunks that haven't been absorbed.
+	if restoreErr := workingTree.ReadIndexTree(stagedTree); err == nil {
+		err = restoreErr
+	}
+
+	return err
+}
+
+func (self *FixupHelper) createFixupCommitsFromTree(files []*absorbFile, targets []*models.Commit) error {
+	workingTree := self.c.Git().WorkingTree
+
+	if err := workingTree.ReadIndexTree("HEAD"); err != nil {
+		return err
+	}
+	headTree, err := workingTree.WriteIndexTree()
+	if err != nil {
+		return err
+	}
+
+	absorbed := set.New[string]()
+	for _, target := range targets {
+		absorbed.Add(target.Hash())
+		patch := absorbPatch(files, func(h *absorbHunk) bool {
+			return h.target != nil && absorbed.Includes(h.target.Hash())
+		})
+
+		if err := workingTree.ReadIndexTree(headTree); err != nil {
+			return err
+		}
+		if err := self.c.Git().Patch.ApplyPatch(patch, git_commands.ApplyPatchOpts{Cached: true, UnidiffZero: true}); err != nil {
+			return err
+		}
+		if err := self.c.Git().Commit.CreateFixupCommit(target.Hash()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseAbsorbDiff splits a zero-context diff into files and hunks. Files that
+// were added, deleted, or changed in some way other than their text content are
+// marked as unsupported; if there are no hunks at all for such a file, it gets
+// a single placeholder hunk so that it still shows up in the preview.
+func parseAbsorbDiff(diff string) []*absorbFile {
+	if diff == "" {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	hunkHeaderRegexp := regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+	// Added or deleted files, mode changes, and binary files
+	unsupportedHeaderRegexp := regexp.MustCompile(`^(new file mode|deleted file mode|old mode|Binary files|GIT binary patch)`)
+
+	files := []*absorbFile{}
+	var file *absorbFile
+	var currentHunk *absorbHunk
+
+	finishFile := func() {
+		if file == nil {
+			return
+		}
+		if file.unsupported && len(file.hunks) == 0 {
+			file.hunks = []*absorbHunk{{filename: file.filename}}
+		}
+		files = append(files, file)
+	}
+
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git"):
+			finishFile()
+			file = &absorbFile{header: []string{line}}
+			currentHunk = nil
+		case file == nil:
+			continue
+		case strings.HasPrefix(line, "@@ "):
+			match := hunkHeaderRegexp.FindStringSubmatch(line)
+			if match == nil {
+				file.unsupported = true
+				continue
+			}
+			currentHunk = &absorbHunk{
+				filename: file.filename,
+				oldStart: utils.MustConvertToInt(match[1]),
+				newStart: utils.MustConvertToInt(match[2]),
+				lines:    []string{line},
+			}
+			file.hunks = append(file.hunks, currentHunk)
+		case currentHunk != nil:
+			currentHunk.lines = append(currentHunk.lines, line)
+			if strings.HasPrefix(line, "-") {
+				currentHunk.numDeleted++
+			} else if strings.HasPrefix(line, "+") {
+				currentHunk.numAdded++
+			}
+		default:
+			file.header = append(file.header, line)
+			if path, ok := strings.CutPrefix(line, "--- a/"); ok {
+				// For some reason, the line ends with a tab character if the
+				// file name contains spaces
+				file.filename = strings.TrimRight(path, "\t")
+			} else if path, ok := strings.CutPrefix(line, "+++ b/"); ok && file.filename == "" {
+				file.filename = strings.TrimRight(path, "\t")
+			} else if unsupportedHeaderRegexp.MatchString(line) {
+				file.unsupported = true
+			}
+		}
+	}
+	finishFile()
+
+	return files
+}
+
+// absorbPatch builds a zero-context patch out of the hunks for which include
+// returns true
+func absorbPatch(files []*absorbFile, include func(*absorbHunk) bool) string {
+	var result strings.Builder
+	for _, file := range files {
+		hunks := lo.Filter(file.hunks, func(h *absorbHunk, _ int) bool { return include(h) })
+		if len(hunks) == 0 {
+			continue
+		}
+
+		for _, line := range file.header {
+			result.WriteString(line + "\n")
+		}
+		for _, h := range hunks {
+			for _, line := range h.lines {
+				result.WriteString(line + "\n")
+			}
+		}
+	}
+	return result.String()
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -339,3 +340,180 @@ func TestFixupHelper_removeFixupCommits(t *testing.T) {
 		})
 	}
 }
+
+func TestFixupHelper_parseAbsorbDiff(t *testing.T) {
+	diff := `diff --git a/file1.txt b/file1.txt
+index 9ce8efb33..eb246cf98 100644
+--- a/file1.txt
++++ b/file1.txt
+@@ -3 +3 @@ bbb
+-xxx
++yyy
+@@ -8,0 +9,2 @@ ggg
++zzz
++www
+diff --git a/new.txt b/new.txt
+new file mode 100644
+index 000000000..8e27be7d6
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++text
+diff --git a/script.sh b/script.sh
+old mode 100644
+new mode 100755
+`
+	files := parseAbsorbDiff(diff)
+
+	assert.Len(t, files, 3)
+
+	assert.Equal(t, "file1.txt", files[0].filename)
+	assert.False(t, files[0].unsupported)
+	assert.Equal(t, []string{
+		"diff --git a/file1.txt b/file1.txt",
+		"index 9ce8efb33..eb246cf98 100644",
+		"--- a/file1.txt",
+		"+++ b/file1.txt",
+	}, files[0].header)
+	assert.Equal(t, []*absorbHunk{
+		{
+			filename:   "file1.txt",
+			oldStart:   3,
+			newStart:   3,
+			numDeleted: 1,
+			numAdded:   1,
+			lines:      []string{"@@ -3 +3 @@ bbb", "-xxx", "+yyy"},
+		},
+		{
+			filename: "file1.txt",
+			oldStart: 8,
+			newStart: 9,
+			numAdded: 2,
+			lines:    []string{"@@ -8,0 +9,2 @@ ggg", "+zzz", "+www"},
+		},
+	}, files[0].hunks)
+
+	assert.Equal(t, "new.txt", files[1].filename)
+	assert.True(t, files[1].unsupported)
+	assert.Len(t, files[1].hunks, 1)
+	assert.Equal(t, "new.txt:1", files[1].hunks[0].location())
+
+	assert.True(t, files[2].unsupported)
+	assert.Equal(t, []*absorbHunk{{}}, files[2].hunks)
+
+	assert.Empty(t, parseAbsorbDiff(""))
+}
+
+func TestFixupHelper_absorbPatch(t *testing.T) {
+	files := parseAbsorbDiff(`diff --git a/file1.txt b/file1.txt
+index 9ce8efb33..eb246cf98 100644
+--- a/file1.txt
++++ b/file1.txt
+@@ -3 +3 @@ bbb
+-xxx
++yyy
+@@ -8,0 +9 @@ ggg
++zzz
+\ No newline at end of file
+diff --git a/file2.txt b/file2.txt
+index 9ce8efb33..eb246cf98 100644
+--- a/file2.txt
++++ b/file2.txt
+@@ -1 +0,0 @@
+-aaa
+`)
+
+	patch := absorbPatch(files, func(h *absorbHunk) bool { return h.numAdded > 0 && h.numDeleted == 0 })
+	assert.Equal(t, `diff --git a/file1.txt b/file1.txt
+index 9ce8efb33..eb246cf98 100644
+--- a/file1.txt
++++ b/file1.txt
+@@ -8,0 +9 @@ ggg
++zzz
+\ No newline at end of file
+`, patch)
+
+	assert.Equal(t, "", absorbPatch(files, func(*absorbHunk) bool { return false }))
+}
+
+func TestFixupHelper_absorbTarget(t *testing.T) {
+	hashPool := &utils.StringPool{}
+	tr := i18n.EnglishTranslationSet()
+
+	commits := []*models.Commit{
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "fff1234", Name: "fixup! Feature", Status: models.StatusUnpushed}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "eee1234", Name: "Feature", Status: models.StatusUnpushed}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "ddd1234", Name: "Other feature", Status: models.StatusUnpushed}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "ccc1234", Name: "Pushed", Status: models.StatusPushed}),
+		models.NewCommit(hashPool, models.NewCommitOpts{Hash: "bbb1234", Name: "Merged", Status: models.StatusMerged}),
+	}
+
+	scenarios := []struct {
+		name            string
+		hashes          []string
+		hasUpstream     bool
+		expectedHash    string
+		expectedRefusal string
+	}{
+		{
+			name:            "no hashes",
+			hashes:          []string{},
+			expectedRefusal: tr.AbsorbNoBaseCommit,
+		},
+		{
+			name:         "single unpushed commit",
+			hashes:       []string{"ddd1234"},
+			expectedHash: "ddd1234",
+		},
+		{
+			name:         "commit and its fixup",
+			hashes:       []string{"eee1234", "fff1234"},
+			expectedHash: "eee1234",
+		},
+		{
+			name:            "two unrelated commits",
+			hashes:          []string{"ddd1234", "eee1234"},
+			expectedRefusal: tr.AbsorbAmbiguousHunk + "\n\neee1234 Feature\nddd1234 Other feature",
+		},
+		{
+			name:            "pushed commit",
+			hashes:          []string{"ccc1234"},
+			hasUpstream:     true,
+			expectedRefusal: tr.AbsorbBaseCommitNotUnpushed,
+		},
+		{
+			name:         "commit shown as pushed on a branch without upstream",
+			hashes:       []string{"ccc1234"},
+			hasUpstream:  false,
+			expectedHash: "ccc1234",
+		},
+		{
+			name:            "unpushed and merged commit",
+			hashes:          []string{"ddd1234", "bbb1234"},
+			expectedRefusal: tr.AbsorbBaseCommitNotUnpushed,
+		},
+		{
+			name:         "abbreviated hash of a boundary commit",
+			hashes:       []string{"ddd12"},
+			expectedHash: "ddd1234",
+		},
+		{
+			name:            "commit not in the list",
+			hashes:          []string{"aaa1234"},
+			expectedRefusal: tr.AbsorbBaseCommitNotUnpushed,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			target, refusal := absorbTarget(commits, s.hashes, s.hasUpstream, tr)
+			if s.expectedRefusal != "" {
+				assert.Nil(t, target)
+				assert.Equal(t, s.expectedRefusal, refusal.Text)
+			} else {
+				assert.Nil(t, refusal)
+				assert.Equal(t, s.expectedHash, target.Hash())
+			}
+		})
+	}
+}
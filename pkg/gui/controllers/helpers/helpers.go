@@ -26,17 +26,20 @@ type Helpers struct {
 	Tags           *TagsHelper
 	MergeAndRebase *MergeAndRebaseHelper
 	MergeConflicts *MergeConflictsHelper
-	CherryPick     *CherryPickHelper
-	Host           *HostHelper
-	PatchBuilding  *PatchBuildingHelper
-	Staging        *StagingHelper
-	GPG            *GpgHelper
-	Upstream       *UpstreamHelper
-	AmendHelper    *AmendHelper
-	FixupHelper    *FixupHelper
-	Commits        *CommitsHelper
-	SuspendResume  *SuspendResumeHelper
-	Snake          *SnakeHelper
+	// for resolving a conflict line by line with the three sides next to each other
+	MergeConflictEditor *MergeConflictEditorHelper
+	CherryPick          *CherryPickHelper
+	Host                *HostHelper
+	CreatePullRequest   *CreatePullRequestHelper
+	PatchBuilding       *PatchBuildingHelper
+	Staging             *StagingHelper
+	GPG                 *GpgHelper
+	Upstream            *UpstreamHelper
+	AmendHelper         *AmendHelper
+	FixupHelper         *FixupHelper
+	Commits             *CommitsHelper
+	SuspendResume       *SuspendResumeHelper
+	Snake               *SnakeHelper
 	// lives in context package because our contexts need it to render to main
 	Diff              *DiffHelper
 	Repos             *ReposHelper
@@ -53,42 +56,56 @@ type Helpers struct {
 	Search            *SearchHelper
 	Worktree          *WorktreeHelper
 	SubCommits        *SubCommitsHelper
+	RangeDiff         *RangeDiffHelper
+	Notes             *NotesHelper
+	SparseCheckout    *SparseCheckoutHelper
+	Lfs               *LfsHelper
+	Blame             *BlameHelper
+	LineHistory       *LineHistoryHelper
 }
 
 func NewStubHelpers() *Helpers {
 	return &Helpers{
-		Refs:              &RefsHelper{},
-		Bisect:            &BisectHelper{},
-		Suggestions:       &SuggestionsHelper{},
-		Files:             &FilesHelper{},
-		WorkingTree:       &WorkingTreeHelper{},
-		Tags:              &TagsHelper{},
-		MergeAndRebase:    &MergeAndRebaseHelper{},
-		MergeConflicts:    &MergeConflictsHelper{},
-		CherryPick:        &CherryPickHelper{},
-		Host:              &HostHelper{},
-		PatchBuilding:     &PatchBuildingHelper{},
-		Staging:           &StagingHelper{},
-		GPG:               &GpgHelper{},
-		Upstream:          &UpstreamHelper{},
-		AmendHelper:       &AmendHelper{},
-		FixupHelper:       &FixupHelper{},
-		Commits:           &CommitsHelper{},
-		Snake:             &SnakeHelper{},
-		Diff:              &DiffHelper{},
-		Repos:             &ReposHelper{},
-		RecordDirectory:   &RecordDirectoryHelper{},
-		Update:            &UpdateHelper{},
-		Window:            &WindowHelper{},
-		View:              &ViewHelper{},
-		Refresh:           &RefreshHelper{},
-		Confirmation:      &ConfirmationHelper{},
-		Mode:              &ModeHelper{},
-		AppStatus:         &AppStatusHelper{},
-		InlineStatus:      &InlineStatusHelper{},
-		WindowArrangement: &WindowArrangementHelper{},
-		Search:            &SearchHelper{},
-		Worktree:          &WorktreeHelper{},
-		SubCommits:        &SubCommitsHelper{},
+		Refs:                &RefsHelper{},
+		Bisect:              &BisectHelper{},
+		Suggestions:         &SuggestionsHelper{},
+		Files:               &FilesHelper{},
+		WorkingTree:         &WorkingTreeHelper{},
+		Tags:                &TagsHelper{},
+		MergeAndRebase:      &MergeAndRebaseHelper{},
+		MergeConflicts:      &MergeConflictsHelper{},
+		MergeConflictEditor: &MergeConflictEditorHelper{},
+		CherryPick:          &CherryPickHelper{},
+		Host:                &HostHelper{},
+		CreatePullRequest:   &CreatePullRequestHelper{},
+		PatchBuilding:       &PatchBuildingHelper{},
+		Staging:             &StagingHelper{},
+		GPG:                 &GpgHelper{},
+		Upstream:            &UpstreamHelper{},
+		AmendHelper:         &AmendHelper{},
+		FixupHelper:         &FixupHelper{},
+		Commits:             &CommitsHelper{},
+		Snake:               &SnakeHelper{},
+		Diff:                &DiffHelper{},
+		Repos:               &ReposHelper{},
+		RecordDirectory:     &RecordDirectoryHelper{},
+		Update:              &UpdateHelper{},
+		Window:              &WindowHelper{},
+		View:                &ViewHelper{},
+		Refresh:             &RefreshHelper{},
+		Confirmation:        &ConfirmationHelper{},
+		Mode:                &ModeHelper{},
+		AppStatus:           &AppStatusHelper{},
+		InlineStatus:        &InlineStatusHelper{},
+		WindowArrangement:   &WindowArrangementHelper{},
+		Search:              &SearchHelper{},
+		Worktree:            &WorktreeHelper{},
+		SubCommits:          &SubCommitsHelper{},
+		RangeDiff:           &RangeDiffHelper{},
+		Notes:               &NotesHelper{},
+		SparseCheckout:      &SparseCheckoutHelper{},
+		Lfs:                 &LfsHelper{},
+		Blame:               &BlameHelper{},
+		LineHistory:         &LineHistoryHelper{},
 	}
 }
@@ -0,0 +1,170 @@
+package helpers
+
+import (
+	"errors"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+type LfsHelper struct {
+	c *HelperCommon
+}
+
+func NewLfsHelper(c *HelperCommon) *LfsHelper {
+	return &LfsHelper{
+		c: c,
+	}
+}
+
+func (self *LfsHelper) DisabledReason() *types.DisabledReason {
+	if !self.c.Git().Lfs.IsInstalled() {
+		return &types.DisabledReason{Text: self.c.Tr.LfsNotInstalled}
+	}
+
+	return nil
+}
+
+// OpenLfsMenu shows the LFS options. path is the file that the lock action
+// applies to; pass an empty string if no single file is selected.
+func (self *LfsHelper) OpenLfsMenu(path string) error {
+	var lockDisabledReason *types.DisabledReason
+	if path == "" {
+		lockDisabledReason = &types.DisabledReason{Text: self.c.Tr.SelectFileToLock}
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: self.c.Tr.Lfs,
+		Items: []*types.MenuItem{
+			{
+				Label:   self.c.Tr.LockLfsFile,
+				Tooltip: self.c.Tr.LockLfsFileTooltip,
+				OnPress: func() error {
+					return self.c.WithWaitingStatus(self.c.Tr.LockingLfsFileStatus, func(task gocui.Task) error {
+						self.c.LogAction(self.c.Tr.Actions.LockLfsFile)
+						return self.c.Git().Lfs.Lock(task, path)
+					})
+				},
+				DisabledReason: lockDisabledReason,
+				Keys:           menuKey('l'),
+			},
+			{
+				Label:     self.c.Tr.ViewLfsLocks,
+				Tooltip:   self.c.Tr.ViewLfsLocksTooltip,
+				OnPress:   self.openLocksMenu,
+				OpensMenu: true,
+				Keys:      menuKey('v'),
+			},
+			{
+				Label:   self.c.Tr.FetchLfsObjects,
+				Tooltip: self.c.Tr.FetchLfsObjectsTooltip,
+				OnPress: func() error {
+					return self.c.WithWaitingStatus(self.c.Tr.FetchingLfsObjectsStatus, func(task gocui.Task) error {
+						self.c.LogAction(self.c.Tr.Actions.FetchLfsObjects)
+						return self.c.Git().Lfs.Fetch(task)
+					})
+				},
+				Keys: menuKey('f'),
+			},
+			{
+				Label:   self.c.Tr.PullLfsObjects,
+				Tooltip: self.c.Tr.PullLfsObjectsTooltip,
+				OnPress: func() error {
+					return self.c.WithWaitingStatus(self.c.Tr.PullingLfsObjectsStatus, func(task gocui.Task) error {
+						self.c.LogAction(self.c.Tr.Actions.PullLfsObjects)
+						err := self.c.Git().Lfs.Pull(task)
+						self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.FILES}})
+						return err
+					})
+				},
+				Keys: menuKey('p'),
+			},
+			{
+				Label:   self.c.Tr.PruneLfsObjects,
+				Tooltip: self.c.Tr.PruneLfsObjectsTooltip,
+				OnPress: self.prune,
+				Keys:    menuKey('P'),
+			},
+		},
+	})
+}
+
+func (self *LfsHelper) openLocksMenu() error {
+	return self.c.WithWaitingStatus(self.c.Tr.LoadingLfsLocksStatus, func(gocui.Task) error {
+		locks, err := self.c.Git().Lfs.GetLocks()
+		if err != nil {
+			return err
+		}
+
+		if len(locks) == 0 {
+			return errors.New(self.c.Tr.NoLfsLocks)
+		}
+
+		self.c.OnUIThread(func() error {
+			return self.c.Menu(types.CreateMenuOptions{
+				Title: self.c.Tr.LfsLocks,
+				Items: lo.Map(locks, func(lock *models.LfsLock, _ int) *types.MenuItem {
+					return &types.MenuItem{
+						LabelColumns: []string{
+							lock.Path,
+							style.FgCyan.Sprint(lock.Owner),
+							style.FgBlue.Sprint(utils.UnixToTimeAgo(lock.LockedAt.Unix())),
+						},
+						OnPress: func() error {
+							return self.openUnlockMenu(lock)
+						},
+					}
+				}),
+			})
+		})
+
+		return nil
+	})
+}
+
+func (self *LfsHelper) openUnlockMenu(lock *models.LfsLock) error {
+	unlock := func(force bool) func() error {
+		return func() error {
+			return self.c.WithWaitingStatus(self.c.Tr.UnlockingLfsFileStatus, func(task gocui.Task) error {
+				self.c.LogAction(self.c.Tr.Actions.UnlockLfsFile)
+				return self.c.Git().Lfs.Unlock(task, lock.ID, force)
+			})
+		}
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: lock.Path,
+		Items: []*types.MenuItem{
+			{
+				Label:   self.c.Tr.UnlockLfsFile,
+				OnPress: unlock(false),
+				Keys:    menuKey('u'),
+			},
+			{
+				Label:   self.c.Tr.ForceUnlockLfsFile,
+				Tooltip: self.c.Tr.ForceUnlockLfsFileTooltip,
+				OnPress: unlock(true),
+				Keys:    menuKey('f'),
+			},
+		},
+	})
+}
+
+func (self *LfsHelper) prune() error {
+	self.c.Confirm(types.ConfirmOpts{
+		Title:  self.c.Tr.PruneLfsObjects,
+		Prompt: self.c.Tr.PruneLfsObjectsPrompt,
+		HandleConfirm: func() error {
+			return self.c.WithWaitingStatus(self.c.Tr.PruningLfsObjectsStatus, func(gocui.Task) error {
+				self.c.LogAction(self.c.Tr.Actions.PruneLfsObjects)
+				return self.c.Git().Lfs.Prune()
+			})
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package helpers
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// Shows the history of the lines selected in the staging or patch building
+// view, using `git log -L`.
+type LineHistoryHelper struct {
+	c                *HelperCommon
+	subCommitsHelper *SubCommitsHelper
+}
+
+func NewLineHistoryHelper(c *HelperCommon, subCommitsHelper *SubCommitsHelper) *LineHistoryHelper {
+	return &LineHistoryHelper{
+		c:                c,
+		subCommitsHelper: subCommitsHelper,
+	}
+}
+
+type lineHistoryStart struct {
+	// the revision that the line numbers of lineRange refer to
+	ref       string
+	lineRange *git_commands.LineRange
+	// the context to return to when leaving the history
+	sourceContext types.Context
+}
+
+func (self *LineHistoryHelper) ViewLineHistory(patchExplorerContext types.IPatchExplorerContext) error {
+	start, _ := self.startForSelection(patchExplorerContext)
+	if start == nil {
+		return nil
+	}
+
+	commit := models.NewCommit(self.c.Model().HashPool, models.NewCommitOpts{Hash: start.ref})
+	return self.subCommitsHelper.ViewSubCommits(ViewSubCommitsOpts{
+		Ref:       commit,
+		TitleRef:  start.lineRange.String(),
+		Context:   start.sourceContext,
+		LineRange: start.lineRange,
+	})
+}
+
+func (self *LineHistoryHelper) GetDisabledReason(patchExplorerContext types.IPatchExplorerContext) *types.DisabledReason {
+	_, disabledReason := self.startForSelection(patchExplorerContext)
+	return disabledReason
+}
+
+func (self *LineHistoryHelper) startForSelection(patchExplorerContext types.IPatchExplorerContext) (*lineHistoryStart, *types.DisabledReason) {
+	patchExplorerContext.GetMutex().Lock()
+	defer patchExplorerContext.GetMutex().Unlock()
+
+	state := patchExplorerContext.GetState()
+	if state == nil {
+		return nil, nil
+	}
+
+	switch patchExplorerContext.GetKey() {
+	case context.STAGING_MAIN_CONTEXT_KEY, context.STAGING_SECONDARY_CONTEXT_KEY:
+		file := self.c.Contexts().Files.GetSelectedFile()
+		if file == nil {
+			return nil, nil
+		}
+
+		// The unstaged diff is relative to the index, so its old line numbers
+		// only match those of HEAD if nothing in the file is staged.
+		unstaged := patchExplorerContext.GetKey() == context.STAGING_MAIN_CONTEXT_KEY
+		if unstaged && file.HasStagedChanges {
+			return nil, &types.DisabledReason{Text: self.c.Tr.LineHistoryUnavailableWithStagedChanges}
+		}
+
+		path := file.Path
+		if file.IsRename() {
+			path = file.PreviousPath
+		}
+
+		start, end, ok := state.SelectedFileLineRange(false)
+		if !ok {
+			return nil, &types.DisabledReason{Text: self.c.Tr.SelectedLinesHaveNoHistory}
+		}
+
+		return &lineHistoryStart{
+			ref:           "HEAD",
+			lineRange:     &git_commands.LineRange{Path: path, Start: start, End: end},
+			sourceContext: self.c.Contexts().Files,
+		}, nil
+
+	case context.PATCH_BUILDING_MAIN_CONTEXT_KEY:
+		path := self.c.Contexts().CommitFiles.GetSelectedPath()
+		if path == "" {
+			return nil, nil
+		}
+
+		from, to := self.c.Contexts().CommitFiles.GetFromAndToForDiff()
+		from, reverse := self.c.Modes().Diffing.GetFromAndReverseArgsForDiff(from)
+		oldRef, newRef := from, to
+		if reverse {
+			oldRef, newRef = to, from
+		}
+
+		// Prefer tracing from the newer revision so that the commit we're
+		// looking at is part of the history; if only deleted lines are
+		// selected we have to start from the older one instead.
+		if start, end, ok := state.SelectedFileLineRange(true); ok {
+			return &lineHistoryStart{
+				ref:           newRef,
+				lineRange:     &git_commands.LineRange{Path: path, Start: start, End: end},
+				sourceContext: self.c.Contexts().CommitFiles,
+			}, nil
+		}
+		if start, end, ok := state.SelectedFileLineRange(false); ok {
+			return &lineHistoryStart{
+				ref:           oldRef,
+				lineRange:     &git_commands.LineRange{Path: path, Start: start, End: end},
+				sourceContext: self.c.Contexts().CommitFiles,
+			}, nil
+		}
+		return nil, &types.DisabledReason{Text: self.c.Tr.SelectedLinesHaveNoHistory}
+	}
+
+	return nil, nil
+}
@@ -262,6 +262,60 @@ func (self *MergeAndRebaseHelper) PromptToContinueRebase() error {
 	return nil
 }
 
+// ApplyMailbox prompts for an mbox file (or a directory of patch files as
+// written by `git format-patch`) and applies its patches with `git am`. If a
+// patch conflicts, the user can resolve the conflicts and continue, skip, or
+// abort just like in a rebase.
+func (self *MergeAndRebaseHelper) ApplyMailbox() error {
+	self.c.Prompt(types.PromptOpts{
+		Title: self.c.Tr.MailboxPath,
+		HandleConfirm: func(response string) error {
+			if response == "" {
+				return nil
+			}
+
+			paths, err := mailboxPaths(response)
+			if err != nil {
+				return err
+			}
+			if len(paths) == 0 {
+				return errors.New(utils.ResolvePlaceholderString(self.c.Tr.NoPatchFilesInDirectory,
+					map[string]string{"dir": response}))
+			}
+
+			self.c.LogAction(self.c.Tr.Actions.ApplyMailbox)
+			return self.c.WithWaitingStatus(self.c.Tr.ApplyingPatchesStatus, func(gocui.Task) error {
+				err := self.c.Git().Patch.ApplyMailbox(paths)
+				return self.CheckMergeOrRebase(err)
+			})
+		},
+	})
+
+	return nil
+}
+
+// For a directory, returns the patch files in it in the order in which they
+// need to be applied, leaving out the cover letter because it contains no
+// patch.
+func mailboxPaths(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	patchFiles, err := filepath.Glob(filepath.Join(path, "*.patch"))
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(patchFiles, func(patchFile string, _ int) bool {
+		return filepath.Base(patchFile) != "0000-cover-letter.patch"
+	}), nil
+}
+
 func (self *MergeAndRebaseHelper) RebaseOntoRef(ref string) error {
 	checkedOutBranch := self.c.Model().Branches[0]
 	checkedOutBranchName := checkedOutBranch.Name
@@ -0,0 +1,179 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// Apart from Open, the methods of this helper expect the caller to hold the
+// mutex of the merge conflicts context, because the editor works on that
+// context's state.
+type MergeConflictEditorHelper struct {
+	c *HelperCommon
+
+	mergeConflictsHelper *MergeConflictsHelper
+}
+
+func NewMergeConflictEditorHelper(
+	c *HelperCommon,
+	mergeConflictsHelper *MergeConflictsHelper,
+) *MergeConflictEditorHelper {
+	return &MergeConflictEditorHelper{
+		c:                    c,
+		mergeConflictsHelper: mergeConflictsHelper,
+	}
+}
+
+// Opens the three-way editor on the selected conflict of the merge conflicts
+// context
+func (self *MergeConflictEditorHelper) Open() error {
+	if err := self.loadForOpen(); err != nil {
+		return err
+	}
+
+	self.c.Context().Push(self.context(), types.OnFocusOpts{})
+	return nil
+}
+
+func (self *MergeConflictEditorHelper) loadForOpen() error {
+	self.c.Contexts().MergeConflicts.GetMutex().Lock()
+	defer self.c.Contexts().MergeConflicts.GetMutex().Unlock()
+
+	path := self.mergeState().GetPath()
+
+	// We re-merge the index stages so that we know what the common ancestor of
+	// each conflict looked like, even if the file was written without it. If
+	// any stage is missing, e.g. because the file was added on both sides or
+	// deleted on one, there's no common ancestor to show.
+	stages, err := self.c.Git().WorkingTree.UnmergedStages(path)
+	if err != nil {
+		return err
+	}
+	var remerged []mergeconflicts.ConflictSides
+	if lo.Every(stages, []int{1, 2, 3}) {
+		markerSize := self.mergeState().MarkerSize()
+		remergedContent, err := self.c.Git().WorkingTree.MergeStages(path, git_commands.MergeStagesOpts{WithBase: true, ConflictMarkerSize: markerSize})
+		if err != nil {
+			return err
+		}
+		remerged = mergeconflicts.FindConflictSides(remergedContent, markerSize)
+	}
+	self.context().SetRemerged(path, remerged)
+
+	self.LoadSelectedConflict()
+	return nil
+}
+
+// Starts resolving the selected conflict of the merge conflicts context from
+// scratch
+func (self *MergeConflictEditorHelper) LoadSelectedConflict() {
+	mergeState := self.mergeState()
+	sides, ok := mergeState.CurrentConflictSides()
+	if !ok {
+		self.context().SetState(nil)
+		return
+	}
+
+	remerged, _ := self.context().GetRemerged(mergeState.GetPath())
+	sides = sides.WithBaseFrom(remerged, mergeState.ConflictIndex(), mergeState.ConflictCount())
+	self.context().SetState(mergeconflicts.NewThreeWayState(sides))
+}
+
+func (self *MergeConflictEditorHelper) Render() {
+	state := self.context().GetState()
+	if state == nil {
+		return
+	}
+
+	view := self.context().GetView()
+	titles := map[mergeconflicts.Pane]string{
+		mergeconflicts.BASE_PANE:   self.c.Tr.ThreeWayMergeBase,
+		mergeconflicts.OURS_PANE:   self.c.Tr.ThreeWayMergeOurs,
+		mergeconflicts.THEIRS_PANE: self.c.Tr.ThreeWayMergeTheirs,
+	}
+	panes := mergeconflicts.ColoredThreeWayPanes(
+		state,
+		view.InnerWidth(),
+		titles,
+		self.c.Tr.ThreeWayMergeNoBase,
+		self.c.Context().IsCurrent(self.context()),
+	)
+	// keep the selected line (which comes after the header line) in the middle
+	originY := max(0, state.SelectedLineIdx()+1-view.InnerHeight()/2)
+
+	result := mergeconflicts.ColoredThreeWayResult(state)
+	if len(state.Result()) == 0 {
+		result = style.FgBlack.SetBold().Sprint(self.c.Tr.ThreeWayMergeEmptyResult)
+	}
+
+	mergeState := self.mergeState()
+	self.c.RenderToMainViews(types.RefreshMainOpts{
+		Pair: self.c.MainViewPairs().MergeConflictEditor,
+		Main: &types.ViewUpdateOpts{
+			Title:    self.c.Tr.ThreeWayMergeTitle,
+			SubTitle: fmt.Sprintf("%s (%d/%d)", mergeState.GetPath(), mergeState.ConflictIndex()+1, mergeState.ConflictCount()),
+			Task:     types.NewRenderStringWithScrollTask(panes, 0, originY),
+		},
+		Secondary: &types.ViewUpdateOpts{
+			Title: self.c.Tr.ThreeWayMergeResult,
+			Task:  types.NewRenderStringTask(result),
+		},
+	})
+}
+
+// Replaces the selected conflict in the file with the result built so far, and
+// moves on to the next conflict, or to the files panel if that was the last one
+func (self *MergeConflictEditorHelper) Apply() error {
+	state := self.context().GetState()
+	if state == nil {
+		return nil
+	}
+
+	mergeState := self.mergeState()
+	ok, content := mergeState.ContentAfterConflictReplaced(state.Result())
+	if !ok {
+		return nil
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.ResolveConflictInThreeWayEditor)
+	mergeState.PushContent(content)
+	if err := os.WriteFile(mergeState.GetPath(), []byte(content), 0o644); err != nil {
+		return err
+	}
+
+	if mergeState.AllConflictsResolved() {
+		self.context().SetState(nil)
+		self.mergeConflictsHelper.resetMergeState()
+		// doing this in a separate UI thread so that we're no longer holding the
+		// lock by the time the files panel renders the selected file
+		self.c.OnUIThread(func() error {
+			if self.c.Context().IsCurrent(self.context()) {
+				self.c.Context().Push(self.c.Contexts().Files, types.OnFocusOpts{})
+			}
+			// as part of refreshing files, we handle the situation where a file has had
+			// its merge conflicts resolved.
+			self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.FILES}})
+			return nil
+		})
+		return nil
+	}
+
+	self.LoadSelectedConflict()
+	self.Render()
+	return nil
+}
+
+func (self *MergeConflictEditorHelper) mergeState() *mergeconflicts.State {
+	return self.c.Contexts().MergeConflicts.GetState()
+}
+
+func (self *MergeConflictEditorHelper) context() *context.MergeConflictEditorContext {
+	return self.c.Contexts().MergeConflictEditor
+}
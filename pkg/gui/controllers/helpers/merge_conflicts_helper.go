@@ -1,8 +1,11 @@
 package helpers
 
 import (
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
 )
 
 type MergeConflictsHelper struct {
@@ -34,11 +37,34 @@ func (self *MergeConflictsHelper) setMergeStateWithoutLock(path string) (bool, e
 		self.context().SetUserScrolling(false)
 	}
 
-	self.context().GetState().SetContent(content, path)
+	self.context().GetState().SetContent(content, path, self.conflictMarkerSize(path))
 
 	return !self.context().GetState().NoConflicts(), nil
 }
 
+// Returns the size of the file's conflict markers. We usually know it from the
+// last files refresh, but if the file isn't in the model (yet), we ask git.
+func (self *MergeConflictsHelper) conflictMarkerSize(path string) int {
+	file, ok := lo.Find(self.c.Model().Files, func(file *models.File) bool {
+		return file.Path == path
+	})
+	if ok {
+		if file.ConflictMarkerSize > 0 {
+			return file.ConflictMarkerSize
+		}
+		return git_commands.DEFAULT_CONFLICT_MARKER_SIZE
+	}
+
+	sizes, err := self.c.Git().WorkingTree.ConflictMarkerSizes([]string{path})
+	if err != nil {
+		self.c.Log.Error(err)
+	}
+	if size, ok := sizes[path]; ok {
+		return size
+	}
+	return git_commands.DEFAULT_CONFLICT_MARKER_SIZE
+}
+
 func (self *MergeConflictsHelper) ResetMergeState() {
 	self.context().GetMutex().Lock()
 	defer self.context().GetMutex().Unlock()
@@ -17,6 +17,7 @@ type ModeHelper struct {
 	cherryPickHelper     *CherryPickHelper
 	mergeAndRebaseHelper *MergeAndRebaseHelper
 	bisectHelper         *BisectHelper
+	rangeDiffHelper      *RangeDiffHelper
 	suppressRebasingMode bool
 }
 
@@ -27,6 +28,7 @@ func NewModeHelper(
 	cherryPickHelper *CherryPickHelper,
 	mergeAndRebaseHelper *MergeAndRebaseHelper,
 	bisectHelper *BisectHelper,
+	rangeDiffHelper *RangeDiffHelper,
 ) *ModeHelper {
 	return &ModeHelper{
 		c:                    c,
@@ -35,6 +37,7 @@ func NewModeHelper(
 		cherryPickHelper:     cherryPickHelper,
 		mergeAndRebaseHelper: mergeAndRebaseHelper,
 		bisectHelper:         bisectHelper,
+		rangeDiffHelper:      rangeDiffHelper,
 	}
 }
 
@@ -64,6 +67,23 @@ func (self *ModeHelper) Statuses() []ModeStatus {
 			},
 			Reset: self.diffHelper.ExitDiffMode,
 		},
+		{
+			IsActive: self.c.Modes().RangeDiffing.Active,
+			InfoLabel: func() string {
+				return self.withResetButton(
+					fmt.Sprintf(
+						"%s %s",
+						self.c.Tr.ShowingRangeDiff,
+						self.rangeDiffHelper.Description(),
+					),
+					style.FgMagenta,
+				)
+			},
+			CancelLabel: func() string {
+				return self.c.Tr.ExitRangeDiffMode
+			},
+			Reset: self.rangeDiffHelper.ExitRangeDiffMode,
+		},
 		{
 			IsActive: self.c.Git().Patch.PatchBuilder.Active,
 			InfoLabel: func() string {
@@ -77,12 +97,11 @@ func (self *ModeHelper) Statuses() []ModeStatus {
 		{
 			IsActive: self.c.Modes().Filtering.Active,
 			InfoLabel: func() string {
-				filterContent := lo.Ternary(self.c.Modes().Filtering.GetPath() != "", self.c.Modes().Filtering.GetPath(), self.c.Modes().Filtering.GetAuthor())
 				return self.withResetButton(
 					fmt.Sprintf(
-						"%s '%s'",
+						"%s %s",
 						self.c.Tr.FilteringBy,
-						filterContent,
+						self.filteringDescription(),
 					),
 					style.FgRed,
 				)
@@ -178,6 +197,27 @@ func (self *ModeHelper) IsAnyModeActive() bool {
 	})
 }
 
+// e.g. "'path/to/file', content 'foo', message 'bar'"
+func (self *ModeHelper) filteringDescription() string {
+	filtering := &self.c.Modes().Filtering
+	parts := []string{}
+	if filtering.GetPath() != "" {
+		parts = append(parts, fmt.Sprintf("'%s'", filtering.GetPath()))
+	}
+	if filtering.GetAuthor() != "" {
+		parts = append(parts, fmt.Sprintf("'%s'", filtering.GetAuthor()))
+	}
+	contentFilter := filtering.GetContentFilter()
+	if contentFilter.Pickaxe != "" {
+		label := lo.Ternary(contentFilter.PickaxeIsRegex, self.c.Tr.FilteringByContentRegex, self.c.Tr.FilteringByContent)
+		parts = append(parts, fmt.Sprintf("%s '%s'", label, contentFilter.Pickaxe))
+	}
+	if contentFilter.Message != "" {
+		parts = append(parts, fmt.Sprintf("%s '%s'", self.c.Tr.FilteringByMessage, contentFilter.Message))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (self *ModeHelper) ExitFilterMode() error {
 	return self.ClearFiltering()
 }
@@ -0,0 +1,262 @@
+package helpers
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+type NotesHelper struct {
+	c                 *HelperCommon
+	suggestionsHelper *SuggestionsHelper
+}
+
+func NewNotesHelper(c *HelperCommon, suggestionsHelper *SuggestionsHelper) *NotesHelper {
+	return &NotesHelper{
+		c:                 c,
+		suggestionsHelper: suggestionsHelper,
+	}
+}
+
+func (self *NotesHelper) OpenNotesMenu(commit *models.Commit) error {
+	var removeDisabledReason *types.DisabledReason
+	if !commit.HasNotes {
+		removeDisabledReason = &types.DisabledReason{Text: self.c.Tr.CommitHasNoNote}
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: utils.ResolvePlaceholderString(self.c.Tr.NotesMenuTitle, map[string]string{
+			"notesRef": self.notesRefDisplayName(),
+		}),
+		Items: []*types.MenuItem{
+			{
+				Label:   lo.Ternary(commit.HasNotes, self.c.Tr.EditNote, self.c.Tr.AddNote),
+				Tooltip: self.c.Tr.EditNoteTooltip,
+				OnPress: func() error {
+					return self.editNote(commit)
+				},
+				Keys: menuKey('e'),
+			},
+			{
+				Label: self.c.Tr.EditNoteInEditor,
+				OnPress: func() error {
+					self.c.LogAction(self.c.Tr.Actions.EditNote)
+					return self.c.RunSubprocessAndRefresh(
+						self.c.Git().Notes.EditCmdObj(self.c.Model().NotesRef, commit.Hash()),
+					)
+				},
+				Keys: menuKey('E'),
+			},
+			{
+				Label: self.c.Tr.RemoveNote,
+				OnPress: func() error {
+					return self.removeNote(commit)
+				},
+				DisabledReason: removeDisabledReason,
+				Keys:           menuKey('d'),
+			},
+			{
+				Label:     self.c.Tr.SwitchNotesRef,
+				Tooltip:   self.c.Tr.SwitchNotesRefTooltip,
+				OnPress:   self.openSwitchNotesRefMenu,
+				OpensMenu: true,
+				Keys:      menuKey('r'),
+			},
+			{
+				Label:   self.c.Tr.FetchNotes,
+				Tooltip: self.c.Tr.FetchNotesTooltip,
+				OnPress: self.fetchNotes,
+				Keys:    menuKey('f'),
+			},
+			{
+				Label:   self.c.Tr.PushNotes,
+				Tooltip: self.c.Tr.PushNotesTooltip,
+				OnPress: self.pushNotes,
+				Keys:    menuKey('p'),
+			},
+		},
+	})
+}
+
+func (self *NotesHelper) editNote(commit *models.Commit) error {
+	notesRef := self.c.Model().NotesRef
+
+	initialContent := ""
+	if commit.HasNotes {
+		note, err := self.c.Git().Notes.Get(notesRef, commit.Hash())
+		if err != nil {
+			return err
+		}
+		initialContent = note
+	}
+
+	self.c.Prompt(types.PromptOpts{
+		Title:          self.c.Tr.NoteTitle,
+		InitialContent: initialContent,
+		HandleConfirm: func(response string) error {
+			if response == "" {
+				if !commit.HasNotes {
+					return nil
+				}
+				self.c.LogAction(self.c.Tr.Actions.RemoveNote)
+				if err := self.c.Git().Notes.Remove(notesRef, commit.Hash()); err != nil {
+					return err
+				}
+			} else {
+				self.c.LogAction(self.c.Tr.Actions.SetNote)
+				if err := self.c.Git().Notes.Set(notesRef, commit.Hash(), response); err != nil {
+					return err
+				}
+			}
+
+			self.refreshCommits()
+			return nil
+		},
+	})
+
+	return nil
+}
+
+func (self *NotesHelper) removeNote(commit *models.Commit) error {
+	self.c.Confirm(types.ConfirmOpts{
+		Title: self.c.Tr.RemoveNote,
+		Prompt: utils.ResolvePlaceholderString(self.c.Tr.RemoveNotePrompt, map[string]string{
+			"commitHash": commit.ShortHash(),
+		}),
+		HandleConfirm: func() error {
+			self.c.LogAction(self.c.Tr.Actions.RemoveNote)
+			if err := self.c.Git().Notes.Remove(self.c.Model().NotesRef, commit.Hash()); err != nil {
+				return err
+			}
+
+			self.refreshCommits()
+			return nil
+		},
+	})
+
+	return nil
+}
+
+func (self *NotesHelper) openSwitchNotesRefMenu() error {
+	notesRefs, err := self.c.Git().Notes.GetRefs()
+	if err != nil {
+		return err
+	}
+
+	switchTo := func(notesRef string) func() error {
+		return func() error {
+			self.c.Model().NotesRef = notesRef
+			self.refreshCommits()
+			return nil
+		}
+	}
+
+	menuItems := []*types.MenuItem{
+		{
+			Label:   self.c.Tr.DefaultNotesRef,
+			OnPress: switchTo(""),
+			Widget:  types.MakeMenuRadioButton(self.c.Model().NotesRef == ""),
+		},
+	}
+
+	for _, notesRef := range notesRefs {
+		menuItems = append(menuItems, &types.MenuItem{
+			Label:   notesRef,
+			OnPress: switchTo(notesRef),
+			Widget:  types.MakeMenuRadioButton(self.c.Model().NotesRef == notesRef),
+		})
+	}
+
+	menuItems = append(menuItems, &types.MenuItem{
+		Label: self.c.Tr.EnterNotesRef,
+		OnPress: func() error {
+			self.c.Prompt(types.PromptOpts{
+				Title:               self.c.Tr.EnterNotesRef,
+				FindSuggestionsFunc: FilterFunc(notesRefs, self.c.UserConfig().Gui.UseFuzzySearch()),
+				HandleConfirm: func(response string) error {
+					// Normalize short names like "review" to "refs/notes/review"
+					notesRef, err := self.c.Git().Notes.GetRef(response)
+					if err != nil {
+						return err
+					}
+					return switchTo(notesRef)()
+				},
+			})
+			return nil
+		},
+		Keys: menuKey('n'),
+	})
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: self.c.Tr.SwitchNotesRef,
+		Items: menuItems,
+	})
+}
+
+func (self *NotesHelper) fetchNotes() error {
+	notesRef, err := self.c.Git().Notes.GetRef(self.c.Model().NotesRef)
+	if err != nil {
+		return err
+	}
+
+	self.promptForRemote(
+		utils.ResolvePlaceholderString(self.c.Tr.FetchNotesTitle, map[string]string{"notesRef": notesRef}),
+		func(remoteName string) error {
+			return self.c.WithWaitingStatus(self.c.Tr.FetchingNotesStatus, func(task gocui.Task) error {
+				self.c.LogAction(self.c.Tr.Actions.FetchNotes)
+				if err := self.c.Git().Sync.FetchNotes(task, remoteName, notesRef); err != nil {
+					return err
+				}
+
+				self.refreshCommits()
+				return nil
+			})
+		},
+	)
+
+	return nil
+}
+
+func (self *NotesHelper) pushNotes() error {
+	notesRef, err := self.c.Git().Notes.GetRef(self.c.Model().NotesRef)
+	if err != nil {
+		return err
+	}
+
+	self.promptForRemote(
+		utils.ResolvePlaceholderString(self.c.Tr.PushNotesTitle, map[string]string{"notesRef": notesRef}),
+		func(remoteName string) error {
+			return self.c.WithWaitingStatus(self.c.Tr.PushingNotesStatus, func(task gocui.Task) error {
+				self.c.LogAction(self.c.Tr.Actions.PushNotes)
+				return self.c.Git().Sync.PushNotes(task, remoteName, notesRef)
+			})
+		},
+	)
+
+	return nil
+}
+
+func (self *NotesHelper) promptForRemote(title string, onConfirm func(remoteName string) error) {
+	self.c.Prompt(types.PromptOpts{
+		Title:               title,
+		InitialContent:      "origin",
+		FindSuggestionsFunc: self.suggestionsHelper.GetRemoteSuggestionsFunc(),
+		HandleConfirm:       onConfirm,
+	})
+}
+
+func (self *NotesHelper) notesRefDisplayName() string {
+	if self.c.Model().NotesRef == "" {
+		return self.c.Tr.DefaultNotesRef
+	}
+	return self.c.Model().NotesRef
+}
+
+func (self *NotesHelper) refreshCommits() {
+	self.c.Refresh(types.RefreshOptions{
+		Mode:  types.ASYNC,
+		Scope: []types.RefreshableView{types.COMMITS, types.SUB_COMMITS},
+	})
+}
@@ -0,0 +1,124 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/modes/range_diffing"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+type RangeDiffHelper struct {
+	c            *HelperCommon
+	refsHelper   *RefsHelper
+	windowHelper *WindowHelper
+}
+
+func NewRangeDiffHelper(c *HelperCommon, refsHelper *RefsHelper, windowHelper *WindowHelper) *RangeDiffHelper {
+	return &RangeDiffHelper{
+		c:            c,
+		refsHelper:   refsHelper,
+		windowHelper: windowHelper,
+	}
+}
+
+// Compares the commits of oldRef (e.g. the tip of a branch before it was
+// rebased or force-pushed) with the commits of the checked-out branch, and
+// shows the pairings in the range-diff view.
+func (self *RangeDiffHelper) EnterRangeDiffMode(oldRef string, parentContext types.Context) error {
+	newRef := "HEAD"
+	if branch := self.refsHelper.GetCheckedOutRef(); branch != nil && !branch.DetachedHead {
+		newRef = branch.Name
+	}
+
+	// Using the merge base with the main branch as the start of both ranges
+	// gives much better results than a symmetric range when the branch was
+	// rebased onto a newer main branch, because the commits that were added to
+	// main in the meantime don't show up as new commits. If we are on a main
+	// branch ourselves there is no sensible base, so we let git figure it out.
+	base := ""
+	if !lo.Contains(self.c.Model().MainBranches.Get(), newRef) {
+		base = self.c.Model().MainBranches.GetMergeBase(newRef)
+	}
+
+	self.c.Modes().RangeDiffing = range_diffing.RangeDiffing{
+		OldRef: oldRef,
+		NewRef: newRef,
+		Base:   base,
+	}
+
+	return self.ViewRangeDiff(parentContext)
+}
+
+func (self *RangeDiffHelper) ViewRangeDiff(parentContext types.Context) error {
+	rangeDiffContext := self.c.Contexts().RangeDiff
+	if parentContext == rangeDiffContext {
+		// we're switching to a different old ref while already range-diffing
+		parentContext = rangeDiffContext.GetParentContext()
+	}
+
+	mode := self.c.Modes().RangeDiffing
+	entries, err := self.c.Git().Loaders.RangeDiffLoader.GetRangeDiffEntries(mode.Base, mode.OldRef, mode.NewRef)
+	if err != nil {
+		self.c.Modes().RangeDiffing = range_diffing.New()
+		return err
+	}
+
+	self.c.Model().RangeDiffEntries = entries
+
+	rangeDiffContext.SetSelection(0)
+	rangeDiffContext.SetParentContext(parentContext)
+	rangeDiffContext.SetWindowName(parentContext.GetWindowName())
+	rangeDiffContext.SetTitleRef(utils.TruncateWithEllipsis(self.Description(), 50))
+	rangeDiffContext.GetView().TitlePrefix = parentContext.GetView().TitlePrefix
+
+	self.c.PostRefreshUpdate(rangeDiffContext)
+	rangeDiffContext.FocusLine(true)
+
+	self.c.Context().Push(rangeDiffContext, types.OnFocusOpts{})
+	return nil
+}
+
+func (self *RangeDiffHelper) ExitRangeDiffMode() error {
+	self.ClearRangeDiffMode()
+
+	rangeDiffContext := self.c.Contexts().RangeDiff
+	parentContext := rangeDiffContext.GetParentContext()
+	if parentContext == nil {
+		return nil
+	}
+
+	if self.c.Context().IsCurrent(rangeDiffContext) {
+		self.c.Context().Push(parentContext, types.OnFocusOpts{})
+	} else if self.windowHelper.GetViewNameForWindow(rangeDiffContext.GetWindowName()) == rangeDiffContext.GetViewName() {
+		// the range-diff view is still showing in its window even though we
+		// have moved on to another window; put the parent back in its place
+		self.windowHelper.SetWindowContext(parentContext)
+	}
+
+	return nil
+}
+
+func (self *RangeDiffHelper) ClearRangeDiffMode() {
+	self.c.Modes().RangeDiffing = range_diffing.New()
+	self.c.Model().RangeDiffEntries = []*models.RangeDiffEntry{}
+}
+
+// e.g. "old-tip..my-branch"
+func (self *RangeDiffHelper) Description() string {
+	mode := self.c.Modes().RangeDiffing
+	return fmt.Sprintf("%s..%s", abbreviateIfHash(mode.OldRef), mode.NewRef)
+}
+
+// The old ref is often a full hash (e.g. when picked from the reflog), which is
+// too long for the view title; ref names are left alone.
+func abbreviateIfHash(ref string) string {
+	if len(ref) == 40 && strings.Trim(ref, "0123456789abcdef") == "" {
+		return utils.ShortHash(ref)
+	}
+
+	return ref
+}
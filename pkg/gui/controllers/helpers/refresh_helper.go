@@ -35,7 +35,7 @@ type RefreshHelper struct {
 	// prompt, to avoid re-prompting on every subsequent refresh within the same session.
 	// Keyed by repo path so that switching to a different repo while lazygit is running
 	// still triggers the prompt there.
-	githubBaseRemotePromptDismissed map[string]bool
+	pullRequestBaseRemotePromptDismissed map[string]bool
 }
 
 func NewRefreshHelper(
@@ -193,7 +193,7 @@ func (self *RefreshHelper) Refresh(options types.RefreshOptions) {
 		if scopeSet.Includes(types.PULL_REQUESTS) {
 			refresh("pull requests", func() {
 				branchesAndRemotesWg.Wait()
-				self.refreshGithubPullRequests()
+				self.refreshPullRequests()
 			})
 		}
 
@@ -358,10 +358,14 @@ func (self *RefreshHelper) refreshCommitsWithLimit() error {
 			Limit:                self.c.Contexts().LocalCommits.GetLimitCommits(),
 			FilterPath:           self.c.Modes().Filtering.GetPath(),
 			FilterAuthor:         self.c.Modes().Filtering.GetAuthor(),
+			ContentFilter:        self.c.Modes().Filtering.GetContentFilter(),
 			IncludeRebaseCommits: true,
 			RefName:              self.refForLog(),
 			RefForPushedStatus:   checkedOutRef,
 			All:                  self.c.Contexts().LocalCommits.GetShowWholeGitGraph(),
+			LoadNotes:            true,
+			LoadSignatures:       self.c.UserConfig().Git.Log.ShowSignatureStatus,
+			NotesRef:             self.c.Model().NotesRef,
 			MainBranches:         self.c.Model().MainBranches,
 			HashPool:             self.c.Model().HashPool,
 		},
@@ -395,10 +399,15 @@ func (self *RefreshHelper) refreshSubCommitsWithLimit() error {
 			Limit:                   self.c.Contexts().SubCommits.GetLimitCommits(),
 			FilterPath:              self.c.Modes().Filtering.GetPath(),
 			FilterAuthor:            self.c.Modes().Filtering.GetAuthor(),
+			ContentFilter:           self.c.Modes().Filtering.GetContentFilter(),
 			IncludeRebaseCommits:    false,
 			RefName:                 self.c.Contexts().SubCommits.GetRef().FullRefName(),
 			RefToShowDivergenceFrom: self.c.Contexts().SubCommits.GetRefToShowDivergenceFrom(),
 			RefForPushedStatus:      self.c.Contexts().SubCommits.GetRef(),
+			LineRange:               self.c.Contexts().SubCommits.GetLineRange(),
+			LoadNotes:               true,
+			LoadSignatures:          self.c.UserConfig().Git.Log.ShowSignatureStatus,
+			NotesRef:                self.c.Model().NotesRef,
 			MainBranches:            self.c.Model().MainBranches,
 			HashPool:                self.c.Model().HashPool,
 		},
@@ -571,7 +580,9 @@ func (self *RefreshHelper) refreshStateFiles() error {
 	fileTreeViewModel := self.c.Contexts().Files.FileTreeViewModel
 
 	prevConflictFileCount := 0
-	if self.c.UserConfig().Git.AutoStageResolvedConflicts {
+	autoStageResolved := self.c.UserConfig().Git.AutoStageResolvedConflicts
+	autoStageRerereResolved := self.c.UserConfig().Git.AutoStageRerereResolvedConflicts
+	if autoStageResolved || autoStageRerereResolved {
 		// If git thinks any of our files have inline merge conflicts, but they actually don't,
 		// we stage them.
 		// Note that if files with merge conflicts have both arisen and have been resolved
@@ -581,11 +592,19 @@ func (self *RefreshHelper) refreshStateFiles() error {
 		// we call git status again.
 		pathsToStage := []string{}
 		for _, file := range self.c.Model().Files {
-			if file.HasMergeConflicts {
+			if file.HasMergeConflicts && autoStageResolved {
 				prevConflictFileCount++
 			}
-			if file.HasInlineMergeConflicts {
-				hasConflicts, err := mergeconflicts.FileHasConflictMarkers(file.Path)
+			// Files resolved by rerere have no conflict markers either, but they
+			// have their own setting so that users get a chance to review them
+			if file.ResolvedByRerere {
+				if autoStageRerereResolved {
+					pathsToStage = append(pathsToStage, file.Path)
+				}
+				continue
+			}
+			if file.HasInlineMergeConflicts && autoStageResolved {
+				hasConflicts, err := mergeconflicts.FileHasConflictMarkers(file.Path, file.ConflictMarkerSize)
 				if err != nil {
 					self.c.Log.Error(err)
 				} else if !hasConflicts {
@@ -604,8 +623,13 @@ func (self *RefreshHelper) refreshStateFiles() error {
 
 	files := self.c.Git().Loaders.FileLoader.
 		GetStatusFiles(git_commands.GetStatusFileOptions{
-			ForceShowUntracked: self.c.Contexts().Files.ForceShowUntracked(),
+			ForceShowUntracked:           self.c.Contexts().Files.ForceShowUntracked(),
+			ExpandedUntrackedDirs:        self.c.Contexts().Files.ExpandedUntrackedDirs(),
+			OnStaleExpandedUntrackedDirs: self.c.Contexts().Files.ForgetExpandedUntrackedDirs,
 		})
+	self.markFilesOutsideSparseCheckout(files)
+	self.setConflictMarkerSizes(files)
+	self.markFilesResolvedByRerere(files)
 
 	conflictFileCount := 0
 	for _, file := range files {
@@ -638,6 +662,70 @@ func (self *RefreshHelper) refreshStateFiles() error {
 	return nil
 }
 
+func (self *RefreshHelper) markFilesResolvedByRerere(files []*models.File) {
+	if !lo.SomeBy(files, func(file *models.File) bool { return file.HasMergeConflicts }) {
+		return
+	}
+
+	rerere := self.c.Git().Rerere
+	if !rerere.IsEnabled() {
+		return
+	}
+
+	remaining, err := rerere.Remaining()
+	if err != nil {
+		self.c.Log.Error(err)
+		return
+	}
+
+	remainingSet := set.NewFromSlice(remaining)
+	for _, file := range files {
+		file.ResolvedByRerere = file.HasMergeConflicts && !remainingSet.Includes(file.Path)
+		if file.ResolvedByRerere {
+			file.RerereID, err = rerere.ConflictID(file.Path, file.ConflictMarkerSize)
+			if err != nil {
+				self.c.Log.Error(err)
+			}
+		}
+	}
+}
+
+func (self *RefreshHelper) setConflictMarkerSizes(files []*models.File) {
+	conflictedPaths := lo.FilterMap(files, func(file *models.File, _ int) (string, bool) {
+		return file.Path, file.HasInlineMergeConflicts
+	})
+	if len(conflictedPaths) == 0 {
+		return
+	}
+
+	sizes, err := self.c.Git().WorkingTree.ConflictMarkerSizes(conflictedPaths)
+	if err != nil {
+		self.c.Log.Error(err)
+		return
+	}
+
+	for _, file := range files {
+		file.ConflictMarkerSize = sizes[file.Path]
+	}
+}
+
+func (self *RefreshHelper) markFilesOutsideSparseCheckout(files []*models.File) {
+	sparseCheckout := self.c.Git().SparseCheckout
+	if !sparseCheckout.IsEnabled() || !sparseCheckout.IsConeMode() {
+		return
+	}
+
+	coneDirs, err := sparseCheckout.ConeDirs()
+	if err != nil {
+		self.c.Log.Error(err)
+		return
+	}
+
+	for _, file := range files {
+		file.OutsideSparseCheckout = !git_commands.IsInSparseCheckoutCone(file.Path, coneDirs)
+	}
+}
+
 // the reflogs panel is the only panel where we cache data, in that we only
 // load entries that have been created since we last ran the call. This means
 // we need to be more careful with how we use this, and to ensure we're emptying
@@ -650,14 +738,14 @@ func (self *RefreshHelper) refreshReflogCommits() error {
 	// and we get an out of bounds exception
 	model := self.c.Model()
 
-	refresh := func(stateCommits *[]*models.Commit, filterPath string, filterAuthor string) error {
+	refresh := func(stateCommits *[]*models.Commit, filterPath string, filterAuthor string, contentFilter git_commands.ContentFilter) error {
 		var lastReflogCommit *models.Commit
-		if filterPath == "" && filterAuthor == "" && len(*stateCommits) > 0 {
+		if filterPath == "" && filterAuthor == "" && contentFilter.IsEmpty() && len(*stateCommits) > 0 {
 			lastReflogCommit = (*stateCommits)[0]
 		}
 
 		commits, onlyObtainedNewReflogCommits, err := self.c.Git().Loaders.ReflogCommitLoader.
-			GetReflogCommits(self.c.Model().HashPool, lastReflogCommit, filterPath, filterAuthor)
+			GetReflogCommits(self.c.Model().HashPool, lastReflogCommit, filterPath, filterAuthor, contentFilter)
 		if err != nil {
 			return err
 		}
@@ -670,12 +758,16 @@ func (self *RefreshHelper) refreshReflogCommits() error {
 		return nil
 	}
 
-	if err := refresh(&model.ReflogCommits, "", ""); err != nil {
+	if err := refresh(&model.ReflogCommits, "", "", git_commands.ContentFilter{}); err != nil {
 		return err
 	}
 
 	if self.c.Modes().Filtering.Active() {
-		if err := refresh(&model.FilteredReflogCommits, self.c.Modes().Filtering.GetPath(), self.c.Modes().Filtering.GetAuthor()); err != nil {
+		if err := refresh(&model.FilteredReflogCommits,
+			self.c.Modes().Filtering.GetPath(),
+			self.c.Modes().Filtering.GetAuthor(),
+			self.c.Modes().Filtering.GetContentFilter(),
+		); err != nil {
 			return err
 		}
 	} else {
@@ -808,74 +900,111 @@ func (self *RefreshHelper) refreshView(context types.Context) {
 	})
 }
 
-func (self *RefreshHelper) refreshGithubPullRequests() {
+func (self *RefreshHelper) refreshPullRequests() {
 	self.c.Mutexes().RefreshingPullRequestsMutex.Lock()
 	defer self.c.Mutexes().RefreshingPullRequestsMutex.Unlock()
 
-	githubRemotes := getAuthenticatedGithubRemotes(self.getGithubRemotes(), self.c.Git().GitHub.GetAuthToken)
-	if len(githubRemotes) == 0 {
-		self.c.Model().PullRequests = nil
-		self.c.Model().PullRequestsMap = nil
+	remotes := getAuthenticatedRemotes(self.getPullRequestRemotes(), func(provider string, host string) string {
+		return self.pullRequestLoader(provider).GetAuthToken(host)
+	})
+	if len(remotes) == 0 {
+		self.clearPullRequests()
 		return
 	}
 
-	baseInfo := getGithubBaseRemote(githubRemotes, self.c.Git().GitHub.ConfiguredBaseRemoteName())
+	baseInfo := getPullRequestBaseRemote(remotes, self.c.Git().GitHub.ConfiguredBaseRemoteName())
 	if baseInfo == nil {
-		self.c.Model().PullRequests = nil
-		self.c.Model().PullRequestsMap = nil
+		self.clearPullRequests()
 
-		if !self.githubBaseRemotePromptDismissed[self.c.Git().RepoPaths.RepoPath()] {
-			self.promptForBaseGithubRepo(githubRemotes)
+		if !self.pullRequestBaseRemotePromptDismissed[self.c.Git().RepoPaths.RepoPath()] {
+			self.promptForPullRequestBaseRemote(remotes)
 		}
 		return
 	}
 
-	self.setGithubPullRequests(baseInfo)
+	self.setPullRequests(baseInfo)
 }
 
-type githubRemoteInfo struct {
+func (self *RefreshHelper) clearPullRequests() {
+	self.c.Model().PullRequests = nil
+	self.c.Model().PullRequestsMap = nil
+	self.clearOpenPullRequests()
+}
+
+func (self *RefreshHelper) clearOpenPullRequests() {
+	if len(self.c.Model().OpenPullRequests) > 0 {
+		self.c.Model().OpenPullRequests = nil
+		self.c.Model().PullRequestsRemoteName = ""
+		self.refreshView(self.c.Contexts().PullRequests)
+	}
+}
+
+// pullRequestLoader returns the loader for the given hosting service provider,
+// or nil if we can't load pull requests from that provider
+func (self *RefreshHelper) pullRequestLoader(provider string) git_commands.PullRequestLoader {
+	switch provider {
+	case "github":
+		return self.c.Git().GitHub
+	case "gitlab":
+		return self.c.Git().GitLab
+	case "gitea", "codeberg":
+		return self.c.Git().Gitea
+	case "bitbucket":
+		return self.c.Git().Bitbucket
+	default:
+		return nil
+	}
+}
+
+type hostingRemoteInfo struct {
 	remote      *models.Remote
 	serviceInfo hosting_service.ServiceInfo
 	authToken   string
 }
 
-func (self *RefreshHelper) getGithubRemotes() []githubRemoteInfo {
-	return lo.FilterMap(self.c.Model().Remotes, func(remote *models.Remote, _ int) (githubRemoteInfo, bool) {
+// getPullRequestRemotes returns the remotes on hosting services that we can
+// load pull requests from
+func (self *RefreshHelper) getPullRequestRemotes() []hostingRemoteInfo {
+	return lo.FilterMap(self.c.Model().Remotes, func(remote *models.Remote, _ int) (hostingRemoteInfo, bool) {
 		if len(remote.Urls) == 0 {
-			return githubRemoteInfo{}, false
+			return hostingRemoteInfo{}, false
 		}
 		serviceInfo, err := self.c.Git().HostingService.GetServiceInfo(remote.Urls[0])
-		if err != nil || serviceInfo.Provider != "github" {
-			return githubRemoteInfo{}, false
+		if err != nil || self.pullRequestLoader(serviceInfo.Provider) == nil {
+			return hostingRemoteInfo{}, false
 		}
-		return githubRemoteInfo{remote: remote, serviceInfo: serviceInfo}, true
+		return hostingRemoteInfo{remote: remote, serviceInfo: serviceInfo}, true
 	})
 }
 
-// getAuthenticatedGithubRemotes drops remotes for which no auth token is
+// getAuthenticatedRemotes drops remotes for which no auth token is
 // available and attaches the resolved token to the rest. Token lookups are
-// cached by host so that multiple remotes pointing at the same instance
-// (e.g. origin + a fork on github.com) only trigger one lookup.
-func getAuthenticatedGithubRemotes(githubRemotes []githubRemoteInfo, getAuthToken func(host string) string) []githubRemoteInfo {
-	tokensByHost := map[string]string{}
-	return lo.FilterMap(githubRemotes, func(info githubRemoteInfo, _ int) (githubRemoteInfo, bool) {
-		host := info.serviceInfo.WebDomain
-		token, cached := tokensByHost[host]
+// cached by provider and host so that multiple remotes pointing at the same
+// instance (e.g. origin + a fork on github.com) only trigger one lookup.
+func getAuthenticatedRemotes(remotes []hostingRemoteInfo, getAuthToken func(provider string, host string) string) []hostingRemoteInfo {
+	type tokenKey struct {
+		provider string
+		host     string
+	}
+	tokensByKey := map[tokenKey]string{}
+	return lo.FilterMap(remotes, func(info hostingRemoteInfo, _ int) (hostingRemoteInfo, bool) {
+		key := tokenKey{provider: info.serviceInfo.Provider, host: info.serviceInfo.WebDomain}
+		token, cached := tokensByKey[key]
 		if !cached {
-			token = getAuthToken(host)
-			tokensByHost[host] = token
+			token = getAuthToken(key.provider, key.host)
+			tokensByKey[key] = token
 		}
 		if token == "" {
-			return githubRemoteInfo{}, false
+			return hostingRemoteInfo{}, false
 		}
 		info.authToken = token
 		return info, true
 	})
 }
 
-func getGithubBaseRemote(githubRemotes []githubRemoteInfo, configuredRemoteName string) *githubRemoteInfo {
-	findRemoteByName := func(name string) *githubRemoteInfo {
-		info, ok := lo.Find(githubRemotes, func(info githubRemoteInfo) bool {
+func getPullRequestBaseRemote(remotes []hostingRemoteInfo, configuredRemoteName string) *hostingRemoteInfo {
+	findRemoteByName := func(name string) *hostingRemoteInfo {
+		info, ok := lo.Find(remotes, func(info hostingRemoteInfo) bool {
 			return info.remote.Name == name
 		})
 		if !ok {
@@ -888,8 +1017,8 @@ func getGithubBaseRemote(githubRemotes []githubRemoteInfo, configuredRemoteName
 		return findRemoteByName(configuredRemoteName)
 	}
 
-	if len(githubRemotes) == 1 {
-		return &githubRemotes[0]
+	if len(remotes) == 1 {
+		return &remotes[0]
 	}
 
 	// Not sure if "upstream" is really a common convention for the name of the remote that PRs are
@@ -901,8 +1030,8 @@ func getGithubBaseRemote(githubRemotes []githubRemoteInfo, configuredRemoteName
 	return nil
 }
 
-func (self *RefreshHelper) promptForBaseGithubRepo(githubRemotes []githubRemoteInfo) {
-	menuItems := lo.Map(githubRemotes, func(info githubRemoteInfo, _ int) *types.MenuItem {
+func (self *RefreshHelper) promptForPullRequestBaseRemote(remotes []hostingRemoteInfo) {
+	menuItems := lo.Map(remotes, func(info hostingRemoteInfo, _ int) *types.MenuItem {
 		return &types.MenuItem{
 			LabelColumns: []string{info.remote.Name, style.FgCyan.Sprint(info.serviceInfo.RepoName)},
 			OnPress: func() error {
@@ -911,7 +1040,7 @@ func (self *RefreshHelper) promptForBaseGithubRepo(githubRemotes []githubRemoteI
 						self.c.Log.Error(err)
 					}
 
-					self.setGithubPullRequests(&info)
+					self.setPullRequests(&info)
 					return nil
 				})
 			},
@@ -922,24 +1051,31 @@ func (self *RefreshHelper) promptForBaseGithubRepo(githubRemotes []githubRemoteI
 		Title: self.c.Tr.SelectRemoteRepository,
 		Items: menuItems,
 		OnCancel: func() error {
-			if self.githubBaseRemotePromptDismissed == nil {
-				self.githubBaseRemotePromptDismissed = make(map[string]bool)
+			if self.pullRequestBaseRemotePromptDismissed == nil {
+				self.pullRequestBaseRemotePromptDismissed = make(map[string]bool)
 			}
-			self.githubBaseRemotePromptDismissed[self.c.Git().RepoPaths.RepoPath()] = true
+			self.pullRequestBaseRemotePromptDismissed[self.c.Git().RepoPaths.RepoPath()] = true
 			return nil
 		},
 	})
 }
 
 func (self *RefreshHelper) rebuildPullRequestsMap() {
-	self.c.Model().PullRequestsMap = git_commands.GenerateGithubPullRequestMap(
+	self.c.Model().PullRequestsMap = git_commands.GeneratePullRequestMap(
 		self.c.Model().PullRequests,
 		self.c.Model().Branches,
 		self.c.Model().Remotes,
 	)
 }
 
-func (self *RefreshHelper) setGithubPullRequests(baseInfo *githubRemoteInfo) {
+func (self *RefreshHelper) setPullRequests(baseInfo *hostingRemoteInfo) {
+	// the pull requests panel is only supported for GitHub so far
+	if baseInfo.serviceInfo.Provider == "github" {
+		self.setOpenGithubPullRequests(baseInfo)
+	} else {
+		self.clearOpenPullRequests()
+	}
+
 	if len(self.c.Model().Branches) == 0 {
 		return
 	}
@@ -951,9 +1087,10 @@ func (self *RefreshHelper) setGithubPullRequests(baseInfo *githubRemoteInfo) {
 		return branch.UpstreamBranch
 	})
 
-	prs, err := self.c.Git().GitHub.FetchRecentPRs(branchNames, &baseInfo.serviceInfo, baseInfo.authToken)
+	loader := self.pullRequestLoader(baseInfo.serviceInfo.Provider)
+	prs, err := loader.FetchRecentPRs(branchNames, &baseInfo.serviceInfo, baseInfo.authToken)
 	if err != nil {
-		self.c.Log.Error("error fetching pull requests from GitHub: " + err.Error())
+		self.c.Log.Errorf("error fetching pull requests from %s: %s", baseInfo.serviceInfo.WebDomain, err.Error())
 		return
 	}
 
@@ -967,9 +1104,21 @@ func (self *RefreshHelper) setGithubPullRequests(baseInfo *githubRemoteInfo) {
 	})
 }
 
-func (self *RefreshHelper) savePullRequestsToCache(prs []*models.GithubPullRequest) {
+func (self *RefreshHelper) setOpenGithubPullRequests(baseInfo *hostingRemoteInfo) {
+	prs, err := self.c.Git().GitHub.FetchOpenPRs(&baseInfo.serviceInfo, baseInfo.authToken)
+	if err != nil {
+		self.c.Log.Error("error fetching open pull requests from GitHub: " + err.Error())
+		return
+	}
+
+	self.c.Model().OpenPullRequests = prs
+	self.c.Model().PullRequestsRemoteName = baseInfo.remote.Name
+	self.refreshView(self.c.Contexts().PullRequests)
+}
+
+func (self *RefreshHelper) savePullRequestsToCache(prs []*models.PullRequest) {
 	repoPath := self.c.Git().RepoPaths.RepoPath()
-	cached := lo.Map(prs, func(pr *models.GithubPullRequest, _ int) config.CachedPullRequest {
+	cached := lo.Map(prs, func(pr *models.PullRequest, _ int) config.CachedPullRequest {
 		return config.CachedPullRequest{
 			HeadRefName:         pr.HeadRefName,
 			Number:              pr.Number,
@@ -981,9 +1130,9 @@ func (self *RefreshHelper) savePullRequestsToCache(prs []*models.GithubPullReque
 	})
 
 	appState := self.c.GetAppState()
-	if appState.GithubPullRequests == nil {
-		appState.GithubPullRequests = make(map[string][]config.CachedPullRequest)
+	if appState.PullRequests == nil {
+		appState.PullRequests = make(map[string][]config.CachedPullRequest)
 	}
-	appState.GithubPullRequests[repoPath] = cached
+	appState.PullRequests[repoPath] = cached
 	self.c.SaveAppStateAndLogError()
 }
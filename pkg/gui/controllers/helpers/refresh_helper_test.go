@@ -9,46 +9,46 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestGetGithubBaseRemote(t *testing.T) {
+func TestGetPullRequestBaseRemote(t *testing.T) {
 	cases := []struct {
 		name             string
-		githubRemotes    []githubRemoteInfo
+		remotes          []hostingRemoteInfo
 		configuredRemote string
 		expected         string
 	}{
 		{
 			name:             "configured remote wins",
-			githubRemotes:    makeGithubRemoteInfoList("origin", "upstream", "fork"),
+			remotes:          makeRemoteInfoList("origin", "upstream", "fork"),
 			configuredRemote: "fork",
 			expected:         "fork",
 		},
 		{
-			name:             "configured remote not in github remotes returns nil",
-			githubRemotes:    makeGithubRemoteInfoList("origin"),
+			name:             "configured remote not in hosting service remotes returns nil",
+			remotes:          makeRemoteInfoList("origin"),
 			configuredRemote: "missing",
 			expected:         "",
 		},
 		{
-			name:             "single github remote is auto-picked",
-			githubRemotes:    makeGithubRemoteInfoList("myremote"),
+			name:             "single hosting service remote is auto-picked",
+			remotes:          makeRemoteInfoList("myremote"),
 			configuredRemote: "",
 			expected:         "myremote",
 		},
 		{
-			name:             "upstream is preferred when multiple github remotes exist",
-			githubRemotes:    makeGithubRemoteInfoList("origin", "upstream", "fork"),
+			name:             "upstream is preferred when multiple hosting service remotes exist",
+			remotes:          makeRemoteInfoList("origin", "upstream", "fork"),
 			configuredRemote: "",
 			expected:         "upstream",
 		},
 		{
 			name:             "no upstream and multiple remotes returns nil",
-			githubRemotes:    makeGithubRemoteInfoList("origin", "fork"),
+			remotes:          makeRemoteInfoList("origin", "fork"),
 			configuredRemote: "",
 			expected:         "",
 		},
 		{
 			name:             "empty list returns nil",
-			githubRemotes:    nil,
+			remotes:          nil,
 			configuredRemote: "",
 			expected:         "",
 		},
@@ -56,7 +56,7 @@ func TestGetGithubBaseRemote(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			result := getGithubBaseRemote(c.githubRemotes, c.configuredRemote)
+			result := getPullRequestBaseRemote(c.remotes, c.configuredRemote)
 			if c.expected == "" {
 				assert.Nil(t, result)
 			} else {
@@ -67,58 +67,68 @@ func TestGetGithubBaseRemote(t *testing.T) {
 	}
 }
 
-func TestGetAuthenticatedGithubRemotes(t *testing.T) {
-	githubRemotes := []githubRemoteInfo{
-		makeGithubRemoteInfo("origin", "github.com"),
-		makeGithubRemoteInfo("fork", "github.com"),
-		makeGithubRemoteInfo("enterprise", "ghe.example.com"),
-		makeGithubRemoteInfo("missing-auth", "no-token.example.com"),
+func TestGetAuthenticatedRemotes(t *testing.T) {
+	remotes := []hostingRemoteInfo{
+		makeRemoteInfo("origin", "github", "github.com"),
+		makeRemoteInfo("fork", "github", "github.com"),
+		makeRemoteInfo("enterprise", "github", "ghe.example.com"),
+		makeRemoteInfo("missing-auth", "github", "no-token.example.com"),
+		makeRemoteInfo("work", "gitlab", "gitlab.example.com"),
 	}
 
-	callsByHost := map[string]int{}
-	result := getAuthenticatedGithubRemotes(githubRemotes, func(host string) string {
-		callsByHost[host]++
+	type tokenKey struct {
+		provider string
+		host     string
+	}
+	callsByKey := map[tokenKey]int{}
+	result := getAuthenticatedRemotes(remotes, func(provider string, host string) string {
+		callsByKey[tokenKey{provider, host}]++
 		switch host {
 		case "github.com":
 			return "github-token"
 		case "ghe.example.com":
 			return "ghe-token"
+		case "gitlab.example.com":
+			return "gitlab-token"
 		default:
 			return ""
 		}
 	})
 
-	assert.Equal(t, []githubRemoteInfo{
-		makeAuthenticatedGithubRemoteInfo("origin", "github.com", "github-token"),
-		makeAuthenticatedGithubRemoteInfo("fork", "github.com", "github-token"),
-		makeAuthenticatedGithubRemoteInfo("enterprise", "ghe.example.com", "ghe-token"),
+	assert.Equal(t, []hostingRemoteInfo{
+		makeAuthenticatedRemoteInfo("origin", "github", "github.com", "github-token"),
+		makeAuthenticatedRemoteInfo("fork", "github", "github.com", "github-token"),
+		makeAuthenticatedRemoteInfo("enterprise", "github", "ghe.example.com", "ghe-token"),
+		makeAuthenticatedRemoteInfo("work", "gitlab", "gitlab.example.com", "gitlab-token"),
 	}, result)
 	// Two remotes share github.com; the lookup runs only once.
-	assert.Equal(t, map[string]int{
-		"github.com":           1,
-		"ghe.example.com":      1,
-		"no-token.example.com": 1,
-	}, callsByHost)
+	assert.Equal(t, map[tokenKey]int{
+		{"github", "github.com"}:           1,
+		{"github", "ghe.example.com"}:      1,
+		{"github", "no-token.example.com"}: 1,
+		{"gitlab", "gitlab.example.com"}:   1,
+	}, callsByKey)
 }
 
-func makeGithubRemoteInfoList(names ...string) []githubRemoteInfo {
-	return lo.Map(names, func(name string, _ int) githubRemoteInfo {
-		return makeGithubRemoteInfo(name, name)
+func makeRemoteInfoList(names ...string) []hostingRemoteInfo {
+	return lo.Map(names, func(name string, _ int) hostingRemoteInfo {
+		return makeRemoteInfo(name, "github", name)
 	})
 }
 
-func makeGithubRemoteInfo(name string, webDomain string) githubRemoteInfo {
-	return githubRemoteInfo{
+func makeRemoteInfo(name string, provider string, webDomain string) hostingRemoteInfo {
+	return hostingRemoteInfo{
 		remote: &models.Remote{Name: name},
 		serviceInfo: hosting_service.ServiceInfo{
+			Provider:  provider,
 			RepoName:  name,
 			WebDomain: webDomain,
 		},
 	}
 }
 
-func makeAuthenticatedGithubRemoteInfo(name string, webDomain string, authToken string) githubRemoteInfo {
-	info := makeGithubRemoteInfo(name, webDomain)
+func makeAuthenticatedRemoteInfo(name string, provider string, webDomain string, authToken string) hostingRemoteInfo {
+	info := makeRemoteInfo(name, provider, webDomain)
 	info.authToken = authToken
 	return info
 }
@@ -0,0 +1,186 @@
+package helpers
+
+import (
+	"errors"
+
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+type SparseCheckoutHelper struct {
+	c *HelperCommon
+}
+
+func NewSparseCheckoutHelper(c *HelperCommon) *SparseCheckoutHelper {
+	return &SparseCheckoutHelper{
+		c: c,
+	}
+}
+
+func (self *SparseCheckoutHelper) OpenSparseCheckoutMenu() error {
+	if !self.c.Git().SparseCheckout.IsEnabled() {
+		return self.c.Menu(types.CreateMenuOptions{
+			Title: self.c.Tr.SparseCheckout,
+			Items: []*types.MenuItem{
+				{
+					Label:   self.c.Tr.EnableSparseCheckout,
+					Tooltip: self.c.Tr.EnableSparseCheckoutTooltip,
+					OnPress: self.enable,
+					Keys:    menuKey('e'),
+				},
+			},
+		})
+	}
+
+	dirs, err := self.c.Git().SparseCheckout.List()
+	if err != nil {
+		return err
+	}
+
+	var notConeModeReason *types.DisabledReason
+	if !self.c.Git().SparseCheckout.IsConeMode() {
+		notConeModeReason = &types.DisabledReason{Text: self.c.Tr.SparseCheckoutNotConeMode}
+	}
+
+	menuItems := []*types.MenuItem{
+		{
+			Label:   self.c.Tr.AddSparseCheckoutDirectory,
+			Tooltip: self.c.Tr.AddSparseCheckoutDirectoryTooltip,
+			OnPress: func() error {
+				return self.addDirectory(dirs)
+			},
+			DisabledReason: notConeModeReason,
+			Keys:           menuKey('a'),
+		},
+		{
+			Label:   self.c.Tr.ReapplySparseCheckout,
+			Tooltip: self.c.Tr.ReapplySparseCheckoutTooltip,
+			OnPress: func() error {
+				return self.update(self.c.Tr.Actions.ReapplySparseCheckout, self.c.Git().SparseCheckout.Reapply)
+			},
+			Keys: menuKey('r'),
+		},
+		{
+			Label:   self.c.Tr.DisableSparseCheckout,
+			OnPress: self.disable,
+			Keys:    menuKey('d'),
+		},
+	}
+
+	section := &types.MenuSection{Title: self.c.Tr.SparseCheckoutDirectories}
+	for _, dir := range dirs {
+		menuItems = append(menuItems, &types.MenuItem{
+			Label:   dir,
+			Tooltip: self.c.Tr.RemoveSparseCheckoutDirectoryTooltip,
+			OnPress: func() error {
+				return self.removeDirectory(dirs, dir)
+			},
+			DisabledReason: notConeModeReason,
+			Section:        section,
+		})
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: self.c.Tr.SparseCheckout,
+		Items: menuItems,
+	})
+}
+
+func (self *SparseCheckoutHelper) enable() error {
+	headDirs, err := self.c.Git().SparseCheckout.GetHeadDirectories()
+	if err != nil {
+		return err
+	}
+
+	self.c.Prompt(types.PromptOpts{
+		Title:               self.c.Tr.SelectSparseCheckoutDirectory,
+		FindSuggestionsFunc: FilterFunc(headDirs, self.c.UserConfig().Gui.UseFuzzySearch()),
+		HandleConfirm: func(response string) error {
+			// An empty sparse set would leave only the files at the top level
+			// of the repo checked out, which is almost certainly not what the
+			// user wants when enabling sparse checkout. The prompt doesn't
+			// accept empty input, but let's not rely on that.
+			if response == "" {
+				return errors.New(self.c.Tr.SparseCheckoutDirectoryRequired)
+			}
+			return self.update(self.c.Tr.Actions.SetSparseCheckout, func() error {
+				return self.c.Git().SparseCheckout.Set([]string{response})
+			})
+		},
+	})
+
+	return nil
+}
+
+func (self *SparseCheckoutHelper) addDirectory(currentDirs []string) error {
+	headDirs, err := self.c.Git().SparseCheckout.GetHeadDirectories()
+	if err != nil {
+		return err
+	}
+
+	candidates, _ := lo.Difference(headDirs, currentDirs)
+
+	self.c.Prompt(types.PromptOpts{
+		Title:               self.c.Tr.AddSparseCheckoutDirectory,
+		FindSuggestionsFunc: FilterFunc(candidates, self.c.UserConfig().Gui.UseFuzzySearch()),
+		HandleConfirm: func(response string) error {
+			if response == "" {
+				return nil
+			}
+			return self.update(self.c.Tr.Actions.AddSparseCheckoutDirectory, func() error {
+				return self.c.Git().SparseCheckout.Add([]string{response})
+			})
+		},
+	})
+
+	return nil
+}
+
+func (self *SparseCheckoutHelper) removeDirectory(currentDirs []string, dir string) error {
+	remainingDirs := lo.Without(currentDirs, dir)
+	prompt := self.c.Tr.RemoveSparseCheckoutDirectoryPrompt
+	if len(remainingDirs) == 0 {
+		prompt = self.c.Tr.RemoveLastSparseCheckoutDirectoryPrompt
+	}
+
+	self.c.Confirm(types.ConfirmOpts{
+		Title: self.c.Tr.RemoveSparseCheckoutDirectory,
+		Prompt: utils.ResolvePlaceholderString(prompt, map[string]string{
+			"dir": dir,
+		}),
+		HandleConfirm: func() error {
+			return self.update(self.c.Tr.Actions.SetSparseCheckout, func() error {
+				return self.c.Git().SparseCheckout.Set(remainingDirs)
+			})
+		},
+	})
+
+	return nil
+}
+
+func (self *SparseCheckoutHelper) disable() error {
+	self.c.Confirm(types.ConfirmOpts{
+		Title:  self.c.Tr.DisableSparseCheckout,
+		Prompt: self.c.Tr.DisableSparseCheckoutPrompt,
+		HandleConfirm: func() error {
+			return self.update(self.c.Tr.Actions.DisableSparseCheckout, self.c.Git().SparseCheckout.Disable)
+		},
+	})
+
+	return nil
+}
+
+func (self *SparseCheckoutHelper) update(action string, f func() error) error {
+	return self.c.WithWaitingStatus(self.c.Tr.UpdatingSparseCheckoutStatus, func(gocui.Task) error {
+		self.c.LogAction(action)
+		err := f()
+		// sparse-checkout changes core.sparseCheckout, so make sure we don't
+		// keep showing the state from before
+		self.c.Git().Config.DropConfigCache()
+		self.c.Git().SparseCheckout.DropCache()
+		self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+		return err
+	})
+}
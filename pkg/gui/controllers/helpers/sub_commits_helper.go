@@ -29,6 +29,8 @@ type ViewSubCommitsOpts struct {
 	TitleRef                string
 	Context                 types.Context
 	ShowBranchHeads         bool
+	// if set, only show the commits that touched this range of lines
+	LineRange *git_commands.LineRange
 }
 
 func (self *SubCommitsHelper) ViewSubCommits(opts ViewSubCommitsOpts) error {
@@ -37,10 +39,15 @@ func (self *SubCommitsHelper) ViewSubCommits(opts ViewSubCommitsOpts) error {
 			Limit:                   true,
 			FilterPath:              self.c.Modes().Filtering.GetPath(),
 			FilterAuthor:            self.c.Modes().Filtering.GetAuthor(),
+			ContentFilter:           self.c.Modes().Filtering.GetContentFilter(),
 			IncludeRebaseCommits:    false,
 			RefName:                 opts.Ref.FullRefName(),
 			RefForPushedStatus:      opts.Ref,
 			RefToShowDivergenceFrom: opts.RefToShowDivergenceFrom,
+			LineRange:               opts.LineRange,
+			LoadNotes:               true,
+			LoadSignatures:          self.c.UserConfig().Git.Log.ShowSignatureStatus,
+			NotesRef:                self.c.Model().NotesRef,
 			MainBranches:            self.c.Model().MainBranches,
 			HashPool:                self.c.Model().HashPool,
 		},
@@ -61,6 +68,7 @@ func (self *SubCommitsHelper) ViewSubCommits(opts ViewSubCommitsOpts) error {
 	subCommitsContext.SetRefToShowDivergenceFrom(opts.RefToShowDivergenceFrom)
 	subCommitsContext.SetLimitCommits(true)
 	subCommitsContext.SetShowBranchHeads(opts.ShowBranchHeads)
+	subCommitsContext.SetLineRange(opts.LineRange)
 	subCommitsContext.ClearSearchString()
 	subCommitsContext.GetView().ClearSearch()
 	subCommitsContext.GetView().TitlePrefix = opts.Context.GetView().TitlePrefix
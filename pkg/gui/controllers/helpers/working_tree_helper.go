@@ -13,6 +13,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/samber/lo"
 )
 
@@ -293,44 +294,7 @@ func (self *WorkingTreeHelper) mergeFile(filepath string, strategy string) (stri
 }
 
 func (self *WorkingTreeHelper) mergeFileWithTempFiles(filepath string, strategy string) (string, error) {
-	showToTempFile := func(stage int, label string) (string, error) {
-		output, err := self.c.Git().WorkingTree.ShowFileAtStage(filepath, stage)
-		if err != nil {
-			return "", err
-		}
-
-		f, err := os.CreateTemp(self.c.GetConfig().GetTempDir(), "mergefile-"+label+"-*")
-		if err != nil {
-			return "", err
-		}
-		defer f.Close()
-
-		if _, err := f.Write([]byte(output)); err != nil {
-			return "", err
-		}
-
-		return f.Name(), nil
-	}
-
-	baseFilepath, err := showToTempFile(1, "base")
-	if err != nil {
-		return "", err
-	}
-	defer os.Remove(baseFilepath)
-
-	oursFilepath, err := showToTempFile(2, "ours")
-	if err != nil {
-		return "", err
-	}
-	defer os.Remove(oursFilepath)
-
-	theirsFilepath, err := showToTempFile(3, "theirs")
-	if err != nil {
-		return "", err
-	}
-	defer os.Remove(theirsFilepath)
-
-	return self.c.Git().WorkingTree.MergeFileForFiles(strategy, oursFilepath, baseFilepath, theirsFilepath)
+	return self.c.Git().WorkingTree.MergeStages(filepath, git_commands.MergeStagesOpts{Strategy: strategy})
 }
 
 func (self *WorkingTreeHelper) mergeFileWithObjectIDs(filepath, strategy string) (string, error) {
@@ -371,47 +335,111 @@ func (self *WorkingTreeHelper) CreateMergeConflictMenu(selectedFilepaths []strin
 	}
 
 	cmdColor := style.FgBlue
-	return self.c.Menu(types.CreateMenuOptions{
-		Title: self.c.Tr.MergeConflictOptionsTitle,
-		Items: []*types.MenuItem{
-			{
-				LabelColumns: []string{
-					self.c.Tr.UseCurrentChanges,
-					cmdColor.Sprint("git merge-file --ours"),
-				},
-				OnPress: func() error {
-					return onMergeStrategySelected("--ours")
-				},
-				Keys: menuKey('c'),
+	menuItems := []*types.MenuItem{
+		{
+			LabelColumns: []string{
+				self.c.Tr.UseCurrentChanges,
+				cmdColor.Sprint("git merge-file --ours"),
 			},
-			{
-				LabelColumns: []string{
-					self.c.Tr.UseIncomingChanges,
-					cmdColor.Sprint("git merge-file --theirs"),
-				},
-				OnPress: func() error {
-					return onMergeStrategySelected("--theirs")
-				},
-				Keys: menuKey('i'),
+			OnPress: func() error {
+				return onMergeStrategySelected("--ours")
 			},
-			{
-				LabelColumns: []string{
-					self.c.Tr.UseBothChanges,
-					cmdColor.Sprint("git merge-file --union"),
-				},
-				OnPress: func() error {
-					return onMergeStrategySelected("--union")
-				},
-				Keys: menuKey('b'),
+			Keys: menuKey('c'),
+		},
+		{
+			LabelColumns: []string{
+				self.c.Tr.UseIncomingChanges,
+				cmdColor.Sprint("git merge-file --theirs"),
 			},
-			{
-				LabelColumns: []string{
-					self.c.Tr.OpenMergeTool,
-					cmdColor.Sprint("git mergetool"),
-				},
-				OnPress: self.OpenMergeTool,
-				Keys:    menuKey('m'),
+			OnPress: func() error {
+				return onMergeStrategySelected("--theirs")
+			},
+			Keys: menuKey('i'),
+		},
+		{
+			LabelColumns: []string{
+				self.c.Tr.UseBothChanges,
+				cmdColor.Sprint("git merge-file --union"),
+			},
+			OnPress: func() error {
+				return onMergeStrategySelected("--union")
 			},
+			Keys: menuKey('b'),
 		},
+		{
+			LabelColumns: []string{
+				self.c.Tr.OpenMergeTool,
+				cmdColor.Sprint("git mergetool"),
+			},
+			OnPress: self.OpenMergeTool,
+			Keys:    menuKey('m'),
+		},
+	}
+
+	// Only worth offering to teams that actually use rerere
+	if self.c.Git().Rerere.IsEnabled() {
+		menuItems = append(menuItems, self.rerereMenuItems(selectedFilepaths, cmdColor)...)
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: self.c.Tr.MergeConflictOptionsTitle,
+		Items: menuItems,
+	})
+}
+
+func (self *WorkingTreeHelper) rerereMenuItems(selectedFilepaths []string, cmdColor style.TextStyle) []*types.MenuItem {
+	resolvedFilepaths := lo.Filter(selectedFilepaths, func(path string, _ int) bool {
+		file, found := lo.Find(self.c.Model().Files, func(file *models.File) bool { return file.Path == path })
+		return found && file.ResolvedByRerere
 	})
+
+	var forgetDisabledReason *types.DisabledReason
+	if len(resolvedFilepaths) == 0 {
+		forgetDisabledReason = &types.DisabledReason{Text: self.c.Tr.NoFilesResolvedByRerere}
+	}
+
+	return []*types.MenuItem{
+		{
+			LabelColumns: []string{
+				self.c.Tr.ForgetRerereResolution,
+				cmdColor.Sprint("git rerere forget"),
+			},
+			Tooltip:        self.c.Tr.ForgetRerereResolutionTooltip,
+			DisabledReason: forgetDisabledReason,
+			OnPress: func() error {
+				return self.forgetRerereResolutions(resolvedFilepaths)
+			},
+			Keys: menuKey('f'),
+		},
+	}
+}
+
+// Forgetting the resolution recreates the conflict markers, which throws away
+// whatever is in the files now
+func (self *WorkingTreeHelper) forgetRerereResolutions(paths []string) error {
+	self.c.Confirm(types.ConfirmOpts{
+		Title: self.c.Tr.ForgetRerereResolution,
+		Prompt: utils.ResolvePlaceholderString(self.c.Tr.ForgetRerereResolutionPrompt, map[string]string{
+			"files": strings.Join(paths, ", "),
+		}),
+		HandleConfirm: func() error {
+			self.c.LogAction(self.c.Tr.Actions.ForgetRerereResolution)
+			err := self.c.Git().Rerere.Forget(paths)
+			self.c.Refresh(types.RefreshOptions{Mode: types.SYNC, Scope: []types.RefreshableView{types.FILES}})
+			return err
+		},
+	})
+
+	return nil
+}
+
+// RerereResolutionTask shows the resolution that rerere replayed for the
+// given file
+func (self *WorkingTreeHelper) RerereResolutionTask(file *models.File) types.UpdateTask {
+	if file.RerereID == "" {
+		return types.NewRenderStringTask(self.c.Tr.NoRerereResolutionFound)
+	}
+
+	cmdObj := self.c.Git().Rerere.ResolutionDiffCmdObj(file.RerereID)
+	return types.NewRunPtyTask(cmdObj.GetCmd())
 }
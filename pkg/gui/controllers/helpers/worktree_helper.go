@@ -10,6 +10,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 type WorktreeHelper struct {
@@ -219,9 +220,21 @@ func (self *WorktreeHelper) Detach(worktree *models.Worktree) error {
 	})
 }
 
-func (self *WorktreeHelper) ViewWorktreeOptions(context types.IListContext, ref string) error {
+func (self *WorktreeHelper) ViewWorktreeOptions(listContext types.IListContext, ref string) error {
+	// in the worktrees panel the selected item is a worktree (identified by its
+	// path) rather than a ref, so we offer to manage it instead
+	if listContext.GetKey() == context.WORKTREES_CONTEXT_KEY {
+		worktree, ok := lo.Find(self.c.Model().Worktrees, func(worktree *models.Worktree) bool {
+			return worktree.Path == ref
+		})
+		if !ok {
+			return nil
+		}
+		return self.ViewManageWorktreeOptions(worktree)
+	}
+
 	currentBranch := self.refsHelper.GetCheckedOutRef()
-	canCheckoutBase := context == self.c.Contexts().Branches && ref != currentBranch.RefName()
+	canCheckoutBase := listContext == self.c.Contexts().Branches && ref != currentBranch.RefName()
 
 	return self.ViewBranchWorktreeOptions(ref, canCheckoutBase)
 }
@@ -247,3 +260,146 @@ func (self *WorktreeHelper) ViewBranchWorktreeOptions(branchName string, canChec
 		},
 	})
 }
+
+func (self *WorktreeHelper) ViewManageWorktreeOptions(worktree *models.Worktree) error {
+	lockItem := &types.MenuItem{
+		Label:   self.c.Tr.LockWorktree,
+		Tooltip: self.c.Tr.LockWorktreeTooltip,
+		OnPress: func() error { return self.Lock(worktree) },
+		Keys:    menuKey('l'),
+	}
+	if worktree.IsLocked {
+		lockItem = &types.MenuItem{
+			Label:   self.c.Tr.UnlockWorktree,
+			Tooltip: self.c.Tr.UnlockWorktreeTooltip,
+			OnPress: func() error { return self.Unlock(worktree) },
+			Keys:    menuKey('l'),
+		}
+	}
+	if worktree.IsMain {
+		lockItem.DisabledReason = &types.DisabledReason{Text: self.c.Tr.CantLockMainWorktree}
+	}
+
+	moveItem := &types.MenuItem{
+		Label:   self.c.Tr.MoveWorktree,
+		Tooltip: self.c.Tr.MoveWorktreeTooltip,
+		OnPress: func() error { return self.Move(worktree) },
+		Keys:    menuKey('m'),
+	}
+	if worktree.IsMain {
+		moveItem.DisabledReason = &types.DisabledReason{Text: self.c.Tr.CantMoveMainWorktree}
+	} else if worktree.IsCurrent {
+		moveItem.DisabledReason = &types.DisabledReason{Text: self.c.Tr.CantMoveCurrentWorktree}
+	} else if worktree.IsLocked {
+		moveItem.DisabledReason = &types.DisabledReason{Text: self.c.Tr.CantMoveLockedWorktree}
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: worktree.Name,
+		Items: []*types.MenuItem{
+			lockItem,
+			moveItem,
+			{
+				Label:   self.c.Tr.RepairWorktree,
+				Tooltip: self.c.Tr.RepairWorktreeTooltip,
+				OnPress: func() error { return self.Repair(worktree) },
+				Keys:    menuKey('r'),
+			},
+			{
+				Label:   self.c.Tr.PruneWorktrees,
+				Tooltip: self.c.Tr.PruneWorktreesTooltip,
+				OnPress: self.Prune,
+				Keys:    menuKey('p'),
+			},
+		},
+	})
+}
+
+func (self *WorktreeHelper) Lock(worktree *models.Worktree) error {
+	self.c.Prompt(types.PromptOpts{
+		Title:           self.c.Tr.LockWorktreeReason,
+		AllowEmptyInput: true,
+		HandleConfirm: func(reason string) error {
+			return self.c.WithWaitingStatus(self.c.Tr.LockingWorktree, func(gocui.Task) error {
+				self.c.LogAction(self.c.Tr.LockWorktree)
+				if err := self.c.Git().Worktree.Lock(worktree.Path, reason); err != nil {
+					return err
+				}
+				self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.WORKTREES}})
+				return nil
+			})
+		},
+	})
+
+	return nil
+}
+
+func (self *WorktreeHelper) Unlock(worktree *models.Worktree) error {
+	return self.c.WithWaitingStatus(self.c.Tr.UnlockingWorktree, func(gocui.Task) error {
+		self.c.LogAction(self.c.Tr.UnlockWorktree)
+		if err := self.c.Git().Worktree.Unlock(worktree.Path); err != nil {
+			return err
+		}
+		self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.WORKTREES}})
+		return nil
+	})
+}
+
+func (self *WorktreeHelper) Move(worktree *models.Worktree) error {
+	self.c.Prompt(types.PromptOpts{
+		Title: utils.ResolvePlaceholderString(self.c.Tr.MoveWorktreePath,
+			map[string]string{"worktreeName": worktree.Name}),
+		InitialContent: worktree.Path,
+		HandleConfirm: func(newPath string) error {
+			return self.c.WithWaitingStatus(self.c.Tr.MovingWorktree, func(gocui.Task) error {
+				self.c.LogAction(self.c.Tr.MoveWorktree)
+				if err := self.c.Git().Worktree.Move(worktree.Path, newPath); err != nil {
+					return err
+				}
+				self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.WORKTREES}})
+				return nil
+			})
+		},
+	})
+
+	return nil
+}
+
+func (self *WorktreeHelper) Repair(worktree *models.Worktree) error {
+	self.c.Prompt(types.PromptOpts{
+		Title: utils.ResolvePlaceholderString(self.c.Tr.RepairWorktreePath,
+			map[string]string{"worktreeName": worktree.Name}),
+		InitialContent: worktree.Path,
+		HandleConfirm: func(path string) error {
+			return self.c.WithWaitingStatus(self.c.Tr.RepairingWorktree, func(gocui.Task) error {
+				self.c.LogAction(self.c.Tr.RepairWorktree)
+				if err := self.c.Git().Worktree.Repair(path); err != nil {
+					return err
+				}
+				self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.WORKTREES, types.BRANCHES}})
+				return nil
+			})
+		},
+	})
+
+	return nil
+}
+
+func (self *WorktreeHelper) Prune() error {
+	self.c.Confirm(types.ConfirmOpts{
+		Title:  self.c.Tr.PruneWorktrees,
+		Prompt: self.c.Tr.PruneWorktreesPrompt,
+		HandleConfirm: func() error {
+			return self.c.WithWaitingStatus(self.c.Tr.PruningWorktrees, func(gocui.Task) error {
+				self.c.LogAction(self.c.Tr.PruneWorktrees)
+				if err := self.c.Git().Worktree.Prune(); err != nil {
+					return err
+				}
+				self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.WORKTREES, types.BRANCHES}})
+				return nil
+			})
+		},
+	})
+
+	return nil
+}
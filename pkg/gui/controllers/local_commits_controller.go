@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -12,6 +13,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/samber/lo"
 	"github.com/stefanhaller/git-todo-parser/todo"
@@ -245,6 +247,22 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 			Description:       self.c.Tr.TagCommit,
 			Tooltip:           self.c.Tr.TagCommitTooltip,
 		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Commits.ViewNotesOptions),
+			Handler:           self.withItem(self.c.Helpers().Notes.OpenNotesMenu),
+			GetDisabledReason: self.require(self.singleItemSelected(self.canEditNotes)),
+			Description:       self.c.Tr.ViewNotesOptions,
+			Tooltip:           self.c.Tr.ViewNotesOptionsTooltip,
+			OpensMenu:         true,
+		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Commits.ExportPatchSeries),
+			Handler:           self.withItemsRange(self.exportPatchSeries),
+			GetDisabledReason: self.require(self.itemRangeSelected(self.canExportPatchSeries)),
+			Description:       self.c.Tr.ExportPatchSeries,
+			Tooltip:           self.c.Tr.ExportPatchSeriesTooltip,
+			OpensMenu:         true,
+		},
 		{
 			Keys:        opts.GetKeys(opts.Config.Commits.OpenLogMenu),
 			Handler:     self.handleOpenLogMenu,
@@ -1211,6 +1229,80 @@ func (self *LocalCommitsController) createTag(commit *models.Commit) error {
 	return self.c.Helpers().Tags.OpenCreateTagPrompt(commit.Hash(), func() {})
 }
 
+func (self *LocalCommitsController) canEditNotes(commit *models.Commit) *types.DisabledReason {
+	if commit.IsTODO() {
+		return &types.DisabledReason{Text: self.c.Tr.NotesNotAvailableForTodo}
+	}
+
+	return nil
+}
+
+func (self *LocalCommitsController) canExportPatchSeries(selectedCommits []*models.Commit, startIdx int, endIdx int) *types.DisabledReason {
+	if lo.SomeBy(selectedCommits, func(commit *models.Commit) bool { return commit.IsTODO() }) {
+		return &types.DisabledReason{Text: self.c.Tr.CannotExportTodoCommits}
+	}
+
+	return nil
+}
+
+func (self *LocalCommitsController) exportPatchSeries(selectedCommits []*models.Commit, startIdx int, endIdx int) error {
+	// commits are listed newest first
+	newestCommit := selectedCommits[0]
+	oldestCommit := selectedCommits[len(selectedCommits)-1]
+	base := ""
+	if !oldestCommit.IsFirstCommit() {
+		base = oldestCommit.ParentRefName()
+	}
+
+	export := func(coverLetter bool) func() error {
+		return func() error {
+			self.c.Prompt(types.PromptOpts{
+				Title:          self.c.Tr.PatchSeriesOutputDirectory,
+				InitialContent: ".",
+				HandleConfirm: func(outputDir string) error {
+					return self.c.WithWaitingStatus(self.c.Tr.ExportingPatchSeriesStatus, func(gocui.Task) error {
+						self.c.LogAction(self.c.Tr.Actions.ExportPatchSeries)
+						files, err := self.c.Git().Patch.FormatPatch(git_commands.FormatPatchOpts{
+							Base:        base,
+							To:          newestCommit.Hash(),
+							OutputDir:   outputDir,
+							CoverLetter: coverLetter,
+						})
+						if err != nil {
+							return err
+						}
+
+						self.c.Toast(utils.ResolvePlaceholderString(self.c.Tr.ExportedPatchSeries, map[string]string{
+							"count": strconv.Itoa(len(files)),
+							"dir":   outputDir,
+						}))
+						return nil
+					})
+				},
+			})
+
+			return nil
+		}
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title: self.c.Tr.ExportPatchSeries,
+		Items: []*types.MenuItem{
+			{
+				Label:   self.c.Tr.ExportWithoutCoverLetter,
+				OnPress: export(false),
+				Keys:    menuKey('e'),
+			},
+			{
+				Label:   self.c.Tr.ExportWithCoverLetter,
+				Tooltip: self.c.Tr.ExportWithCoverLetterTooltip,
+				OnPress: export(true),
+				Keys:    menuKey('c'),
+			},
+		},
+	})
+}
+
 func (self *LocalCommitsController) openSearch() error {
 	// we usually lazyload these commits but now that we're searching we need to load them now
 	if self.context().GetLimitCommits() {
@@ -1402,20 +1494,12 @@ func (self *LocalCommitsController) canFindCommitForSquashFixupsInCurrentBranch(
 }
 
 func (self *LocalCommitsController) canSquashOrFixup(selectedCommits []*models.Commit, startIdx int, endIdx int) *types.DisabledReason {
-	if endIdx >= len(self.c.Model().Commits)-1 {
-		return &types.DisabledReason{Text: self.c.Tr.CannotSquashOrFixupFirstCommit}
-	}
-
-	if lo.SomeBy(selectedCommits, func(c *models.Commit) bool { return c.IsMerge() }) {
-		return &types.DisabledReason{Text: self.c.Tr.CannotSquashOrFixupMergeCommit}
-	}
-
-	return nil
+	return CanSquashOrFixupCommits(self.c.Tr, self.c.Model().Commits, startIdx, endIdx)
 }
 
 func (self *LocalCommitsController) canMoveDown(selectedCommits []*models.Commit, startIdx int, endIdx int) *types.DisabledReason {
-	if endIdx >= len(self.c.Model().Commits)-1 {
-		return &types.DisabledReason{Text: self.c.Tr.CannotMoveAnyFurther}
+	if reason := CanMoveCommitsDown(self.c.Tr, self.c.Model().Commits, startIdx, endIdx); reason != nil {
+		return reason
 	}
 
 	if self.isRebasing() {
@@ -1430,8 +1514,8 @@ func (self *LocalCommitsController) canMoveDown(selectedCommits []*models.Commit
 }
 
 func (self *LocalCommitsController) canMoveUp(selectedCommits []*models.Commit, startIdx int, endIdx int) *types.DisabledReason {
-	if startIdx == 0 {
-		return &types.DisabledReason{Text: self.c.Tr.CannotMoveAnyFurther}
+	if reason := CanMoveCommitsUp(self.c.Tr, self.c.Model().Commits, startIdx, endIdx); reason != nil {
+		return reason
 	}
 
 	if self.isRebasing() {
@@ -1475,11 +1559,7 @@ func (self *LocalCommitsController) midRebaseMoveCommandEnabled(selectedCommits
 	}
 
 	if !self.isRebasing() {
-		if lo.SomeBy(selectedCommits, func(c *models.Commit) bool { return c.IsMerge() }) {
-			return &types.DisabledReason{Text: self.c.Tr.CannotMoveMergeCommit}
-		}
-
-		return nil
+		return CanMoveCommits(self.c.Tr, selectedCommits)
 	}
 
 	for _, commit := range selectedCommits {
@@ -1503,11 +1583,7 @@ func (self *LocalCommitsController) canDropCommits(selectedCommits []*models.Com
 	}
 
 	if !self.isRebasing() {
-		if len(selectedCommits) > 1 && lo.SomeBy(selectedCommits, func(c *models.Commit) bool { return c.IsMerge() }) {
-			return &types.DisabledReason{Text: self.c.Tr.DroppingMergeRequiresSingleSelection}
-		}
-
-		return nil
+		return CanDropCommits(self.c.Tr, selectedCommits)
 	}
 
 	nonUpdateRefTodos := lo.Filter(selectedCommits, func(c *models.Commit, _ int) bool {
@@ -1527,6 +1603,53 @@ func (self *LocalCommitsController) canDropCommits(selectedCommits []*models.Com
 	return nil
 }
 
+// The checks below are the ones that apply when no rebase is in progress. The
+// `lazygit rebase` subcommands do the same checks before touching any commits.
+
+func CanMoveCommitsUp(tr *i18n.TranslationSet, commits []*models.Commit, startIdx int, endIdx int) *types.DisabledReason {
+	if startIdx == 0 {
+		return &types.DisabledReason{Text: tr.CannotMoveAnyFurther}
+	}
+
+	return nil
+}
+
+func CanMoveCommitsDown(tr *i18n.TranslationSet, commits []*models.Commit, startIdx int, endIdx int) *types.DisabledReason {
+	if endIdx >= len(commits)-1 {
+		return &types.DisabledReason{Text: tr.CannotMoveAnyFurther}
+	}
+
+	return nil
+}
+
+func CanMoveCommits(tr *i18n.TranslationSet, selectedCommits []*models.Commit) *types.DisabledReason {
+	if lo.SomeBy(selectedCommits, func(c *models.Commit) bool { return c.IsMerge() }) {
+		return &types.DisabledReason{Text: tr.CannotMoveMergeCommit}
+	}
+
+	return nil
+}
+
+func CanDropCommits(tr *i18n.TranslationSet, selectedCommits []*models.Commit) *types.DisabledReason {
+	if len(selectedCommits) > 1 && lo.SomeBy(selectedCommits, func(c *models.Commit) bool { return c.IsMerge() }) {
+		return &types.DisabledReason{Text: tr.DroppingMergeRequiresSingleSelection}
+	}
+
+	return nil
+}
+
+func CanSquashOrFixupCommits(tr *i18n.TranslationSet, commits []*models.Commit, startIdx int, endIdx int) *types.DisabledReason {
+	if endIdx >= len(commits)-1 {
+		return &types.DisabledReason{Text: tr.CannotSquashOrFixupFirstCommit}
+	}
+
+	if lo.SomeBy(commits[startIdx:endIdx+1], func(c *models.Commit) bool { return c.IsMerge() }) {
+		return &types.DisabledReason{Text: tr.CannotSquashOrFixupMergeCommit}
+	}
+
+	return nil
+}
+
 // These actions represent standard things you might want to do with a commit,
 // as opposed to TODO actions like 'merge', 'update-ref', etc.
 var standardActions = []todo.TodoCommand{
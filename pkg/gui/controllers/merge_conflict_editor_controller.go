@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+type MergeConflictEditorController struct {
+	baseController
+	c *ControllerCommon
+}
+
+var _ types.IController = &MergeConflictEditorController{}
+
+func NewMergeConflictEditorController(
+	c *ControllerCommon,
+) *MergeConflictEditorController {
+	return &MergeConflictEditorController{
+		baseController: baseController{},
+		c:              c,
+	}
+}
+
+func (self *MergeConflictEditorController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	return []*types.Binding{
+		{
+			Keys:    opts.GetKeys(opts.Config.Universal.PrevItem),
+			Handler: self.withRender(self.selectPrevLine),
+			Tag:     "navigation",
+		},
+		{
+			Keys:    opts.GetKeys(opts.Config.Universal.NextItem),
+			Handler: self.withRender(self.selectNextLine),
+			Tag:     "navigation",
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.PrevBlock),
+			Handler:     self.withRender(self.selectPrevPane),
+			Description: self.c.Tr.SelectPrevPane,
+			Tag:         "navigation",
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.NextBlock),
+			Handler:     self.withRender(self.selectNextPane),
+			Description: self.c.Tr.SelectNextPane,
+			Tag:         "navigation",
+		},
+		{
+			Keys:            opts.GetKeys(opts.Config.Universal.Select),
+			Handler:         self.withRender(self.pickLine),
+			Description:     self.c.Tr.PickLine,
+			Tooltip:         self.c.Tr.PickLineTooltip,
+			DisplayOnScreen: true,
+		},
+		{
+			Keys:            opts.GetKeys(opts.Config.Main.PickBothHunks),
+			Handler:         self.withRender(self.pickAllLinesOfPane),
+			Description:     self.c.Tr.PickAllLinesOfPane,
+			Tooltip:         self.c.Tr.PickAllLinesOfPaneTooltip,
+			DisplayOnScreen: true,
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.Remove),
+			Handler:     self.withRender(self.clearResult),
+			Description: self.c.Tr.ClearResult,
+		},
+		{
+			Keys:            opts.GetKeys(opts.Config.Universal.Edit),
+			Handler:         self.editResult,
+			Description:     self.c.Tr.EditResult,
+			Tooltip:         self.c.Tr.EditResultTooltip,
+			DisplayOnScreen: true,
+		},
+		{
+			Keys:            opts.GetKeys(opts.Config.Universal.GoInto),
+			Handler:         self.apply,
+			Description:     self.c.Tr.ApplyResult,
+			Tooltip:         self.c.Tr.ApplyResultTooltip,
+			DisplayOnScreen: true,
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.PrevTab),
+			Handler:     self.withLock(self.prevConflict),
+			Description: self.c.Tr.PrevConflict,
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.NextTab),
+			Handler:     self.withLock(self.nextConflict),
+			Description: self.c.Tr.NextConflict,
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Universal.Return),
+			Handler:     self.escape,
+			Description: self.c.Tr.ReturnToMergeConflictsView,
+		},
+	}
+}
+
+func (self *MergeConflictEditorController) GetOnFocus() func(types.OnFocusOpts) {
+	return func(types.OnFocusOpts) {
+		self.c.Contexts().MergeConflicts.GetMutex().Lock()
+		defer self.c.Contexts().MergeConflicts.GetMutex().Unlock()
+
+		self.c.Helpers().MergeConflictEditor.Render()
+	}
+}
+
+func (self *MergeConflictEditorController) Context() types.Context {
+	return self.context()
+}
+
+func (self *MergeConflictEditorController) context() *context.MergeConflictEditorContext {
+	return self.c.Contexts().MergeConflictEditor
+}
+
+func (self *MergeConflictEditorController) selectPrevLine(state *mergeconflicts.ThreeWayState) error {
+	state.SelectPrevLine()
+	return nil
+}
+
+func (self *MergeConflictEditorController) selectNextLine(state *mergeconflicts.ThreeWayState) error {
+	state.SelectNextLine()
+	return nil
+}
+
+func (self *MergeConflictEditorController) selectPrevPane(state *mergeconflicts.ThreeWayState) error {
+	state.SelectPrevPane()
+	return nil
+}
+
+func (self *MergeConflictEditorController) selectNextPane(state *mergeconflicts.ThreeWayState) error {
+	state.SelectNextPane()
+	return nil
+}
+
+func (self *MergeConflictEditorController) pickLine(state *mergeconflicts.ThreeWayState) error {
+	state.TogglePickSelectedLine()
+	return nil
+}
+
+func (self *MergeConflictEditorController) pickAllLinesOfPane(state *mergeconflicts.ThreeWayState) error {
+	state.PickAllLinesOfSelectedPane()
+	return nil
+}
+
+func (self *MergeConflictEditorController) clearResult(state *mergeconflicts.ThreeWayState) error {
+	state.ClearResult()
+	return nil
+}
+
+func (self *MergeConflictEditorController) prevConflict() error {
+	self.c.Contexts().MergeConflicts.GetState().SelectPrevConflict()
+	self.c.Helpers().MergeConflictEditor.LoadSelectedConflict()
+	self.c.Helpers().MergeConflictEditor.Render()
+	return nil
+}
+
+func (self *MergeConflictEditorController) nextConflict() error {
+	self.c.Contexts().MergeConflicts.GetState().SelectNextConflict()
+	self.c.Helpers().MergeConflictEditor.LoadSelectedConflict()
+	self.c.Helpers().MergeConflictEditor.Render()
+	return nil
+}
+
+// Lets the user change the result by hand. We don't hold the lock while the
+// editor is open, because editing triggers a refresh which needs it.
+func (self *MergeConflictEditorController) editResult() error {
+	state := self.context().GetState()
+	if state == nil {
+		return nil
+	}
+
+	path := self.c.Contexts().MergeConflicts.GetState().GetPath()
+	f, err := os.CreateTemp(self.c.GetConfig().GetTempDir(), "merge-result-*"+filepath.Ext(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	content := ""
+	if result := state.Result(); len(result) > 0 {
+		content = strings.Join(result, "\n") + "\n"
+	}
+	_, err = f.WriteString(content)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := self.c.Helpers().Files.EditFileAtLineAndWait(f.Name(), 1); err != nil {
+		return err
+	}
+
+	editedContent, err := self.c.Git().File.Cat(f.Name())
+	if err != nil {
+		return err
+	}
+
+	return self.withRender(func(state *mergeconflicts.ThreeWayState) error {
+		state.SetEditedResult(utils.SplitLines(editedContent))
+		return nil
+	})()
+}
+
+// Applying an empty result removes the conflict without keeping any of its
+// lines, which is rarely what you want, so we ask first
+func (self *MergeConflictEditorController) apply() error {
+	apply := self.withLock(self.c.Helpers().MergeConflictEditor.Apply)
+
+	self.c.Contexts().MergeConflicts.GetMutex().Lock()
+	state := self.context().GetState()
+	resultIsEmpty := state != nil && len(state.Result()) == 0
+	self.c.Contexts().MergeConflicts.GetMutex().Unlock()
+
+	if !resultIsEmpty {
+		return apply()
+	}
+
+	self.c.Confirm(types.ConfirmOpts{
+		Title:         self.c.Tr.ApplyResult,
+		Prompt:        self.c.Tr.ApplyEmptyResultPrompt,
+		HandleConfirm: apply,
+	})
+	return nil
+}
+
+func (self *MergeConflictEditorController) escape() error {
+	self.c.Context().Push(self.c.Contexts().MergeConflicts, types.OnFocusOpts{})
+	return nil
+}
+
+func (self *MergeConflictEditorController) withRender(f func(*mergeconflicts.ThreeWayState) error) func() error {
+	return self.withLock(func() error {
+		state := self.context().GetState()
+		if state == nil {
+			return nil
+		}
+
+		if err := f(state); err != nil {
+			return err
+		}
+
+		self.c.Helpers().MergeConflictEditor.Render()
+		return nil
+	})
+}
+
+func (self *MergeConflictEditorController) withLock(f func() error) func() error {
+	return func() error {
+		// the editor works on the state of the merge conflicts context
+		self.c.Contexts().MergeConflicts.GetMutex().Lock()
+		defer self.c.Contexts().MergeConflicts.GetMutex().Unlock()
+
+		return f()
+	}
+}
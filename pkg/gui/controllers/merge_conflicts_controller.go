@@ -63,6 +63,13 @@ func (self *MergeConflictsController) GetKeybindings(opts types.KeybindingsOpts)
 			Description:     self.c.Tr.NextConflict,
 			DisplayOnScreen: true,
 		},
+		{
+			Keys:            opts.GetKeys(opts.Config.Main.OpenThreeWayMergeEditor),
+			Handler:         self.c.Helpers().MergeConflictEditor.Open,
+			Description:     self.c.Tr.OpenThreeWayMergeEditor,
+			Tooltip:         self.c.Tr.OpenThreeWayMergeEditorTooltip,
+			DisplayOnScreen: true,
+		},
 		{
 			Keys:            opts.GetKeys(opts.Config.Universal.Undo),
 			Handler:         self.withRenderAndFocus(self.HandleUndo),
@@ -143,9 +150,12 @@ func (self *MergeConflictsController) GetOnFocus() func(types.OnFocusOpts) {
 }
 
 func (self *MergeConflictsController) GetOnFocusLost() func(types.OnFocusLostOpts) {
-	return func(types.OnFocusLostOpts) {
+	return func(opts types.OnFocusLostOpts) {
 		self.context().SetUserScrolling(false)
-		self.context().GetState().ResetConflictSelection()
+		// the three-way editor continues with the selected conflict
+		if opts.NewContextKey != context.MERGE_CONFLICT_EDITOR_CONTEXT_KEY {
+			self.context().GetState().ResetConflictSelection()
+		}
 		self.c.Views().MergeConflicts.Wrap = true
 	}
 }
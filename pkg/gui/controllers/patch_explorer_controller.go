@@ -123,6 +123,13 @@ func (self *PatchExplorerController) GetKeybindings(opts types.KeybindingsOpts)
 			Keys:    opts.GetKeys(opts.Config.Universal.ScrollRight),
 			Handler: self.withRenderAndFocus(self.HandleScrollRight),
 		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Main.ViewLineHistory),
+			Handler:           self.ViewLineHistory,
+			GetDisabledReason: self.getDisabledReasonForViewLineHistory,
+			Description:       self.c.Tr.ViewLineHistory,
+			Tooltip:           self.c.Tr.ViewLineHistoryTooltip,
+		},
 		{
 			Keys:        opts.GetKeys(opts.Config.Universal.CopyToClipboard),
 			Handler:     self.withLock(self.CopySelectedToClipboard),
@@ -283,6 +290,14 @@ func (self *PatchExplorerController) CopySelectedToClipboard() error {
 	return nil
 }
 
+func (self *PatchExplorerController) ViewLineHistory() error {
+	return self.c.Helpers().LineHistory.ViewLineHistory(self.context)
+}
+
+func (self *PatchExplorerController) getDisabledReasonForViewLineHistory() *types.DisabledReason {
+	return self.c.Helpers().LineHistory.GetDisabledReason(self.context)
+}
+
 // Removes '+' or '-' from the beginning of each line in the diff string, except
 // when both '+' and '-' lines are present, or diff header lines, in which case
 // the diff is returned unchanged. This is useful for copying parts of diffs to
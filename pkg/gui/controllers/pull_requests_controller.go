@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+type PullRequestsController struct {
+	baseController
+	*ListControllerTrait[*models.PullRequest]
+	c *ControllerCommon
+}
+
+var _ types.IController = &PullRequestsController{}
+
+func NewPullRequestsController(
+	c *ControllerCommon,
+) *PullRequestsController {
+	return &PullRequestsController{
+		baseController: baseController{},
+		ListControllerTrait: NewListControllerTrait(
+			c,
+			c.Contexts().PullRequests,
+			c.Contexts().PullRequests.GetSelected,
+			c.Contexts().PullRequests.GetSelectedItems,
+		),
+		c: c,
+	}
+}
+
+func (self *PullRequestsController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	bindings := []*types.Binding{
+		{
+			Keys:              opts.GetKeys(opts.Config.Universal.Select),
+			Handler:           self.withItem(self.checkout),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.CheckoutPullRequest,
+			Tooltip:           self.c.Tr.CheckoutPullRequestTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Branches.OpenPullRequestInBrowser),
+			Handler:           self.withItem(self.openInBrowser),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.OpenPullRequestInBrowser,
+		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Branches.CopyPullRequestURL),
+			Handler:           self.withItem(self.copyURL),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.CopyPullRequestURL,
+		},
+	}
+
+	return bindings
+}
+
+func (self *PullRequestsController) GetOnRenderToMain() func() {
+	return func() {
+		var task types.UpdateTask
+		pr := self.context().GetSelected()
+		if pr == nil {
+			task = types.NewRenderStringTask(self.c.Tr.NoOpenPullRequests)
+		} else {
+			var builder strings.Builder
+			builder.WriteString(style.AttrBold.Sprintf("%s #%d", pr.Title, pr.Number))
+			builder.WriteString("\n\n")
+
+			w := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "%s:\t%s\n", self.c.Tr.PullRequestAuthor, style.FgCyan.Sprint(pr.Author))
+			_, _ = fmt.Fprintf(w, "%s:\t%s → %s\n", self.c.Tr.PullRequestBranches,
+				style.FgGreen.Sprint(pr.HeadRefName), style.FgYellow.Sprint(pr.BaseRefName))
+			if review := presentation.PullRequestReviewDecision(pr.ReviewDecision, self.c.Tr); review != "" {
+				_, _ = fmt.Fprintf(w, "%s:\t%s\n", self.c.Tr.PullRequestReview, review)
+			}
+			if checks := self.checksDescription(pr.ChecksStatus); checks != "" {
+				_, _ = fmt.Fprintf(w, "%s:\t%s\n", self.c.Tr.PullRequestChecks, checks)
+			}
+			_ = w.Flush()
+
+			builder.WriteString("\n")
+			body := strings.TrimSpace(pr.Body)
+			if body == "" {
+				body = self.c.Tr.PullRequestNoDescription
+			}
+			builder.WriteString(body)
+
+			task = types.NewRenderStringTask(builder.String())
+		}
+
+		self.c.RenderToMainViews(types.RefreshMainOpts{
+			Pair: self.c.MainViewPairs().Normal,
+			Main: &types.ViewUpdateOpts{
+				Title: self.c.Tr.PullRequestTitle,
+				Task:  task,
+			},
+		})
+	}
+}
+
+func (self *PullRequestsController) checksDescription(checksStatus string) string {
+	icon := presentation.PullRequestChecksIcon(checksStatus)
+	switch checksStatus {
+	case "SUCCESS":
+		return icon + " " + self.c.Tr.PullRequestChecksPassed
+	case "FAILURE", "ERROR":
+		return icon + " " + self.c.Tr.PullRequestChecksFailed
+	case "PENDING", "EXPECTED":
+		return icon + " " + self.c.Tr.PullRequestChecksPending
+	default:
+		return ""
+	}
+}
+
+func (self *PullRequestsController) checkout(pr *models.PullRequest) error {
+	remoteName := self.c.Model().PullRequestsRemoteName
+
+	return self.c.WithWaitingStatus(self.c.Tr.CheckingOutPullRequest, func(task gocui.Task) error {
+		self.c.LogAction(self.c.Tr.Actions.CheckoutPullRequest)
+
+		if pr.IsCrossRepository {
+			return self.checkoutFromFork(task, pr, remoteName)
+		}
+
+		return self.checkoutFromBaseRepo(task, pr, remoteName)
+	})
+}
+
+func (self *PullRequestsController) checkoutFromFork(task gocui.Task, pr *models.PullRequest, remoteName string) error {
+	// The head branch lives in a fork which we usually don't have as a
+	// remote, so fetch the pull request ref into a local branch instead
+	localBranchName := fmt.Sprintf("pr/%d", pr.Number)
+	localBranchExists := lo.SomeBy(self.c.Model().Branches, func(branch *models.Branch) bool {
+		return branch.Name == localBranchName
+	})
+
+	if localBranchExists {
+		headHash, err := self.c.Git().GitHub.FetchPullRequestHeadCommit(task, remoteName, pr.Number)
+		if err != nil {
+			return err
+		}
+
+		// The local branch may have commits of its own (e.g. review fixups),
+		// the pull request may have been force-pushed to, or the branch may
+		// not have anything to do with the pull request at all
+		if !self.c.Git().Branch.IsAncestor(localBranchName, headHash) {
+			self.c.OnUIThread(func() error {
+				self.c.Confirm(types.ConfirmOpts{
+					Title: self.c.Tr.CheckoutPullRequest,
+					Prompt: utils.ResolvePlaceholderString(self.c.Tr.PullRequestLocalBranchDivergedPrompt, map[string]string{
+						"localBranch":  localBranchName,
+						"remoteBranch": pr.HeadRepositoryOwner.Login + ":" + pr.HeadRefName,
+					}),
+					HandleConfirm: func() error {
+						return self.c.Helpers().Refs.CheckoutRef(localBranchName, types.CheckoutRefOptions{})
+					},
+				})
+				return nil
+			})
+			return nil
+		}
+
+		if self.isCheckedOut(localBranchName) {
+			err := self.c.Git().GitHub.PullPullRequestHead(task, remoteName, pr.Number)
+			self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+			return err
+		}
+	}
+
+	if err := self.c.Git().GitHub.FetchPullRequestHead(task, remoteName, pr.Number, localBranchName); err != nil {
+		return err
+	}
+
+	self.c.OnUIThread(func() error {
+		return self.c.Helpers().Refs.CheckoutRef(localBranchName, types.CheckoutRefOptions{RefreshPullRequests: true})
+	})
+	return nil
+}
+
+func (self *PullRequestsController) checkoutFromBaseRepo(task gocui.Task, pr *models.PullRequest, remoteName string) error {
+	if err := self.c.Git().Sync.FetchRemote(task, remoteName); err != nil {
+		return err
+	}
+
+	branchName := pr.HeadRefName
+	remoteBranchName := remoteName + "/" + branchName
+	localBranchExists := lo.SomeBy(self.c.Model().Branches, func(branch *models.Branch) bool {
+		return branch.Name == branchName
+	})
+	if !localBranchExists {
+		if err := self.c.Git().Branch.CreateWithUpstream(branchName, remoteBranchName); err != nil {
+			return err
+		}
+
+		self.c.OnUIThread(func() error {
+			return self.c.Helpers().Refs.CheckoutRef(branchName, types.CheckoutRefOptions{RefreshPullRequests: true})
+		})
+		return nil
+	}
+
+	// The local branch may be behind the pull request, or it may not have
+	// anything to do with it at all and just happen to have the same name
+	if !self.c.Git().Branch.IsAncestor(branchName, remoteBranchName) {
+		self.c.OnUIThread(func() error {
+			self.c.Confirm(types.ConfirmOpts{
+				Title: self.c.Tr.CheckoutPullRequest,
+				Prompt: utils.ResolvePlaceholderString(self.c.Tr.PullRequestLocalBranchDivergedPrompt, map[string]string{
+					"localBranch":  branchName,
+					"remoteBranch": remoteBranchName,
+				}),
+				HandleConfirm: func() error {
+					return self.c.Helpers().Refs.CheckoutRef(branchName, types.CheckoutRefOptions{})
+				},
+			})
+			return nil
+		})
+		return nil
+	}
+
+	if self.isCheckedOut(branchName) {
+		err := self.c.Git().Sync.Pull(task, git_commands.PullOptions{
+			RemoteName:      remoteName,
+			BranchName:      branchName,
+			FastForwardOnly: true,
+		})
+		self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+		return err
+	}
+
+	if err := self.c.Git().Sync.FastForward(task, branchName, remoteName, branchName); err != nil {
+		return err
+	}
+
+	self.c.OnUIThread(func() error {
+		return self.c.Helpers().Refs.CheckoutRef(branchName, types.CheckoutRefOptions{})
+	})
+	return nil
+}
+
+func (self *PullRequestsController) isCheckedOut(branchName string) bool {
+	checkedOutBranch := self.c.Helpers().Refs.GetCheckedOutRef()
+	return checkedOutBranch != nil && checkedOutBranch.Name == branchName
+}
+
+func (self *PullRequestsController) openInBrowser(pr *models.PullRequest) error {
+	self.c.LogAction(self.c.Tr.Actions.OpenPullRequest)
+	return self.c.OS().OpenLink(pr.Url)
+}
+
+func (self *PullRequestsController) copyURL(pr *models.PullRequest) error {
+	self.c.LogAction(self.c.Tr.Actions.CopyPullRequestURL)
+	if err := self.c.OS().CopyToClipboard(pr.Url); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.PullRequestURLCopiedToClipboard)
+
+	return nil
+}
+
+func (self *PullRequestsController) context() *context.PullRequestsContext {
+	return self.c.Contexts().PullRequests
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+type RangeDiffController struct {
+	baseController
+	*ListControllerTrait[*models.RangeDiffEntry]
+	c *ControllerCommon
+}
+
+var _ types.IController = &RangeDiffController{}
+
+func NewRangeDiffController(
+	c *ControllerCommon,
+) *RangeDiffController {
+	return &RangeDiffController{
+		baseController: baseController{},
+		ListControllerTrait: NewListControllerTrait(
+			c,
+			c.Contexts().RangeDiff,
+			c.Contexts().RangeDiff.GetSelected,
+			c.Contexts().RangeDiff.GetSelectedItems,
+		),
+		c: c,
+	}
+}
+
+func (self *RangeDiffController) Context() types.Context {
+	return self.context()
+}
+
+func (self *RangeDiffController) context() *context.RangeDiffContext {
+	return self.c.Contexts().RangeDiff
+}
+
+func (self *RangeDiffController) GetOnRenderToMain() func() {
+	return func() {
+		var task types.UpdateTask
+		entry := self.context().GetSelected()
+		switch {
+		case entry == nil:
+			task = types.NewRenderStringTask(self.c.Tr.NoRangeDiffEntries)
+		case entry.HasOld() && entry.HasNew():
+			task = types.NewRunPtyTask(self.c.Git().Diff.RangeDiffCmdObj(entry.OldHash, entry.NewHash).GetCmd())
+		case entry.HasOld():
+			task = types.NewRunPtyTask(self.c.Git().Commit.ShowCmdObj(entry.OldHash, nil, self.c.Model().NotesRef).GetCmd())
+		default:
+			task = types.NewRunPtyTask(self.c.Git().Commit.ShowCmdObj(entry.NewHash, nil, self.c.Model().NotesRef).GetCmd())
+		}
+
+		self.c.RenderToMainViews(types.RefreshMainOpts{
+			Pair: self.c.MainViewPairs().Normal,
+			Main: &types.ViewUpdateOpts{
+				Title: self.c.Tr.RangeDiffTitle,
+				Task:  task,
+			},
+		})
+	}
+}
+
+// Going back to the context we came from ends range-diff mode; there's no
+// point in keeping it active when its view is no longer shown.
+func (self *RangeDiffController) GetOnFocusLost() func(types.OnFocusLostOpts) {
+	return func(opts types.OnFocusLostOpts) {
+		parentContext := self.context().GetParentContext()
+		if parentContext != nil && opts.NewContextKey == parentContext.GetKey() {
+			self.c.Helpers().RangeDiff.ClearRangeDiffMode()
+		}
+	}
+}
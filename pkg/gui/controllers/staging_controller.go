@@ -8,6 +8,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/gocui"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
 )
 
 type StagingController struct {
@@ -102,6 +103,13 @@ func (self *StagingController) GetKeybindings(opts types.KeybindingsOpts) []*typ
 			Handler:     self.c.Helpers().WorkingTree.HandleCommitEditorPress,
 			Description: self.c.Tr.CommitChangesWithEditor,
 		},
+		{
+			Keys:              opts.GetKeys(opts.Config.Files.StashAllChanges),
+			Handler:           self.StashSelection,
+			GetDisabledReason: self.stashSelectionDisabledReason,
+			Description:       self.c.Tr.StashSelectedLines,
+			Tooltip:           self.c.Tr.StashSelectedLinesTooltip,
+		},
 		{
 			Keys:        opts.GetKeys(opts.Config.Files.FindBaseCommitForFixup),
 			Handler:     self.c.Helpers().FixupHelper.HandleFindBaseCommitForFixupPress,
@@ -260,13 +268,24 @@ func (self *StagingController) applySelection(reverse bool) error {
 	// apply the patch then refresh this panel
 	// create a new temp file with the patch, then call git apply with that patch
 	self.c.LogAction(self.c.Tr.Actions.ApplyPatch)
-	err := self.c.Git().Patch.ApplyPatch(
-		patchToApply,
-		git_commands.ApplyPatchOpts{
-			Reverse: reverse,
-			Cached:  !reverse || self.staged,
-		},
-	)
+	applyPatch := func() error {
+		return self.c.Git().Patch.ApplyPatch(
+			patchToApply,
+			git_commands.ApplyPatchOpts{
+				Reverse: reverse,
+				Cached:  !reverse || self.staged,
+			},
+		)
+	}
+	var err error
+	if reverse {
+		// unstaging or discarding lines doesn't show up in the reflog, so we
+		// record it in the journal to make it undoable
+		description := lo.Ternary(self.staged, self.c.Tr.Actions.UnstageLines, self.c.Tr.Actions.DiscardLines)
+		err = self.c.Git().Journal.Record(description, []string{path}, applyPatch)
+	} else {
+		err = applyPatch()
+	}
 	if err != nil {
 		return err
 	}
@@ -279,6 +298,90 @@ func (self *StagingController) applySelection(reverse bool) error {
 	return nil
 }
 
+func (self *StagingController) stashSelectionDisabledReason() *types.DisabledReason {
+	// The patch we stash must be relative to HEAD, which the unstaged diff
+	// only is if nothing of the file is staged
+	file := self.c.Contexts().Files.GetSelectedFile()
+	if self.staged || file == nil {
+		return nil
+	}
+
+	if !file.Tracked {
+		return &types.DisabledReason{Text: self.c.Tr.CantStashLinesOfUntrackedFile}
+	}
+
+	if file.HasStagedChanges {
+		return &types.DisabledReason{Text: self.c.Tr.CantStashLinesWithStagedChanges}
+	}
+
+	return nil
+}
+
+func (self *StagingController) StashSelection() error {
+	if self.c.UserConfig().Git.DiffContextSize == 0 {
+		return fmt.Errorf(self.c.Tr.Actions.NotEnoughContextToStage,
+			self.c.UserConfig().Keybinding.Universal.IncreaseContextInDiffView)
+	}
+
+	self.c.Prompt(types.PromptOpts{
+		Title: self.c.Tr.StashChanges,
+		HandleConfirm: func(stashComment string) error {
+			if err := self.stashSelection(stashComment); err != nil {
+				return err
+			}
+
+			self.c.Refresh(types.RefreshOptions{Scope: []types.RefreshableView{types.STASH, types.FILES, types.STAGING}})
+			return nil
+		},
+		AllowEmptyInput: true,
+	})
+
+	return nil
+}
+
+func (self *StagingController) stashSelection(message string) error {
+	self.context.GetMutex().Lock()
+	defer self.context.GetMutex().Unlock()
+
+	state := self.context.GetState()
+	path := self.FilePath()
+	if path == "" || state == nil {
+		return nil
+	}
+
+	firstLineIdx, lastLineIdx := state.SelectedPatchRange()
+	transform := func(reverse bool) string {
+		return patch.
+			Parse(state.GetDiff()).
+			Transform(patch.TransformOpts{
+				Reverse:             reverse,
+				IncludedLineIndices: patch.ExpandRange(firstLineIdx, lastLineIdx),
+				FileNameOverride:    path,
+			}).
+			FormatPlain()
+	}
+
+	patchToStash := transform(false)
+	if patchToStash == "" {
+		return nil
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.StashSelectedLines)
+	if err := self.c.Git().Patch.StashPatch(patchToStash, transform(true), git_commands.StashPatchOpts{
+		Message: message,
+		Staged:  self.staged,
+	}); err != nil {
+		return err
+	}
+
+	if state.SelectingRange() {
+		firstLine, _ := state.SelectedViewRange()
+		state.SelectLine(firstLine)
+	}
+
+	return nil
+}
+
 func (self *StagingController) EditHunkAndRefresh() error {
 	if err := self.editHunk(); err != nil {
 		return err
@@ -2,12 +2,16 @@ package controllers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gocui"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 type StashController struct {
@@ -85,7 +89,12 @@ func (self *StashController) GetOnRenderToMain() func() {
 			if stashEntry == nil {
 				task = types.NewRenderStringTask(self.c.Tr.NoStashEntries)
 			} else {
-				prefix := style.FgYellow.Sprintf("%s\n\n", stashEntry.Description())
+				self.loadCommitsBehindHead(stashEntry)
+				prefix := style.FgYellow.Sprintf("%s\n", stashEntry.Description())
+				if baseContext := presentation.StashBaseContext(stashEntry, self.c.Tr); baseContext != "" {
+					prefix += style.FgBlue.Sprintf("%s\n", baseContext)
+				}
+				prefix += "\n"
 				task = types.NewRunPtyTaskWithPrefix(
 					self.c.Git().Stash.ShowStashEntryCmdObj(stashEntry.Index).GetCmd(),
 					prefix,
@@ -104,28 +113,58 @@ func (self *StashController) GetOnRenderToMain() func() {
 	}
 }
 
+// Counting commits can be slow in big repos, so rather than doing it for all
+// entries on every refresh, we do it in the background once an entry gets
+// selected, and render again when we know.
+func (self *StashController) loadCommitsBehindHead(stashEntry *models.StashEntry) {
+	if stashEntry.BaseHash == "" || stashEntry.CommitsBehindHeadLoaded {
+		return
+	}
+	stashEntry.CommitsBehindHeadLoaded = true
+
+	self.c.OnWorker(func(gocui.Task) error {
+		count, err := self.c.Git().Stash.CountCommitsBehindHead(stashEntry.BaseHash)
+		if err != nil {
+			self.c.Log.Error(err)
+			return nil
+		}
+
+		self.c.OnUIThread(func() error {
+			stashEntry.CommitsBehindHead = count
+			self.context().HandleRender()
+			if self.c.Context().Current() == self.context() && self.context().GetSelected() == stashEntry {
+				self.context().HandleRenderToMain()
+			}
+			return nil
+		})
+		return nil
+	})
+}
+
 func (self *StashController) context() *context.StashContext {
 	return self.c.Contexts().Stash
 }
 
 func (self *StashController) handleStashApply(stashEntry *models.StashEntry) error {
-	return self.c.ConfirmIf(!self.c.UserConfig().Gui.SkipStashWarning,
-		types.ConfirmOpts{
-			Title:  self.c.Tr.StashApply,
-			Prompt: self.c.Tr.SureApplyStashEntry,
-			HandleConfirm: func() error {
-				self.c.LogAction(self.c.Tr.Actions.ApplyStash)
-				err := self.c.Git().Stash.Apply(stashEntry.Index)
-				self.postStashRefresh()
-				if err != nil {
-					return err
-				}
-				if self.c.UserConfig().Gui.SwitchToFilesAfterStashApply {
-					self.c.Context().Push(self.c.Contexts().Files, types.OnFocusOpts{})
-				}
-				return nil
-			},
-		})
+	return self.withStashConfirmationPrompt(stashEntry, self.c.Tr.SureApplyStashEntry, func(prompt string, conflicts bool) error {
+		return self.c.ConfirmIf(!self.c.UserConfig().Gui.SkipStashWarning || conflicts,
+			types.ConfirmOpts{
+				Title:  self.c.Tr.StashApply,
+				Prompt: prompt,
+				HandleConfirm: func() error {
+					self.c.LogAction(self.c.Tr.Actions.ApplyStash)
+					err := self.c.Git().Stash.Apply(stashEntry.Index)
+					self.postStashRefresh()
+					if err != nil {
+						return err
+					}
+					if self.c.UserConfig().Gui.SwitchToFilesAfterStashApply {
+						self.c.Context().Push(self.c.Contexts().Files, types.OnFocusOpts{})
+					}
+					return nil
+				},
+			})
+	})
 }
 
 func (self *StashController) handleStashPop(stashEntry *models.StashEntry) error {
@@ -143,19 +182,47 @@ func (self *StashController) handleStashPop(stashEntry *models.StashEntry) error
 		return nil
 	}
 
-	if self.c.UserConfig().Gui.SkipStashWarning {
-		return pop()
-	}
-
-	self.c.Confirm(types.ConfirmOpts{
-		Title:  self.c.Tr.StashPop,
-		Prompt: self.c.Tr.SurePopStashEntry,
-		HandleConfirm: func() error {
+	return self.withStashConfirmationPrompt(stashEntry, self.c.Tr.SurePopStashEntry, func(prompt string, conflicts bool) error {
+		if self.c.UserConfig().Gui.SkipStashWarning && !conflicts {
 			return pop()
-		},
+		}
+
+		self.c.Confirm(types.ConfirmOpts{
+			Title:  self.c.Tr.StashPop,
+			Prompt: prompt,
+			HandleConfirm: func() error {
+				return pop()
+			},
+		})
+
+		return nil
 	})
+}
 
-	return nil
+// Checks in the background which files would conflict if the stash entry were
+// applied, and then calls f on the UI thread with the prompt for confirming
+// applying or popping it, warning about those files, and whether there are
+// any. We ask for confirmation in that case even if the user turned the
+// warning off.
+func (self *StashController) withStashConfirmationPrompt(stashEntry *models.StashEntry, prompt string, f func(prompt string, conflicts bool) error) error {
+	return self.c.WithWaitingStatus(self.c.Tr.CheckingForStashConflictsStatus, func(gocui.Task) error {
+		conflicts, err := self.c.Git().Stash.PredictConflicts(stashEntry)
+		if err != nil {
+			self.c.Log.Error(err)
+		}
+
+		self.c.OnUIThread(func() error {
+			if len(conflicts) == 0 {
+				return f(prompt, false)
+			}
+
+			warning := utils.ResolvePlaceholderString(self.c.Tr.StashWouldConflict, map[string]string{
+				"files": strings.Join(lo.Map(conflicts, func(file string, _ int) string { return "  " + file }), "\n"),
+			})
+			return f(style.FgRed.Sprint(warning)+"\n\n"+prompt, true)
+		})
+		return nil
+	})
 }
 
 func (self *StashController) handleStashDrop(stashEntries []*models.StashEntry) error {
@@ -164,13 +231,20 @@ func (self *StashController) handleStashDrop(stashEntries []*models.StashEntry)
 		Prompt: self.c.Tr.SureDropStashEntry,
 		HandleConfirm: func() error {
 			self.c.LogAction(self.c.Tr.Actions.DropStash)
-			for i := len(stashEntries) - 1; i >= 0; i-- {
-				self.c.LogCommand(fmt.Sprintf(self.c.Tr.Log.DroppingStash, stashEntries[i].Hash), false)
-				err := self.c.Git().Stash.Drop(stashEntries[i].Index)
-				self.c.Refresh(types.RefreshOptions{Scope: []types.RefreshableView{types.STASH}})
-				if err != nil {
-					return err
+			indices := lo.Reverse(lo.Map(stashEntries, func(entry *models.StashEntry, _ int) int { return entry.Index }))
+			err := self.c.Git().Journal.RecordStashDrops(self.c.Tr.Actions.DropStash, indices, func() error {
+				for i := len(stashEntries) - 1; i >= 0; i-- {
+					self.c.LogCommand(fmt.Sprintf(self.c.Tr.Log.DroppingStash, stashEntries[i].Hash), false)
+					err := self.c.Git().Stash.Drop(stashEntries[i].Index)
+					self.c.Refresh(types.RefreshOptions{Scope: []types.RefreshableView{types.STASH}})
+					if err != nil {
+						return err
+					}
 				}
+				return nil
+			})
+			if err != nil {
+				return err
 			}
 			self.context().CollapseRangeSelectionToTop()
 			return nil
@@ -3,6 +3,7 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"runtime"
 	"strings"
 	"time"
 
@@ -68,6 +69,19 @@ func (self *StatusController) GetKeybindings(opts types.KeybindingsOpts) []*type
 			Handler:     func() error { self.switchToOrRotateAllBranchesLogsBackward(); return nil },
 			Description: self.c.Tr.AllBranchesLogGraphReverse,
 		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Status.ViewSparseCheckoutOptions),
+			Handler:     self.c.Helpers().SparseCheckout.OpenSparseCheckoutMenu,
+			Description: self.c.Tr.ViewSparseCheckoutOptions,
+			Tooltip:     self.c.Tr.ViewSparseCheckoutOptionsTooltip,
+			OpensMenu:   true,
+		},
+		{
+			Keys:        opts.GetKeys(opts.Config.Status.ApplyMailbox),
+			Handler:     self.c.Helpers().MergeAndRebase.ApplyMailbox,
+			Description: self.c.Tr.ApplyMailbox,
+			Tooltip:     self.c.Tr.ApplyMailboxTooltip,
+		},
 	}
 
 	return bindings
@@ -233,9 +247,13 @@ func (self *StatusController) showDashboard() {
 		versionStr = fmt.Sprintf("v%d.%d.%d", version.Major, version.Minor, version.Patch)
 	}
 
+	dashboardLines := []string{lazygitTitle()}
+	if hint := self.slowStatusHint(); hint != "" {
+		dashboardLines = append(dashboardLines, style.FgYellow.Sprint(hint))
+	}
+
 	dashboardString := strings.Join(
-		[]string{
-			lazygitTitle(),
+		append(dashboardLines,
 			fmt.Sprintf("Copyright %d Jesse Duffield", time.Now().Year()),
 			fmt.Sprintf("Keybindings: %s", fmt.Sprintf(constants.Links.Docs.Keybindings, versionStr)),
 			fmt.Sprintf("Config Options: %s", fmt.Sprintf(constants.Links.Docs.Config, versionStr)),
@@ -243,7 +261,7 @@ func (self *StatusController) showDashboard() {
 			fmt.Sprintf("Raise an Issue: %s", constants.Links.Issues),
 			fmt.Sprintf("Release Notes: %s", constants.Links.Releases),
 			style.FgMagenta.Sprintf("Become a sponsor: %s", constants.Links.Donate), // caffeine ain't free
-		}, "\n\n") + "\n"
+		), "\n\n") + "\n"
 
 	self.c.RenderToMainViews(types.RefreshMainOpts{
 		Pair: self.c.MainViewPairs().Normal,
@@ -254,6 +272,36 @@ func (self *StatusController) showDashboard() {
 	})
 }
 
+// How long loading the files may take before we suggest ways to speed it up
+const slowStatusThreshold = time.Second
+
+// slowStatusHint returns a suggestion for speeding up `git status` if the last
+// call was slow and the repo doesn't use the git features that help with that
+func (self *StatusController) slowStatusHint() string {
+	duration := self.c.Git().Loaders.FileLoader.LastStatusDuration()
+	if duration < slowStatusThreshold {
+		return ""
+	}
+
+	durationStr := duration.Round(100 * time.Millisecond).String()
+	gitConfig := self.c.Git().Config
+	// git's built-in file system monitor only exists on macOS and Windows
+	hasBuiltinFsmonitor := (runtime.GOOS == "darwin" || runtime.GOOS == "windows") &&
+		!self.c.Git().Version.IsOlderThan(2, 37, 0)
+	if hasBuiltinFsmonitor && !gitConfig.UsesFsmonitor() {
+		return fmt.Sprintf(self.c.Tr.SlowStatusFsmonitorHint, durationStr)
+	}
+	// git only uses its untracked cache when it doesn't have to list all
+	// untracked files
+	if !self.c.Git().Loaders.FileLoader.ListsUntrackedFilesLazily() {
+		return fmt.Sprintf(self.c.Tr.SlowStatusLazyUntrackedFilesHint, durationStr)
+	}
+	if !gitConfig.UsesUntrackedCache() {
+		return fmt.Sprintf(self.c.Tr.SlowStatusUntrackedCacheHint, durationStr)
+	}
+	return ""
+}
+
 func (self *StatusController) handleCheckForUpdate() error {
 	return self.c.Helpers().Update.CheckForUpdateInForeground()
 }
@@ -29,6 +29,21 @@ func NewSubCommitsController(
 	}
 }
 
+func (self *SubCommitsController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	bindings := []*types.Binding{
+		{
+			Keys:              opts.GetKeys(opts.Config.Commits.ViewNotesOptions),
+			Handler:           self.withItem(self.c.Helpers().Notes.OpenNotesMenu),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.ViewNotesOptions,
+			Tooltip:           self.c.Tr.ViewNotesOptionsTooltip,
+			OpensMenu:         true,
+		},
+	}
+
+	return bindings
+}
+
 func (self *SubCommitsController) Context() types.Context {
 	return self.context()
 }
@@ -44,6 +59,10 @@ func (self *SubCommitsController) GetOnRenderToMain() func() {
 			var task types.UpdateTask
 			if commit == nil {
 				task = types.NewRenderStringTask("No commits")
+			} else if lineRange := self.context().GetLineRange(); lineRange != nil {
+				cmdObj := self.c.Git().Commit.ShowLineRangeCmdObj(
+					self.context().GetRef().FullRefName(), lineRange, self.context().GetSelectedLineIdx())
+				task = types.NewRunPtyTask(cmdObj.GetCmd())
 			} else {
 				refRange := self.context().GetSelectedRefRangeForDiffFiles()
 				task = self.c.Helpers().Diff.GetUpdateTaskForRenderingCommitsDiff(commit, refRange)
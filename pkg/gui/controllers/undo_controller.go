@@ -3,10 +3,13 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"math"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/gocui"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 // Quick summary of how this all works:
@@ -18,6 +21,11 @@ import (
 // actions we can skip. E.g. if I do three things, A, B, and C, and hit undo twice,
 // the reflog will read UUCBA, and when I read the first two undos, I know to skip the following
 // two user actions, meaning we end up undoing reflog entry C. Redoing works in a similar way.
+// Operations that don't show up in the reflog (discarding changes, dropping stashes, ...)
+// are recorded in lazygit's own journal, together with undo/redo markers of their own,
+// and we interleave those with the reflog entries based on when they were recorded.
+// The reflog and the journal each keep their own counter, so that e.g. discarding a file
+// after undoing a commit doesn't prevent redoing the commit.
 
 type UndoController struct {
 	baseController
@@ -42,12 +50,18 @@ const (
 	COMMIT
 	REBASE
 	CURRENT_REBASE
+	JOURNAL
 )
 
 type reflogAction struct {
 	kind ReflogActionKind
 	from string
 	to   string
+	// only set for JOURNAL actions
+	journalEntry *git_commands.JournalEntry
+	// how many entries (of the reflog and the journal combined) are newer than
+	// the most recent undo marker of the action's own stream; -1 if there is none
+	newestUndoMarker int
 }
 
 func (self *UndoController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
@@ -128,6 +142,23 @@ func (self *UndoController) reflogUndo() error {
 			})
 			return true, nil
 
+		case JOURNAL:
+			self.c.Confirm(types.ConfirmOpts{
+				Title:  self.c.Tr.Actions.Undo,
+				Prompt: fmt.Sprintf(self.c.Tr.UndoJournalPrompt, action.journalEntry.Description),
+				HandleConfirm: func() error {
+					self.c.LogAction(self.c.Tr.Actions.Undo)
+					return self.c.WithWaitingStatus(undoingStatus, func(gocui.Task) error {
+						if err := self.c.Git().Journal.Undo(action.journalEntry); err != nil {
+							return err
+						}
+						self.refreshAfterJournalAction()
+						return nil
+					})
+				},
+			})
+			return true, nil
+
 		case CURRENT_REBASE:
 			// do nothing
 		}
@@ -145,66 +176,151 @@ func (self *UndoController) reflogRedo() error {
 		return errors.New(self.c.Tr.CantRedoWhileRebasing)
 	}
 
-	return self.parseReflogForActions(func(counter int, action reflogAction) (bool, error) {
-		// if we're redoing and the counter is zero, we just return
-		if counter == 0 {
-			return true, nil
-		} else if counter > 1 {
+	// Both the reflog and the journal may have an undone action that can be
+	// redone; we redo the one that was undone most recently
+	var candidates []reflogAction
+	reflogDone, journalDone := false, false
+	err := self.parseReflogForActions(func(counter int, action reflogAction) (bool, error) {
+		done := &reflogDone
+		if action.kind == JOURNAL {
+			done = &journalDone
+		}
+		if *done || counter > 1 {
 			return false, nil
 		}
 
-		switch action.kind {
-		case COMMIT, REBASE:
-			self.c.Confirm(types.ConfirmOpts{
-				Title:  self.c.Tr.Actions.Redo,
-				Prompt: fmt.Sprintf(self.c.Tr.HardResetAutostashPrompt, utils.ShortHash(action.to)),
-				HandleConfirm: func() error {
-					self.c.LogAction(self.c.Tr.Actions.Redo)
-					return self.hardResetWithAutoStash(action.to, hardResetOptions{
-						EnvVars:       redoEnvVars,
-						WaitingStatus: redoingStatus,
-					})
-				},
-			})
-			return true, nil
-
-		case CHECKOUT:
-			self.c.Confirm(types.ConfirmOpts{
-				Title:  self.c.Tr.Actions.Redo,
-				Prompt: fmt.Sprintf(self.c.Tr.CheckoutAutostashPrompt, action.to),
-				HandleConfirm: func() error {
-					self.c.LogAction(self.c.Tr.Actions.Redo)
-					return self.c.Helpers().Refs.CheckoutRef(action.to, types.CheckoutRefOptions{
-						EnvVars:       redoEnvVars,
-						WaitingStatus: redoingStatus,
-					})
-				},
-			})
-			return true, nil
-
-		case CURRENT_REBASE:
-			// do nothing
+		// if the counter is zero there is nothing left to redo in this stream
+		*done = true
+		if counter == 1 {
+			candidates = append(candidates, action)
 		}
+		return reflogDone && journalDone, nil
+	})
+	if err != nil || len(candidates) == 0 {
+		return err
+	}
 
-		self.c.Log.Error("didn't match on the user action when trying to redo")
-		return true, nil
+	action := lo.MinBy(candidates, func(a, b reflogAction) bool {
+		return a.newestUndoMarker < b.newestUndoMarker
 	})
+	return self.redoAction(action, redoEnvVars, redoingStatus)
+}
+
+func (self *UndoController) redoAction(action reflogAction, redoEnvVars []string, redoingStatus string) error {
+	switch action.kind {
+	case COMMIT, REBASE:
+		self.c.Confirm(types.ConfirmOpts{
+			Title:  self.c.Tr.Actions.Redo,
+			Prompt: fmt.Sprintf(self.c.Tr.HardResetAutostashPrompt, utils.ShortHash(action.to)),
+			HandleConfirm: func() error {
+				self.c.LogAction(self.c.Tr.Actions.Redo)
+				return self.hardResetWithAutoStash(action.to, hardResetOptions{
+					EnvVars:       redoEnvVars,
+					WaitingStatus: redoingStatus,
+				})
+			},
+		})
+		return nil
+
+	case CHECKOUT:
+		self.c.Confirm(types.ConfirmOpts{
+			Title:  self.c.Tr.Actions.Redo,
+			Prompt: fmt.Sprintf(self.c.Tr.CheckoutAutostashPrompt, action.to),
+			HandleConfirm: func() error {
+				self.c.LogAction(self.c.Tr.Actions.Redo)
+				return self.c.Helpers().Refs.CheckoutRef(action.to, types.CheckoutRefOptions{
+					EnvVars:       redoEnvVars,
+					WaitingStatus: redoingStatus,
+				})
+			},
+		})
+		return nil
+
+	case JOURNAL:
+		self.c.Confirm(types.ConfirmOpts{
+			Title:  self.c.Tr.Actions.Redo,
+			Prompt: fmt.Sprintf(self.c.Tr.RedoJournalPrompt, action.journalEntry.Description),
+			HandleConfirm: func() error {
+				self.c.LogAction(self.c.Tr.Actions.Redo)
+				return self.c.WithWaitingStatus(redoingStatus, func(gocui.Task) error {
+					if err := self.c.Git().Journal.Redo(action.journalEntry); err != nil {
+						return err
+					}
+					self.refreshAfterJournalAction()
+					return nil
+				})
+			},
+		})
+		return nil
+
+	case CURRENT_REBASE:
+		// do nothing
+	}
+
+	self.c.Log.Error("didn't match on the user action when trying to redo")
+	return nil
 }
 
 // Here we're going through the reflog and maintaining a counter that represents how many
 // undos/redos/user actions we've seen. when we hit a user action we call the callback specifying
 // what the counter is up to and the nature of the action.
+// The journal entries are interleaved with the reflog entries, with a separate counter.
 // If we find ourselves mid-rebase, we just return because undo/redo mid rebase
 // requires knowledge of previous TODO file states, which you can't just get from the reflog.
 // Though we might support this later, hence the use of the CURRENT_REBASE action kind.
 func (self *UndoController) parseReflogForActions(onUserAction func(counter int, action reflogAction) (bool, error)) error {
-	counter := 0
+	counter, journalCounter := 0, 0
+	// the number of reflog and journal entries seen so far
+	position := 0
+	newestUndoMarker, newestJournalUndoMarker := -1, -1
 	reflogCommits := self.c.Model().ReflogCommits
 	rebaseFinishCommitHash := ""
+
+	journalEntries, err := self.c.Git().Journal.Entries()
+	if err != nil {
+		return err
+	}
+	journalIdx := len(journalEntries) - 1
+	// Handles the journal entries (newest first) that are newer than the
+	// reflog entry at the given index
+	parseJournalEntries := func(reflogCommitIdx int) (bool, error) {
+		for ; journalIdx >= 0 && journalEntries[journalIdx].NewerReflogEntries <= reflogCommitIdx; journalIdx-- {
+			entry := journalEntries[journalIdx]
+			position++
+			// operations done in the middle of a rebase are undone along with
+			// the rebase as a whole
+			if rebaseFinishCommitHash != "" {
+				continue
+			}
+
+			switch entry.Kind {
+			case git_commands.JOURNAL_UNDO:
+				journalCounter++
+				if newestJournalUndoMarker == -1 {
+					newestJournalUndoMarker = position
+				}
+			case git_commands.JOURNAL_REDO:
+				journalCounter--
+			case git_commands.JOURNAL_OPERATION:
+				action := reflogAction{kind: JOURNAL, journalEntry: entry, newestUndoMarker: newestJournalUndoMarker}
+				if ok, err := onUserAction(journalCounter, action); ok {
+					return true, err
+				}
+				journalCounter--
+			}
+		}
+		return false, nil
+	}
+
 	var action *reflogAction
 	for reflogCommitIdx, reflogCommit := range reflogCommits {
 		action = nil
 
+		if ok, err := parseJournalEntries(reflogCommitIdx); ok {
+			return err
+		}
+		position++
+
 		prevCommitHash := ""
 		if len(reflogCommits)-1 >= reflogCommitIdx+1 {
 			prevCommitHash = reflogCommits[reflogCommitIdx+1].Hash()
@@ -213,6 +329,9 @@ func (self *UndoController) parseReflogForActions(onUserAction func(counter int,
 		if rebaseFinishCommitHash == "" {
 			if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^\[lazygit undo\]`); ok {
 				counter++
+				if newestUndoMarker == -1 {
+					newestUndoMarker = position
+				}
 			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^\[lazygit redo\]`); ok {
 				counter--
 			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase (-i )?\(abort\)|^rebase (-i )?\(finish\)`); ok {
@@ -235,6 +354,7 @@ func (self *UndoController) parseReflogForActions(onUserAction func(counter int,
 				// if we're going from one place to the same place we'll ignore the action.
 				continue
 			}
+			action.newestUndoMarker = newestUndoMarker
 			ok, err := onUserAction(counter, *action)
 			if ok {
 				return err
@@ -242,7 +362,17 @@ func (self *UndoController) parseReflogForActions(onUserAction func(counter int,
 			counter--
 		}
 	}
-	return nil
+
+	// whatever is left is older than the oldest remaining reflog entry
+	_, err = parseJournalEntries(math.MaxInt)
+	return err
+}
+
+func (self *UndoController) refreshAfterJournalAction() {
+	self.c.Refresh(types.RefreshOptions{
+		Mode:  types.ASYNC,
+		Scope: []types.RefreshableView{types.FILES, types.STASH, types.STAGING},
+	})
 }
 
 type hardResetOptions struct {
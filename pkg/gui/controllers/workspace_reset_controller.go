@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gocui"
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
@@ -37,7 +38,8 @@ func (self *FilesController) createResetMenu() error {
 						Prompt: self.c.Tr.NukeTreeConfirmation,
 						HandleConfirm: func() error {
 							self.c.LogAction(self.c.Tr.Actions.NukeWorkingTree)
-							if err := self.c.Git().WorkingTree.ResetAndClean(); err != nil {
+							paths := journalPathsForFiles(self.c.Model().Files, func(*models.File) bool { return true })
+							if err := self.c.Git().Journal.Record(self.c.Tr.Actions.NukeWorkingTree, paths, self.c.Git().WorkingTree.ResetAndClean); err != nil {
 								return err
 							}
 
@@ -63,7 +65,8 @@ func (self *FilesController) createResetMenu() error {
 			},
 			OnPress: func() error {
 				self.c.LogAction(self.c.Tr.Actions.DiscardUnstagedFileChanges)
-				if err := self.c.Git().WorkingTree.DiscardAnyUnstagedFileChanges(); err != nil {
+				paths := journalPathsForFiles(self.c.Model().Files, (*models.File).GetHasUnstagedChanges)
+				if err := self.c.Git().Journal.Record(self.c.Tr.Actions.DiscardUnstagedFileChanges, paths, self.c.Git().WorkingTree.DiscardAnyUnstagedFileChanges); err != nil {
 					return err
 				}
 
@@ -81,7 +84,8 @@ func (self *FilesController) createResetMenu() error {
 			},
 			OnPress: func() error {
 				self.c.LogAction(self.c.Tr.Actions.RemoveUntrackedFiles)
-				if err := self.c.Git().WorkingTree.RemoveUntrackedFiles(); err != nil {
+				paths := journalPathsForFiles(self.c.Model().Files, func(file *models.File) bool { return !file.Tracked })
+				if err := self.c.Git().Journal.Record(self.c.Tr.Actions.RemoveUntrackedFiles, paths, self.c.Git().WorkingTree.RemoveUntrackedFiles); err != nil {
 					return err
 				}
 
@@ -103,10 +107,13 @@ func (self *FilesController) createResetMenu() error {
 				if !self.c.Helpers().WorkingTree.IsWorkingTreeDirtyExceptSubmodules() {
 					return errors.New(self.c.Tr.NoTrackedStagedFilesStash)
 				}
-				if err := self.c.Git().Stash.SaveStagedChanges("[lazygit] tmp stash"); err != nil {
-					return err
-				}
-				if err := self.c.Git().Stash.DropNewest(); err != nil {
+				paths := journalPathsForFiles(self.c.Model().Files, (*models.File).GetHasStagedChanges)
+				if err := self.c.Git().Journal.Record(self.c.Tr.Actions.RemoveStagedFiles, paths, func() error {
+					if err := self.c.Git().Stash.SaveStagedChanges("[lazygit] tmp stash"); err != nil {
+						return err
+					}
+					return self.c.Git().Stash.DropNewest()
+				}); err != nil {
 					return err
 				}
 
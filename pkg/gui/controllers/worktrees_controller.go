@@ -102,6 +102,13 @@ func (self *WorktreesController) GetOnRenderToMain() func() {
 			}
 			_, _ = fmt.Fprintf(w, "%s:\t%s\n", self.c.Tr.Branch, branch)
 			_, _ = fmt.Fprintf(w, "%s:\t%s%s\n", self.c.Tr.Path, style.FgCyan.Sprint(worktree.Path), missing)
+			if worktree.IsLocked {
+				reason := worktree.LockReason
+				if reason == "" {
+					reason = self.c.Tr.NoLockReason
+				}
+				_, _ = fmt.Fprintf(w, "%s:\t%s\n", self.c.Tr.Locked, style.FgYellow.Sprint(reason))
+			}
 			_ = w.Flush()
 
 			task = types.NewRenderStringTask(builder.String())
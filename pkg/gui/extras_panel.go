@@ -2,11 +2,15 @@ package gui
 
 import (
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/gocui"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 func (gui *Gui) handleCreateExtrasMenuPanel() error {
@@ -33,8 +37,67 @@ func (gui *Gui) handleCreateExtrasMenuPanel() error {
 				Keys:    []gocui.Key{gocui.NewKeyRune('f')},
 				OnPress: gui.handleFocusCommandLog,
 			},
+			{
+				Label:          gui.c.Tr.CopySessionLog,
+				Keys:           []gocui.Key{gocui.NewKeyRune('c')},
+				OnPress:        gui.handleCopySessionLog,
+				DisabledReason: gui.sessionLogDisabledReason(),
+				Tooltip:        gui.c.Tr.CopySessionLogTooltip,
+			},
+			{
+				Label:          gui.c.Tr.ExportSessionLog,
+				Keys:           []gocui.Key{gocui.NewKeyRune('e')},
+				OnPress:        gui.handleExportSessionLog,
+				DisabledReason: gui.sessionLogDisabledReason(),
+				Tooltip:        gui.c.Tr.ExportSessionLogTooltip,
+			},
+		},
+	})
+}
+
+func (gui *Gui) sessionLogDisabledReason() *types.DisabledReason {
+	if gui.sessionLog == nil {
+		return &types.DisabledReason{Text: gui.c.Tr.SessionLogDisabled}
+	}
+
+	return nil
+}
+
+func (gui *Gui) handleCopySessionLog() error {
+	contents, err := gui.sessionLog.Contents()
+	if err != nil {
+		return err
+	}
+
+	if err := gui.c.OS().CopyToClipboard(string(contents)); err != nil {
+		return err
+	}
+
+	gui.c.Toast(gui.c.Tr.SessionLogCopiedToClipboard)
+	return nil
+}
+
+func (gui *Gui) handleExportSessionLog() error {
+	gui.c.Prompt(types.PromptOpts{
+		Title:          gui.c.Tr.ExportSessionLogPrompt,
+		InitialContent: filepath.Join(gui.c.Git().RepoPaths.WorktreePath(), filepath.Base(gui.sessionLog.Path())),
+		HandleConfirm: func(path string) error {
+			contents, err := gui.sessionLog.Contents()
+			if err != nil {
+				return err
+			}
+
+			path = strings.TrimSpace(path)
+			if err := os.WriteFile(path, contents, 0o600); err != nil {
+				return err
+			}
+
+			gui.c.Toast(utils.ResolvePlaceholderString(gui.c.Tr.SessionLogExported, map[string]string{"path": path}))
+			return nil
 		},
 	})
+
+	return nil
 }
 
 func (gui *Gui) handleFocusCommandLog() error {
@@ -2,7 +2,9 @@ package filetree
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/jesseduffield/generics/set"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/common"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
@@ -43,6 +45,9 @@ type IFileTree interface {
 	FilterFiles(test func(*models.File) bool) []*models.File
 	SetStatusFilter(filter FileTreeDisplayFilter)
 	ForceShowUntracked() bool
+	ExpandUntrackedDir(path string)
+	ExpandedUntrackedDirs() []string
+	ForgetExpandedUntrackedDirs(paths []string)
 	Get(index int) *FileNode
 	GetFile(path string) *models.File
 	GetAllItems() []*FileNode
@@ -62,6 +67,11 @@ type FileTree struct {
 	collapsedPaths *CollapsedPaths
 	textFilter     string
 	useFuzzySearch bool
+	// untracked directories whose files the user wants to see, when we list
+	// untracked files lazily. Guarded by a mutex because we read it while
+	// refreshing files in the background.
+	expandedUntrackedDirs      *set.Set[string]
+	expandedUntrackedDirsMutex sync.Mutex
 }
 
 var _ IFileTree = &FileTree{}
@@ -73,6 +83,8 @@ func NewFileTree(getFiles func() []*models.File, common *common.Common, showTree
 		showTree:       showTree,
 		filter:         DisplayAll,
 		collapsedPaths: NewCollapsedPaths(),
+
+		expandedUntrackedDirs: set.New[string](),
 	}
 }
 
@@ -116,6 +128,30 @@ func (self *FileTree) ForceShowUntracked() bool {
 	return self.filter == DisplayUntracked
 }
 
+func (self *FileTree) ExpandUntrackedDir(path string) {
+	self.expandedUntrackedDirsMutex.Lock()
+	defer self.expandedUntrackedDirsMutex.Unlock()
+
+	self.expandedUntrackedDirs.Add(path)
+}
+
+func (self *FileTree) ExpandedUntrackedDirs() []string {
+	self.expandedUntrackedDirsMutex.Lock()
+	defer self.expandedUntrackedDirsMutex.Unlock()
+
+	return self.expandedUntrackedDirs.ToSlice()
+}
+
+// ForgetExpandedUntrackedDirs is for directories that have disappeared or
+// aren't untracked anymore, so that we don't expand them straight away if they
+// come back as untracked directories.
+func (self *FileTree) ForgetExpandedUntrackedDirs(paths []string) {
+	self.expandedUntrackedDirsMutex.Lock()
+	defer self.expandedUntrackedDirsMutex.Unlock()
+
+	self.expandedUntrackedDirs.RemoveSlice(paths)
+}
+
 func (self *FileTree) FilterFiles(test func(*models.File) bool) []*models.File {
 	return lo.Filter(self.getFiles(), func(file *models.File, _ int) bool { return test(file) })
 }
@@ -224,6 +224,23 @@ func (self *FileTreeViewModel) ExpandAll() {
 	}
 }
 
+// Select the given file, expanding its parent directories if necessary.
+// Returns false if the file isn't shown (e.g. because it's filtered out). Like
+// CommitFileTreeViewModel.SelectPath, this takes a path relative to the repo
+// root, with forward slashes.
+func (self *FileTreeViewModel) SelectPath(filepath string, showRootItem bool) bool {
+	path := InternalTreePathForFilePath(filepath, showRootItem)
+	if self.InTreeMode() {
+		self.ExpandToPath(path)
+	}
+
+	index, found := self.GetIndexForPath(path)
+	if found {
+		self.SetSelection(index)
+	}
+	return found
+}
+
 // IFilterableContext methods
 
 func (self *FileTreeViewModel) SetFilter(filter string, useFuzzySearch bool) {
@@ -0,0 +1,129 @@
+package filewatcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_CLOSE_WRITE |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_ATTRIB | unix.IN_EXCL_UNLINK
+
+type inotifyBackend struct {
+	file   *os.File
+	fd     int
+	events chan event
+	done   chan struct{}
+
+	mutex sync.Mutex
+	dirs  map[int]string // watch descriptor to the directory it watches
+}
+
+func newBackend() (backend, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("creating inotify instance: %w", err)
+	}
+
+	self := &inotifyBackend{
+		// Because the fd is non-blocking, reads go through the runtime's
+		// poller, so closing the file interrupts a read that's in progress
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		fd:     fd,
+		events: make(chan event, 100),
+		done:   make(chan struct{}),
+		dirs:   map[int]string{},
+	}
+	go self.readEvents()
+	return self, nil
+}
+
+func (self *inotifyBackend) add(dir string) error {
+	wd, err := unix.InotifyAddWatch(self.fd, dir, inotifyMask|unix.IN_ONLYDIR)
+	if err != nil {
+		if errors.Is(err, unix.ENOSPC) {
+			return fmt.Errorf("watching %s: inotify watch limit reached, consider raising fs.inotify.max_user_watches", dir)
+		}
+		return fmt.Errorf("watching %s: %w", dir, err)
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.dirs[wd] = dir
+	return nil
+}
+
+func (self *inotifyBackend) eventsChan() <-chan event {
+	return self.events
+}
+
+func (self *inotifyBackend) close() error {
+	close(self.done)
+	return self.file.Close()
+}
+
+func (self *inotifyBackend) readEvents() {
+	defer close(self.events)
+
+	var buf [unix.SizeofInotifyEvent * 4096]byte
+	for {
+		n, err := self.file.Read(buf[:])
+		if err != nil {
+			// either we've been closed, or something's wrong with the fd in
+			// which case there's nothing we can do but stop
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			raw := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+int(raw.Len)]
+			offset += unix.SizeofInotifyEvent + int(raw.Len)
+
+			if ev, ok := self.toEvent(raw, nameBytes); ok {
+				select {
+				case self.events <- ev:
+				case <-self.done:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (self *inotifyBackend) toEvent(raw *unix.InotifyEvent, nameBytes []byte) (event, bool) {
+	if raw.Mask&unix.IN_Q_OVERFLOW != 0 {
+		return event{overflow: true}, true
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	dir, ok := self.dirs[int(raw.Wd)]
+	if !ok {
+		return event{}, false
+	}
+	if raw.Mask&unix.IN_IGNORED != 0 {
+		// the directory was deleted or unmounted; its parent tells us about that
+		delete(self.dirs, int(raw.Wd))
+		return event{}, false
+	}
+
+	// the name is padded with null bytes
+	name := string(nameBytes)
+	for len(name) > 0 && name[len(name)-1] == 0 {
+		name = name[:len(name)-1]
+	}
+	if name == "" {
+		return event{}, false
+	}
+
+	return event{
+		path:       filepath.Join(dir, name),
+		createdDir: raw.Mask&unix.IN_ISDIR != 0 && raw.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0,
+	}, true
+}
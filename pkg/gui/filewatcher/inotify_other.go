@@ -0,0 +1,9 @@
+//go:build !linux
+
+package filewatcher
+
+import "errors"
+
+func newBackend() (backend, error) {
+	return nil, errors.New("watching the file system is only supported on Linux")
+}
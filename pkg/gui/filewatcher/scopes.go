@@ -0,0 +1,137 @@
+package filewatcher
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// The scopes we refresh when we may have missed events, e.g. because the
+// kernel's event queue overflowed
+var allScopes = []types.RefreshableView{
+	types.COMMITS,
+	types.BRANCHES,
+	types.FILES,
+	types.STASH,
+	types.REFLOG,
+	types.TAGS,
+	types.REMOTES,
+	types.WORKTREES,
+	types.STATUS,
+	types.SUBMODULES,
+}
+
+// Scopes affected by a change to the working tree state, i.e. a merge, rebase,
+// cherry-pick, or revert starting, stopping or finishing
+var workingTreeStateScopes = []types.RefreshableView{types.COMMITS, types.FILES, types.STATUS}
+
+// gitDirScopes returns the views to refresh when the given path (relative to a
+// git dir, with '/' separators) changes. Lock files, objects and anything else
+// we don't show return nil.
+func gitDirScopes(path string) []types.RefreshableView {
+	if strings.HasSuffix(path, ".lock") {
+		// git writes ref and index changes to a lock file and then renames it,
+		// so we'll see an event for the real file too
+		return nil
+	}
+
+	switch path {
+	case "HEAD":
+		return []types.RefreshableView{types.BRANCHES, types.COMMITS, types.FILES, types.REFLOG, types.STATUS}
+	case "index":
+		return []types.RefreshableView{types.FILES}
+	case "packed-refs":
+		return []types.RefreshableView{types.BRANCHES, types.COMMITS, types.REMOTES, types.TAGS}
+	case "logs/HEAD":
+		return []types.RefreshableView{types.REFLOG}
+	case "refs/stash", "logs/refs/stash":
+		// dropping any but the newest stash entry only changes the stash's reflog
+		return []types.RefreshableView{types.STASH}
+	case "MERGE_HEAD", "CHERRY_PICK_HEAD", "REVERT_HEAD", "rebase-merge", "rebase-apply":
+		return workingTreeStateScopes
+	case "BISECT_LOG":
+		return []types.RefreshableView{types.BISECT_INFO, types.COMMITS}
+	case "worktrees":
+		return []types.RefreshableView{types.WORKTREES}
+	}
+
+	switch {
+	case strings.HasPrefix(path, "refs/heads/"):
+		return []types.RefreshableView{types.BRANCHES, types.COMMITS}
+	case strings.HasPrefix(path, "refs/remotes/"):
+		// the branches view shows how far each branch is ahead of its upstream,
+		// and the commits view which commits have been pushed
+		return []types.RefreshableView{types.BRANCHES, types.COMMITS, types.REMOTES}
+	case strings.HasPrefix(path, "refs/tags/"):
+		return []types.RefreshableView{types.COMMITS, types.TAGS}
+	case strings.HasPrefix(path, "worktrees/"):
+		return []types.RefreshableView{types.WORKTREES}
+	}
+
+	return nil
+}
+
+// workingTreeScopes returns the views to refresh when the given path (relative
+// to the worktree, with '/' separators) changes, provided git doesn't ignore it
+func workingTreeScopes(path string) []types.RefreshableView {
+	if path == ".gitmodules" {
+		return []types.RefreshableView{types.FILES, types.SUBMODULES}
+	}
+	return []types.RefreshableView{types.FILES}
+}
+
+type repoPaths struct {
+	worktree       string // empty for bare repos
+	worktreeGitDir string
+	repoGitDir     string
+}
+
+// Where a changed path lives
+type pathKind int
+
+const (
+	outsideRepo pathKind = iota
+	inGitDir
+	inWorkingTree
+)
+
+// classify returns where the given absolute path lives, and the path relative
+// to that place, with '/' separators
+func (self repoPaths) classify(path string) (pathKind, string) {
+	// The worktree's git dir comes first because in a linked worktree it is
+	// inside the repo's git dir, and in the main worktree both are inside the
+	// worktree.
+	for _, gitDir := range []string{self.worktreeGitDir, self.repoGitDir} {
+		if rel, ok := relativePath(gitDir, path); ok {
+			return inGitDir, rel
+		}
+	}
+
+	if self.worktree != "" {
+		if rel, ok := relativePath(self.worktree, path); ok && rel != "" {
+			return inWorkingTree, rel
+		}
+	}
+
+	return outsideRepo, ""
+}
+
+func relativePath(base string, path string) (string, bool) {
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if rel == "." {
+		return "", true
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// isWatchedGitSubdir tells whether we watch the given directory (relative to
+// a git dir) when it shows up. We watch the git dir itself, its refs
+// recursively, the top level of its worktrees dir, and the parts of its logs
+// dir that have the HEAD and stash reflogs.
+func isWatchedGitSubdir(path string) bool {
+	return path == "logs" || path == "logs/refs" || path == "worktrees" || path == "refs" || strings.HasPrefix(path, "refs/")
+}
@@ -0,0 +1,81 @@
+package filewatcher
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitDirScopes(t *testing.T) {
+	scenarios := []struct {
+		path     string
+		expected []types.RefreshableView
+	}{
+		{"HEAD", []types.RefreshableView{types.BRANCHES, types.COMMITS, types.FILES, types.REFLOG, types.STATUS}},
+		{"HEAD.lock", nil},
+		{"index", []types.RefreshableView{types.FILES}},
+		{"index.lock", nil},
+		{"refs/heads/feature/login", []types.RefreshableView{types.BRANCHES, types.COMMITS}},
+		{"refs/heads/feature/login.lock", nil},
+		{"refs/remotes/origin/main", []types.RefreshableView{types.BRANCHES, types.COMMITS, types.REMOTES}},
+		{"refs/tags/v1.0", []types.RefreshableView{types.COMMITS, types.TAGS}},
+		{"refs/stash", []types.RefreshableView{types.STASH}},
+		{"packed-refs", []types.RefreshableView{types.BRANCHES, types.COMMITS, types.REMOTES, types.TAGS}},
+		{"logs/HEAD", []types.RefreshableView{types.REFLOG}},
+		{"logs/refs/stash", []types.RefreshableView{types.STASH}},
+		{"logs/refs/heads/main", nil},
+		{"rebase-merge", []types.RefreshableView{types.COMMITS, types.FILES, types.STATUS}},
+		{"MERGE_HEAD", []types.RefreshableView{types.COMMITS, types.FILES, types.STATUS}},
+		{"worktrees/feature", []types.RefreshableView{types.WORKTREES}},
+		{"objects/ab", nil},
+		{"FETCH_HEAD", nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			assert.Equal(t, s.expected, gitDirScopes(s.path))
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	mainWorktree := repoPaths{
+		worktree:       root,
+		worktreeGitDir: filepath.Join(root, ".git"),
+		repoGitDir:     filepath.Join(root, ".git"),
+	}
+	linkedWorktree := repoPaths{
+		worktree:       filepath.FromSlash("/linked"),
+		worktreeGitDir: filepath.Join(root, ".git", "worktrees", "linked"),
+		repoGitDir:     filepath.Join(root, ".git"),
+	}
+
+	scenarios := []struct {
+		name         string
+		paths        repoPaths
+		path         string
+		expectedKind pathKind
+		expectedRel  string
+	}{
+		{"file in working tree", mainWorktree, "/repo/src/main.go", inWorkingTree, "src/main.go"},
+		{"file in git dir", mainWorktree, "/repo/.git/refs/heads/main", inGitDir, "refs/heads/main"},
+		{"git dir itself", mainWorktree, "/repo/.git", inGitDir, ""},
+		{"outside repo", mainWorktree, "/repository/file", outsideRepo, ""},
+		{"linked worktree HEAD", linkedWorktree, "/repo/.git/worktrees/linked/HEAD", inGitDir, "HEAD"},
+		{"linked worktree refs", linkedWorktree, "/repo/.git/refs/heads/main", inGitDir, "refs/heads/main"},
+		{"linked worktree file", linkedWorktree, "/linked/README.md", inWorkingTree, "README.md"},
+		{"main worktree seen from linked worktree", linkedWorktree, "/repo/README.md", outsideRepo, ""},
+		{"bare repo", repoPaths{worktreeGitDir: root, repoGitDir: root}, "/repo/HEAD", inGitDir, "HEAD"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			kind, rel := s.paths.classify(filepath.FromSlash(s.path))
+			assert.Equal(t, s.expectedKind, kind)
+			assert.Equal(t, s.expectedRel, rel)
+		})
+	}
+}
@@ -0,0 +1,361 @@
+// Package filewatcher watches a repo's working tree and git dir, and tells us
+// which views to refresh when something in them changes. This saves us from
+// running `git status` every few seconds, which is slow in big repos.
+package filewatcher
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// We wait for this long without any new events before refreshing, so
+	// that e.g. a checkout touching thousands of files refreshes only once
+	debounceDelay = 100 * time.Millisecond
+	// but we don't wait longer than this, so that a steady stream of events
+	// doesn't hold back the refresh forever
+	maxDelay = time.Second
+)
+
+// watches directories (not recursively) and reports changes to their entries
+type backend interface {
+	add(dir string) error
+	eventsChan() <-chan event
+	close() error
+}
+
+type event struct {
+	path string
+	// true if the path is a directory that was created or moved in, meaning
+	// we may need to watch it
+	createdDir bool
+	// true if we've missed events
+	overflow bool
+}
+
+type Opts struct {
+	WorktreePath       string // empty for bare repos
+	WorktreeGitDirPath string
+	RepoGitDirPath     string
+	// Returns those of the given paths (relative to the worktree) that git
+	// ignores. We don't watch ignored directories, and don't refresh when
+	// ignored files change.
+	IgnoredPaths func(paths []string) ([]string, error)
+	// Called with the views to refresh after a change. We don't look for
+	// further changes until it returns, so if the refresh runs asynchronously
+	// we may refresh more often than necessary.
+	OnChange func(scopes []types.RefreshableView)
+	Log      *logrus.Entry
+}
+
+type Watcher struct {
+	opts    Opts
+	paths   repoPaths
+	backend backend
+}
+
+// New sets up the watches for the given repo and starts reporting changes.
+// This walks the whole working tree, so call it off the UI thread.
+func New(opts Opts) (*Watcher, error) {
+	backend, err := newBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	self := &Watcher{
+		opts: opts,
+		paths: repoPaths{
+			worktree:       opts.WorktreePath,
+			worktreeGitDir: opts.WorktreeGitDirPath,
+			repoGitDir:     opts.RepoGitDirPath,
+		},
+		backend: backend,
+	}
+
+	if err := self.watchRepo(); err != nil {
+		_ = backend.close()
+		return nil, err
+	}
+
+	go utils.Safe(self.loop)
+
+	return self, nil
+}
+
+// Close stops watching. OnChange won't be called anymore once the call to it
+// that may be in progress returns.
+func (self *Watcher) Close() {
+	if err := self.backend.close(); err != nil {
+		self.opts.Log.Error(err)
+	}
+}
+
+func (self *Watcher) watchRepo() error {
+	gitDirs := lo.Uniq([]string{self.paths.worktreeGitDir, self.paths.repoGitDir})
+	for _, gitDir := range gitDirs {
+		if err := self.backend.add(gitDir); err != nil {
+			return err
+		}
+	}
+
+	// The worktree's git dir has the HEAD reflog, and the repo's git dir has
+	// the refs, the worktrees, and the reflogs of the refs (including the
+	// stash's), which linked worktrees share
+	gitSubdirs := lo.Uniq([]string{
+		filepath.Join(self.paths.worktreeGitDir, "logs"),
+		filepath.Join(self.paths.repoGitDir, "logs"),
+		filepath.Join(self.paths.repoGitDir, "refs"),
+		filepath.Join(self.paths.repoGitDir, "worktrees"),
+	})
+	for _, dir := range gitSubdirs {
+		if err := self.watchTree([]string{dir}, self.filterGitDirs); err != nil {
+			return err
+		}
+	}
+
+	if self.paths.worktree == "" {
+		return nil
+	}
+	return self.watchWorkingTree()
+}
+
+func (self *Watcher) watchWorkingTree() error {
+	return self.watchTree([]string{self.paths.worktree}, self.filterWorkingTreeDirs)
+}
+
+// watchTree watches the given directories and everything below them that
+// the filter lets through, one level at a time so that the filter can check
+// a whole level's directories at once.
+func (self *Watcher) watchTree(dirs []string, filter func(dirs []string) []string) error {
+	for len(dirs) > 0 {
+		var subdirs []string
+		for _, dir := range dirs {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				// most likely the directory doesn't exist (anymore)
+				continue
+			}
+			if err := self.backend.add(dir); err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return err
+			}
+
+			for _, entry := range entries {
+				if entry.IsDir() {
+					subdirs = append(subdirs, filepath.Join(dir, entry.Name()))
+				}
+			}
+		}
+		dirs = filter(subdirs)
+	}
+
+	return nil
+}
+
+func (self *Watcher) filterGitDirs(dirs []string) []string {
+	return lo.Filter(dirs, func(dir string, _ int) bool {
+		kind, rel := self.paths.classify(dir)
+		return kind == inGitDir && isWatchedGitSubdir(rel)
+	})
+}
+
+// filterWorkingTreeDirs leaves out git dirs (including those of submodules and
+// nested repos) and ignored directories
+func (self *Watcher) filterWorkingTreeDirs(dirs []string) []string {
+	relPaths := map[string]string{}
+	for _, dir := range dirs {
+		kind, rel := self.paths.classify(dir)
+		if kind == inWorkingTree && filepath.Base(dir) != ".git" {
+			relPaths[rel] = dir
+		}
+	}
+
+	for _, ignored := range self.ignoredPaths(lo.Keys(relPaths)) {
+		delete(relPaths, ignored)
+	}
+
+	return lo.Values(relPaths)
+}
+
+func (self *Watcher) ignoredPaths(relPaths []string) []string {
+	if len(relPaths) == 0 {
+		return nil
+	}
+
+	ignored, err := self.opts.IgnoredPaths(relPaths)
+	if err != nil {
+		// better to refresh too often than to miss changes
+		self.opts.Log.Error(err)
+		return nil
+	}
+	return ignored
+}
+
+// the changes we've seen since the last refresh
+type changeSet struct {
+	scopes map[types.RefreshableView]struct{}
+	// changed paths in the working tree (relative to it) that we still need
+	// to check against the ignore rules
+	workingTreePaths map[string]struct{}
+	createdDirs      []string
+	gitignoreChanged bool
+}
+
+func newChangeSet() *changeSet {
+	return &changeSet{
+		scopes:           map[types.RefreshableView]struct{}{},
+		workingTreePaths: map[string]struct{}{},
+	}
+}
+
+func (self *changeSet) isEmpty() bool {
+	return len(self.scopes) == 0 && len(self.workingTreePaths) == 0 && len(self.createdDirs) == 0
+}
+
+func (self *changeSet) addScopes(scopes []types.RefreshableView) {
+	for _, scope := range scopes {
+		self.scopes[scope] = struct{}{}
+	}
+}
+
+func (self *Watcher) addEvent(changes *changeSet, ev event) {
+	if ev.overflow {
+		changes.addScopes(allScopes)
+		// directories may have been created without us hearing about it
+		changes.gitignoreChanged = true
+		return
+	}
+
+	kind, rel := self.paths.classify(ev.path)
+	switch kind {
+	case inGitDir:
+		changes.addScopes(gitDirScopes(rel))
+		if ev.createdDir && isWatchedGitSubdir(rel) {
+			changes.createdDirs = append(changes.createdDirs, ev.path)
+		}
+	case inWorkingTree:
+		changes.workingTreePaths[rel] = struct{}{}
+		if ev.createdDir {
+			changes.createdDirs = append(changes.createdDirs, ev.path)
+		}
+		if filepath.Base(rel) == ".gitignore" {
+			changes.gitignoreChanged = true
+		}
+	}
+}
+
+func (self *Watcher) loop() {
+	changes := newChangeSet()
+	timer := time.NewTimer(debounceDelay)
+	timer.Stop()
+	var deadline time.Time
+
+	for {
+		select {
+		case ev, ok := <-self.backend.eventsChan():
+			if !ok {
+				timer.Stop()
+				return
+			}
+
+			if changes.isEmpty() {
+				deadline = time.Now().Add(maxDelay)
+			}
+			self.addEvent(changes, ev)
+			if !changes.isEmpty() {
+				timer.Reset(min(debounceDelay, time.Until(deadline)))
+			}
+		case <-timer.C:
+			self.flush(changes)
+			changes = newChangeSet()
+		}
+	}
+}
+
+func (self *Watcher) flush(changes *changeSet) {
+	_, filesChanged := changes.scopes[types.FILES]
+	if !filesChanged || len(changes.createdDirs) > 0 {
+		relPaths := lo.Keys(changes.workingTreePaths)
+		ignored := lo.SliceToMap(self.ignoredPaths(relPaths), func(path string) (string, struct{}) {
+			return path, struct{}{}
+		})
+		for _, path := range relPaths {
+			if _, ok := ignored[path]; !ok {
+				changes.addScopes(workingTreeScopes(path))
+			}
+		}
+
+		self.watchCreatedDirs(changes, ignored)
+	} else {
+		// We're refreshing the files anyway, so there's no need to check the
+		// ignore rules, but some paths (e.g. .gitmodules) affect other views too
+		for path := range changes.workingTreePaths {
+			changes.addScopes(workingTreeScopes(path))
+		}
+	}
+
+	if changes.gitignoreChanged && self.paths.worktree != "" {
+		// directories that were ignored before may not be anymore. Watching a
+		// directory again is harmless, so we just walk the whole tree again.
+		if err := self.watchWorkingTree(); err != nil {
+			self.opts.Log.Error(err)
+		}
+	}
+
+	if len(changes.scopes) == 0 {
+		return
+	}
+
+	scopes := lo.Keys(changes.scopes)
+	slices.Sort(scopes)
+	self.opts.OnChange(scopes)
+}
+
+func (self *Watcher) watchCreatedDirs(changes *changeSet, ignored map[string]struct{}) {
+	for _, dir := range changes.createdDirs {
+		kind, rel := self.paths.classify(dir)
+		var err error
+		switch kind {
+		case inGitDir:
+			err = self.watchTree([]string{dir}, self.filterGitDirs)
+			// git may have written to the directory before we started watching it
+			self.addScopesForExistingGitFiles(changes, dir)
+		case inWorkingTree:
+			if _, ok := ignored[rel]; ok || filepath.Base(dir) == ".git" {
+				continue
+			}
+			err = self.watchTree([]string{dir}, self.filterWorkingTreeDirs)
+		}
+		if err != nil {
+			self.opts.Log.Error(err)
+		}
+	}
+}
+
+func (self *Watcher) addScopesForExistingGitFiles(changes *changeSet, dir string) {
+	_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		_, rel := self.paths.classify(path)
+		if entry.IsDir() {
+			if path != dir && !isWatchedGitSubdir(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		changes.addScopes(gitDirScopes(rel))
+		return nil
+	})
+}
@@ -0,0 +1,119 @@
+package filewatcher
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{".git/refs/heads", ".git/logs", "src", "build"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+	}
+
+	writeFile := func(path string) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte("content"), 0o644))
+	}
+
+	changes := make(chan []types.RefreshableView, 10)
+	watcher, err := New(Opts{
+		WorktreePath:       root,
+		WorktreeGitDirPath: filepath.Join(root, ".git"),
+		RepoGitDirPath:     filepath.Join(root, ".git"),
+		IgnoredPaths: func(paths []string) ([]string, error) {
+			return slices.DeleteFunc(slices.Clone(paths), func(path string) bool {
+				return path != "build" && filepath.Ext(path) != ".log"
+			}), nil
+		},
+		OnChange: func(scopes []types.RefreshableView) { changes <- scopes },
+		Log:      utils.NewDummyLog(),
+	})
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	expectChange := func(expected []types.RefreshableView) {
+		t.Helper()
+		select {
+		case scopes := <-changes:
+			assert.Equal(t, expected, scopes)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected a change to %v", expected)
+		}
+	}
+
+	writeFile("src/main.go")
+	expectChange([]types.RefreshableView{types.FILES})
+
+	// Changes to ignored files don't refresh anything, so the next change we
+	// hear about is the branch, without the files
+	writeFile("build/main.o")
+	writeFile("debug.log")
+	writeFile(".git/refs/heads/main")
+	expectChange([]types.RefreshableView{types.COMMITS, types.BRANCHES})
+
+	// we watch directories as they're created
+	require.NoError(t, os.Mkdir(filepath.Join(root, "src", "pkg"), 0o755))
+	expectChange([]types.RefreshableView{types.FILES})
+	writeFile("src/pkg/util.go")
+	expectChange([]types.RefreshableView{types.FILES})
+
+	// .gitmodules refreshes the submodules even if the files are refreshed
+	// for another reason
+	writeFile(".git/index")
+	writeFile(".gitmodules")
+	expectChange([]types.RefreshableView{types.FILES, types.SUBMODULES})
+
+	// dropping an older stash entry only rewrites the stash's reflog
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git", "logs", "refs"), 0o755))
+	writeFile(".git/logs/refs/stash")
+	expectChange([]types.RefreshableView{types.STASH})
+}
+
+func TestWatcherLinkedWorktree(t *testing.T) {
+	root := t.TempDir()
+	repoGitDir := filepath.Join(root, "repo", ".git")
+	worktreeGitDir := filepath.Join(repoGitDir, "worktrees", "wt")
+	worktree := filepath.Join(root, "wt")
+	for _, dir := range []string{filepath.Join(repoGitDir, "refs", "heads"), filepath.Join(repoGitDir, "logs"), filepath.Join(worktreeGitDir, "logs"), worktree} {
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+	}
+
+	changes := make(chan []types.RefreshableView, 10)
+	watcher, err := New(Opts{
+		WorktreePath:       worktree,
+		WorktreeGitDirPath: worktreeGitDir,
+		RepoGitDirPath:     repoGitDir,
+		IgnoredPaths:       func(paths []string) ([]string, error) { return nil, nil },
+		OnChange:           func(scopes []types.RefreshableView) { changes <- scopes },
+		Log:                utils.NewDummyLog(),
+	})
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	expectChange := func(expected []types.RefreshableView) {
+		t.Helper()
+		select {
+		case scopes := <-changes:
+			assert.Equal(t, expected, scopes)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected a change to %v", expected)
+		}
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeGitDir, "logs", "HEAD"), []byte("content"), 0o644))
+	expectChange([]types.RefreshableView{types.REFLOG})
+
+	// the stash's reflog is shared by all worktrees, so it lives in the
+	// repo's git dir
+	require.NoError(t, os.Mkdir(filepath.Join(repoGitDir, "logs", "refs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoGitDir, "logs", "refs", "stash"), []byte("content"), 0o644))
+	expectChange([]types.RefreshableView{types.STASH})
+}
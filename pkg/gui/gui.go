@@ -30,6 +30,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/diffing"
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/filtering"
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/marked_base_commit"
+	"github.com/jesseduffield/lazygit/pkg/gui/modes/range_diffing"
 	"github.com/jesseduffield/lazygit/pkg/gui/popup"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation/authors"
@@ -146,6 +147,13 @@ type Gui struct {
 
 	integrationTest integrationTypes.IntegrationTest
 
+	// set by the app if remote control is enabled
+	RemoteControlServer RemoteControlServer
+	remoteDriver        *RemoteDriver
+
+	// nil if the session log is disabled
+	sessionLog *oscommands.SessionLog
+
 	afterLayoutFuncs chan func() error
 }
 
@@ -309,6 +317,11 @@ func (self *GuiRepoState) GetSplitMainPanel() bool {
 
 func (gui *Gui) onSwitchToNewRepo(startArgs appTypes.StartArgs, contextKey types.ContextKey) error {
 	err := gui.onNewRepo(startArgs, contextKey)
+	if err == nil {
+		gui.BackgroundRoutineMgr.onSwitchToNewRepo()
+		gui.startNewSessionLog()
+		gui.handleRemoteControl()
+	}
 	if err == nil && gui.UserConfig().Git.AutoFetch && gui.UserConfig().Refresher.FetchInterval > 0 {
 		if time.Since(gui.State.LastBackgroundFetchTime) > gui.UserConfig().Refresher.FetchIntervalDuration() {
 			gui.BackgroundRoutineMgr.triggerImmediateFetch()
@@ -330,6 +343,12 @@ func (gui *Gui) onNewRepo(startArgs appTypes.StartArgs, contextKey types.Context
 		return err
 	}
 
+	if gui.integrationTest != nil {
+		if apiURL := gui.integrationTest.GitHubAPIURL(); apiURL != "" {
+			gui.git.GitHub.OverrideAPIURL(apiURL)
+		}
+	}
+
 	err = gui.Config.ReloadUserConfigForRepo(gui.getPerRepoConfigFiles())
 	if err != nil {
 		return err
@@ -351,6 +370,7 @@ func (gui *Gui) onNewRepo(startArgs appTypes.StartArgs, contextKey types.Context
 	gui.g.SetFocusHandler(func(Focused bool) error {
 		if Focused {
 			gui.git.Config.DropConfigCache()
+			gui.git.SparseCheckout.DropCache()
 
 			oldConfig := gui.Config.GetUserConfig()
 			reloadErr, didChange := gui.Config.ReloadChangedUserConfigFiles()
@@ -517,8 +537,13 @@ func (gui *Gui) checkForChangedConfigsThatDontAutoReload(oldConfig *config.UserC
 		"Git.AutoRefresh",
 		"Refresher.RefreshInterval",
 		"Refresher.FetchInterval",
+		"Refresher.WatchFileSystem",
 		"Update.Method",
 		"Update.Days",
+		"RemoteControl.Enabled",
+		"RemoteControl.SocketPath",
+		"SessionLog.Enabled",
+		"SessionLog.MaxSessions",
 	}
 
 	changedConfigs := []string{}
@@ -610,13 +635,15 @@ func (gui *Gui) resetState(startArgs appTypes.StartArgs) types.Context {
 			MainBranches:          git_commands.NewMainBranches(gui.c.Common, gui.os.Cmd),
 			HashPool:              &utils.StringPool{},
 			PullRequests:          gui.loadCachedPullRequests(),
-			PullRequestsMap:       make(map[string]*models.GithubPullRequest),
+			PullRequestsMap:       make(map[string]*models.PullRequest),
+			RangeDiffEntries:      make([]*models.RangeDiffEntry, 0),
 		},
 		Modes: &types.Modes{
 			Filtering:        filtering.New(startArgs.FilterPath, ""),
 			CherryPicking:    cherrypicking.New(),
 			Diffing:          diffing.New(),
 			MarkedBaseCommit: marked_base_commit.New(),
+			RangeDiffing:     range_diffing.New(),
 		},
 		ScreenMode: initialScreenMode,
 		// TODO: only use contexts from context manager
@@ -631,18 +658,18 @@ func (gui *Gui) resetState(startArgs appTypes.StartArgs) types.Context {
 	return initialContext(contextTree, startArgs)
 }
 
-func (gui *Gui) loadCachedPullRequests() []*models.GithubPullRequest {
+func (gui *Gui) loadCachedPullRequests() []*models.PullRequest {
 	repoPath := gui.git.RepoPaths.RepoPath()
-	cachedPRs := gui.c.GetAppState().GithubPullRequests[repoPath]
+	cachedPRs := gui.c.GetAppState().PullRequests[repoPath]
 
-	return lo.Map(cachedPRs, func(cached config.CachedPullRequest, _ int) *models.GithubPullRequest {
-		return &models.GithubPullRequest{
+	return lo.Map(cachedPRs, func(cached config.CachedPullRequest, _ int) *models.PullRequest {
+		return &models.PullRequest{
 			HeadRefName: cached.HeadRefName,
 			Number:      cached.Number,
 			Title:       cached.Title,
 			State:       cached.State,
 			Url:         cached.Url,
-			HeadRepositoryOwner: models.GithubRepositoryOwner{
+			HeadRepositoryOwner: models.RepositoryOwner{
 				Login: cached.HeadRepositoryOwner,
 			},
 		}
@@ -748,6 +775,8 @@ func NewGui(
 		afterLayoutFuncs: make(chan func() error, 1000),
 
 		itemOperations: make(map[string]types.ItemOperation),
+
+		sessionLog: newSessionLog(cmn),
 	}
 
 	gui.PopupHandler = popup.NewPopupHandler(
@@ -778,6 +807,8 @@ func NewGui(
 		gui.LogCommand,
 		gui.getCmdWriter,
 		credentialsHelper.PromptUserForCredential,
+		gui.recordCommand,
+		gui.cmdRecording,
 	)
 
 	osCommand := oscommands.NewOSCommand(cmn, configurer, oscommands.GetPlatform(), guiIO)
@@ -848,6 +879,10 @@ func (gui *Gui) viewTabMap() map[string][]context.TabView {
 				Tab:      gui.c.Tr.TagsTitle,
 				ViewName: "tags",
 			},
+			{
+				Tab:      gui.c.Tr.PullRequestsTitle,
+				ViewName: "pullRequests",
+			},
 		},
 		"commits": {
 			{
@@ -888,6 +923,15 @@ func (gui *Gui) Run(startArgs appTypes.StartArgs) error {
 	gui.g = g
 	defer gui.g.Close()
 
+	if gui.RemoteControlServer != nil {
+		gui.remoteDriver = newRemoteDriver(gui)
+	}
+
+	if gui.sessionLog != nil {
+		defer gui.sessionLog.Close()
+		gui.resetSessionLogActionWhenIdle()
+	}
+
 	g.ErrorHandler = gui.PopupHandler.ErrorHandler
 
 	gui.g.ShouldHandleMouseEvent = func(view *gocui.View, key gocui.KeyName) bool {
@@ -927,6 +971,9 @@ func (gui *Gui) Run(startArgs appTypes.StartArgs) error {
 		return err
 	}
 
+	// setting here so we can use it in onNewRepo and layout.go
+	gui.integrationTest = startArgs.IntegrationTest
+
 	// onNewRepo must be called after g.SetManager because SetManager deletes keybindings
 	if err := gui.onNewRepo(startArgs, context.NO_CONTEXT); err != nil {
 		return err
@@ -940,9 +987,6 @@ func (gui *Gui) Run(startArgs appTypes.StartArgs) error {
 
 	gui.c.Log.Info("starting main loop")
 
-	// setting here so we can use it in layout.go
-	gui.integrationTest = startArgs.IntegrationTest
-
 	err = gui.g.MainLoop()
 	if errors.Is(err, gocui.ErrQuit) {
 		// Give the focused context a chance to clean up before we tear down the app.
@@ -1036,6 +1080,7 @@ func (gui *Gui) runSubprocessWithSuspense(subprocess *oscommands.CmdObj) (bool,
 
 func (gui *Gui) runSubprocess(cmdObj *oscommands.CmdObj) error {
 	gui.LogCommand(cmdObj.ToString(), true)
+	startTime := time.Now()
 
 	subprocess := cmdObj.GetCmd()
 	subprocess.Stdout = os.Stdout
@@ -1045,6 +1090,7 @@ func (gui *Gui) runSubprocess(cmdObj *oscommands.CmdObj) error {
 	fmt.Fprintf(os.Stdout, "\n%s\n\n", style.FgBlue.Sprint("+ "+strings.Join(subprocess.Args, " ")))
 
 	err := subprocess.Run()
+	gui.recordCommand(oscommands.NewCmdRecord(cmdObj, startTime, ""))
 
 	subprocess.Stdout = io.Discard
 	subprocess.Stderr = io.Discard
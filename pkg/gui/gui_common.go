@@ -134,10 +134,12 @@ func (self *guiCommon) RenderToMainViews(opts types.RefreshMainOpts) {
 
 func (self *guiCommon) MainViewPairs() types.MainViewPairs {
 	return types.MainViewPairs{
-		Normal:         self.gui.normalMainContextPair(),
-		Staging:        self.gui.stagingMainContextPair(),
-		PatchBuilding:  self.gui.patchBuildingMainContextPair(),
-		MergeConflicts: self.gui.mergingMainContextPair(),
+		Normal:              self.gui.normalMainContextPair(),
+		Staging:             self.gui.stagingMainContextPair(),
+		PatchBuilding:       self.gui.patchBuildingMainContextPair(),
+		MergeConflicts:      self.gui.mergingMainContextPair(),
+		MergeConflictEditor: self.gui.mergeConflictEditorMainContextPair(),
+		Blame:               self.gui.blameMainContextPair(),
 	}
 }
 
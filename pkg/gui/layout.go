@@ -158,6 +158,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		}
 
 		gui.handleTestMode()
+		gui.handleRemoteControl()
 
 		gui.ViewsSetup = true
 	}
@@ -16,6 +16,9 @@ func (gui *Gui) runTaskForView(view *gocui.View, task types.UpdateTask) error {
 	case *types.RenderStringWithScrollTask:
 		return gui.newStringTaskWithScroll(view, v.Str, v.OriginX, v.OriginY)
 
+	case *types.RenderStringFuncTask:
+		return gui.newStringFuncTask(view, v.GetStr, v.FallbackCmd)
+
 	case *types.RunCommandTask:
 		return gui.newCmdTask(view, v.Cmd, v.Prefix)
 
@@ -97,12 +100,28 @@ func (gui *Gui) mergingMainContextPair() types.MainContextPair {
 	)
 }
 
+func (gui *Gui) mergeConflictEditorMainContextPair() types.MainContextPair {
+	return types.NewMainContextPair(
+		gui.State.Contexts.MergeConflictEditor,
+		gui.State.Contexts.MergeConflictResult,
+	)
+}
+
+func (gui *Gui) blameMainContextPair() types.MainContextPair {
+	return types.NewMainContextPair(
+		gui.State.Contexts.Blame,
+		nil,
+	)
+}
+
 func (gui *Gui) allMainContextPairs() []types.MainContextPair {
 	return []types.MainContextPair{
 		gui.normalMainContextPair(),
 		gui.stagingMainContextPair(),
 		gui.patchBuildingMainContextPair(),
 		gui.mergingMainContextPair(),
+		gui.mergeConflictEditorMainContextPair(),
+		gui.blameMainContextPair(),
 	}
 }
 
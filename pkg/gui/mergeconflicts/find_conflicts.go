@@ -2,11 +2,11 @@ package mergeconflicts
 
 import (
 	"bufio"
-	"bytes"
 	"io"
 	"os"
 	"strings"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -22,70 +22,123 @@ const (
 	NOT_A_MARKER
 )
 
-func findConflicts(content string) []*mergeConflict {
+// callers pass 0 for files that don't set the conflict-marker-size attribute
+func markerSizeOrDefault(markerSize int) int {
+	if markerSize <= 0 {
+		return git_commands.DEFAULT_CONFLICT_MARKER_SIZE
+	}
+	return markerSize
+}
+
+// a conflict that has been opened by a start marker while parsing
+type openConflict struct {
+	conflict *mergeConflict
+	// the conflict that this one is nested in, if any
+	parent *openConflict
+	closed bool
+}
+
+// findConflicts returns the outermost conflicts in the content. Markers only
+// count if they have exactly the given size, so the longer markers of the
+// inner conflicts that git writes for recursive merges are treated as content.
+// Conflicts can also be nested with markers of the same size (e.g. when a file
+// with committed conflict markers conflicts again); these inner conflicts are
+// part of the outer conflict's content too.
+func findConflicts(content string, markerSize int) []*mergeConflict {
 	conflicts := make([]*mergeConflict, 0)
 
 	if content == "" {
 		return conflicts
 	}
 
-	var newConflict *mergeConflict
+	closed := []*openConflict{}
+	var current *openConflict
 	for i, line := range utils.SplitLines(content) {
-		switch determineLineType(line) {
+		switch determineLineType(line, markerSize) {
 		case START:
-			newConflict = &mergeConflict{start: i, ancestor: -1}
+			current = &openConflict{
+				conflict: &mergeConflict{start: i, ancestor: -1},
+				parent:   current,
+			}
 		case ANCESTOR:
-			if newConflict != nil {
-				newConflict.ancestor = i
+			if current != nil {
+				current.conflict.ancestor = i
 			}
 		case TARGET:
-			if newConflict != nil {
-				newConflict.target = i
+			if current != nil {
+				current.conflict.target = i
 			}
 		case END:
-			if newConflict != nil {
-				newConflict.end = i
-				conflicts = append(conflicts, newConflict)
+			if current != nil {
+				current.conflict.end = i
+				current.closed = true
+				closed = append(closed, current)
+				current = current.parent
 			}
-			// reset value to avoid any possible silent mutations in further iterations
-			newConflict = nil
 		default:
 			// line isn't a merge conflict marker so we just continue
 		}
 	}
 
+	// A start marker that is never closed (e.g. because the user deleted the
+	// rest of its markers) doesn't make the conflicts after it nested ones.
+	for _, c := range closed {
+		if !hasClosedParent(c) {
+			conflicts = append(conflicts, c.conflict)
+		}
+	}
+
 	return conflicts
 }
 
-var (
-	CONFLICT_START       = "<<<<<<< "
-	CONFLICT_END         = ">>>>>>> "
-	CONFLICT_START_BYTES = []byte(CONFLICT_START)
-	CONFLICT_END_BYTES   = []byte(CONFLICT_END)
-)
+func hasClosedParent(c *openConflict) bool {
+	for parent := c.parent; parent != nil; parent = parent.parent {
+		if parent.closed {
+			return true
+		}
+	}
+	return false
+}
 
-func determineLineType(line string) LineType {
+func determineLineType(line string, markerSize int) LineType {
 	// TODO: find out whether we ever actually get this prefix
 	trimmedLine := strings.TrimPrefix(line, "++")
 
 	switch {
-	case strings.HasPrefix(trimmedLine, CONFLICT_START):
+	case isMarkerLine(trimmedLine, '<', markerSize):
 		return START
-	case strings.HasPrefix(trimmedLine, "||||||| "):
+	case isMarkerLine(trimmedLine, '|', markerSize):
 		return ANCESTOR
-	case trimmedLine == "=======":
+	case isMarkerLine(trimmedLine, '=', markerSize):
 		return TARGET
-	case strings.HasPrefix(trimmedLine, CONFLICT_END):
+	case isMarkerLine(trimmedLine, '>', markerSize):
 		return END
 	default:
 		return NOT_A_MARKER
 	}
 }
 
+// A marker is the marker character repeated markerSize times, optionally
+// followed by a space and a label. Rerere, for example, writes markers without
+// labels.
+func isMarkerLine[T string | []byte](line T, char byte, markerSize int) bool {
+	if len(line) < markerSize || (len(line) > markerSize && line[markerSize] != ' ') {
+		return false
+	}
+
+	for i := range markerSize {
+		if line[i] != char {
+			return false
+		}
+	}
+
+	return true
+}
+
 // tells us whether a file actually has inline merge conflicts. We need to run this
 // because git will continue showing a status of 'UU' even after the conflicts have
 // been resolved in the user's editor
-func FileHasConflictMarkers(path string) (bool, error) {
+func FileHasConflictMarkers(path string, markerSize int) (bool, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return false, err
@@ -93,22 +146,18 @@ func FileHasConflictMarkers(path string) (bool, error) {
 
 	defer file.Close()
 
-	return fileHasConflictMarkersAux(file)
+	return fileHasConflictMarkersAux(file, markerSizeOrDefault(markerSize))
 }
 
 // Efficiently scans through a file looking for merge conflict markers. Returns true if it does
-func fileHasConflictMarkersAux(file io.Reader) (bool, error) {
+func fileHasConflictMarkersAux(file io.Reader, markerSize int) (bool, error) {
 	scanner := bufio.NewScanner(file)
 	scanner.Split(utils.ScanLinesAndTruncateWhenLongerThanBuffer(bufio.MaxScanTokenSize))
 	for scanner.Scan() {
 		line := scanner.Bytes()
 
 		// only searching for start/end markers because the others are more ambiguous
-		if bytes.HasPrefix(line, CONFLICT_START_BYTES) {
-			return true, nil
-		}
-
-		if bytes.HasPrefix(line, CONFLICT_END_BYTES) {
+		if isMarkerLine(line, '<', markerSize) || isMarkerLine(line, '>', markerSize) {
 			return true, nil
 		}
 	}
@@ -4,13 +4,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDetermineLineType(t *testing.T) {
 	type scenario struct {
-		line     string
-		expected LineType
+		line       string
+		markerSize int
+		expected   LineType
 	}
 
 	scenarios := []scenario{
@@ -54,17 +56,66 @@ func TestDetermineLineType(t *testing.T) {
 			line:     "||||||| adf33b9",
 			expected: ANCESTOR,
 		},
+		{
+			line:     "<<<<<<<",
+			expected: START,
+		},
+		{
+			line:     "|||||||",
+			expected: ANCESTOR,
+		},
+		{
+			line:     ">>>>>>>",
+			expected: END,
+		},
+		{
+			line:     "<<<<<<<< HEAD",
+			expected: NOT_A_MARKER,
+		},
+		{
+			line:     "<<<<<<<HEAD",
+			expected: NOT_A_MARKER,
+		},
+		{
+			line:     "=========",
+			expected: NOT_A_MARKER,
+		},
+		{
+			line:       "<<<<<<<<< Temporary merge branch 1",
+			markerSize: 9,
+			expected:   START,
+		},
+		{
+			line:       "=========",
+			markerSize: 9,
+			expected:   TARGET,
+		},
+		{
+			line:       "<<<<<<< HEAD",
+			markerSize: 9,
+			expected:   NOT_A_MARKER,
+		},
+		{
+			line:       "<<< HEAD",
+			markerSize: 3,
+			expected:   START,
+		},
 	}
 
 	for _, s := range scenarios {
-		assert.EqualValues(t, s.expected, determineLineType(s.line))
+		markerSize := s.markerSize
+		if markerSize == 0 {
+			markerSize = git_commands.DEFAULT_CONFLICT_MARKER_SIZE
+		}
+		assert.EqualValues(t, s.expected, determineLineType(s.line, markerSize), s.line)
 	}
 }
 
 func TestFindConflictsAux(t *testing.T) {
 	type scenario struct {
-		content  string
-		expected bool
+		content    string
+		markerSize int
+		expected   bool
 	}
 
 	scenarios := []scenario{
@@ -92,11 +143,33 @@ func TestFindConflictsAux(t *testing.T) {
 			content:  "a\nb\nc\n<<<<<<< ",
 			expected: true,
 		},
+		{
+			content:  "<<<<<<<",
+			expected: true,
+		},
+		{
+			content:  "<<<<<<<<< ",
+			expected: false,
+		},
+		{
+			content:    "<<<<<<< ",
+			markerSize: 9,
+			expected:   false,
+		},
+		{
+			content:    "a\n>>>>>>>>> branch\n",
+			markerSize: 9,
+			expected:   true,
+		},
 	}
 
 	for _, s := range scenarios {
+		markerSize := s.markerSize
+		if markerSize == 0 {
+			markerSize = git_commands.DEFAULT_CONFLICT_MARKER_SIZE
+		}
 		reader := strings.NewReader(s.content)
-		result, err := fileHasConflictMarkersAux(reader)
+		result, err := fileHasConflictMarkersAux(reader, markerSize)
 		assert.NoError(t, err)
 		assert.EqualValues(t, s.expected, result)
 	}
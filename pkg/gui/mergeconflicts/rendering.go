@@ -2,10 +2,12 @@ package mergeconflicts
 
 import (
 	"bytes"
+	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 func ColoredConflictFile(state *State) string {
@@ -33,3 +35,77 @@ func ColoredConflictFile(state *State) string {
 func shiftConflict(conflicts []*mergeConflict) (*mergeConflict, []*mergeConflict) {
 	return conflicts[0], conflicts[1:]
 }
+
+const threeWayColumnSeparator = " │ "
+
+// ColoredThreeWayPanes renders the sides of the conflict next to each other,
+// in the given total width. Lines that have been picked for the result are
+// marked, and the selected line is highlighted if the editor has focus.
+func ColoredThreeWayPanes(state *ThreeWayState, width int, titles map[Pane]string, noBaseText string, focused bool) string {
+	panes := []Pane{BASE_PANE, OURS_PANE, THEIRS_PANE}
+	columnWidth := max((width-2*utils.StringWidth(threeWayColumnSeparator))/len(panes), 1)
+	sides := state.Sides()
+
+	rowCount := 1
+	for _, pane := range panes {
+		rowCount = max(rowCount, len(sides.Lines(pane)))
+	}
+
+	cell := func(str string, textStyle style.TextStyle) string {
+		str = utils.TruncateWithEllipsis(strings.ReplaceAll(str, "\t", "    "), columnWidth)
+		return textStyle.Sprint(utils.WithPadding(str, columnWidth, utils.AlignLeft))
+	}
+
+	var outputBuffer bytes.Buffer
+	outputBuffer.WriteString(strings.Join(lo.Map(panes, func(pane Pane, _ int) string {
+		return cell(titles[pane], style.AttrBold)
+	}), threeWayColumnSeparator))
+	outputBuffer.WriteByte('\n')
+
+	for row := range rowCount {
+		cells := lo.Map(panes, func(pane Pane, _ int) string {
+			if pane == BASE_PANE && !sides.HasBase {
+				if row == 0 {
+					return cell(noBaseText, style.FgBlack.SetBold())
+				}
+				return cell("", theme.DefaultTextColor)
+			}
+
+			lines := sides.Lines(pane)
+			if row >= len(lines) {
+				return cell("", theme.DefaultTextColor)
+			}
+
+			marker, textStyle := "  ", theme.DefaultTextColor
+			if state.IsPicked(pane, row) {
+				marker, textStyle = "+ ", style.FgGreen
+			}
+			if focused && pane == state.SelectedPane() && row == state.SelectedLineIdx() {
+				textStyle = textStyle.MergeStyle(theme.SelectedLineBgColor)
+			}
+			return cell(marker+lines[row], textStyle)
+		})
+		outputBuffer.WriteString(strings.Join(cells, threeWayColumnSeparator))
+		outputBuffer.WriteByte('\n')
+	}
+
+	return outputBuffer.String()
+}
+
+// ColoredThreeWayResult renders the lines picked so far, coloured by the pane
+// they were picked from
+func ColoredThreeWayResult(state *ThreeWayState) string {
+	paneStyles := map[Pane]style.TextStyle{
+		BASE_PANE:   style.FgBlue,
+		OURS_PANE:   style.FgGreen,
+		THEIRS_PANE: style.FgMagenta,
+		EDITED:      theme.DefaultTextColor,
+	}
+
+	var outputBuffer bytes.Buffer
+	for i, pane := range state.ResultPanes() {
+		outputBuffer.WriteString(paneStyles[pane].Sprint(state.Result()[i]))
+		outputBuffer.WriteByte('\n')
+	}
+	return outputBuffer.String()
+}
@@ -3,6 +3,7 @@ package mergeconflicts
 import (
 	"strings"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/samber/lo"
 )
@@ -12,6 +13,9 @@ type State struct {
 	// path of the file with the conflicts
 	path string
 
+	// length of the file's conflict markers
+	markerSize int
+
 	// This is a stack of the file content. It is used to undo changes.
 	// The last item is the current file content.
 	contents []string
@@ -31,6 +35,7 @@ func NewState() *State {
 		selectionIndex: 0,
 		conflicts:      []*mergeConflict{},
 		contents:       []string{},
+		markerSize:     git_commands.DEFAULT_CONFLICT_MARKER_SIZE,
 	}
 }
 
@@ -74,12 +79,19 @@ func (s *State) currentConflict() *mergeConflict {
 }
 
 // this is for starting a new merge conflict session
-func (s *State) SetContent(content string, path string) {
-	if content == s.GetContent() && path == s.path {
+// MarkerSize returns the size of the conflict markers in the content
+func (s *State) MarkerSize() int {
+	return s.markerSize
+}
+
+func (s *State) SetContent(content string, path string, markerSize int) {
+	markerSize = markerSizeOrDefault(markerSize)
+	if content == s.GetContent() && path == s.path && markerSize == s.markerSize {
 		return
 	}
 
 	s.path = path
+	s.markerSize = markerSize
 	s.contents = []string{}
 	s.PushContent(content)
 }
@@ -88,7 +100,7 @@ func (s *State) SetContent(content string, path string) {
 // state
 func (s *State) PushContent(content string) {
 	s.contents = append(s.contents, content)
-	s.setConflicts(findConflicts(content))
+	s.setConflicts(findConflicts(content, s.markerSize))
 }
 
 func (s *State) GetContent() string {
@@ -112,7 +124,7 @@ func (s *State) Undo() bool {
 
 	newContent := s.GetContent()
 	// We could be storing the old conflicts and selected index on a stack too.
-	s.setConflicts(findConflicts(newContent))
+	s.setConflicts(findConflicts(newContent, s.markerSize))
 
 	return true
 }
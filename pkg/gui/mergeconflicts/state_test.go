@@ -3,14 +3,16 @@ package mergeconflicts
 import (
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestFindConflicts(t *testing.T) {
 	type scenario struct {
-		name     string
-		content  string
-		expected []*mergeConflict
+		name       string
+		content    string
+		markerSize int
+		expected   []*mergeConflict
 	}
 
 	scenarios := []scenario{
@@ -112,11 +114,129 @@ baz
 				},
 			},
 		},
+		{
+			// zdiff3 moves lines that both sides share out of the conflict, which
+			// leaves the markers themselves as they are with diff3
+			name: "zdiff3",
+			content: `shared
+<<<<<<< HEAD
+ours
+||||||| merged common ancestors
+base
+=======
+theirs
+>>>>>>> branch
+shared
+`,
+			expected: []*mergeConflict{
+				{start: 1, ancestor: 3, target: 5, end: 7},
+			},
+		},
+		{
+			name: "custom marker size",
+			content: `<<<<<<<<<< HEAD
+ours
+<<<<<<< not a marker
+==========
+theirs
+=======
+>>>>>>>>>> branch
+`,
+			markerSize: 10,
+			expected: []*mergeConflict{
+				{start: 0, ancestor: -1, target: 3, end: 6},
+			},
+		},
+		{
+			name: "markers of the default size are content when the size is custom",
+			content: `<<<<<<< HEAD
+ours
+=======
+theirs
+>>>>>>> branch
+`,
+			markerSize: 10,
+			expected:   []*mergeConflict{},
+		},
+		{
+			// for recursive merges, git writes the conflicts of the virtual merge
+			// base with markers that are two characters longer
+			name: "conflict in the base of a recursive merge",
+			content: `<<<<<<< HEAD
+ours
+||||||| merged common ancestors
+<<<<<<<<< Temporary merge branch 1
+base one
+=========
+base two
+>>>>>>>>> Temporary merge branch 2
+=======
+theirs
+>>>>>>> branch
+`,
+			expected: []*mergeConflict{
+				{start: 0, ancestor: 2, target: 8, end: 10},
+			},
+		},
+		{
+			name: "nested conflict with markers of the same size",
+			content: `<<<<<<< HEAD
+<<<<<<< committed by accident
+old ours
+=======
+old theirs
+>>>>>>> committed by accident
+=======
+theirs
+>>>>>>> branch
+<<<<<<< HEAD
+second ours
+=======
+second theirs
+>>>>>>> branch
+`,
+			expected: []*mergeConflict{
+				{start: 0, ancestor: -1, target: 6, end: 8},
+				{start: 9, ancestor: -1, target: 11, end: 13},
+			},
+		},
+		{
+			name: "markers without labels",
+			content: `<<<<<<<
+ours
+|||||||
+base
+=======
+theirs
+>>>>>>>
+`,
+			expected: []*mergeConflict{
+				{start: 0, ancestor: 2, target: 4, end: 6},
+			},
+		},
+		{
+			name: "start marker that is never closed",
+			content: `<<<<<<< HEAD
+left over
+<<<<<<< HEAD
+ours
+=======
+theirs
+>>>>>>> branch
+`,
+			expected: []*mergeConflict{
+				{start: 2, ancestor: -1, target: 4, end: 6},
+			},
+		},
 	}
 
 	for _, s := range scenarios {
 		t.Run(s.name, func(t *testing.T) {
-			assert.EqualValues(t, s.expected, findConflicts(s.content))
+			markerSize := s.markerSize
+			if markerSize == 0 {
+				markerSize = git_commands.DEFAULT_CONFLICT_MARKER_SIZE
+			}
+			assert.EqualValues(t, s.expected, findConflicts(s.content, markerSize))
 		})
 	}
 }
@@ -0,0 +1,270 @@
+package mergeconflicts
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// Pane is one of the columns of the three-way merge editor
+type Pane int
+
+const (
+	BASE_PANE Pane = iota
+	OURS_PANE
+	THEIRS_PANE
+	// for lines of the result that were typed in by hand rather than picked
+	// from one of the panes
+	EDITED
+)
+
+// ConflictSides holds the lines that each side contributes to a single conflict
+type ConflictSides struct {
+	Base   []string
+	Ours   []string
+	Theirs []string
+	// false if we couldn't find out what the common ancestor looked like
+	HasBase bool
+}
+
+func (self ConflictSides) Lines(pane Pane) []string {
+	switch pane {
+	case BASE_PANE:
+		return self.Base
+	case OURS_PANE:
+		return self.Ours
+	case THEIRS_PANE:
+		return self.Theirs
+	}
+
+	return nil
+}
+
+func conflictSides(lines []string, c *mergeConflict) ConflictSides {
+	oursEnd := c.target
+	if c.hasAncestor() {
+		oursEnd = c.ancestor
+	}
+
+	sides := ConflictSides{
+		Ours:    lines[c.start+1 : oursEnd],
+		Theirs:  lines[c.target+1 : c.end],
+		HasBase: c.hasAncestor(),
+	}
+	if c.hasAncestor() {
+		sides.Base = lines[c.ancestor+1 : c.target]
+	}
+
+	return sides
+}
+
+// FindConflictSides returns the sides of each conflict in the given content,
+// whose conflict markers have the given size (0 meaning the default size)
+func FindConflictSides(content string, markerSize int) []ConflictSides {
+	lines := utils.SplitLines(content)
+	return lo.Map(findConflicts(content, markerSizeOrDefault(markerSize)), func(c *mergeConflict, _ int) ConflictSides {
+		return conflictSides(lines, c)
+	})
+}
+
+// WithBaseFrom fills in the common ancestor of a conflict that was written
+// without one, taking it from the conflicts of a re-merge of the file's index
+// stages. We look for the re-merged conflict with the same ours and theirs
+// lines, falling back to the one at the same position if the re-merge produced
+// as many conflicts as the file has.
+func (self ConflictSides) WithBaseFrom(remerged []ConflictSides, index int, conflictCount int) ConflictSides {
+	if self.HasBase {
+		return self
+	}
+
+	match, ok := lo.Find(remerged, func(other ConflictSides) bool {
+		return other.HasBase && slices.Equal(other.Ours, self.Ours) && slices.Equal(other.Theirs, self.Theirs)
+	})
+	if !ok && len(remerged) == conflictCount && index < len(remerged) && remerged[index].HasBase {
+		match, ok = remerged[index], true
+	}
+	if !ok {
+		return self
+	}
+
+	self.Base = match.Base
+	self.HasBase = true
+	return self
+}
+
+type resultLine struct {
+	text string
+	pane Pane
+	// index of the line within its pane; unused for EDITED lines
+	idx int
+}
+
+// ThreeWayState is the state of the three-way merge editor, which resolves a
+// single conflict by picking individual lines from any of its sides.
+type ThreeWayState struct {
+	sides ConflictSides
+
+	// the pane that the cursor is in, and the selected line within that pane
+	pane    Pane
+	lineIdx int
+
+	// lines of the resolution, in the order they were picked
+	result []resultLine
+}
+
+func NewThreeWayState(sides ConflictSides) *ThreeWayState {
+	return &ThreeWayState{
+		sides:  sides,
+		pane:   OURS_PANE,
+		result: []resultLine{},
+	}
+}
+
+func (s *ThreeWayState) Sides() ConflictSides {
+	return s.sides
+}
+
+func (s *ThreeWayState) SelectedPane() Pane {
+	return s.pane
+}
+
+func (s *ThreeWayState) SelectedLineIdx() int {
+	return s.lineIdx
+}
+
+func (s *ThreeWayState) availablePanes() []Pane {
+	if s.sides.HasBase {
+		return []Pane{BASE_PANE, OURS_PANE, THEIRS_PANE}
+	}
+	return []Pane{OURS_PANE, THEIRS_PANE}
+}
+
+func (s *ThreeWayState) selectPane(pane Pane) {
+	s.pane = pane
+	s.setLineIdx(s.lineIdx)
+}
+
+func (s *ThreeWayState) SelectPrevPane() {
+	panes := s.availablePanes()
+	s.selectPane(panes[max(slices.Index(panes, s.pane)-1, 0)])
+}
+
+func (s *ThreeWayState) SelectNextPane() {
+	panes := s.availablePanes()
+	s.selectPane(panes[min(slices.Index(panes, s.pane)+1, len(panes)-1)])
+}
+
+func (s *ThreeWayState) setLineIdx(idx int) {
+	s.lineIdx = lo.Clamp(idx, 0, max(len(s.sides.Lines(s.pane))-1, 0))
+}
+
+func (s *ThreeWayState) SelectPrevLine() {
+	s.setLineIdx(s.lineIdx - 1)
+}
+
+func (s *ThreeWayState) SelectNextLine() {
+	s.setLineIdx(s.lineIdx + 1)
+}
+
+func (s *ThreeWayState) IsPicked(pane Pane, idx int) bool {
+	return lo.ContainsBy(s.result, func(line resultLine) bool {
+		return line.pane == pane && line.idx == idx
+	})
+}
+
+// TogglePickSelectedLine adds the selected line to the end of the result, or
+// takes it out again if it has already been picked
+func (s *ThreeWayState) TogglePickSelectedLine() {
+	lines := s.sides.Lines(s.pane)
+	if s.lineIdx >= len(lines) {
+		return
+	}
+
+	if s.IsPicked(s.pane, s.lineIdx) {
+		s.result = lo.Reject(s.result, func(line resultLine, _ int) bool {
+			return line.pane == s.pane && line.idx == s.lineIdx
+		})
+		return
+	}
+
+	s.result = append(s.result, resultLine{text: lines[s.lineIdx], pane: s.pane, idx: s.lineIdx})
+}
+
+// PickAllLinesOfSelectedPane adds all lines of the selected pane that haven't
+// been picked yet to the end of the result
+func (s *ThreeWayState) PickAllLinesOfSelectedPane() {
+	for idx, text := range s.sides.Lines(s.pane) {
+		if !s.IsPicked(s.pane, idx) {
+			s.result = append(s.result, resultLine{text: text, pane: s.pane, idx: idx})
+		}
+	}
+}
+
+func (s *ThreeWayState) ClearResult() {
+	s.result = []resultLine{}
+}
+
+// SetEditedResult replaces the result with lines that the user has edited by
+// hand. These no longer count as picked from any pane.
+func (s *ThreeWayState) SetEditedResult(lines []string) {
+	s.result = lo.Map(lines, func(text string, _ int) resultLine {
+		return resultLine{text: text, pane: EDITED}
+	})
+}
+
+func (s *ThreeWayState) Result() []string {
+	return lo.Map(s.result, func(line resultLine, _ int) string { return line.text })
+}
+
+func (s *ThreeWayState) ResultPanes() []Pane {
+	return lo.Map(s.result, func(line resultLine, _ int) Pane { return line.pane })
+}
+
+// CurrentConflictSides returns the sides of the selected conflict as they are
+// written in the file
+func (s *State) CurrentConflictSides() (ConflictSides, bool) {
+	conflict := s.currentConflict()
+	if conflict == nil {
+		return ConflictSides{}, false
+	}
+
+	return conflictSides(utils.SplitLines(s.GetContent()), conflict), true
+}
+
+func (s *State) ConflictIndex() int {
+	return s.conflictIndex
+}
+
+func (s *State) ConflictCount() int {
+	return len(s.conflicts)
+}
+
+// ContentAfterConflictReplaced returns the file content with the selected
+// conflict, markers included, replaced by the given lines
+func (s *State) ContentAfterConflictReplaced(resolution []string) (bool, string) {
+	conflict := s.currentConflict()
+	if conflict == nil {
+		return false, ""
+	}
+
+	lines := strings.SplitAfter(s.GetContent(), "\n")
+	lineEnding := "\n"
+	if strings.HasSuffix(lines[conflict.start], "\r\n") {
+		lineEnding = "\r\n"
+	}
+
+	var builder strings.Builder
+	for _, line := range lines[:conflict.start] {
+		builder.WriteString(line)
+	}
+	for _, line := range resolution {
+		builder.WriteString(line + lineEnding)
+	}
+	for _, line := range lines[conflict.end+1:] {
+		builder.WriteString(line)
+	}
+
+	return true, builder.String()
+}
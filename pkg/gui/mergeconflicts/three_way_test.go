@@ -0,0 +1,219 @@
+package mergeconflicts
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindConflictSides(t *testing.T) {
+	scenarios := []struct {
+		name       string
+		content    string
+		markerSize int
+		expected   []ConflictSides
+	}{
+		{
+			name:     "no conflicts",
+			content:  "foo\nbar\n",
+			expected: []ConflictSides{},
+		},
+		{
+			name: "without common ancestor",
+			content: `before
+<<<<<<< HEAD
+ours 1
+ours 2
+=======
+theirs
+>>>>>>> branch
+after
+`,
+			expected: []ConflictSides{
+				{Ours: []string{"ours 1", "ours 2"}, Theirs: []string{"theirs"}},
+			},
+		},
+		{
+			name: "with common ancestor",
+			content: `<<<<<<< ours
+ours
+||||||| base
+base
+=======
+>>>>>>> theirs
+middle
+<<<<<<< ours
+=======
+theirs
+>>>>>>> theirs
+`,
+			expected: []ConflictSides{
+				{Base: []string{"base"}, Ours: []string{"ours"}, Theirs: []string{}, HasBase: true},
+				{Ours: []string{}, Theirs: []string{"theirs"}},
+			},
+		},
+		{
+			name: "custom marker size",
+			content: `<<<<<<<<<< ours
+<<<<<<< not a marker
+||||||||||
+base
+==========
+=======
+>>>>>>>>>> theirs
+`,
+			markerSize: 10,
+			expected: []ConflictSides{
+				{Base: []string{"base"}, Ours: []string{"<<<<<<< not a marker"}, Theirs: []string{"======="}, HasBase: true},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, FindConflictSides(s.content, s.markerSize))
+		})
+	}
+}
+
+func TestConflictSidesWithBaseFrom(t *testing.T) {
+	remerged := []ConflictSides{
+		{Base: []string{"base 1"}, Ours: []string{"a"}, Theirs: []string{"b"}, HasBase: true},
+		{Base: []string{"base 2"}, Ours: []string{"c"}, Theirs: []string{"d"}, HasBase: true},
+	}
+
+	scenarios := []struct {
+		name          string
+		sides         ConflictSides
+		index         int
+		conflictCount int
+		expected      ConflictSides
+	}{
+		{
+			name:          "already has a base",
+			sides:         ConflictSides{Base: []string{"own base"}, Ours: []string{"a"}, Theirs: []string{"b"}, HasBase: true},
+			index:         0,
+			conflictCount: 2,
+			expected:      ConflictSides{Base: []string{"own base"}, Ours: []string{"a"}, Theirs: []string{"b"}, HasBase: true},
+		},
+		{
+			name:          "matched by content",
+			sides:         ConflictSides{Ours: []string{"c"}, Theirs: []string{"d"}},
+			index:         0,
+			conflictCount: 1,
+			expected:      ConflictSides{Base: []string{"base 2"}, Ours: []string{"c"}, Theirs: []string{"d"}, HasBase: true},
+		},
+		{
+			name:          "matched by position",
+			sides:         ConflictSides{Ours: []string{"x"}, Theirs: []string{"y"}},
+			index:         1,
+			conflictCount: 2,
+			expected:      ConflictSides{Base: []string{"base 2"}, Ours: []string{"x"}, Theirs: []string{"y"}, HasBase: true},
+		},
+		{
+			name:          "no match when the number of conflicts differs",
+			sides:         ConflictSides{Ours: []string{"x"}, Theirs: []string{"y"}},
+			index:         1,
+			conflictCount: 3,
+			expected:      ConflictSides{Ours: []string{"x"}, Theirs: []string{"y"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, s.sides.WithBaseFrom(remerged, s.index, s.conflictCount))
+		})
+	}
+}
+
+func TestThreeWayStatePicking(t *testing.T) {
+	state := NewThreeWayState(ConflictSides{
+		Base:    []string{"base"},
+		Ours:    []string{"ours 1", "ours 2"},
+		Theirs:  []string{"theirs 1", "theirs 2", "theirs 3"},
+		HasBase: true,
+	})
+	assert.Equal(t, OURS_PANE, state.SelectedPane())
+
+	state.SelectNextLine()
+	state.TogglePickSelectedLine()
+	state.SelectNextPane()
+	state.SelectNextLine()
+	state.TogglePickSelectedLine()
+	state.SelectPrevPane()
+	state.SelectPrevPane()
+	assert.Equal(t, BASE_PANE, state.SelectedPane())
+	// the selected line is clamped to the lines of the pane
+	assert.Equal(t, 0, state.SelectedLineIdx())
+	state.TogglePickSelectedLine()
+	assert.Equal(t, []string{"ours 2", "theirs 3", "base"}, state.Result())
+	assert.Equal(t, []Pane{OURS_PANE, THEIRS_PANE, BASE_PANE}, state.ResultPanes())
+
+	// picking a line again takes it out of the result
+	state.TogglePickSelectedLine()
+	assert.Equal(t, []string{"ours 2", "theirs 3"}, state.Result())
+
+	state.SelectNextPane()
+	state.PickAllLinesOfSelectedPane()
+	assert.Equal(t, []string{"ours 2", "theirs 3", "ours 1"}, state.Result())
+	assert.True(t, state.IsPicked(OURS_PANE, 0))
+
+	state.SetEditedResult([]string{"edited"})
+	assert.Equal(t, []string{"edited"}, state.Result())
+	assert.False(t, state.IsPicked(OURS_PANE, 0))
+
+	state.ClearResult()
+	assert.Empty(t, state.Result())
+}
+
+func TestThreeWayStateWithoutBase(t *testing.T) {
+	state := NewThreeWayState(ConflictSides{Ours: []string{"ours"}, Theirs: []string{}})
+
+	state.SelectPrevPane()
+	assert.Equal(t, OURS_PANE, state.SelectedPane())
+
+	state.SelectNextPane()
+	assert.Equal(t, THEIRS_PANE, state.SelectedPane())
+	// nothing to pick in an empty pane
+	state.TogglePickSelectedLine()
+	assert.Empty(t, state.Result())
+}
+
+func TestContentAfterConflictReplaced(t *testing.T) {
+	scenarios := []struct {
+		name       string
+		content    string
+		resolution []string
+		expected   string
+	}{
+		{
+			name:       "replaces the first conflict",
+			content:    "a\n<<<<<<< HEAD\nb\n=======\nc\n>>>>>>> x\nd\n<<<<<<< HEAD\ne\n=======\nf\n>>>>>>> x\n",
+			resolution: []string{"c", "b"},
+			expected:   "a\nc\nb\nd\n<<<<<<< HEAD\ne\n=======\nf\n>>>>>>> x\n",
+		},
+		{
+			name:       "empty resolution",
+			content:    "<<<<<<< HEAD\nb\n=======\nc\n>>>>>>> x\nd\n",
+			resolution: []string{},
+			expected:   "d\n",
+		},
+		{
+			name:       "keeps windows line endings",
+			content:    "a\r\n<<<<<<< HEAD\r\nb\r\n=======\r\nc\r\n>>>>>>> x\r\n",
+			resolution: []string{"b", "c"},
+			expected:   "a\r\nb\r\nc\r\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			state := NewState()
+			state.SetContent(s.content, "path", git_commands.DEFAULT_CONFLICT_MARKER_SIZE)
+			ok, content := state.ContentAfterConflictReplaced(s.resolution)
+			assert.True(t, ok)
+			assert.Equal(t, s.expected, content)
+		})
+	}
+}
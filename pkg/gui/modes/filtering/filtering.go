@@ -1,9 +1,12 @@
 package filtering
 
+import "github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+
 type Filtering struct {
-	path               string // the filename that gets passed to git log
-	author             string // the author that gets passed to git log
-	selectedCommitHash string // the commit that was selected before we entered filtering mode
+	path               string                     // the filename that gets passed to git log
+	author             string                     // the author that gets passed to git log
+	contentFilter      git_commands.ContentFilter // the pickaxe and message filters that get passed to git log
+	selectedCommitHash string                     // the commit that was selected before we entered filtering mode
 }
 
 func New(path string, author string) Filtering {
@@ -11,12 +14,13 @@ func New(path string, author string) Filtering {
 }
 
 func (m *Filtering) Active() bool {
-	return m.path != "" || m.author != ""
+	return m.path != "" || m.author != "" || !m.contentFilter.IsEmpty()
 }
 
 func (m *Filtering) Reset() {
 	m.path = ""
 	m.author = ""
+	m.contentFilter = git_commands.ContentFilter{}
 }
 
 func (m *Filtering) SetPath(path string) {
@@ -35,6 +39,19 @@ func (m *Filtering) GetAuthor() string {
 	return m.author
 }
 
+func (m *Filtering) SetPickaxe(pickaxe string, isRegex bool) {
+	m.contentFilter.Pickaxe = pickaxe
+	m.contentFilter.PickaxeIsRegex = isRegex
+}
+
+func (m *Filtering) SetMessage(message string) {
+	m.contentFilter.Message = message
+}
+
+func (m *Filtering) GetContentFilter() git_commands.ContentFilter {
+	return m.contentFilter
+}
+
 func (m *Filtering) SetSelectedCommitHash(hash string) {
 	m.selectedCommitHash = hash
 }
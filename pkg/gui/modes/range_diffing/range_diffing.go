@@ -0,0 +1,21 @@
+package range_diffing
+
+// Compares two versions of a branch using `git range-diff`. If OldRef is
+// blank we're not range-diffing anything.
+type RangeDiffing struct {
+	// the old tip of the branch, e.g. a reflog entry from before a rebase
+	OldRef string
+	// the new tip of the branch; usually the checked-out branch
+	NewRef string
+	// the commit that both ranges start from. If blank, the merge base of
+	// OldRef and NewRef is used.
+	Base string
+}
+
+func New() RangeDiffing {
+	return RangeDiffing{}
+}
+
+func (self *RangeDiffing) Active() bool {
+	return self.OldRef != ""
+}
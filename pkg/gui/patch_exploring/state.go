@@ -372,6 +372,13 @@ func (s *State) SelectedPatchRange() (int, int) {
 	return s.patchLineIndices[start], s.patchLineIndices[end]
 }
 
+// Returns the range of line numbers in the old (or new) version of the file
+// that the selection covers; see Patch.FileLineRange
+func (s *State) SelectedFileLineRange(newFile bool) (int, int, bool) {
+	start, end := s.SelectedPatchRange()
+	return s.patch.FileLineRange(start, end, newFile)
+}
+
 // Returns the line indices of the selected patch range that are changes (i.e. additions or deletions)
 func (s *State) LineIndicesOfAddedOrDeletedLinesInSelectedPatchRange() []int {
 	viewStart, viewEnd := s.SelectedViewRange()
@@ -29,7 +29,7 @@ var colorPatterns *colorMatcher
 func GetBranchListDisplayStrings(
 	branches []*models.Branch,
 	getItemOperation func(item types.HasUrn) types.ItemOperation,
-	prs map[string]*models.GithubPullRequest,
+	prs map[string]*models.PullRequest,
 	fullDescription bool,
 	diffName string,
 	viewWidth int,
@@ -54,7 +54,7 @@ func getBranchDisplayStrings(
 	userConfig *config.UserConfig,
 	worktrees []*models.Worktree,
 	now time.Time,
-	prs map[string]*models.GithubPullRequest,
+	prs map[string]*models.PullRequest,
 ) []string {
 	checkedOutByWorkTree := git_commands.CheckedOutByOtherWorktree(b, worktrees)
 	showCommitHash := fullDescription || userConfig.Gui.ShowBranchCommitHash
@@ -287,7 +287,7 @@ func WithPrColor(state string, text string, isBg bool) string {
 	}
 }
 
-func ShouldShowPrForBranch(pr *models.GithubPullRequest, branchName string, userConfig *config.UserConfig) bool {
+func ShouldShowPrForBranch(pr *models.PullRequest, branchName string, userConfig *config.UserConfig) bool {
 	if !lo.Contains(userConfig.Git.MainBranches, branchName) {
 		return true
 	}
@@ -413,6 +413,11 @@ func displayCommit(
 		}
 	}
 
+	notesString := ""
+	if commit.HasNotes {
+		notesString = style.FgYellow.Sprint(lo.Ternary(icons.IsIconEnabled(), icons.NOTES_ICON, "✎")) + " "
+	}
+
 	name := commit.Name
 	if commit.Action == todo.UpdateRef {
 		name = strings.TrimPrefix(name, "refs/heads/")
@@ -439,21 +444,42 @@ func displayCommit(
 	}
 	author := authors.AuthorWithLength(commit.AuthorName, authorLength)
 
-	cols := make([]string, 0, 7)
+	cols := make([]string, 0, 8)
 	cols = append(
 		cols,
 		divergenceString,
 		hashString,
+		getSignatureString(commit),
 		bisectString,
 		descriptionString,
 		actionString,
 		author,
-		graphLine+mark+tagString+theme.DefaultTextColor.Sprint(name),
+		graphLine+mark+tagString+notesString+theme.DefaultTextColor.Sprint(name),
 	)
 
 	return cols
 }
 
+// Empty if we didn't load signatures (see git.log.showSignatureStatus), in
+// which case the column is omitted
+func getSignatureString(commit *models.Commit) string {
+	switch commit.Signature {
+	case models.SignatureGood:
+		return style.FgGreen.Sprint("✓")
+	case models.SignatureGoodUnknownValidity:
+		return style.FgYellow.Sprint("✓")
+	case models.SignatureExpired, models.SignatureRevoked:
+		return style.FgYellow.Sprint("!")
+	case models.SignatureUnverifiable:
+		return style.FgYellow.Sprint("?")
+	case models.SignatureBad:
+		return style.FgRed.Sprint("✗")
+	case models.SignatureNone:
+		return style.FgRed.Sprint("-")
+	}
+	return ""
+}
+
 func getBisectStatusColor(status BisectStatus) style.TextStyle {
 	switch status {
 	case BisectStatusNone:
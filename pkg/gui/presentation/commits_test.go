@@ -89,6 +89,44 @@ func TestGetCommitListDisplayStrings(t *testing.T) {
 		hash2 commit2
 						`),
 		},
+		{
+			testName: "commit with notes",
+			commitOpts: []models.NewCommitOpts{
+				{Name: "commit1", Hash: "hash1", Tags: []string{"tag1"}, HasNotes: true},
+				{Name: "commit2", Hash: "hash2"},
+			},
+			startIdx:                  0,
+			endIdx:                    2,
+			showGraph:                 false,
+			bisectInfo:                git_commands.NewNullBisectInfo(),
+			cherryPickedCommitHashSet: set.New[string](),
+			now:                       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: formatExpected(`
+		hash1 tag1 ✎ commit1
+		hash2 commit2
+						`),
+		},
+		{
+			testName: "commits with signature status",
+			commitOpts: []models.NewCommitOpts{
+				{Name: "commit1", Hash: "hash1", Signature: models.SignatureGood},
+				{Name: "commit2", Hash: "hash2", Signature: models.SignatureBad},
+				{Name: "commit3", Hash: "hash3", Signature: models.SignatureNone},
+				{Name: "commit4", Hash: "hash4", Signature: models.SignatureUnverifiable},
+			},
+			startIdx:                  0,
+			endIdx:                    4,
+			showGraph:                 false,
+			bisectInfo:                git_commands.NewNullBisectInfo(),
+			cherryPickedCommitHashSet: set.New[string](),
+			now:                       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: formatExpected(`
+		hash1 ✓ commit1
+		hash2 ✗ commit2
+		hash3 - commit3
+		hash4 ? commit4
+						`),
+		},
 		{
 			testName: "show local branch head, except the current branch, main branches, or merged branches",
 			commitOpts: []models.NewCommitOpts{
@@ -158,7 +158,8 @@ func getFileLine(
 
 	isSubmodule := file != nil && file.IsSubmodule(submoduleConfigs)
 	isLinkedWorktree := file != nil && file.IsWorktree
-	isDirectory := file == nil
+	isCollapsedUntrackedDir := file != nil && file.IsCollapsedUntrackedDir
+	isDirectory := file == nil || isCollapsedUntrackedDir
 
 	if showFileIcons {
 		icon := icons.IconForFile(name, isSubmodule, isLinkedWorktree, isDirectory, customIconsConfig)
@@ -168,10 +169,23 @@ func getFileLine(
 
 	output += nameColor.Sprint(utils.EscapeSpecialChars(name))
 
+	if isCollapsedUntrackedDir {
+		// like git status does, to tell it apart from an untracked file
+		output += nameColor.Sprint("/")
+	}
+
 	if isSubmodule {
 		output += theme.DefaultTextColor.Sprint(" (submodule)")
 	}
 
+	if file != nil && file.OutsideSparseCheckout {
+		output += style.FgYellow.Sprint(" (outside sparse checkout)")
+	}
+
+	if file != nil && file.ResolvedByRerere {
+		output += style.FgCyan.Sprint(" (resolved by rerere)")
+	}
+
 	if file != nil && showNumstat {
 		if lineChanges := formatLineChanges(file.LinesAdded, file.LinesDeleted); lineChanges != "" {
 			output += " " + lineChanges
@@ -60,6 +60,32 @@ func TestRenderFileTree(t *testing.T) {
 				"   M test4",
 			},
 		},
+		{
+			name: "file outside sparse checkout",
+			files: []*models.File{
+				{Path: "docs/test", ShortStatus: "??", HasUnstagedChanges: true, OutsideSparseCheckout: true},
+				{Path: "test", ShortStatus: "??", HasUnstagedChanges: true},
+			},
+			showRootItem: false,
+			expected: []string{
+				"▼ docs",
+				"  ?? test (outside sparse checkout)",
+				"?? test",
+			},
+		},
+		{
+			name: "collapsed untracked dir",
+			files: []*models.File{
+				{Path: "src/build", ShortStatus: "??", HasUnstagedChanges: true, IsCollapsedUntrackedDir: true},
+				{Path: "src/main.go", ShortStatus: " M", HasUnstagedChanges: true},
+			},
+			showRootItem: false,
+			expected: []string{
+				"▼ src",
+				"  ?? build/",
+				"   M main.go",
+			},
+		},
 		{
 			name: "big example",
 			files: []*models.File{
@@ -14,6 +14,7 @@ var (
 	MERGE_COMMIT_ICON            = "\U000f062d" // 󰘭
 	DEFAULT_REMOTE_ICON          = "\U000f02a2" // 󰊢
 	STASH_ICON                   = "\uf01c"     // 
+	NOTES_ICON                   = "\uf249"     // 
 	LINKED_WORKTREE_ICON         = "\U000f0339" // 󰌹
 	MISSING_LINKED_WORKTREE_ICON = "\U000f033a" // 󰌺
 )
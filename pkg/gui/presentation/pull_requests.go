@@ -0,0 +1,46 @@
+package presentation
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/samber/lo"
+)
+
+func GetPullRequestListDisplayStrings(prs []*models.PullRequest, tr *i18n.TranslationSet) [][]string {
+	return lo.Map(prs, func(pr *models.PullRequest, _ int) []string {
+		return []string{
+			WithPrColor(pr.State, "#"+pr.ID(), false),
+			PullRequestChecksIcon(pr.ChecksStatus),
+			pr.Title,
+			style.FgCyan.Sprint(pr.Author),
+			PullRequestReviewDecision(pr.ReviewDecision, tr),
+		}
+	})
+}
+
+func PullRequestChecksIcon(checksStatus string) string {
+	switch checksStatus {
+	case "SUCCESS":
+		return style.FgGreen.Sprint("✓")
+	case "FAILURE", "ERROR":
+		return style.FgRed.Sprint("✗")
+	case "PENDING", "EXPECTED":
+		return style.FgYellow.Sprint("●")
+	default:
+		return " "
+	}
+}
+
+func PullRequestReviewDecision(reviewDecision string, tr *i18n.TranslationSet) string {
+	switch reviewDecision {
+	case "APPROVED":
+		return style.FgGreen.Sprint(tr.PullRequestApproved)
+	case "CHANGES_REQUESTED":
+		return style.FgRed.Sprint(tr.PullRequestChangesRequested)
+	case "REVIEW_REQUIRED":
+		return style.FgYellow.Sprint(tr.PullRequestReviewRequired)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,36 @@
+package presentation
+
+import (
+	"testing"
+
+	"github.com/gookit/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/xo/terminfo"
+)
+
+func TestGetPullRequestListDisplayStrings(t *testing.T) {
+	tr := &i18n.TranslationSet{
+		PullRequestApproved:         "Approved",
+		PullRequestChangesRequested: "Changes requested",
+		PullRequestReviewRequired:   "Review required",
+	}
+
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelNone)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	prs := []*models.PullRequest{
+		{Number: 12, Title: "Add feature", Author: "alice", State: "OPEN", ReviewDecision: "APPROVED", ChecksStatus: "SUCCESS"},
+		{Number: 11, Title: "Fix bug", Author: "bob", State: "OPEN", ReviewDecision: "CHANGES_REQUESTED", ChecksStatus: "FAILURE"},
+		{Number: 10, Title: "Draft", Author: "carol", State: "DRAFT", ReviewDecision: "REVIEW_REQUIRED", ChecksStatus: "PENDING"},
+		{Number: 9, Title: "No checks", Author: "dave", State: "OPEN"},
+	}
+
+	assert.Equal(t, [][]string{
+		{"#12", "✓", "Add feature", "alice", "Approved"},
+		{"#11", "✗", "Fix bug", "bob", "Changes requested"},
+		{"#10", "●", "Draft", "carol", "Review required"},
+		{"#9", " ", "No checks", "dave", ""},
+	}, GetPullRequestListDisplayStrings(prs, tr))
+}
@@ -0,0 +1,51 @@
+package presentation
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+func GetRangeDiffEntryListDisplayStrings(entries []*models.RangeDiffEntry) [][]string {
+	return lo.Map(entries, func(entry *models.RangeDiffEntry, _ int) []string {
+		return getRangeDiffEntryDisplayStrings(entry)
+	})
+}
+
+// Mirrors the way `git range-diff` itself renders a pairing, e.g.
+// `1: a1f4111 ! 2: 1c2cba1 subject`
+func getRangeDiffEntryDisplayStrings(entry *models.RangeDiffEntry) []string {
+	statusStyle, statusStr := rangeDiffStatusStyleAndSymbol(entry.Status)
+
+	return []string{
+		rangeDiffSide(entry.OldIndex, entry.OldHash, style.FgRed),
+		statusStyle.Sprint(statusStr),
+		rangeDiffSide(entry.NewIndex, entry.NewHash, style.FgGreen),
+		theme.DefaultTextColor.Sprint(entry.Subject),
+	}
+}
+
+func rangeDiffSide(index int, hash string, hashStyle style.TextStyle) string {
+	if index == 0 {
+		return style.FgBlue.Sprint("-: -------")
+	}
+
+	return fmt.Sprintf("%d: %s", index, hashStyle.Sprint(utils.ShortHash(hash)))
+}
+
+func rangeDiffStatusStyleAndSymbol(status models.RangeDiffStatus) (style.TextStyle, string) {
+	switch status {
+	case models.RangeDiffStatusChanged:
+		return style.FgYellow, "!"
+	case models.RangeDiffStatusRemoved:
+		return style.FgRed, "<"
+	case models.RangeDiffStatusAdded:
+		return style.FgGreen, ">"
+	default:
+		return style.FgDefault, "="
+	}
+}
@@ -1,22 +1,26 @@
 package presentation
 
 import (
+	"strconv"
+
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation/icons"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
 	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/samber/lo"
 )
 
-func GetStashEntryListDisplayStrings(stashEntries []*models.StashEntry, diffName string) [][]string {
+func GetStashEntryListDisplayStrings(stashEntries []*models.StashEntry, diffName string, tr *i18n.TranslationSet) [][]string {
 	return lo.Map(stashEntries, func(stashEntry *models.StashEntry, _ int) []string {
 		diffed := stashEntry.RefName() == diffName
-		return getStashEntryDisplayStrings(stashEntry, diffed)
+		return getStashEntryDisplayStrings(stashEntry, diffed, tr)
 	})
 }
 
 // getStashEntryDisplayStrings returns the display string of branch
-func getStashEntryDisplayStrings(s *models.StashEntry, diffed bool) []string {
+func getStashEntryDisplayStrings(s *models.StashEntry, diffed bool, tr *i18n.TranslationSet) []string {
 	textStyle := theme.DefaultTextColor
 	if diffed {
 		textStyle = theme.DiffTerminalColor
@@ -29,6 +33,34 @@ func getStashEntryDisplayStrings(s *models.StashEntry, diffed bool) []string {
 		res = append(res, textStyle.Sprint(icons.IconForStash(s)))
 	}
 
-	res = append(res, textStyle.Sprint(s.Name))
+	name := textStyle.Sprint(s.Name)
+	// Only worth the space in the list if HEAD has moved on since stashing;
+	// the main view always shows it
+	if s.CommitsBehindHead > 0 {
+		name += " " + style.FgBlue.Sprint("("+StashBaseContext(s, tr)+")")
+	}
+	res = append(res, name)
 	return res
 }
+
+// StashBaseContext describes what the stash entry was based on, e.g. "based on
+// master@abc12345, 3 commit(s) behind HEAD"
+func StashBaseContext(s *models.StashEntry, tr *i18n.TranslationSet) string {
+	if s.BaseHash == "" {
+		return ""
+	}
+
+	base := utils.ShortHash(s.BaseHash)
+	if s.BaseBranch != "" {
+		base = s.BaseBranch + "@" + base
+	}
+
+	if s.CommitsBehindHead == 0 {
+		return utils.ResolvePlaceholderString(tr.StashBasedOn, map[string]string{"base": base})
+	}
+
+	return utils.ResolvePlaceholderString(tr.StashBasedOnBehindHead, map[string]string{
+		"base":  base,
+		"count": strconv.Itoa(s.CommitsBehindHead),
+	})
+}
@@ -0,0 +1,46 @@
+package presentation
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StashBaseContext(t *testing.T) {
+	tr := i18n.EnglishTranslationSet()
+
+	scenarios := []struct {
+		testName   string
+		stashEntry *models.StashEntry
+		expected   string
+	}{
+		{
+			testName:   "Unknown base",
+			stashEntry: &models.StashEntry{Name: "WIP on master: abc1234 subject"},
+			expected:   "",
+		},
+		{
+			testName:   "Base is HEAD",
+			stashEntry: &models.StashEntry{BaseHash: "1234567890abcdef", BaseBranch: "master"},
+			expected:   "based on master@12345678",
+		},
+		{
+			testName:   "HEAD has moved on",
+			stashEntry: &models.StashEntry{BaseHash: "1234567890abcdef", BaseBranch: "feature/x", CommitsBehindHead: 3},
+			expected:   "based on feature/x@12345678, 3 commit(s) behind HEAD",
+		},
+		{
+			testName:   "Branch unknown because the stash was renamed",
+			stashEntry: &models.StashEntry{BaseHash: "1234567890abcdef", CommitsBehindHead: 1},
+			expected:   "based on 12345678, 1 commit(s) behind HEAD",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.Equal(t, s.expected, StashBaseContext(s.stashEntry, tr))
+		})
+	}
+}
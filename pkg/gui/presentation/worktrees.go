@@ -51,10 +51,20 @@ func GetWorktreeDisplayString(tr *i18n.TranslationSet, worktree *models.Worktree
 	} else if worktree.Head != "" {
 		branch = style.FgYellow.Sprint("HEAD detached at " + utils.ShortHash(worktree.Head))
 	}
-	res = append(res, branch+mainWorktreeLabel(tr, worktree))
+	res = append(res, branch+mainWorktreeLabel(tr, worktree)+worktreeStateLabel(tr, worktree))
 	return res
 }
 
+func worktreeStateLabel(tr *i18n.TranslationSet, worktree *models.Worktree) string {
+	if worktree.IsLocked {
+		return style.FgYellow.Sprint(" " + tr.LockedWorktree)
+	}
+	if worktree.IsPrunable {
+		return style.FgRed.Sprint(" " + tr.PrunableWorktree)
+	}
+	return ""
+}
+
 func mainWorktreeLabel(tr *i18n.TranslationSet, worktree *models.Worktree) string {
 	if worktree.IsMain {
 		return style.FgDefault.Sprint(" " + tr.MainWorktree)
@@ -0,0 +1,264 @@
+package gui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// Set in the environment of the commands we run once the remote-control server
+// is listening, so that e.g. custom commands and editors started from lazygit
+// can call `lazygit --remote` without having to find the socket.
+const REMOTE_SOCKET_ENV_VAR = "LAZYGIT_REMOTE_SOCKET"
+
+// RemoteControlServer lets other programs (e.g. editor plugins) drive a running
+// lazygit. It's implemented in pkg/app; see docs/Remote_Control.md.
+type RemoteControlServer interface {
+	// Listen is called once the views are set up, and again whenever we switch
+	// to another repo. It should stop listening on the previous repo's socket
+	// if that's not the same as the new one, serve requests on its own
+	// goroutines, and return the path of the socket.
+	Listen(driver *RemoteDriver, worktreePath string) (string, error)
+}
+
+// RemoteDriver is how the remote-control server interacts with the gui. Much
+// like GuiDriver does for integration tests, it waits until lazygit is idle
+// after each request, so that once a request returns the client sees its full
+// effect (e.g. a refresh has actually finished).
+type RemoteDriver struct {
+	gui *Gui
+
+	// we only handle one request at a time
+	requestMutex sync.Mutex
+
+	idleMutex   sync.Mutex
+	idleWaiters []chan struct{}
+}
+
+// RemoteState is what we tell remote-control clients about lazygit's state.
+type RemoteState struct {
+	RepoPath         string `json:"repoPath"`
+	CheckedOutBranch string `json:"checkedOutBranch"`
+	CurrentContext   string `json:"currentContext"`
+	SelectedCommit   string `json:"selectedCommit,omitempty"`
+	SelectedFile     string `json:"selectedFile,omitempty"`
+}
+
+// must be called before the main loop starts, because gocui doesn't expect
+// idle listeners to be added while it's running
+func newRemoteDriver(gui *Gui) *RemoteDriver {
+	self := &RemoteDriver{gui: gui}
+
+	isIdleChan := make(chan struct{})
+	gui.g.AddIdleListener(isIdleChan)
+	go utils.Safe(func() { self.forwardIdleNotifications(isIdleChan) })
+
+	return self
+}
+
+func (gui *Gui) handleRemoteControl() {
+	if gui.remoteDriver == nil {
+		return
+	}
+
+	socketPath, err := gui.RemoteControlServer.Listen(gui.remoteDriver, gui.git.RepoPaths.WorktreePath())
+	if err != nil {
+		gui.c.Log.Errorf("Not listening for remote control: %v", err)
+		gui.os.Cmd.UnsetEnvVar(REMOTE_SOCKET_ENV_VAR)
+		return
+	}
+
+	gui.os.Cmd.SetEnvVar(REMOTE_SOCKET_ENV_VAR, socketPath)
+}
+
+// gocui blocks until we receive its idle notification, so we always need to
+// receive them, even if no request is waiting for one
+func (self *RemoteDriver) forwardIdleNotifications(isIdleChan chan struct{}) {
+	for range isIdleChan {
+		self.idleMutex.Lock()
+		waiters := self.idleWaiters
+		self.idleWaiters = nil
+		self.idleMutex.Unlock()
+
+		for _, waiter := range waiters {
+			close(waiter)
+		}
+	}
+}
+
+// runOnUIThread runs f on the UI thread, and waits until both f and whatever
+// it kicked off (e.g. a refresh on a worker goroutine) are done, or until ctx
+// is cancelled. In the latter case f still finishes, but if it hasn't started
+// yet it won't.
+func (self *RemoteDriver) runOnUIThread(ctx context.Context, f func() error) error {
+	self.requestMutex.Lock()
+	defer self.requestMutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var err error
+	idle := make(chan struct{})
+	quitting := make(chan struct{})
+	self.gui.c.OnUIThread(func() error {
+		if ctx.Err() != nil {
+			// nobody is waiting for us anymore
+			return nil
+		}
+
+		// We register for the idle notification here rather than before
+		// calling OnUIThread, so that we can't mistake a notification for
+		// something that finished before f ran for the one we're waiting for.
+		self.idleMutex.Lock()
+		self.idleWaiters = append(self.idleWaiters, idle)
+		self.idleMutex.Unlock()
+
+		err = f()
+		if errors.Is(err, gocui.ErrQuit) {
+			// we won't go idle again, so don't wait for it
+			close(quitting)
+			err = nil
+			return gocui.ErrQuit
+		}
+
+		// other errors go back to the client rather than to an error popup
+		return nil
+	})
+
+	select {
+	case <-idle:
+	case <-quitting:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return err
+}
+
+// WorktreePath returns the path of the repo that lazygit is showing.
+func (self *RemoteDriver) WorktreePath() string {
+	return self.gui.git.RepoPaths.WorktreePath()
+}
+
+func (self *RemoteDriver) Refresh(ctx context.Context) error {
+	return self.runOnUIThread(ctx, func() error {
+		self.gui.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+		return nil
+	})
+}
+
+// FocusCommit selects the commit with the given hash (or hash prefix) in the
+// commits view and focuses it.
+func (self *RemoteDriver) FocusCommit(ctx context.Context, hash string) error {
+	return self.runOnUIThread(ctx, func() error {
+		commitsContext := self.gui.c.Contexts().LocalCommits
+		_, idx, found := lo.FindIndexOf(commitsContext.GetItems(), func(commit *models.Commit) bool {
+			return strings.HasPrefix(commit.Hash(), hash)
+		})
+		if !found {
+			return fmt.Errorf("commit %s is not in the commits view", hash)
+		}
+
+		commitsContext.SetSelection(idx)
+		self.gui.c.Context().Push(commitsContext, types.OnFocusOpts{})
+		return nil
+	})
+}
+
+// OpenFileInStaging selects the given file in the files view and enters it,
+// which for a file with changes opens the staging view. The path can be
+// absolute, or relative to the repo root.
+func (self *RemoteDriver) OpenFileInStaging(ctx context.Context, path string) error {
+	return self.runOnUIThread(ctx, func() error {
+		if filepath.IsAbs(path) {
+			relPath, err := filepath.Rel(self.WorktreePath(), path)
+			if err != nil {
+				return err
+			}
+			path = relPath
+		}
+		path = filepath.ToSlash(path)
+
+		filesContext := self.gui.c.Contexts().Files
+		if !filesContext.FileTreeViewModel.SelectPath(path, self.gui.c.UserConfig().Gui.ShowRootItemInFileTree) {
+			return fmt.Errorf("%s is not in the files view", path)
+		}
+
+		self.gui.c.Context().Push(filesContext, types.OnFocusOpts{})
+		return self.pressKey(self.gui.c.UserConfig().Keybinding.Universal.GoInto)
+	})
+}
+
+// RunCustomCommand runs the custom command with the given description. If ctx
+// is cancelled while the command is running, we stop waiting for it, but the
+// command itself keeps running.
+func (self *RemoteDriver) RunCustomCommand(ctx context.Context, description string) error {
+	return self.runOnUIThread(ctx, func() error {
+		return self.gui.CustomCommandsClient.RunCustomCommand(description)
+	})
+}
+
+// PressKey does what pressing the given key (e.g. "<c-r>") would do in the
+// focused view.
+func (self *RemoteDriver) PressKey(ctx context.Context, keyStr string) error {
+	if _, ok := config.KeyFromLabel(keyStr); !ok {
+		return fmt.Errorf("unrecognized key: %s", keyStr)
+	}
+
+	return self.runOnUIThread(ctx, func() error {
+		return self.pressKey(config.Keybinding{keyStr})
+	})
+}
+
+// Like gocui, we try the focused view's bindings first and fall back to the
+// global ones
+func (self *RemoteDriver) pressKey(keybinding config.Keybinding) error {
+	keys := config.GetValidatedKeyBindingKeys(keybinding)
+	viewName := self.gui.c.Context().Current().GetViewName()
+	bindings, _ := self.gui.GetInitialKeybindingsWithCustomCommands()
+
+	for _, bindingViewName := range []string{viewName, ""} {
+		for _, binding := range bindings {
+			if binding.ViewName != bindingViewName || binding.Handler == nil ||
+				!lo.ContainsBy(binding.Keys, func(key gocui.Key) bool { return lo.Contains(keys, key) }) {
+				continue
+			}
+
+			err := self.gui.callKeybindingHandler(binding)
+			var notHandledErr *types.ErrKeybindingNotHandled
+			if !errors.As(err, &notHandledErr) {
+				return err
+			}
+		}
+	}
+
+	return fmt.Errorf("nothing is bound to %s in the %s view", strings.Join(keybinding, ", "), viewName)
+}
+
+func (self *RemoteDriver) State(ctx context.Context) (RemoteState, error) {
+	var state RemoteState
+	err := self.runOnUIThread(ctx, func() error {
+		state = RemoteState{
+			RepoPath:       self.WorktreePath(),
+			CurrentContext: string(self.gui.c.Context().Current().GetKey()),
+			SelectedCommit: self.gui.c.Contexts().LocalCommits.GetSelectedCommitHash(),
+			SelectedFile:   self.gui.c.Contexts().Files.GetSelectedPath(),
+		}
+		if branch := self.gui.helpers.Refs.GetCheckedOutRef(); branch != nil {
+			state.CheckedOutBranch = branch.Name
+		}
+		return nil
+	})
+	return state, err
+}
@@ -1,6 +1,8 @@
 package custom_commands
 
 import (
+	"fmt"
+
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
@@ -63,6 +65,33 @@ func (self *Client) GetCustomCommandKeybindings() ([]*types.Binding, error) {
 	return bindings, nil
 }
 
+// RunCustomCommand runs the custom command with the given description, as if
+// its key had been pressed. Commands inside command menus are found too.
+func (self *Client) RunCustomCommand(description string) error {
+	customCommand, ok := findCustomCommand(self.c.UserConfig().CustomCommands, description)
+	if !ok {
+		return fmt.Errorf("no custom command with description %q", description)
+	}
+
+	if len(customCommand.CommandMenu) > 0 {
+		return self.showCustomCommandsMenu(customCommand)
+	}
+	return self.handlerCreator.call(customCommand)()
+}
+
+func findCustomCommand(customCommands []config.CustomCommand, description string) (config.CustomCommand, bool) {
+	for _, customCommand := range customCommands {
+		if customCommand.Description == description {
+			return customCommand, true
+		}
+		if found, ok := findCustomCommand(customCommand.CommandMenu, description); ok {
+			return found, true
+		}
+	}
+
+	return config.CustomCommand{}, false
+}
+
 func (self *Client) showCustomCommandsMenu(customCommand config.CustomCommand) error {
 	menuItems := make([]*types.MenuItem, 0, len(customCommand.CommandMenu))
 	for _, subCommand := range customCommand.CommandMenu {
@@ -10,6 +10,16 @@ import (
 )
 
 func (gui *Gui) newCmdTask(view *gocui.View, cmd *exec.Cmd, prefix string) error {
+	manager := gui.getManager(view)
+
+	if err := manager.NewTask(gui.cmdTaskFunc(view, cmd, prefix), strings.Join(cmd.Args, " ")); err != nil {
+		gui.c.Log.Error(err)
+	}
+
+	return nil
+}
+
+func (gui *Gui) cmdTaskFunc(view *gocui.View, cmd *exec.Cmd, prefix string) func(tasks.TaskOpts) error {
 	cmdStr := strings.Join(cmd.Args, " ")
 	gui.c.Log.WithField(
 		"command",
@@ -43,7 +53,34 @@ func (gui *Gui) newCmdTask(view *gocui.View, cmd *exec.Cmd, prefix string) error
 	}
 
 	linesToRead := gui.linesToReadFromCmdTask(view)
-	if err := manager.NewTask(manager.NewCmdTask(start, prefix, linesToRead, onClose), cmdStr); err != nil {
+	return manager.NewCmdTask(start, prefix, linesToRead, onClose)
+}
+
+// The fallback command runs without a pty, so it doesn't go through the user's
+// pager
+func (gui *Gui) newStringFuncTask(view *gocui.View, getStr func() (string, bool), fallbackCmd *exec.Cmd) error {
+	manager := gui.getManager(view)
+	runFallbackCmd := gui.cmdTaskFunc(view, fallbackCmd, "")
+
+	f := func(opts tasks.TaskOpts) error {
+		str, ok := getStr()
+		if !ok {
+			return runFallbackCmd(opts)
+		}
+
+		select {
+		case <-opts.Stop:
+			// another task has replaced us while we were busy
+			return nil
+		default:
+		}
+
+		gui.c.ResetViewOrigin(view)
+		gui.c.SetViewContent(view, str)
+		return nil
+	}
+
+	if err := manager.NewTask(f, strings.Join(fallbackCmd.Args, " ")); err != nil {
 		gui.c.Log.Error(err)
 	}
 
@@ -302,8 +302,20 @@ type Model struct {
 	SubCommits      []*models.Commit
 	Remotes         []*models.Remote
 	Worktrees       []*models.Worktree
-	PullRequests    []*models.GithubPullRequest
-	PullRequestsMap map[string]*models.GithubPullRequest
+	PullRequests    []*models.PullRequest
+	PullRequestsMap map[string]*models.PullRequest
+
+	// The open pull requests of the GitHub repo that PRs are made against, and
+	// the name of our remote for that repo
+	OpenPullRequests       []*models.PullRequest
+	PullRequestsRemoteName string
+
+	// The notes ref whose notes are shown in the commits views; empty means
+	// git's default notes ref
+	NotesRef string
+
+	// The commit pairings shown while in range-diff mode
+	RangeDiffEntries []*models.RangeDiffEntry
 
 	// FilteredReflogCommits are the ones that appear in the reflog panel.
 	// When in filtering mode we only include the ones that match the given path
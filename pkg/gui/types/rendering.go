@@ -14,10 +14,12 @@ func NewMainContextPair(main Context, secondary Context) MainContextPair {
 }
 
 type MainViewPairs struct {
-	Normal         MainContextPair
-	MergeConflicts MainContextPair
-	Staging        MainContextPair
-	PatchBuilding  MainContextPair
+	Normal              MainContextPair
+	MergeConflicts      MainContextPair
+	MergeConflictEditor MainContextPair
+	Staging             MainContextPair
+	PatchBuilding       MainContextPair
+	Blame               MainContextPair
 }
 
 type ViewUpdateOpts struct {
@@ -69,6 +71,20 @@ func NewRenderStringWithScrollTask(str string, originX int, originY int) *Render
 	return &RenderStringWithScrollTask{Str: str, OriginX: originX, OriginY: originY}
 }
 
+// Renders the string returned by GetStr, which is called in the background
+// because it may need to run commands. If it returns false, we run
+// FallbackCmd instead.
+type RenderStringFuncTask struct {
+	GetStr      func() (string, bool)
+	FallbackCmd *exec.Cmd
+}
+
+func (t *RenderStringFuncTask) IsUpdateTask() {}
+
+func NewRenderStringFuncTask(getStr func() (string, bool), fallbackCmd *exec.Cmd) *RenderStringFuncTask {
+	return &RenderStringFuncTask{GetStr: getStr, FallbackCmd: fallbackCmd}
+}
+
 type RunCommandTask struct {
 	Cmd    *exec.Cmd
 	Prefix string
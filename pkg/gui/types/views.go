@@ -10,6 +10,7 @@ type Views struct {
 	Remotes        *gocui.View
 	Worktrees      *gocui.View
 	Tags           *gocui.View
+	PullRequests   *gocui.View
 	RemoteBranches *gocui.View
 	ReflogCommits  *gocui.View
 	Commits        *gocui.View
@@ -22,6 +23,9 @@ type Views struct {
 	PatchBuilding          *gocui.View
 	PatchBuildingSecondary *gocui.View
 	MergeConflicts         *gocui.View
+	MergeConflictEditor    *gocui.View
+	MergeConflictResult    *gocui.View
+	Blame                  *gocui.View
 
 	Options           *gocui.View
 	Confirmation      *gocui.View
@@ -31,6 +35,7 @@ type Views struct {
 	CommitDescription *gocui.View
 	CommitFiles       *gocui.View
 	SubCommits        *gocui.View
+	RangeDiff         *gocui.View
 	Information       *gocui.View
 	AppStatus         *gocui.View
 	Search            *gocui.View
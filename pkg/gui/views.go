@@ -33,6 +33,7 @@ func (gui *Gui) orderedViewNameMappings() []viewNameMapping {
 		{viewPtr: &gui.Views.Worktrees, name: "worktrees"},
 		{viewPtr: &gui.Views.Files, name: "files"},
 		{viewPtr: &gui.Views.Tags, name: "tags"},
+		{viewPtr: &gui.Views.PullRequests, name: "pullRequests"},
 		{viewPtr: &gui.Views.Remotes, name: "remotes"},
 		{viewPtr: &gui.Views.Branches, name: "localBranches"},
 		{viewPtr: &gui.Views.RemoteBranches, name: "remoteBranches"},
@@ -40,6 +41,7 @@ func (gui *Gui) orderedViewNameMappings() []viewNameMapping {
 		{viewPtr: &gui.Views.Commits, name: "commits"},
 		{viewPtr: &gui.Views.Stash, name: "stash"},
 		{viewPtr: &gui.Views.SubCommits, name: "subCommits"},
+		{viewPtr: &gui.Views.RangeDiff, name: "rangeDiff"},
 		{viewPtr: &gui.Views.CommitFiles, name: "commitFiles"},
 
 		{viewPtr: &gui.Views.Staging, name: "staging"},
@@ -47,6 +49,9 @@ func (gui *Gui) orderedViewNameMappings() []viewNameMapping {
 		{viewPtr: &gui.Views.PatchBuilding, name: "patchBuilding"},
 		{viewPtr: &gui.Views.PatchBuildingSecondary, name: "patchBuildingSecondary"},
 		{viewPtr: &gui.Views.MergeConflicts, name: "mergeConflicts"},
+		{viewPtr: &gui.Views.MergeConflictEditor, name: "mergeConflictEditor"},
+		{viewPtr: &gui.Views.MergeConflictResult, name: "mergeConflictResult"},
+		{viewPtr: &gui.Views.Blame, name: "blame"},
 		{viewPtr: &gui.Views.Secondary, name: "secondary"},
 		{viewPtr: &gui.Views.Main, name: "main"},
 
@@ -101,7 +106,7 @@ func (gui *Gui) createAllViews() error {
 	gui.Views.Search.Frame = false
 	gui.Views.Search.Editor = gocui.EditorFunc(gui.searchEditor)
 
-	for _, view := range []*gocui.View{gui.Views.Main, gui.Views.Secondary, gui.Views.Staging, gui.Views.StagingSecondary, gui.Views.PatchBuilding, gui.Views.PatchBuildingSecondary, gui.Views.MergeConflicts} {
+	for _, view := range []*gocui.View{gui.Views.Main, gui.Views.Secondary, gui.Views.Staging, gui.Views.StagingSecondary, gui.Views.PatchBuilding, gui.Views.PatchBuildingSecondary, gui.Views.MergeConflicts, gui.Views.MergeConflictEditor, gui.Views.MergeConflictResult, gui.Views.Blame} {
 		view.Wrap = true
 		view.UnderlineHyperLinksOnlyOnHover = true
 		view.AutoRenderHyperLinks = true
@@ -112,6 +117,8 @@ func (gui *Gui) createAllViews() error {
 	gui.Views.PatchBuilding.Wrap = true
 	gui.Views.PatchBuildingSecondary.Wrap = true
 	gui.Views.MergeConflicts.Wrap = false
+	gui.Views.MergeConflictEditor.Wrap = false
+	gui.Views.Blame.Wrap = false
 	gui.Views.Limit.Wrap = true
 
 	gui.Views.AppStatus.BgColor = gocui.ColorDefault
@@ -187,6 +194,7 @@ func (gui *Gui) configureViewProperties() {
 	gui.Views.Remotes.Title = gui.c.Tr.RemotesTitle
 	gui.Views.Worktrees.Title = gui.c.Tr.WorktreesTitle
 	gui.Views.Tags.Title = gui.c.Tr.TagsTitle
+	gui.Views.PullRequests.Title = gui.c.Tr.PullRequestsTitle
 	gui.Views.Files.Title = gui.c.Tr.FilesTitle
 	gui.Views.PatchBuilding.Title = gui.c.Tr.Patch
 	gui.Views.PatchBuildingSecondary.Title = gui.c.Tr.CustomPatch
@@ -200,7 +208,7 @@ func (gui *Gui) configureViewProperties() {
 	gui.Views.Extras.Title = gui.c.Tr.CommandLog
 	gui.Views.Snake.Title = gui.c.Tr.SnakeTitle
 
-	for _, view := range []*gocui.View{gui.Views.Main, gui.Views.Secondary, gui.Views.Staging, gui.Views.StagingSecondary, gui.Views.PatchBuilding, gui.Views.PatchBuildingSecondary, gui.Views.MergeConflicts} {
+	for _, view := range []*gocui.View{gui.Views.Main, gui.Views.Secondary, gui.Views.Staging, gui.Views.StagingSecondary, gui.Views.PatchBuilding, gui.Views.PatchBuildingSecondary, gui.Views.MergeConflicts, gui.Views.MergeConflictEditor, gui.Views.MergeConflictResult, gui.Views.Blame} {
 		view.Title = gui.c.Tr.DiffTitle
 		view.CanScrollPastBottom = gui.c.UserConfig().Gui.ScrollPastBottom
 		view.TabWidth = gui.c.UserConfig().Gui.TabWidth
@@ -231,6 +239,7 @@ func (gui *Gui) configureViewProperties() {
 		gui.Views.Branches.TitlePrefix = jumpLabels[2]
 		gui.Views.Remotes.TitlePrefix = jumpLabels[2]
 		gui.Views.Tags.TitlePrefix = jumpLabels[2]
+		gui.Views.PullRequests.TitlePrefix = jumpLabels[2]
 
 		gui.Views.Commits.TitlePrefix = jumpLabels[3]
 		gui.Views.ReflogCommits.TitlePrefix = jumpLabels[3]
@@ -248,6 +257,7 @@ func (gui *Gui) configureViewProperties() {
 		gui.Views.Branches.TitlePrefix = ""
 		gui.Views.Remotes.TitlePrefix = ""
 		gui.Views.Tags.TitlePrefix = ""
+		gui.Views.PullRequests.TitlePrefix = ""
 
 		gui.Views.Commits.TitlePrefix = ""
 		gui.Views.ReflogCommits.TitlePrefix = ""
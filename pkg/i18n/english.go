@@ -59,6 +59,12 @@ type TranslationSet struct {
 	ToggleTreeViewTooltip                 string
 	OpenDiffTool                          string
 	OpenMergeTool                         string
+	ForgetRerereResolution                string
+	ForgetRerereResolutionTooltip         string
+	ForgetRerereResolutionPrompt          string
+	NoRerereResolutionFound               string
+	RerereResolutionTitle                 string
+	NoFilesResolvedByRerere               string
 	Refresh                               string
 	RefreshTooltip                        string
 	Push                                  string
@@ -99,6 +105,11 @@ type TranslationSet struct {
 	MergeConflictIncomingDiff             string
 	MergeConflictCurrentDiff              string
 	MergeConflictPressEnterToResolve      string
+	CollapsedUntrackedDirHint             string
+	ListingUntrackedFiles                 string
+	SlowStatusFsmonitorHint               string
+	SlowStatusLazyUntrackedFilesHint      string
+	SlowStatusUntrackedCacheHint          string
 	MergeConflictKeepFile                 string
 	MergeConflictDeleteFile               string
 	Checkout                              string
@@ -224,6 +235,10 @@ type TranslationSet struct {
 	SureDropStashEntry                    string
 	StashPop                              string
 	SurePopStashEntry                     string
+	StashBasedOn                          string
+	StashBasedOnBehindHead                string
+	StashWouldConflict                    string
+	CheckingForStashConflictsStatus       string
 	StashApply                            string
 	SureApplyStashEntry                   string
 	NoTrackedStagedFilesStash             string
@@ -318,12 +333,35 @@ type TranslationSet struct {
 	AbortMenuItem                         string
 	PickHunk                              string
 	PickAllHunks                          string
+	OpenThreeWayMergeEditor               string
+	OpenThreeWayMergeEditorTooltip        string
+	ThreeWayMergeTitle                    string
+	ThreeWayMergeResult                   string
+	ThreeWayMergeBase                     string
+	ThreeWayMergeOurs                     string
+	ThreeWayMergeTheirs                   string
+	ThreeWayMergeNoBase                   string
+	ThreeWayMergeEmptyResult              string
+	PickLine                              string
+	PickLineTooltip                       string
+	PickAllLinesOfPane                    string
+	PickAllLinesOfPaneTooltip             string
+	SelectPrevPane                        string
+	SelectNextPane                        string
+	ClearResult                           string
+	EditResult                            string
+	EditResultTooltip                     string
+	ApplyResult                           string
+	ApplyResultTooltip                    string
+	ApplyEmptyResultPrompt                string
+	ReturnToMergeConflictsView            string
 	ViewMergeRebaseOptions                string
 	ViewMergeRebaseOptionsTooltip         string
 	ViewMergeOptions                      string
 	ViewRebaseOptions                     string
 	ViewCherryPickOptions                 string
 	ViewRevertOptions                     string
+	ViewApplyPatchesOptions               string
 	NotMergingOrRebasing                  string
 	AlreadyRebasing                       string
 	NotMidRebase                          string
@@ -333,6 +371,7 @@ type TranslationSet struct {
 	RebaseOptionsTitle                    string
 	CherryPickOptionsTitle                string
 	RevertOptionsTitle                    string
+	ApplyPatchesOptionsTitle              string
 	CommitSummaryTitle                    string
 	CommitDescriptionTitle                string
 	CommitDescriptionSubTitle             string
@@ -341,6 +380,25 @@ type TranslationSet struct {
 	LocalBranchesTitle                    string
 	SearchTitle                           string
 	TagsTitle                             string
+	PullRequestsTitle                     string
+	NoOpenPullRequests                    string
+	PullRequestTitle                      string
+	CheckoutPullRequest                   string
+	CheckoutPullRequestTooltip            string
+	CheckingOutPullRequest                string
+	PullRequestLocalBranchDivergedPrompt  string
+	PullRequestAuthor                     string
+	PullRequestBranches                   string
+	PullRequestReview                     string
+	PullRequestChecks                     string
+	PullRequestApproved                   string
+	PullRequestChangesRequested           string
+	PullRequestReviewRequired             string
+	PullRequestChecksPassed               string
+	PullRequestChecksFailed               string
+	PullRequestChecksPending              string
+	PullRequestNoDescription              string
+	NoGithubRemoteForPullRequests         string
 	MenuTitle                             string
 	CommitMenuTitle                       string
 	RemotesTitle                          string
@@ -419,6 +477,7 @@ type TranslationSet struct {
 	LowercaseMergingStatus                string
 	LowercaseCherryPickingStatus          string
 	LowercaseRevertingStatus              string
+	LowercaseApplyingPatchesStatus        string
 	AmendingStatus                        string
 	CherryPickingStatus                   string
 	UndoingStatus                         string
@@ -427,6 +486,7 @@ type TranslationSet struct {
 	CommittingStatus                      string
 	RewordingStatus                       string
 	RevertingStatus                       string
+	ApplyingPatchesStatus                 string
 	CreatingFixupCommitStatus             string
 	MovingCommitsToNewBranchStatus        string
 	CommitFiles                           string
@@ -510,6 +570,13 @@ type TranslationSet struct {
 	StashStagedChanges                    string
 	StashAllChangesKeepIndex              string
 	StashUnstagedChanges                  string
+	StashSelectedFiles                    string
+	StashSelectedFilesTooltip             string
+	CantStashSubmoduleSelection           string
+	StashSelectedLines                    string
+	StashSelectedLinesTooltip             string
+	CantStashLinesWithStagedChanges       string
+	CantStashLinesOfUntrackedFile         string
 	StashIncludeUntrackedChanges          string
 	StashOptions                          string
 	NotARepository                        string
@@ -670,6 +737,14 @@ type TranslationSet struct {
 	ViewDiffingOptions                    string
 	ViewDiffingOptionsTooltip             string
 	CancelDiffingMode                     string
+	RangeDiffAgainst                      string
+	RangeDiffTooltip                      string
+	EnterRefToRangeDiff                   string
+	ExitRangeDiffMode                     string
+	ShowingRangeDiff                      string
+	RangeDiffTitle                        string
+	RangeDiffDynamicTitle                 string
+	NoRangeDiffEntries                    string
 	OpenCommandLogMenu                    string
 	OpenCommandLogMenuTooltip             string
 	ShowingGitDiff                        string
@@ -768,6 +843,14 @@ type TranslationSet struct {
 	CommandLog                               string
 	ToggleShowCommandLog                     string
 	FocusCommandLog                          string
+	CopySessionLog                           string
+	CopySessionLogTooltip                    string
+	SessionLogCopiedToClipboard              string
+	ExportSessionLog                         string
+	ExportSessionLogTooltip                  string
+	ExportSessionLogPrompt                   string
+	SessionLogExported                       string
+	SessionLogDisabled                       string
 	CommandLogHeader                         string
 	RandomTip                                string
 	ToggleWhitespaceInDiffView               string
@@ -790,6 +873,18 @@ type TranslationSet struct {
 	SelectTargetRemote                       string
 	NoValidRemoteName                        string
 	CreatePullRequest                        string
+	PullRequestSummaryTitle                  string
+	PullRequestDescriptionTitle              string
+	PullRequestBaseBranchTitle               string
+	PullRequestReviewersTitle                string
+	CreatePullRequestReadyForReview          string
+	CreatePullRequestAsDraft                 string
+	CreatePullRequestInBrowserInstead        string
+	CreatePullRequestViaAPI                  string
+	CreatePullRequestViaAPITooltip           string
+	CreatePullRequestViaAPIUnavailable       string
+	CreatingPullRequest                      string
+	PullRequestCreated                       string
 	SelectConfigFile                         string
 	NoConfigFileFoundErr                     string
 	LoadingFileSuggestions                   string
@@ -818,6 +913,138 @@ type TranslationSet struct {
 	CantChangeContextSizeError               string
 	OpenCommitInBrowser                      string
 	ViewBisectOptions                        string
+	ViewNotesOptions                         string
+	ViewNotesOptionsTooltip                  string
+	SparseCheckout                           string
+	ViewSparseCheckoutOptions                string
+	ViewSparseCheckoutOptionsTooltip         string
+	EnableSparseCheckout                     string
+	EnableSparseCheckoutTooltip              string
+	DisableSparseCheckout                    string
+	DisableSparseCheckoutPrompt              string
+	ReapplySparseCheckout                    string
+	ReapplySparseCheckoutTooltip             string
+	AddSparseCheckoutDirectory               string
+	AddSparseCheckoutDirectoryTooltip        string
+	RemoveSparseCheckoutDirectory            string
+	RemoveSparseCheckoutDirectoryTooltip     string
+	RemoveSparseCheckoutDirectoryPrompt      string
+	RemoveLastSparseCheckoutDirectoryPrompt  string
+	SparseCheckoutDirectoryRequired          string
+	SparseCheckoutDirectories                string
+	SparseCheckoutNotConeMode                string
+	SelectSparseCheckoutDirectory            string
+	UpdatingSparseCheckoutStatus             string
+	Lfs                                      string
+	ViewLfsOptions                           string
+	ViewLfsOptionsTooltip                    string
+	LfsNotInstalled                          string
+	LockLfsFile                              string
+	LockLfsFileTooltip                       string
+	SelectFileToLock                         string
+	ViewLfsLocks                             string
+	ViewLfsLocksTooltip                      string
+	LfsLocks                                 string
+	NoLfsLocks                               string
+	UnlockLfsFile                            string
+	ForceUnlockLfsFile                       string
+	ForceUnlockLfsFileTooltip                string
+	FetchLfsObjects                          string
+	FetchLfsObjectsTooltip                   string
+	PullLfsObjects                           string
+	PullLfsObjectsTooltip                    string
+	PruneLfsObjects                          string
+	PruneLfsObjectsTooltip                   string
+	PruneLfsObjectsPrompt                    string
+	LfsObjectChanged                         string
+	LfsObjectAdded                           string
+	LfsObjectDeleted                         string
+	LoadingLfsLocksStatus                    string
+	LockingLfsFileStatus                     string
+	UnlockingLfsFileStatus                   string
+	FetchingLfsObjectsStatus                 string
+	PullingLfsObjectsStatus                  string
+	PruningLfsObjectsStatus                  string
+	ExportPatchSeries                        string
+	ExportPatchSeriesTooltip                 string
+	ExportWithoutCoverLetter                 string
+	ExportWithCoverLetter                    string
+	ExportWithCoverLetterTooltip             string
+	PatchSeriesOutputDirectory               string
+	CannotExportTodoCommits                  string
+	ExportedPatchSeries                      string
+	ExportingPatchSeriesStatus               string
+	ApplyMailbox                             string
+	ApplyMailboxTooltip                      string
+	MailboxPath                              string
+	NoPatchFilesInDirectory                  string
+	AbsorbStagedChanges                      string
+	AbsorbStagedChangesTooltip               string
+	CreateFixupCommitsForAbsorb              string
+	CreateFixupCommitsForAbsorbTooltip       string
+	CreateFixupCommitsAndSquash              string
+	CreateFixupCommitsAndSquashTooltip       string
+	AbsorbHunks                              string
+	AbsorbHunkTooltip                        string
+	NotAbsorbed                              string
+	NoStagedChangesToAbsorb                  string
+	NoHunksCanBeAbsorbed                     string
+	AbsorbNoBaseCommit                       string
+	AbsorbBaseCommitNotUnpushed              string
+	AbsorbAmbiguousHunk                      string
+	AbsorbUnsupportedFile                    string
+	AbsorbingStatus                          string
+	ViewBlame                                string
+	ViewBlameTooltip                         string
+	BlameTitle                               string
+	BlameWorkingTree                         string
+	ViewBlameCommit                          string
+	ViewBlameCommitTooltip                   string
+	BlameParentCommit                        string
+	BlameParentCommitTooltip                 string
+	BlameLineNotCommitted                    string
+	NoHistoryBeforeBlameCommit               string
+	CannotBlameDirectory                     string
+	CannotBlameUncommittedFile               string
+	CannotBlameDeletedFile                   string
+	ViewLineHistory                          string
+	ViewLineHistoryTooltip                   string
+	LineHistoryUnavailableWithStagedChanges  string
+	SelectedLinesHaveNoHistory               string
+	FilterContentOption                      string
+	FilterContentRegexOption                 string
+	FilterMessageOption                      string
+	EnterContent                             string
+	EnterContentRegex                        string
+	EnterMessagePattern                      string
+	FilteringByContent                       string
+	FilteringByContentRegex                  string
+	FilteringByMessage                       string
+	WillCombineWithExistingFilterTooltip     string
+	UndoJournalPrompt                        string
+	RedoJournalPrompt                        string
+	NotesMenuTitle                           string
+	AddNote                                  string
+	EditNote                                 string
+	EditNoteTooltip                          string
+	EditNoteInEditor                         string
+	RemoveNote                               string
+	RemoveNotePrompt                         string
+	CommitHasNoNote                          string
+	NoteTitle                                string
+	SwitchNotesRef                           string
+	SwitchNotesRefTooltip                    string
+	DefaultNotesRef                          string
+	EnterNotesRef                            string
+	FetchNotes                               string
+	FetchNotesTooltip                        string
+	PushNotes                                string
+	PushNotesTooltip                         string
+	FetchNotesTitle                          string
+	PushNotesTitle                           string
+	FetchingNotesStatus                      string
+	PushingNotesStatus                       string
+	NotesNotAvailableForTodo                 string
 	ConfirmRevertCommit                      string
 	ConfirmRevertCommitRange                 string
 	RewordInEditorTitle                      string
@@ -895,6 +1122,33 @@ type TranslationSet struct {
 	CreateWorktreeFrom                       string
 	CreateWorktreeFromDetached               string
 	LcWorktree                               string
+	LockWorktree                             string
+	LockWorktreeTooltip                      string
+	LockWorktreeReason                       string
+	LockingWorktree                          string
+	UnlockWorktree                           string
+	UnlockWorktreeTooltip                    string
+	UnlockingWorktree                        string
+	MoveWorktree                             string
+	MoveWorktreeTooltip                      string
+	MoveWorktreePath                         string
+	MovingWorktree                           string
+	PruneWorktrees                           string
+	PruneWorktreesTooltip                    string
+	PruneWorktreesPrompt                     string
+	PruningWorktrees                         string
+	RepairWorktree                           string
+	RepairWorktreeTooltip                    string
+	RepairWorktreePath                       string
+	RepairingWorktree                        string
+	CantLockMainWorktree                     string
+	CantMoveMainWorktree                     string
+	CantMoveCurrentWorktree                  string
+	CantMoveLockedWorktree                   string
+	LockedWorktree                           string
+	PrunableWorktree                         string
+	Locked                                   string
+	NoLockReason                             string
 	ChangingDirectoryTo                      string
 	DirenvApprovalTitle                      string
 	DirenvApprovalPrompt                     string
@@ -1028,6 +1282,7 @@ type Actions struct {
 	StageAllFiles                    string
 	ResolveConflictByKeepingFile     string
 	ResolveConflictByDeletingFile    string
+	ResolveConflictInThreeWayEditor  string
 	NotEnoughContextToStage          string
 	NotEnoughContextToDiscard        string
 	NotEnoughContextToRemoveLines    string
@@ -1044,6 +1299,8 @@ type Actions struct {
 	StashAllChangesKeepIndex         string
 	StashStagedChanges               string
 	StashUnstagedChanges             string
+	StashSelectedFiles               string
+	StashSelectedLines               string
 	StashIncludeUntrackedChanges     string
 	GitFlowFinish                    string
 	GitFlowStart                     string
@@ -1080,6 +1337,25 @@ type Actions struct {
 	DeleteLocalTag                   string
 	DeleteRemoteTag                  string
 	PushTag                          string
+	SetNote                          string
+	EditNote                         string
+	RemoveNote                       string
+	FetchNotes                       string
+	PushNotes                        string
+	SetSparseCheckout                string
+	AddSparseCheckoutDirectory       string
+	ReapplySparseCheckout            string
+	DisableSparseCheckout            string
+	LockLfsFile                      string
+	UnlockLfsFile                    string
+	FetchLfsObjects                  string
+	PullLfsObjects                   string
+	PruneLfsObjects                  string
+	ExportPatchSeries                string
+	ApplyMailbox                     string
+	AbsorbStagedChanges              string
+	UnstageLines                     string
+	DiscardLines                     string
 	NukeWorkingTree                  string
 	DiscardUnstagedFileChanges       string
 	RemoveUntrackedFiles             string
@@ -1091,8 +1367,11 @@ type Actions struct {
 	Redo                             string
 	CopyPullRequestURL               string
 	OpenMergeTool                    string
+	ForgetRerereResolution           string
 	OpenCommitInBrowser              string
 	OpenPullRequest                  string
+	CreatePullRequest                string
+	CheckoutPullRequest              string
 	StartBisect                      string
 	ResetBisect                      string
 	BisectSkip                       string
@@ -1184,6 +1463,12 @@ func EnglishTranslationSet() *TranslationSet {
 		ToggleTreeViewTooltip:                "Toggle file view between flat and tree layout. Flat layout shows all file paths in a single list, tree layout groups files by directory.\n\nThe default can be changed in the config file with the key 'gui.showFileTree'.",
 		OpenDiffTool:                         "Open external diff tool (git difftool)",
 		OpenMergeTool:                        "Open external merge tool",
+		ForgetRerereResolution:               "Forget recorded resolution",
+		ForgetRerereResolutionTooltip:        "Make git's rerere forget the resolution it replayed for the selected files, and bring back their conflict markers so that you can resolve them again. Your new resolution gets recorded instead.",
+		ForgetRerereResolutionPrompt:         "Are you sure you want to forget the recorded resolution of {{.files}}? The conflict markers come back, and any changes you made to the resolution are lost.",
+		NoRerereResolutionFound:              "Couldn't find the resolution that rerere recorded for this file.",
+		RerereResolutionTitle:                "Recorded resolution",
+		NoFilesResolvedByRerere:              "None of the selected files were resolved by rerere",
 		Refresh:                              "Refresh",
 		RefreshTooltip:                       "Refresh the git state (i.e. run `git status`, `git branch`, etc in background to update the contents of panels). This does not run `git fetch`.",
 		Push:                                 "Push",
@@ -1199,6 +1484,11 @@ func EnglishTranslationSet() *TranslationSet {
 		MergeConflictIncomingDiff:            "Incoming changes:",
 		MergeConflictCurrentDiff:             "Current changes:",
 		MergeConflictPressEnterToResolve:     "Press %s to resolve.",
+		CollapsedUntrackedDirHint:            "Untracked directory. Its files aren't listed yet because lazygit lists untracked files lazily in this repo (see git.lazyUntrackedFiles).\n\nPress %s to list them.",
+		ListingUntrackedFiles:                "Listing untracked files",
+		SlowStatusFsmonitorHint:              "Loading the changed files took %s. Enabling git's built-in file system monitor may speed this up a lot:\n  git config core.fsmonitor true",
+		SlowStatusLazyUntrackedFilesHint:     "Loading the changed files took %s. Listing the files in untracked directories only once you expand them may speed this up; to do that, set git.lazyUntrackedFiles to 'always' in your lazygit config.",
+		SlowStatusUntrackedCacheHint:         "Loading the changed files took %s. Enabling git's untracked cache may speed this up:\n  git config core.untrackedCache true",
 		MergeConflictKeepFile:                "Keep file",
 		MergeConflictDeleteFile:              "Delete file",
 		Checkout:                             "Checkout",
@@ -1330,11 +1620,33 @@ func EnglishTranslationSet() *TranslationSet {
 		Error:                                "Error",
 		PickHunk:                             "Pick hunk",
 		PickAllHunks:                         "Pick all hunks",
+		OpenThreeWayMergeEditor:              "Open three-way editor",
+		OpenThreeWayMergeEditorTooltip:       "Resolve the selected conflict line by line, with the common ancestor ('base'), our version and their version shown next to each other. The common ancestor is taken from the index, so this works even if the file was written without it.",
+		ThreeWayMergeTitle:                   "Three-way merge",
+		ThreeWayMergeResult:                  "Result",
+		ThreeWayMergeBase:                    "Base",
+		ThreeWayMergeOurs:                    "Ours",
+		ThreeWayMergeTheirs:                  "Theirs",
+		ThreeWayMergeNoBase:                  "(not available)",
+		ThreeWayMergeEmptyResult:             "No lines picked yet. Applying now will remove the conflict without keeping any of its lines.",
+		PickLine:                             "Pick line",
+		PickLineTooltip:                      "Add the selected line to the end of the result, or remove it from the result if it was already picked.",
+		PickAllLinesOfPane:                   "Pick all lines of pane",
+		PickAllLinesOfPaneTooltip:            "Add all lines of the selected pane that haven't been picked yet to the end of the result.",
+		SelectPrevPane:                       "Previous pane",
+		SelectNextPane:                       "Next pane",
+		ClearResult:                          "Clear result",
+		EditResult:                           "Edit result",
+		EditResultTooltip:                    "Open the result in your editor, to change it by hand before applying it.",
+		ApplyResult:                          "Apply result",
+		ApplyEmptyResultPrompt:               "You haven't picked any lines, so applying will remove the conflict without keeping any of its lines. Are you sure?",
+		ApplyResultTooltip:                   "Replace the conflict in the file with the result, and move on to the next conflict.",
+		ReturnToMergeConflictsView:           "Return to merge conflicts view",
 		Undo:                                 "Undo",
 		UndoReflog:                           "Undo",
 		RedoReflog:                           "Redo",
-		UndoTooltip:                          "The reflog will be used to determine what git command to run to undo the last git command. This does not include changes to the working tree; only commits are taken into consideration.",
-		RedoTooltip:                          "The reflog will be used to determine what git command to run to redo the last git command. This does not include changes to the working tree; only commits are taken into consideration.",
+		UndoTooltip:                          "The reflog will be used to determine what git command to run to undo the last git command. Discarded changes, unstaged lines and dropped stashes are tracked by lazygit itself and can be undone as well.",
+		RedoTooltip:                          "The reflog will be used to determine what git command to run to redo the last git command. Discarded changes, unstaged lines and dropped stashes are tracked by lazygit itself and can be redone as well.",
 		UndoMergeResolveTooltip:              "Undo last merge conflict resolution.",
 		DiscardAllTooltip:                    "Discard both staged and unstaged changes in '{{.path}}'.",
 		DiscardUnstagedTooltip:               "Discard unstaged changes in '{{.path}}'.",
@@ -1350,6 +1662,10 @@ func EnglishTranslationSet() *TranslationSet {
 		SureDropStashEntry:                   "Are you sure you want to drop the selected stash entry(ies)?",
 		StashPop:                             "Stash pop",
 		SurePopStashEntry:                    "Are you sure you want to pop this stash entry?",
+		StashBasedOn:                         "based on {{.base}}",
+		StashBasedOnBehindHead:               "based on {{.base}}, {{.count}} commit(s) behind HEAD",
+		CheckingForStashConflictsStatus:      "Checking for conflicts",
+		StashWouldConflict:                   "Applying this stash entry now would cause conflicts in:\n{{.files}}",
 		StashApply:                           "Stash apply",
 		SureApplyStashEntry:                  "Are you sure you want to apply this stash entry?",
 		NoTrackedStagedFilesStash:            "You have no tracked/staged files to stash",
@@ -1408,6 +1724,18 @@ func EnglishTranslationSet() *TranslationSet {
 		AllBranchesLogGraphReverse:           `Show/cycle all branch logs (reverse)`,
 		UnsupportedGitService:                `Unsupported git service`,
 		CreatePullRequest:                    `Create pull request`,
+		PullRequestSummaryTitle:              "Pull request title",
+		PullRequestDescriptionTitle:          "Pull request description",
+		PullRequestBaseBranchTitle:           "Base branch (leave empty for the default branch)",
+		PullRequestReviewersTitle:            "Reviewers (comma-separated user names or org/team, optional)",
+		CreatePullRequestReadyForReview:      "Ready for review",
+		CreatePullRequestAsDraft:             "Draft",
+		CreatePullRequestInBrowserInstead:    "Open in browser instead",
+		CreatePullRequestViaAPI:              "Create pull request from selected branch in lazygit",
+		CreatePullRequestViaAPITooltip:       "Enter the title, description, base branch and reviewers of the pull request here and create it via the GitHub API, rather than in the browser.",
+		CreatePullRequestViaAPIUnavailable:   "Creating pull requests in lazygit requires a GitHub remote whose pull requests are shown in the pull requests panel, and a GitHub token (e.g. from `gh auth login`)",
+		CreatingPullRequest:                  "Creating pull request",
+		PullRequestCreated:                   "Created pull request #%d",
 		CopyPullRequestURL:                   `Copy pull request URL to clipboard`,
 		OpenPullRequestInBrowser:             `Open pull request in browser`,
 		NoPullRequestForBranch:               `No pull request found for this branch`,
@@ -1451,6 +1779,7 @@ func EnglishTranslationSet() *TranslationSet {
 		ViewRebaseOptions:                    "View rebase options",
 		ViewCherryPickOptions:                "View cherry-pick options",
 		ViewRevertOptions:                    "View revert options",
+		ViewApplyPatchesOptions:              "View apply patches options",
 		NotMergingOrRebasing:                 "You are currently neither rebasing nor merging",
 		AlreadyRebasing:                      "Can't perform this action during a rebase",
 		NotMidRebase:                         "This action only works during an interactive rebase",
@@ -1460,6 +1789,7 @@ func EnglishTranslationSet() *TranslationSet {
 		RebaseOptionsTitle:                   "Rebase options",
 		CherryPickOptionsTitle:               "Cherry-pick options",
 		RevertOptionsTitle:                   "Revert options",
+		ApplyPatchesOptionsTitle:             "Apply patches options",
 		CommitSummaryTitle:                   "Commit summary",
 		CommitDescriptionTitle:               "Commit description",
 		CommitDescriptionSubTitle:            "Press {{.togglePanelKeyBinding}} to toggle focus, {{.commitMenuKeybinding}} to open menu",
@@ -1468,6 +1798,25 @@ func EnglishTranslationSet() *TranslationSet {
 		LocalBranchesTitle:                   "Local branches",
 		SearchTitle:                          "Search",
 		TagsTitle:                            "Tags",
+		PullRequestsTitle:                    "Pull requests",
+		NoOpenPullRequests:                   "No open pull requests",
+		PullRequestTitle:                     "Pull request",
+		CheckoutPullRequest:                  "Checkout pull request",
+		CheckoutPullRequestTooltip:           "Fetch the head of the selected pull request and check it out. If a local branch of the same name exists, it is fast-forwarded. Pull requests from forks are checked out into a local branch named pr/<number>.",
+		CheckingOutPullRequest:               "Checking out pull request",
+		PullRequestLocalBranchDivergedPrompt: "The local branch '{{.localBranch}}' has commits that aren't in the pull request's branch '{{.remoteBranch}}', so it can't be fast-forwarded. Check out the local branch anyway?",
+		PullRequestAuthor:                    "Author",
+		PullRequestBranches:                  "Branches",
+		PullRequestReview:                    "Review",
+		PullRequestChecks:                    "Checks",
+		PullRequestApproved:                  "Approved",
+		PullRequestChangesRequested:          "Changes requested",
+		PullRequestReviewRequired:            "Review required",
+		PullRequestChecksPassed:              "Passed",
+		PullRequestChecksFailed:              "Failed",
+		PullRequestChecksPending:             "Pending",
+		PullRequestNoDescription:             "No description provided.",
+		NoGithubRemoteForPullRequests:        "Pull requests are only available for repos with an authenticated GitHub remote",
 		MenuTitle:                            "Menu",
 		CommitMenuTitle:                      "Commit Menu",
 		RemotesTitle:                         "Remotes",
@@ -1548,10 +1897,11 @@ func EnglishTranslationSet() *TranslationSet {
 		MovingStatus:                         "Moving",
 		RebasingStatus:                       "Rebasing",
 		MergingStatus:                        "Merging",
-		LowercaseRebasingStatus:              "rebasing",       // lowercase because it shows up in parentheses
-		LowercaseMergingStatus:               "merging",        // lowercase because it shows up in parentheses
-		LowercaseCherryPickingStatus:         "cherry-picking", // lowercase because it shows up in parentheses
-		LowercaseRevertingStatus:             "reverting",      // lowercase because it shows up in parentheses
+		LowercaseRebasingStatus:              "rebasing",         // lowercase because it shows up in parentheses
+		LowercaseMergingStatus:               "merging",          // lowercase because it shows up in parentheses
+		LowercaseCherryPickingStatus:         "cherry-picking",   // lowercase because it shows up in parentheses
+		LowercaseRevertingStatus:             "reverting",        // lowercase because it shows up in parentheses
+		LowercaseApplyingPatchesStatus:       "applying patches", // lowercase because it shows up in parentheses
 		AmendingStatus:                       "Amending",
 		CherryPickingStatus:                  "Cherry-picking",
 		UndoingStatus:                        "Undoing",
@@ -1560,6 +1910,7 @@ func EnglishTranslationSet() *TranslationSet {
 		CommittingStatus:                     "Committing",
 		RewordingStatus:                      "Rewording",
 		RevertingStatus:                      "Reverting",
+		ApplyingPatchesStatus:                "Applying patches",
 		CreatingFixupCommitStatus:            "Creating fixup commit",
 		MovingCommitsToNewBranchStatus:       "Moving commits to new branch",
 		CommitFiles:                          "Commit files",
@@ -1644,6 +1995,13 @@ func EnglishTranslationSet() *TranslationSet {
 		StashStagedChanges:                   "Stash staged changes",
 		StashAllChangesKeepIndex:             "Stash all changes and keep index",
 		StashUnstagedChanges:                 "Stash unstaged changes",
+		StashSelectedFiles:                   "Stash selected files",
+		StashSelectedFilesTooltip:            "Stash the changes of the selected files only, including untracked ones, and leave all other changes in the working tree.",
+		CantStashSubmoduleSelection:          "Stashing a selection that contains submodules is not supported",
+		StashSelectedLines:                   "Stash selected lines",
+		StashSelectedLinesTooltip:            "Stash the selected lines only and remove them from the file, leaving all other changes in place. When used in the staged changes view, the lines are stashed as staged changes.",
+		CantStashLinesWithStagedChanges:      "Can't stash unstaged lines of a file that also has staged changes; stash from the staged view or unstage the file first",
+		CantStashLinesOfUntrackedFile:        "Can't stash lines of an untracked file; use 'Stash selected files' from the stash options menu in the files panel instead",
 		StashIncludeUntrackedChanges:         "Stash all changes including untracked files",
 		StashOptions:                         "Stash options",
 		NotARepository:                       "Error: must be run inside a git repository",
@@ -1801,6 +2159,14 @@ func EnglishTranslationSet() *TranslationSet {
 		ViewDiffingOptions:               "View diffing options",
 		ViewDiffingOptionsTooltip:        "View options relating to diffing two refs e.g. diffing against selected ref, entering ref to diff against, and reversing the diff direction.",
 		CancelDiffingMode:                "Cancel diffing mode",
+		RangeDiffAgainst:                 "Range-diff {{.ref}} against {{.currentBranch}}",
+		RangeDiffTooltip:                 "Compare the commits of the selected ref (e.g. the tip of the branch before a rebase or force-push) with the commits of the checked-out branch, using `git range-diff`.",
+		EnterRefToRangeDiff:              "Enter old ref to range-diff against current branch",
+		ExitRangeDiffMode:                "Exit range-diff mode",
+		ShowingRangeDiff:                 "Showing range-diff:",
+		RangeDiffTitle:                   "Range-diff",
+		RangeDiffDynamicTitle:            "Range-diff (%s)",
+		NoRangeDiffEntries:               "No commits to compare",
 		// the actual view is the extras view which I intend to give more tabs in future but for now we'll only mention the command log part
 		OpenCommandLogMenu:                       "View command log options",
 		OpenCommandLogMenuTooltip:                "View options for the command log e.g. show/hide the command log and focus the command log.",
@@ -1899,6 +2265,14 @@ func EnglishTranslationSet() *TranslationSet {
 		ErrWorktreeMovedOrRemoved:                "Cannot find worktree. It might have been moved or removed ¯\\_(ツ)_/¯",
 		ToggleShowCommandLog:                     "Toggle show/hide command log",
 		FocusCommandLog:                          "Focus command log",
+		CopySessionLog:                           "Copy session log to clipboard",
+		CopySessionLogTooltip:                    "Copy the log of every command that lazygit has run in this session, as JSON Lines. Useful for bug reports.",
+		SessionLogCopiedToClipboard:              "Session log copied to clipboard",
+		ExportSessionLog:                         "Export session log",
+		ExportSessionLogTooltip:                  "Save a copy of the log of every command that lazygit has run in this session, as JSON Lines.",
+		ExportSessionLogPrompt:                   "Export session log to:",
+		SessionLogExported:                       "Session log exported to {{.path}}",
+		SessionLogDisabled:                       "There's no session log, because it's disabled in the config (sessionLog.enabled)",
 		CommandLogHeader:                         "You can hide/focus this panel by pressing '%s'\n",
 		RandomTip:                                "Random tip",
 		ToggleWhitespaceInDiffView:               "Toggle whitespace",
@@ -1947,6 +2321,138 @@ func EnglishTranslationSet() *TranslationSet {
 		CantChangeContextSizeError:               "Cannot change context while in patch building mode because we were too lazy to support it when releasing the feature. If you really want it, please let us know!",
 		OpenCommitInBrowser:                      "Open commit in browser",
 		ViewBisectOptions:                        "View bisect options",
+		ViewNotesOptions:                         "View notes options",
+		ViewNotesOptionsTooltip:                  "View options for the notes attached to the selected commit, e.g. adding, editing or removing a note, switching the notes ref, or fetching/pushing notes.",
+		SparseCheckout:                           "Sparse checkout",
+		ViewSparseCheckoutOptions:                "View sparse checkout options",
+		ViewSparseCheckoutOptionsTooltip:         "View the directories included in the sparse checkout (cone mode), and add or remove directories.",
+		EnableSparseCheckout:                     "Enable sparse checkout",
+		EnableSparseCheckoutTooltip:              "Enable sparse checkout in cone mode, keeping only the files at the top level of the repo and the directory you choose checked out.",
+		DisableSparseCheckout:                    "Disable sparse checkout",
+		DisableSparseCheckoutPrompt:              "Are you sure you want to disable sparse checkout? This will check out all files of the repo.",
+		ReapplySparseCheckout:                    "Reapply sparse checkout rules",
+		ReapplySparseCheckoutTooltip:             "Remove files outside of the sparse set from the working tree again, e.g. after resolving a conflict that brought them back.",
+		AddSparseCheckoutDirectory:               "Add directory",
+		AddSparseCheckoutDirectoryTooltip:        "Add a directory from the tree of HEAD to the sparse checkout.",
+		RemoveSparseCheckoutDirectory:            "Remove directory from sparse checkout",
+		RemoveSparseCheckoutDirectoryTooltip:     "Remove this directory from the sparse checkout. Its files will be removed from the working tree.",
+		RemoveSparseCheckoutDirectoryPrompt:      "Are you sure you want to remove '{{.dir}}' from the sparse checkout? Its files will be removed from the working tree.",
+		RemoveLastSparseCheckoutDirectoryPrompt:  "'{{.dir}}' is the last directory in the sparse checkout. If you remove it, only the files at the top level of the repo stay checked out. Are you sure?",
+		SparseCheckoutDirectoryRequired:          "Enter the directory to check out",
+		SparseCheckoutDirectories:                "Directories",
+		SparseCheckoutNotConeMode:                "Sparse checkout is not in cone mode; directories can only be added or removed in cone mode.",
+		SelectSparseCheckoutDirectory:            "Directory to check out",
+		UpdatingSparseCheckoutStatus:             "Updating sparse checkout",
+		Lfs:                                      "Git LFS",
+		ViewLfsOptions:                           "View Git LFS options",
+		ViewLfsOptionsTooltip:                    "View options for Git LFS: lock and unlock files, and fetch, pull or prune LFS objects.",
+		LfsNotInstalled:                          "git-lfs is not installed.",
+		LockLfsFile:                              "Lock file",
+		LockLfsFileTooltip:                       "Lock the selected file on the LFS server so that nobody else can push changes to it.",
+		SelectFileToLock:                         "Select a file to lock.",
+		ViewLfsLocks:                             "View locks",
+		ViewLfsLocksTooltip:                      "List the locks held on the LFS server, along with their owners, and release them.",
+		LfsLocks:                                 "LFS locks",
+		NoLfsLocks:                               "No files are locked.",
+		UnlockLfsFile:                            "Unlock",
+		ForceUnlockLfsFile:                       "Force unlock",
+		ForceUnlockLfsFileTooltip:                "Release the lock even if it is held by somebody else.",
+		FetchLfsObjects:                          "Fetch LFS objects",
+		FetchLfsObjectsTooltip:                   "Download the LFS objects needed for the current checkout without changing the working tree.",
+		PullLfsObjects:                           "Pull LFS objects",
+		PullLfsObjectsTooltip:                    "Download the LFS objects needed for the current checkout and replace pointer files in the working tree with their content.",
+		PruneLfsObjects:                          "Prune LFS objects",
+		PruneLfsObjectsTooltip:                   "Delete local copies of LFS objects that are old and have already been pushed.",
+		PruneLfsObjectsPrompt:                    "Are you sure you want to delete old LFS objects from the local cache?",
+		LfsObjectChanged:                         "LFS object changed ({{.oldSize}} → {{.newSize}})",
+		LfsObjectAdded:                           "LFS object added ({{.size}})",
+		LfsObjectDeleted:                         "LFS object deleted ({{.size}})",
+		LoadingLfsLocksStatus:                    "Loading locks",
+		LockingLfsFileStatus:                     "Locking file",
+		UnlockingLfsFileStatus:                   "Unlocking file",
+		FetchingLfsObjectsStatus:                 "Fetching LFS objects",
+		PullingLfsObjectsStatus:                  "Pulling LFS objects",
+		PruningLfsObjectsStatus:                  "Pruning LFS objects",
+		ExportPatchSeries:                        "Export as patch series",
+		ExportPatchSeriesTooltip:                 "Write the selected commits to patch files with `git format-patch`, e.g. for sending them by mail.",
+		ExportWithoutCoverLetter:                 "Export without cover letter",
+		ExportWithCoverLetter:                    "Export with cover letter",
+		ExportWithCoverLetterTooltip:             "Also write a cover letter (0000-cover-letter.patch) with a summary of the series, to be filled in before sending.",
+		PatchSeriesOutputDirectory:               "Output directory",
+		CannotExportTodoCommits:                  "Commits that haven't been rebased yet can't be exported.",
+		ExportedPatchSeries:                      "Exported {{.count}} patch file(s) to {{.dir}}",
+		ExportingPatchSeriesStatus:               "Exporting patches",
+		ApplyMailbox:                             "Apply mbox",
+		ApplyMailboxTooltip:                      "Apply the patches of an mbox file, or of a directory of patch files, as new commits with `git am`. Conflicts are resolved like in a rebase.",
+		MailboxPath:                              "Mbox file or directory of patches",
+		NoPatchFilesInDirectory:                  "There are no .patch files in {{.dir}}.",
+		AbsorbStagedChanges:                      "Absorb staged changes",
+		AbsorbStagedChangesTooltip:               "Find the commit that each staged hunk belongs to and create a fixup commit for each of these commits. Only unpushed commits of the current branch are considered; hunks that can't be attributed to exactly one of them stay staged. The assignments are shown for review before anything is committed.",
+		CreateFixupCommitsForAbsorb:              "Create fixup commits",
+		CreateFixupCommitsForAbsorbTooltip:       "Create one fixup commit per target commit, to be squashed later.",
+		CreateFixupCommitsAndSquash:              "Create fixup commits and squash them",
+		CreateFixupCommitsAndSquashTooltip:       "Create the fixup commits and squash them into their target commits right away.",
+		AbsorbHunks:                              "Hunks",
+		AbsorbHunkTooltip:                        "Select the commit that this hunk is going to be absorbed into.",
+		NotAbsorbed:                              "not absorbed",
+		NoStagedChangesToAbsorb:                  "There are no staged changes to absorb.",
+		NoHunksCanBeAbsorbed:                     "None of the staged hunks can be absorbed.",
+		AbsorbNoBaseCommit:                       "Can't tell which commit this hunk belongs to.",
+		AbsorbBaseCommitNotUnpushed:              "This hunk changes lines of a commit that is already pushed or not part of the current branch.",
+		AbsorbAmbiguousHunk:                      "This hunk changes lines of more than one commit. Split it up or commit it manually.",
+		AbsorbUnsupportedFile:                    "Only changes to the content of existing text files can be absorbed.",
+		AbsorbingStatus:                          "Absorbing",
+		ViewBlame:                                "View blame",
+		ViewBlameTooltip:                         "Show who last changed each line of the selected file, and in which commit. From there you can jump to a line's commit, or blame the parent of that commit to walk further back through the file's history.",
+		BlameTitle:                               "Blame",
+		BlameWorkingTree:                         "working tree",
+		ViewBlameCommit:                          "View commit",
+		ViewBlameCommitTooltip:                   "Show the commit that last changed the selected line, along with the history leading up to it.",
+		BlameParentCommit:                        "Blame parent commit",
+		BlameParentCommitTooltip:                 "Blame the file as of the parent of the commit that last changed the selected line, to see what the line looked like before that commit.",
+		BlameLineNotCommitted:                    "The selected line hasn't been committed yet.",
+		NoHistoryBeforeBlameCommit:               "The selected line was added in commit %s, so there is no earlier version of it to blame.",
+		CannotBlameDirectory:                     "Can only blame a single file, not a directory.",
+		CannotBlameUncommittedFile:               "This file hasn't been committed yet, so there is nothing to blame.",
+		CannotBlameDeletedFile:                   "This file was deleted in this commit, so there is nothing to blame.",
+		ViewLineHistory:                          "View history of selected lines",
+		ViewLineHistoryTooltip:                   "Show the commits that changed the selected lines, each with its diff limited to those lines. Uses `git log -L`.",
+		LineHistoryUnavailableWithStagedChanges:  "Cannot view the history of unstaged lines while the file also has staged changes, because their line numbers may not match the last commit. View them from the staged changes instead, or unstage the file first.",
+		SelectedLinesHaveNoHistory:               "The selected lines have no history because they haven't been committed yet.",
+		FilterContentOption:                      "Enter text to search for in diffs (-S)",
+		FilterContentRegexOption:                 "Enter regex to search for in diffs (-G)",
+		FilterMessageOption:                      "Enter pattern to search for in commit messages (--grep)",
+		EnterContent:                             "Enter text:",
+		EnterContentRegex:                        "Enter regex:",
+		EnterMessagePattern:                      "Enter pattern:",
+		FilteringByContent:                       "content",
+		FilteringByContentRegex:                  "content regex",
+		FilteringByMessage:                       "message",
+		WillCombineWithExistingFilterTooltip:     "Note: this will be combined with the existing filter",
+		UndoJournalPrompt:                        "Are you sure you want to undo '%s'? The affected files and index entries will be restored to their previous state.",
+		RedoJournalPrompt:                        "Are you sure you want to redo '%s'? The affected files and index entries will be restored to the state after the operation.",
+		NotesMenuTitle:                           "Notes ({{.notesRef}})",
+		AddNote:                                  "Add note",
+		EditNote:                                 "Edit note",
+		EditNoteTooltip:                          "Set the note attached to the selected commit. Leaving the note empty removes it.",
+		EditNoteInEditor:                         "Edit note in editor",
+		RemoveNote:                               "Remove note",
+		RemoveNotePrompt:                         "Are you sure you want to remove the note from commit '{{.commitHash}}'?",
+		CommitHasNoNote:                          "The selected commit has no note",
+		NoteTitle:                                "Note",
+		SwitchNotesRef:                           "Switch notes ref",
+		SwitchNotesRefTooltip:                    "Choose which notes ref (refs/notes/*) is shown in the commits views and used when adding, editing or removing notes.",
+		DefaultNotesRef:                          "Default notes ref",
+		EnterNotesRef:                            "Enter notes ref",
+		FetchNotes:                               "Fetch notes",
+		FetchNotesTooltip:                        "Fetch the current notes ref from a remote. This fails if your local notes have diverged from the remote ones.",
+		PushNotes:                                "Push notes",
+		PushNotesTooltip:                         "Push the current notes ref to a remote.",
+		FetchNotesTitle:                          "Fetch {{.notesRef}} from remote",
+		PushNotesTitle:                           "Push {{.notesRef}} to remote",
+		FetchingNotesStatus:                      "Fetching notes",
+		PushingNotesStatus:                       "Pushing notes",
+		NotesNotAvailableForTodo:                 "Notes can only be attached to real commits, not to rebase todos",
 		ConfirmRevertCommit:                      "Are you sure you want to revert {{.selectedCommit}}?",
 		ConfirmRevertCommitRange:                 "Are you sure you want to revert the selected commits?",
 		RewordInEditorTitle:                      "Reword in editor",
@@ -2024,6 +2530,33 @@ func EnglishTranslationSet() *TranslationSet {
 		CreateWorktreeFrom:                       "Create worktree from {{.ref}}",
 		CreateWorktreeFromDetached:               "Create worktree from {{.ref}} (detached)",
 		LcWorktree:                               "worktree",
+		LockWorktree:                             "Lock worktree",
+		LockWorktreeTooltip:                      "Lock the selected worktree so that `git worktree prune` leaves it alone while its directory is unavailable, e.g. because it lives on a removable drive. Locked worktrees can't be moved or removed.",
+		LockWorktreeReason:                       "Lock reason (optional)",
+		LockingWorktree:                          "Locking worktree",
+		UnlockWorktree:                           "Unlock worktree",
+		UnlockWorktreeTooltip:                    "Unlock the selected worktree so that it can be pruned, moved or removed again.",
+		UnlockingWorktree:                        "Unlocking worktree",
+		MoveWorktree:                             "Move worktree",
+		MoveWorktreeTooltip:                      "Move the selected worktree to a different directory.",
+		MoveWorktreePath:                         "New path for worktree '{{.worktreeName}}'",
+		MovingWorktree:                           "Moving worktree",
+		PruneWorktrees:                           "Prune worktrees",
+		PruneWorktreesTooltip:                    "Remove the metadata of worktrees whose directories no longer exist. Locked worktrees are kept.",
+		PruneWorktreesPrompt:                     "Are you sure you want to remove the metadata of all worktrees whose directories no longer exist? Locked worktrees will be kept.",
+		PruningWorktrees:                         "Pruning worktrees",
+		RepairWorktree:                           "Repair worktree",
+		RepairWorktreeTooltip:                    "Reconnect the selected worktree with the repository after its directory (or the repository itself) was moved without `git worktree move`. Enter the worktree's current location.",
+		RepairWorktreePath:                       "Current path of worktree '{{.worktreeName}}'",
+		RepairingWorktree:                        "Repairing worktree",
+		CantLockMainWorktree:                     "The main worktree cannot be locked",
+		CantMoveMainWorktree:                     "The main worktree cannot be moved",
+		CantMoveCurrentWorktree:                  "You cannot move the current worktree",
+		CantMoveLockedWorktree:                   "Unlock the worktree before moving it",
+		LockedWorktree:                           "(locked)",
+		PrunableWorktree:                         "(prunable)",
+		Locked:                                   "Locked",
+		NoLockReason:                             "(no reason given)",
 		ChangingDirectoryTo:                      "Changing directory to {{.path}}",
 		DirenvApprovalTitle:                      "Approve .envrc?",
 		DirenvApprovalPrompt:                     "Press {{.confirmKey}} to run 'direnv allow' and load the environment.\nPress {{.cancelKey}} to skip.\n\n{{.content}}",
@@ -2116,6 +2649,7 @@ func EnglishTranslationSet() *TranslationSet {
 			StageAllFiles:                    "Stage all files",
 			ResolveConflictByKeepingFile:     "Resolve by keeping file",
 			ResolveConflictByDeletingFile:    "Resolve by deleting file",
+			ResolveConflictInThreeWayEditor:  "Resolve conflict in three-way editor",
 			NotEnoughContextToStage:          "Staging or unstaging changes is not possible with a diff context size of 0. Increase the context using '%s'.",
 			NotEnoughContextToDiscard:        "Discarding changes is not possible with a diff context size of 0. Increase the context using '%s'.",
 			NotEnoughContextToRemoveLines:    "Removing lines from a commit is not possible with a diff context size of 0. Increase the context using '%s'.",
@@ -2132,6 +2666,8 @@ func EnglishTranslationSet() *TranslationSet {
 			StashAllChangesKeepIndex:         "Stash all changes and keep index",
 			StashStagedChanges:               "Stash staged changes",
 			StashUnstagedChanges:             "Stash unstaged changes",
+			StashSelectedFiles:               "Stash selected files",
+			StashSelectedLines:               "Stash selected lines",
 			StashIncludeUntrackedChanges:     "Stash all changes including untracked files",
 			GitFlowFinish:                    "git flow finish",
 			GitFlowStart:                     "git flow start",
@@ -2166,6 +2702,25 @@ func EnglishTranslationSet() *TranslationSet {
 			DeleteLocalTag:                   "Delete local tag",
 			DeleteRemoteTag:                  "Delete remote tag",
 			PushTag:                          "Push tag",
+			SetNote:                          "Set note",
+			EditNote:                         "Edit note",
+			RemoveNote:                       "Remove note",
+			FetchNotes:                       "Fetch notes",
+			PushNotes:                        "Push notes",
+			SetSparseCheckout:                "Set sparse checkout directories",
+			AddSparseCheckoutDirectory:       "Add sparse checkout directory",
+			ReapplySparseCheckout:            "Reapply sparse checkout",
+			DisableSparseCheckout:            "Disable sparse checkout",
+			LockLfsFile:                      "Lock LFS file",
+			UnlockLfsFile:                    "Unlock LFS file",
+			FetchLfsObjects:                  "Fetch LFS objects",
+			PullLfsObjects:                   "Pull LFS objects",
+			PruneLfsObjects:                  "Prune LFS objects",
+			ExportPatchSeries:                "Export patch series",
+			ApplyMailbox:                     "Apply mbox",
+			AbsorbStagedChanges:              "Absorb staged changes",
+			UnstageLines:                     "Unstage lines",
+			DiscardLines:                     "Discard lines",
 			NukeWorkingTree:                  "Nuke working tree",
 			DiscardUnstagedFileChanges:       "Discard unstaged file changes",
 			RemoveUntrackedFiles:             "Remove untracked files",
@@ -2179,8 +2734,11 @@ func EnglishTranslationSet() *TranslationSet {
 			Redo:                             "Redo",
 			CopyPullRequestURL:               "Copy pull request URL",
 			OpenMergeTool:                    "Open merge tool",
+			ForgetRerereResolution:           "Forget rerere resolution",
 			OpenCommitInBrowser:              "Open commit in browser",
 			OpenPullRequest:                  "Open pull request in browser",
+			CreatePullRequest:                "Create pull request",
+			CheckoutPullRequest:              "Checkout pull request",
 			StartBisect:                      "Start bisect",
 			ResetBisect:                      "Reset bisect",
 			BisectSkip:                       "Bisect skip",
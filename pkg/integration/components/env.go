@@ -11,6 +11,7 @@ const (
 	SANDBOX_ENV_VAR           = "SANDBOX"
 	TEST_NAME_ENV_VAR         = "TEST_NAME"
 	WAIT_FOR_DEBUGGER_ENV_VAR = "WAIT_FOR_DEBUGGER"
+	GITHUB_API_URL_ENV_VAR    = "FAKE_GITHUB_API_URL"
 
 	// These values will be passed to both lazygit and shell commands
 	GIT_CONFIG_GLOBAL_ENV_VAR = "GIT_CONFIG_GLOBAL"
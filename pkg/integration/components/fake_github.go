@@ -0,0 +1,42 @@
+package components
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/samber/lo"
+)
+
+// The token that lazygit authenticates to the fake GitHub API with. Without
+// one, lazygit doesn't try to load pull requests at all.
+const fakeGitHubToken = "fake-github-token"
+
+// newFakeGitHubServer starts a server that answers every GraphQL query with
+// the given pull requests. That's enough for both the query for the pull
+// requests of our branches and the one for the open pull requests, since
+// lazygit matches pull requests to branches by name itself.
+func newFakeGitHubServer(pullRequests []git_commands.PullRequestNode) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+
+		response := git_commands.Response{
+			Data: git_commands.RepositoryQuery{
+				Repository: map[string]git_commands.PullRequest{
+					"pullRequests": {
+						Edges: lo.Map(pullRequests, func(node git_commands.PullRequestNode, _ int) git_commands.PullRequestEdge {
+							return git_commands.PullRequestEdge{Node: node}
+						}),
+					},
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
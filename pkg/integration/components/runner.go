@@ -99,6 +99,16 @@ func runTest(
 		return err
 	}
 
+	if pullRequests := test.GitHubPullRequests(); pullRequests != nil {
+		server := newFakeGitHubServer(pullRequests)
+		defer server.Close()
+
+		cmd.Env = append(cmd.Env,
+			fmt.Sprintf("%s=%s", GITHUB_API_URL_ENV_VAR, server.URL),
+			"GH_TOKEN="+fakeGitHubToken,
+		)
+	}
+
 	pid, err := args.RunCmd(cmd)
 
 	// Print race detector log regardless of the command's exit status
@@ -45,6 +45,17 @@ func (self *Shell) RunCommandWithEnv(args []string, env []string) *Shell {
 	return self
 }
 
+// Runs the lazygit binary under test with the given args, e.g. to test the
+// subcommands that don't open the UI. Only works from a test's Run function,
+// because lazygit needs to know which test it's running.
+func (self *Shell) RunLazygit(args ...string) *Shell {
+	return self.RunCommand(append([]string{tempLazygitPath()}, args...))
+}
+
+func (self *Shell) RunLazygitExpectError(args ...string) *Shell {
+	return self.RunCommandExpectError(append([]string{tempLazygitPath()}, args...))
+}
+
 func (self *Shell) RunCommandExpectError(args []string) *Shell {
 	output, err := self.runCommandWithOutput(args)
 	if err == nil {
@@ -374,6 +385,17 @@ func (self *Shell) CloneIntoSubmodule(submoduleName string, submodulePath string
 	return self
 }
 
+// Points the given remote (created with CloneIntoRemote) at github.com, so
+// that lazygit treats it as a GitHub repo, while git keeps talking to the
+// local clone. Use this together with GitHubPullRequests.
+func (self *Shell) MakeRemoteLookLikeGitHub(remoteName string, ownerAndRepo string) *Shell {
+	url := "https://github.com/" + ownerAndRepo + ".git"
+	self.SetConfig("url.../"+remoteName+".insteadOf", url)
+	self.SetConfig("remote."+remoteName+".url", url)
+
+	return self
+}
+
 func (self *Shell) Clone(repoName string) *Shell {
 	self.RunCommand([]string{"git", "clone", "--bare", ".", "../" + repoName})
 
@@ -29,6 +29,7 @@ type IntegrationTest struct {
 	extraCmdArgs []string
 	extraEnvVars map[string]string
 	skip         bool
+	pullRequests []git_commands.PullRequestNode
 	setupRepo    func(shell *Shell)
 	setupConfig  func(config *config.AppConfig)
 	run          func(
@@ -55,6 +56,10 @@ type NewIntegrationTestArgs struct {
 	// additional args passed to lazygit
 	ExtraCmdArgs []string
 	ExtraEnvVars map[string]string
+	// pull requests served by a fake GitHub API, which lazygit talks to
+	// instead of the real one. Only needed for tests of the pull requests
+	// panel; the repo needs a github.com remote for lazygit to load them.
+	GitHubPullRequests []git_commands.PullRequestNode
 	// for when a test is flakey
 	Skip bool
 	// to run a test only on certain git versions
@@ -130,6 +135,7 @@ func NewIntegrationTest(args NewIntegrationTestArgs) *IntegrationTest {
 		extraCmdArgs: args.ExtraCmdArgs,
 		extraEnvVars: args.ExtraEnvVars,
 		skip:         args.Skip,
+		pullRequests: args.GitHubPullRequests,
 		setupRepo:    args.SetupRepo,
 		setupConfig:  args.SetupConfig,
 		run:          args.Run,
@@ -156,6 +162,16 @@ func (self *IntegrationTest) ExtraEnvVars() map[string]string {
 	return self.extraEnvVars
 }
 
+func (self *IntegrationTest) GitHubPullRequests() []git_commands.PullRequestNode {
+	return self.pullRequests
+}
+
+// GitHubAPIURL returns the URL of the fake GitHub API that the runner started
+// for this test's GitHubPullRequests
+func (self *IntegrationTest) GitHubAPIURL() string {
+	return os.Getenv(GITHUB_API_URL_ENV_VAR)
+}
+
 func (self *IntegrationTest) Skip() bool {
 	return self.skip
 }
@@ -354,7 +354,7 @@ func (self *ViewDriver) Focus() *ViewDriver {
 	windows := []window{
 		{name: "status", viewNames: []string{"status"}},
 		{name: "files", viewNames: []string{"files", "worktrees", "submodules"}},
-		{name: "branches", viewNames: []string{"localBranches", "remotes", "tags"}},
+		{name: "branches", viewNames: []string{"localBranches", "remotes", "tags", "pullRequests"}},
 		{name: "commits", viewNames: []string{"commits", "reflogCommits"}},
 		{name: "stash", viewNames: []string{"stash"}},
 	}
@@ -42,6 +42,18 @@ func (self *Views) MergeConflicts() *ViewDriver {
 	return self.regularView("mergeConflicts")
 }
 
+func (self *Views) MergeConflictEditor() *ViewDriver {
+	return self.regularView("mergeConflictEditor")
+}
+
+func (self *Views) MergeConflictResult() *ViewDriver {
+	return self.regularView("mergeConflictResult")
+}
+
+func (self *Views) Blame() *ViewDriver {
+	return self.regularView("blame")
+}
+
 func (self *Views) Commits() *ViewDriver {
 	return self.regularView("commits")
 }
@@ -86,6 +98,10 @@ func (self *Views) Tags() *ViewDriver {
 	return self.regularView("tags")
 }
 
+func (self *Views) PullRequests() *ViewDriver {
+	return self.regularView("pullRequests")
+}
+
 func (self *Views) ReflogCommits() *ViewDriver {
 	return self.regularView("reflogCommits")
 }
@@ -94,6 +110,10 @@ func (self *Views) SubCommits() *ViewDriver {
 	return self.regularView("subCommits")
 }
 
+func (self *Views) RangeDiff() *ViewDriver {
+	return self.regularView("rangeDiff")
+}
+
 func (self *Views) CommitFiles() *ViewDriver {
 	return self.regularView("commitFiles")
 }
@@ -0,0 +1,53 @@
+package branch
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CheckoutPullRequest = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Check out a pull request whose branch already exists locally, fast-forwarding the local branch",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.NewBranch("feature")
+		shell.EmptyCommit("two")
+		shell.CloneIntoRemote("origin")
+		// the local branch is now behind the pull request
+		shell.HardReset("HEAD^")
+		shell.Checkout("master")
+		shell.MakeRemoteLookLikeGitHub("origin", "owner/repo")
+	},
+	GitHubPullRequests: []git_commands.PullRequestNode{
+		{
+			Number:      1,
+			Title:       "Add feature",
+			HeadRefName: "feature",
+			BaseRefName: "master",
+			State:       "OPEN",
+		},
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().PullRequests().
+			Focus().
+			Lines(
+				Contains("#1").Contains("Add feature").IsSelected(),
+			).
+			PressPrimaryAction()
+
+		t.Views().Branches().
+			Lines(
+				Contains("feature"),
+				Contains("master"),
+			)
+
+		t.Views().Commits().
+			Lines(
+				Contains("two"),
+				Contains("one"),
+			)
+	},
+})
@@ -0,0 +1,59 @@
+package branch
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CheckoutPullRequestDivergedLocalBranch = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Check out a pull request whose branch exists locally with commits that aren't in the pull request",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.NewBranch("feature")
+		shell.EmptyCommit("two")
+		shell.CloneIntoRemote("origin")
+		shell.HardReset("HEAD^")
+		shell.EmptyCommit("local change")
+		shell.Checkout("master")
+		shell.MakeRemoteLookLikeGitHub("origin", "owner/repo")
+	},
+	GitHubPullRequests: []git_commands.PullRequestNode{
+		{
+			Number:      1,
+			Title:       "Add feature",
+			HeadRefName: "feature",
+			BaseRefName: "master",
+			State:       "OPEN",
+		},
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().PullRequests().
+			Focus().
+			Lines(
+				Contains("#1").Contains("Add feature").IsSelected(),
+			).
+			PressPrimaryAction()
+
+		t.ExpectPopup().Confirmation().
+			Title(Equals("Checkout pull request")).
+			Content(Contains("The local branch 'feature' has commits that aren't in the pull request's branch 'origin/feature'")).
+			Confirm()
+
+		t.Views().Branches().
+			Lines(
+				Contains("feature"),
+				Contains("master"),
+			)
+
+		// the local branch is checked out as it is
+		t.Views().Commits().
+			Lines(
+				Contains("local change"),
+				Contains("one"),
+			)
+	},
+})
@@ -0,0 +1,131 @@
+package branch
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CheckoutPullRequestFromFork = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Check out a pull request from a fork, and check it out again after it was pushed to and after it was force-pushed to",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.NewBranch("fork-v1")
+		shell.EmptyCommit("fork change")
+		shell.NewBranch("fork-v2")
+		shell.EmptyCommit("another fork change")
+		shell.NewBranch("fork-v3")
+		shell.EmptyCommit("third fork change")
+		shell.NewBranchFrom("fork-v4", "master")
+		shell.EmptyCommit("rewritten fork change")
+		shell.Checkout("master")
+		shell.CloneIntoRemote("origin")
+		shell.RunCommand([]string{"git", "branch", "-D", "fork-v1", "fork-v2", "fork-v3", "fork-v4"})
+		// GitHub makes the head of every pull request available in the base
+		// repo under this ref
+		shell.RunCommand([]string{"git", "-C", "../origin", "update-ref", "refs/pull/7/head", "fork-v1"})
+		shell.MakeRemoteLookLikeGitHub("origin", "owner/repo")
+	},
+	GitHubPullRequests: []git_commands.PullRequestNode{
+		{
+			Number:              7,
+			Title:               "Change from a fork",
+			HeadRefName:         "fork-change",
+			BaseRefName:         "master",
+			State:               "OPEN",
+			HeadRepositoryOwner: git_commands.GithubRepositoryOwner{Login: "contributor"},
+			IsCrossRepository:   true,
+		},
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().PullRequests().
+			Focus().
+			Lines(
+				Contains("#7").Contains("Change from a fork").IsSelected(),
+			).
+			PressPrimaryAction()
+
+		t.Views().Branches().
+			Lines(
+				Contains("pr/7"),
+				Contains("master"),
+			)
+
+		t.Views().Commits().
+			Lines(
+				Contains("fork change"),
+				Contains("one"),
+			)
+
+		// The pull request is pushed to while we're on another branch
+		t.Shell().RunCommand([]string{"git", "-C", "../origin", "update-ref", "refs/pull/7/head", "fork-v2"})
+
+		t.Views().Branches().
+			Focus().
+			NavigateToLine(Contains("master")).
+			PressPrimaryAction().
+			Lines(
+				Contains("master").IsSelected(),
+				Contains("pr/7"),
+			)
+
+		t.Views().PullRequests().
+			Focus().
+			PressPrimaryAction()
+
+		t.Views().Commits().
+			Lines(
+				Contains("another fork change"),
+				Contains("fork change"),
+				Contains("one"),
+			)
+
+		// The pull request is pushed to while its branch is checked out
+		t.Shell().RunCommand([]string{"git", "-C", "../origin", "update-ref", "refs/pull/7/head", "fork-v3"})
+
+		t.Views().PullRequests().
+			Focus().
+			PressPrimaryAction()
+
+		t.Views().Commits().
+			Lines(
+				Contains("third fork change"),
+				Contains("another fork change"),
+				Contains("fork change"),
+				Contains("one"),
+			)
+
+		// The pull request is force-pushed to while we're on another branch;
+		// the local branch must not be overwritten
+		t.Shell().RunCommand([]string{"git", "-C", "../origin", "update-ref", "refs/pull/7/head", "fork-v4"})
+
+		t.Views().Branches().
+			Focus().
+			NavigateToLine(Contains("master")).
+			PressPrimaryAction().
+			Lines(
+				Contains("master").IsSelected(),
+				Contains("pr/7"),
+			)
+
+		t.Views().PullRequests().
+			Focus().
+			PressPrimaryAction()
+
+		t.ExpectPopup().Confirmation().
+			Title(Equals("Checkout pull request")).
+			Content(Contains("The local branch 'pr/7' has commits that aren't in the pull request's branch 'contributor:fork-change'")).
+			Confirm()
+
+		t.Views().Commits().
+			Lines(
+				Contains("third fork change"),
+				Contains("another fork change"),
+				Contains("fork change"),
+				Contains("one"),
+			)
+	},
+})
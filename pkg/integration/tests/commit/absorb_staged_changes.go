@@ -0,0 +1,78 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var AbsorbStagedChanges = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Absorb staged hunks into the commits they belong to, leaving an ambiguous hunk staged",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.NewBranch("mybranch").
+			CreateFileAndAdd("file1", "1a\n1b\n1c\n").
+			Commit("commit 1").
+			CreateFileAndAdd("file2", "2a\n2b\n2c\n").
+			UpdateFileAndAdd("file1", "1a\n1b\n1c\n2d\n").
+			Commit("commit 2").
+			UpdateFileAndAdd("file1", "1A\n1b\n1C\n2D\n").
+			UpdateFileAndAdd("file2", "2a\n2B\n2c\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			Focus().
+			Press(keys.Files.AbsorbStagedChanges)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Absorb staged changes")).
+			ContainsLines(
+				Contains("file1:1").Contains("commit 1"),
+				Contains("file1:3").Contains("not absorbed"),
+				Contains("file2:2").Contains("commit 2"),
+			).
+			Select(Contains("file1:3")).
+			Confirm()
+
+		t.ExpectPopup().Alert().
+			Title(Equals("Error")).
+			Content(MatchesRegexp("This hunk changes lines of more than one commit.*\n\n" +
+				".*commit 2\n" +
+				".*commit 1")).
+			Confirm()
+
+		t.Views().Files().
+			IsFocused().
+			Press(keys.Files.AbsorbStagedChanges)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Absorb staged changes")).
+			Select(Contains("Create fixup commits").DoesNotContain("squash")).
+			Confirm()
+
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("fixup! commit 2"),
+				Contains("fixup! commit 1"),
+				Contains("commit 2"),
+				Contains("commit 1"),
+			).
+			NavigateToLine(Contains("fixup! commit 1")).
+			Tap(func() {
+				t.Views().Main().
+					Content(Contains("-1a\n+1A")).
+					Content(DoesNotContain("2B"))
+			})
+
+		t.Views().Files().
+			Focus().
+			Lines(
+				Equals("M  file1"),
+			)
+
+		t.Views().Main().
+			Content(Contains(" 1A\n 1b\n-1c\n-2d\n+1C\n+2D"))
+	},
+})
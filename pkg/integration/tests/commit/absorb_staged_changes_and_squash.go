@@ -0,0 +1,55 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var AbsorbStagedChangesAndSquash = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Absorb staged hunks into the commits they belong to and squash the fixups right away",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.NewBranch("mybranch").
+			CreateFileAndAdd("file1", "1a\n1b\n").
+			Commit("commit 1").
+			CreateFileAndAdd("file2", "2a\n2b\n").
+			Commit("commit 2").
+			CreateFileAndAdd("file3", "3a\n").
+			Commit("commit 3").
+			UpdateFileAndAdd("file1", "1a\n1B\n").
+			UpdateFileAndAdd("file2", "2A\n2b\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			Focus().
+			Press(keys.Files.AbsorbStagedChanges)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Absorb staged changes")).
+			Select(Contains("Create fixup commits and squash them")).
+			Confirm()
+
+		t.Views().Files().
+			IsEmpty()
+
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("commit 3"),
+				Contains("commit 2"),
+				Contains("commit 1"),
+			).
+			NavigateToLine(Contains("commit 2")).
+			Tap(func() {
+				t.Views().Main().
+					Content(Contains("+2A\n+2b"))
+			}).
+			NavigateToLine(Contains("commit 1")).
+			Tap(func() {
+				t.Views().Main().
+					Content(Contains("+1a\n+1B"))
+			})
+	},
+})
@@ -0,0 +1,50 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var BlameCommitFile = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Blame a file as of the selected commit from the commit files view",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file.txt", "one\ntwo\n").
+			Commit("first").
+			UpdateFileAndAdd("file.txt", "one\nTWO\n").
+			Commit("second").
+			UpdateFileAndAdd("file.txt", "ONE\nTWO\n").
+			Commit("third")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("third").IsSelected(),
+				Contains("second"),
+				Contains("first"),
+			).
+			SelectNextItem().
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("file.txt").IsSelected(),
+			).
+			Press(keys.CommitFiles.ViewBlame)
+
+		t.Views().Blame().
+			IsFocused().
+			Lines(
+				Contains("one"),
+				Contains("TWO"),
+			).
+			PressEscape()
+
+		t.Views().CommitFiles().
+			IsFocused()
+	},
+})
@@ -0,0 +1,58 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var ExportPatchSeries = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Export a range of commits as a patch series with a cover letter",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "original\n")
+		shell.Commit("original")
+		shell.CreateFileAndAdd("file1", "one\n")
+		shell.Commit("first change")
+		shell.CreateFileAndAdd("file2", "two\n")
+		shell.Commit("second change")
+		shell.CreateFileAndAdd("file3", "three\n")
+		shell.Commit("third change")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("third change").IsSelected(),
+				Contains("second change"),
+				Contains("first change"),
+				Contains("original"),
+			).
+			SelectNextItem().
+			Press(keys.Universal.RangeSelectDown).
+			Press(keys.Commits.ExportPatchSeries).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Export as patch series")).
+					Select(Contains("Export with cover letter")).
+					Confirm()
+
+				t.ExpectPopup().Prompt().
+					Title(Equals("Output directory")).
+					InitialText(Equals(".")).
+					Clear().
+					Type("../patches").
+					Confirm()
+
+				t.ExpectToast(Equals("Exported 3 patch file(s) to ../patches"))
+
+				t.FileSystem().
+					PathPresent("../patches/0000-cover-letter.patch").
+					PathPresent("../patches/0001-first-change.patch").
+					PathPresent("../patches/0002-second-change.patch").
+					PathNotPresent("../patches/0003-third-change.patch").
+					FileContent("../patches/0002-second-change.patch", Contains("+two"))
+			})
+	},
+})
@@ -0,0 +1,113 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var Notes = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Add, edit and remove a note on a commit, and switch to a different notes ref",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.EmptyCommit("two")
+		shell.RunCommand([]string{"git", "notes", "--ref=review", "add", "-m", "looks good", "HEAD~1"})
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("two").DoesNotContain("✎").IsSelected(),
+				Contains("one").DoesNotContain("✎"),
+			).
+			Press(keys.Commits.ViewNotesOptions)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Notes (Default notes ref)")).
+			Select(Contains("Add note")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Note")).
+			Type("first note").
+			Confirm()
+
+		t.Views().Commits().
+			Lines(
+				Contains("✎ two").IsSelected(),
+				Contains("one").DoesNotContain("✎"),
+			)
+
+		t.Views().Main().
+			Content(Contains("Notes:").Contains("first note"))
+
+		t.Views().Commits().
+			Press(keys.Commits.ViewNotesOptions)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Notes (Default notes ref)")).
+			Select(Contains("Edit note").DoesNotContain("editor")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Note")).
+			InitialText(Equals("first note")).
+			Clear().
+			Type("second note").
+			Confirm()
+
+		t.Views().Main().
+			Content(Contains("second note").DoesNotContain("first note"))
+
+		t.Views().Commits().
+			Press(keys.Commits.ViewNotesOptions)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Notes (Default notes ref)")).
+			Select(Contains("Switch notes ref")).
+			Confirm()
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Switch notes ref")).
+			Lines(
+				Contains("Default notes ref").IsSelected(),
+				Contains("refs/notes/commits"),
+				Contains("refs/notes/review"),
+				Contains("Enter notes ref"),
+				Contains("Cancel"),
+			).
+			Select(Contains("refs/notes/review")).
+			Confirm()
+
+		t.Views().Commits().
+			Lines(
+				Contains("two").DoesNotContain("✎").IsSelected(),
+				Contains("✎ one"),
+			).
+			NavigateToLine(Contains("one"))
+
+		t.Views().Main().
+			Content(Contains("Notes (review):").Contains("looks good"))
+
+		t.Views().Commits().
+			Press(keys.Commits.ViewNotesOptions)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Notes (refs/notes/review)")).
+			Select(Contains("Remove note")).
+			Confirm()
+
+		t.ExpectPopup().Confirmation().
+			Title(Equals("Remove note")).
+			Content(Contains("Are you sure you want to remove the note from commit")).
+			Confirm()
+
+		t.Views().Commits().
+			Lines(
+				Contains("two").DoesNotContain("✎"),
+				Contains("one").DoesNotContain("✎").IsSelected(),
+			)
+	},
+})
@@ -0,0 +1,49 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var SignatureStatus = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Show whether commits are signed, and the signature details in the main view, using ssh signing keys",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().Git.Log.ShowSignatureStatus = true
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.RunShellCommand(`ssh-keygen -q -t ed25519 -N "" -C trusted -f .git/trusted_key`).
+			RunShellCommand(`ssh-keygen -q -t ed25519 -N "" -C stranger -f .git/stranger_key`).
+			RunShellCommand(`echo "CI@example.com namespaces=\"git\" $(cat .git/trusted_key.pub)" > .git/allowed_signers`).
+			SetConfig("gpg.format", "ssh").
+			SetConfig("gpg.ssh.allowedSignersFile", ".git/allowed_signers").
+			EmptyCommit("unsigned").
+			RunCommand([]string{"git", "-c", "user.signingkey=.git/trusted_key", "commit", "-S", "--allow-empty", "-m", "trusted"}).
+			RunCommand([]string{"git", "-c", "user.signingkey=.git/stranger_key", "commit", "-S", "--allow-empty", "-m", "stranger"}).
+			RunCommand([]string{"git", "-c", "user.signingkey=.git/trusted_key", "commit", "-S", "--allow-empty", "-m", "original"}).
+			// change the message of the last commit without re-signing it
+			RunShellCommand(`git reset --hard $(git cat-file commit HEAD | sed 's/^original$/tampered/' | git hash-object -t commit -w --stdin)`)
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("✗").Contains("tampered").IsSelected(),
+				Contains("✓").Contains("stranger"),
+				Contains("✓").Contains("trusted"),
+				Contains("-").Contains("unsigned"),
+			).
+			// a good signature from a key that isn't trusted gets a yellow
+			// checkmark, a trusted one a green checkmark
+			ContainsColoredText("#808000", "✓").
+			ContainsColoredText("#008000", "✓").
+			Tap(func() {
+				t.Views().Main().Content(Contains("Could not verify signature"))
+			}).
+			NavigateToLine(Contains("trusted")).
+			Tap(func() {
+				t.Views().Main().Content(Contains(`Good "git" signature for CI@example.com`))
+			})
+	},
+})
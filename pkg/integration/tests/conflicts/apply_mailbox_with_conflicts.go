@@ -0,0 +1,64 @@
+package conflicts
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var ApplyMailboxWithConflicts = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Apply a directory of patches with git am, resolving a conflict along the way",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "original\n")
+		shell.Commit("original")
+		shell.UpdateFileAndAdd("file", "patched\n")
+		shell.Commit("patched change")
+		shell.CreateFileAndAdd("other-file", "other\n")
+		shell.Commit("other change")
+		shell.RunCommand([]string{"git", "format-patch", "--output-directory", "../mailbox", "HEAD~2"})
+		shell.HardReset("HEAD~2")
+		shell.UpdateFileAndAdd("file", "conflicting\n")
+		shell.Commit("conflicting change")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			Focus().
+			Press(keys.Files.ApplyMailbox)
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Mbox file or directory of patches")).
+			Type("../mailbox").
+			Confirm()
+
+		t.Common().AcknowledgeConflicts()
+
+		t.Views().Status().Content(Contains("(applying patches)"))
+
+		t.Views().Files().
+			IsFocused().
+			SelectedLine(Contains("UU file")).
+			PressEnter()
+
+		t.Views().MergeConflicts().
+			IsFocused().
+			// picking 'patched'
+			SelectNextItem().
+			PressPrimaryAction()
+
+		t.Common().ContinueOnConflictsResolved("am")
+
+		t.Views().Files().IsEmpty()
+
+		t.Views().Commits().
+			Lines(
+				Contains("other change"),
+				Contains("patched change"),
+				Contains("conflicting change"),
+				Contains("original"),
+			)
+
+		t.FileSystem().FileContent("file", Equals("patched\n"))
+	},
+})
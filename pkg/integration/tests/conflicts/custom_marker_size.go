@@ -0,0 +1,60 @@
+package conflicts
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+	"github.com/jesseduffield/lazygit/pkg/integration/tests/shared"
+)
+
+var CustomMarkerSize = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Resolve a conflict in a file whose conflict markers have a size set through gitattributes, and look at its common ancestor in the three-way editor",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.SetConfig("merge.conflictStyle", "merge")
+		shell.
+			CreateFileAndAdd(".gitattributes", "file conflict-marker-size=10\n").
+			Commit("set conflict marker size")
+
+		shared.CreateMergeConflictFile(shell)
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("UU file").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().MergeConflicts().
+			IsFocused().
+			SelectedLines(
+				Contains("<<<<<<<<<< HEAD"),
+				Contains("First Change"),
+				Contains("=========="),
+			).
+			// the three-way editor re-merges the file with the same marker size
+			// to find the common ancestor
+			Press(keys.Main.OpenThreeWayMergeEditor)
+
+		t.Views().MergeConflictEditor().
+			IsFocused().
+			Content(Contains("Original").Contains("First Change").Contains("Second Change")).
+			Press(keys.Universal.Return)
+
+		t.Views().MergeConflicts().
+			IsFocused().
+			SelectNextItem().
+			SelectedLines(
+				Contains("=========="),
+				Contains("Second Change"),
+				Contains(">>>>>>>>>> second-change-branch"),
+			).
+			PressPrimaryAction()
+
+		t.Common().ContinueOnConflictsResolved("merge")
+
+		t.FileSystem().FileContent("file", Equals(shared.SecondChangeFileContent))
+	},
+})
@@ -0,0 +1,35 @@
+package conflicts
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+	"github.com/jesseduffield/lazygit/pkg/integration/tests/shared"
+)
+
+var RerereAutoStage = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Automatically stage files resolved by rerere when configured to",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().Git.AutoStageRerereResolvedConflicts = true
+	},
+	SetupRepo: func(shell *Shell) {
+		shared.CreateRerereResolvedConflict(shell)
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("  UU file (resolved by rerere)"),
+				Equals("  UU other"),
+			).
+			// like other resolved conflicts, this happens on the next refresh;
+			// the view only shows conflicting files, so the staged one disappears
+			Press(keys.Files.RefreshFiles).
+			Lines(
+				Equals("UU other"),
+			)
+
+	},
+})
@@ -0,0 +1,84 @@
+package conflicts
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+	"github.com/jesseduffield/lazygit/pkg/integration/tests/shared"
+)
+
+var RerereResolved = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Show files resolved by rerere without auto-staging them, show their recorded resolution, and forget it",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shared.CreateRerereResolvedConflict(shell)
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("  UU file (resolved by rerere)"),
+				Equals("  UU other"),
+			).
+			// refreshing again would have auto-staged the file if it weren't
+			// for rerere
+			Press(keys.Files.RefreshFiles).
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("  UU file (resolved by rerere)"),
+				Equals("  UU other"),
+			).
+			NavigateToLine(Contains("other")).
+			Press(keys.Files.OpenMergeOptions)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Resolve merge conflicts")).
+			Select(Contains("Forget recorded resolution")).
+			Confirm().
+			Tap(func() {
+				t.ExpectToast(Equals("Disabled: None of the selected files were resolved by rerere"))
+			}).
+			Cancel()
+
+		t.Views().Files().
+			IsFocused().
+			NavigateToLine(Contains("file"))
+
+		t.Views().Secondary().
+			Title(Equals("Recorded resolution")).
+			Content(
+				Contains("--- preimage").
+					Contains("+++ postimage").
+					Contains("-<<<<<<<").
+					Contains("-master").
+					Contains("-feature").
+					Contains("+resolved"),
+			)
+
+		t.Views().Files().
+			IsFocused().
+			Press(keys.Files.OpenMergeOptions)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Resolve merge conflicts")).
+			Select(Contains("Forget recorded resolution")).
+			Confirm()
+
+		t.ExpectPopup().Confirmation().
+			Title(Equals("Forget recorded resolution")).
+			Content(Contains("Are you sure you want to forget the recorded resolution of file?")).
+			Confirm()
+
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /"),
+				Equals("  UU file").IsSelected(),
+				Equals("  UU other"),
+			)
+
+		t.FileSystem().FileContent("file", Contains("<<<<<<<").Contains("master").Contains("feature"))
+	},
+})
@@ -0,0 +1,102 @@
+package conflicts
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+	"github.com/jesseduffield/lazygit/pkg/integration/tests/shared"
+)
+
+var ThreeWayMergeEditor = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Resolve conflicts line by line in the three-way editor, taking the common ancestor from the index",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.EditAtLineAndWait = "printf 'Edited by hand\\n' > {{filename}}"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.SetConfig("merge.conflictStyle", "merge")
+		shared.CreateMergeConflictFileMultiple(shell)
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("UU file").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().MergeConflicts().
+			IsFocused().
+			// the file was written without the common ancestor
+			Content(DoesNotContain("|||||||")).
+			SelectedLines(
+				Contains("<<<<<<< HEAD"),
+				Contains("First Change"),
+				Contains("======="),
+			).
+			Press(keys.Main.OpenThreeWayMergeEditor)
+
+		t.Views().MergeConflictEditor().
+			IsFocused().
+			Title(Equals("Three-way merge")).
+			Content(Contains("Base").Contains("Ours").Contains("Theirs")).
+			Content(Contains("Original").Contains("First Change").Contains("Second Change")).
+			Press(keys.Universal.Return)
+
+		// we can go back and forth
+		t.Views().MergeConflicts().
+			IsFocused().
+			Press(keys.Main.OpenThreeWayMergeEditor)
+
+		t.Views().MergeConflictResult().
+			Content(Contains("No lines picked yet"))
+
+		t.Views().MergeConflictEditor().
+			IsFocused().
+			// take our line, then theirs
+			PressPrimaryAction().
+			Press(keys.Universal.NextBlock).
+			PressPrimaryAction()
+
+		t.Views().MergeConflictResult().
+			Content(Equals("First Change\nSecond Change"))
+
+		t.Views().MergeConflictEditor().
+			PressEnter().
+			// on to the second conflict
+			Content(Contains("Options").Contains("Other First Change").Contains("Other Second Change")).
+			Press(keys.Universal.NextBlock).
+			Press(keys.Main.PickBothHunks)
+
+		t.Views().MergeConflictResult().
+			Content(Equals("Other Second Change"))
+
+		t.Views().MergeConflictEditor().
+			Press(keys.Universal.Edit)
+
+		t.Views().MergeConflictResult().
+			Content(Equals("Edited by hand"))
+
+		t.Views().MergeConflictEditor().
+			PressEnter()
+
+		t.Common().ContinueOnConflictsResolved("merge")
+
+		t.FileSystem().FileContent("file", Equals(`
+This
+Is
+The
+First Change
+Second Change
+File
+..
+It
+Is
+Longer
+Than
+The
+Other
+Edited by hand
+`))
+	},
+})
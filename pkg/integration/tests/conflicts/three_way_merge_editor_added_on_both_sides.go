@@ -0,0 +1,60 @@
+package conflicts
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var ThreeWayMergeEditorAddedOnBothSides = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Resolve a conflict in a file that was added on both sides, which has no common ancestor, and confirm before applying an empty result",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.
+			EmptyCommit("initial commit").
+			NewBranch("theirs").
+			CreateFileAndAdd("file", "theirs\n").
+			Commit("add file on theirs").
+			Checkout("master").
+			CreateFileAndAdd("file", "ours\n").
+			Commit("add file on ours").
+			RunCommandExpectError([]string{"git", "merge", "--no-edit", "theirs"})
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("AA file").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().MergeConflicts().
+			IsFocused().
+			Press(keys.Main.OpenThreeWayMergeEditor)
+
+		t.Views().MergeConflictEditor().
+			IsFocused().
+			Content(Contains("Base").Contains("(not available)").Contains("ours").Contains("theirs")).
+			PressEnter()
+
+		t.ExpectPopup().Confirmation().
+			Title(Equals("Apply result")).
+			Content(Contains("You haven't picked any lines")).
+			Cancel()
+
+		t.Views().MergeConflictEditor().
+			IsFocused().
+			PressPrimaryAction()
+
+		t.Views().MergeConflictResult().
+			Content(Equals("ours"))
+
+		t.Views().MergeConflictEditor().
+			PressEnter()
+
+		t.Common().ContinueOnConflictsResolved("merge")
+
+		t.FileSystem().FileContent("file", Equals("ours\n"))
+	},
+})
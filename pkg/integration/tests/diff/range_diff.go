@@ -0,0 +1,72 @@
+package diff
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var RangeDiff = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Compare the old version of a rewritten branch with the current one using range-diff",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("base", "base")
+		shell.Commit("base")
+
+		shell.NewBranch("feature")
+		shell.CreateFileAndAdd("file1", "one")
+		shell.Commit("one")
+		shell.CreateFileAndAdd("file2", "a\nb\nc\nd\ne\nf\n")
+		shell.Commit("two")
+		shell.CreateFileAndAdd("file3", "three")
+		shell.Commit("three")
+
+		// keep the first version of the branch around, then rewrite it
+		shell.RunCommand([]string{"git", "branch", "feature-v1"})
+		shell.HardReset("HEAD~2")
+		shell.CreateFileAndAdd("file2", "a\nb\nc\nd\ne\nf changed\n")
+		shell.Commit("two")
+		shell.CreateFileAndAdd("file4", "four")
+		shell.Commit("four")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Branches().
+			Focus().
+			NavigateToLine(Contains("feature-v1")).
+			Press(keys.Universal.DiffingMenu)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Diffing")).
+			Select(Contains("Range-diff feature-v1 against feature")).
+			Confirm()
+
+		t.Views().RangeDiff().
+			IsFocused().
+			Title(Equals("Range-diff (feature-v1..feature)")).
+			Lines(
+				Contains("-: -------").Contains(">").Contains("3:").Contains("four").IsSelected(),
+				Contains("3:").Contains("<").Contains("-: -------").Contains("three"),
+				Contains("2:").Contains("!").Contains("2:").Contains("two"),
+				Contains("1:").Contains("=").Contains("1:").Contains("one"),
+			).
+			Tap(func() {
+				t.Views().Information().Content(Contains("Showing range-diff: feature-v1..feature"))
+				t.Views().Main().Content(Contains("+four"))
+			}).
+			SelectNextItem().
+			Tap(func() {
+				t.Views().Main().Content(Contains("+three"))
+			}).
+			SelectNextItem().
+			Tap(func() {
+				t.Views().Main().Content(Contains("-+f").Contains("++f changed"))
+			}).
+			PressEscape()
+
+		t.Views().Branches().
+			IsFocused()
+
+		t.Views().Information().Content(DoesNotContain("Showing range-diff"))
+	},
+})
@@ -0,0 +1,74 @@
+package file
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var Blame = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Blame a file, walk back through its history across a rename, and jump to the commit of a line",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("old.txt", "one\ntwo\n").
+			Commit("first").
+			RenameFileInGit("old.txt", "file.txt").
+			UpdateFileAndAdd("file.txt", "one\nTWO\n").
+			Commit("second").
+			UpdateFile("file.txt", "one\nTWO\nthree\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("file.txt").IsSelected(),
+			).
+			Press(keys.Files.ViewBlame)
+
+		t.Views().Blame().
+			IsFocused().
+			Title(Equals("Blame")).
+			Lines(
+				Contains("old.txt").Contains("one"),
+				Contains("file.txt").Contains("TWO"),
+				Contains("Not Committed Yet").Contains("three"),
+			).
+			SelectedLine(Contains("one")).
+			SelectNextItem().
+			SelectNextItem().
+			SelectedLine(Contains("three")).
+			Press(keys.Universal.GoInto).
+			Tap(func() {
+				t.ExpectToast(Equals("Disabled: The selected line hasn't been committed yet."))
+			}).
+			SelectPreviousItem().
+			SelectedLine(Contains("TWO")).
+			Press(keys.Main.BlameParent).
+			Lines(
+				Contains("one"),
+				Contains("two"),
+			).
+			SelectedLine(Contains("one")).
+			SelectNextItem().
+			SelectedLine(Contains("two")).
+			Press(keys.Main.BlameParent).
+			Tap(func() {
+				t.ExpectPopup().Alert().
+					Title(Equals("Error")).
+					Content(Contains("The selected line was added in commit")).
+					Confirm()
+			}).
+			PressEnter()
+
+		t.Views().SubCommits().
+			IsFocused().
+			Lines(
+				Contains("first").IsSelected(),
+			).
+			PressEscape()
+
+		t.Views().Files().
+			IsFocused()
+	},
+})
@@ -0,0 +1,81 @@
+package file
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var LazyUntrackedFiles = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "List the files of an untracked directory only when expanding it",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().Git.LazyUntrackedFiles = "always"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("tracked", "foo")
+		shell.Commit("first commit")
+		shell.UpdateFile("tracked", "bar")
+		shell.CreateDir("build/sub")
+		shell.CreateFile("build/a.o", "a")
+		shell.CreateFile("build/sub/b.o", "b")
+		shell.CreateFile("untracked", "baz")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("  ?? build/"),
+				Equals("   M tracked"),
+				Equals("  ?? untracked"),
+			).
+			NavigateToLine(Contains("build/"))
+
+		t.Views().Main().
+			Content(Contains("Its files aren't listed yet"))
+
+		t.Views().Files().
+			PressEnter().
+			Lines(
+				Equals("▼ /"),
+				Equals("  ▼ build").IsSelected(),
+				Equals("    ?? a.o"),
+				Equals("    ▼ sub"),
+				Equals("      ?? b.o"),
+				Equals("   M tracked"),
+				Equals("  ?? untracked"),
+			).
+			// the directory stays expanded when refreshing
+			Press(keys.Universal.Refresh).
+			Lines(
+				Equals("▼ /"),
+				Equals("  ▼ build").IsSelected(),
+				Equals("    ?? a.o"),
+				Equals("    ▼ sub"),
+				Equals("      ?? b.o"),
+				Equals("   M tracked"),
+				Equals("  ?? untracked"),
+			).
+			// but once it's gone, we forget that it was expanded
+			Tap(func() {
+				t.Shell().DeleteFile("build")
+			}).
+			Press(keys.Universal.Refresh).
+			Lines(
+				Equals("▼ /"),
+				Equals("   M tracked").IsSelected(),
+				Equals("  ?? untracked"),
+			).
+			Tap(func() {
+				t.Shell().CreateFile("build/c.o", "c")
+			}).
+			Press(keys.Universal.Refresh).
+			Lines(
+				Equals("▼ /"),
+				Equals("  ?? build/"),
+				Equals("   M tracked").IsSelected(),
+				Equals("  ?? untracked"),
+			)
+	},
+})
@@ -0,0 +1,63 @@
+package file
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var LfsLocks = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Lock a file tracked by Git LFS, and force-unlock somebody else's lock",
+	ExtraCmdArgs: []string{},
+	ExtraEnvVars: map[string]string{
+		"PATH": "{{actualPath}}/bin:" + os.Getenv("PATH"),
+	},
+	Skip:        false,
+	SetupConfig: func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		setupFakeGitLfs(shell)
+		shell.CreateFileAndAdd("image.png", lfsPointer(strings.Repeat("a", 64), 1024))
+		shell.Commit("add image")
+		shell.UpdateFile("image.png", lfsPointer(strings.Repeat("b", 64), 2048))
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals(" M image.png").IsSelected(),
+			).
+			Press(keys.Files.ViewLfsOptions)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Git LFS")).
+			Select(Contains("Lock file")).
+			Confirm()
+
+		t.FileSystem().FileContent("../bin/lfs-calls", Contains("lock -- image.png"))
+
+		t.Views().Files().
+			Press(keys.Files.ViewLfsOptions)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Git LFS")).
+			Select(Contains("View locks")).
+			Confirm()
+
+		t.ExpectPopup().Menu().
+			Title(Equals("LFS locks")).
+			Lines(
+				Contains("image.png").Contains("Jane Doe"),
+				Contains("Cancel"),
+			).
+			Confirm()
+
+		t.ExpectPopup().Menu().
+			Title(Equals("image.png")).
+			Select(Contains("Force unlock")).
+			Confirm()
+
+		t.FileSystem().FileContent("../bin/lfs-calls", Contains("unlock --id=42 --force"))
+	},
+})
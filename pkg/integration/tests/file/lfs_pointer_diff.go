@@ -0,0 +1,57 @@
+package file
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var (
+	lfsOldOid = strings.Repeat("a", 64)
+	lfsNewOid = strings.Repeat("b", 64)
+)
+
+var LfsPointerDiff = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Show a summary instead of the pointer diff for a file tracked by Git LFS",
+	ExtraCmdArgs: []string{},
+	ExtraEnvVars: map[string]string{
+		"PATH": "{{actualPath}}/bin:" + os.Getenv("PATH"),
+	},
+	Skip:        false,
+	SetupConfig: func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		setupFakeGitLfs(shell)
+		shell.CreateFileAndAdd("image.png", lfsPointer(lfsOldOid, 1024))
+		shell.CreateFileAndAdd("notes.txt", "notes\n")
+		shell.Commit("add image")
+		shell.UpdateFile("image.png", lfsPointer(lfsNewOid, 2048))
+		shell.UpdateFile("notes.txt", "more notes\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("   M image.png"),
+				Equals("   M notes.txt"),
+			).
+			NavigateToLine(Contains("image.png"))
+
+		t.Views().Main().
+			Content(
+				Contains("LFS object changed (1.0 KiB → 2.0 KiB)").
+					Contains("-sha256:" + lfsOldOid).
+					Contains("+sha256:" + lfsNewOid).
+					DoesNotContain("version https://git-lfs.github.com/spec/v1"),
+			)
+
+		// files that aren't tracked by LFS show the usual diff
+		t.Views().Files().
+			NavigateToLine(Contains("notes.txt"))
+
+		t.Views().Main().
+			Content(Contains("-notes").Contains("+more notes"))
+	},
+})
@@ -1,6 +1,8 @@
 package file
 
 import (
+	"fmt"
+
 	. "github.com/jesseduffield/lazygit/pkg/integration/components"
 )
 
@@ -63,3 +65,25 @@ func createAllPossiblePermutationsOfChangedFiles(shell *Shell) {
 	shell.RunShellCommand(`rm renamed.txt && git add renamed.txt`)
 	shell.RunShellCommand(`echo "renamed\nhaha" > renamed2.txt && git add renamed2.txt`)
 }
+
+// Puts a fake git-lfs on the PATH (tests using it need to prepend
+// {{actualPath}}/bin to it) that appends its arguments to ../bin/lfs-calls,
+// and answers `git lfs locks` with a lock held by somebody else. Without a
+// real git-lfs, git leaves the contents of LFS-tracked files alone, so tests
+// can commit pointer files directly.
+func setupFakeGitLfs(shell *Shell) {
+	shell.CreateFile("../bin/git-lfs", `#!/bin/sh
+echo "$@" >> "$(dirname "$0")/lfs-calls"
+case "$1" in
+locks)
+    echo '[{"id":"42","path":"image.png","owner":{"name":"Jane Doe"},"locked_at":"2024-01-01T00:00:00Z"}]'
+    ;;
+esac
+`)
+	shell.MakeExecutable("../bin/git-lfs")
+	shell.CreateFileAndAdd(".gitattributes", "*.png filter=lfs diff=lfs merge=lfs -text\n")
+}
+
+func lfsPointer(oid string, size int) string {
+	return fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, size)
+}
@@ -0,0 +1,120 @@
+package file
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var SparseCheckout = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Enable sparse checkout, add and remove directories, and disable it again",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("root-file", "root\n")
+		shell.CreateFileAndAdd("a/file", "a\n")
+		shell.CreateFileAndAdd("b/file", "b\n")
+		shell.Commit("initial commit")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			IsEmpty().
+			Press(keys.Files.ViewSparseCheckoutOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Sparse checkout")).
+					Select(Contains("Enable sparse checkout")).
+					Confirm()
+
+				t.ExpectPopup().Prompt().
+					Title(Equals("Directory to check out")).
+					Confirm()
+
+				t.ExpectToast(Equals("Empty input is not allowed"))
+
+				t.ExpectPopup().Prompt().
+					Title(Equals("Directory to check out")).
+					Type("a").
+					SuggestionLines(Equals("a")).
+					Confirm()
+
+				t.FileSystem().
+					PathPresent("root-file").
+					PathPresent("a/file").
+					PathNotPresent("b/file")
+
+				t.Shell().CreateFile("b/new", "new\n")
+			}).
+			Press(keys.Files.RefreshFiles).
+			Lines(
+				Equals("▼ b"),
+				Equals("  ?? new (outside sparse checkout)"),
+			).
+			Press(keys.Files.ViewSparseCheckoutOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Sparse checkout")).
+					Select(Contains("Add directory")).
+					Confirm()
+
+				t.ExpectPopup().Prompt().
+					Title(Equals("Add directory")).
+					Type("b").
+					Confirm()
+
+				t.FileSystem().PathPresent("b/file")
+			}).
+			Lines(
+				Equals("▼ b"),
+				Equals("  ?? new"),
+			).
+			Press(keys.Files.ViewSparseCheckoutOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Sparse checkout")).
+					Select(Equals("  a")).
+					Confirm()
+
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Remove directory from sparse checkout")).
+					Content(Contains("Are you sure you want to remove 'a' from the sparse checkout?")).
+					Confirm()
+
+				t.FileSystem().
+					PathNotPresent("a/file").
+					PathPresent("b/file")
+			}).
+			Press(keys.Files.ViewSparseCheckoutOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Sparse checkout")).
+					Select(Equals("  b")).
+					Confirm()
+
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Remove directory from sparse checkout")).
+					Content(Contains("'b' is the last directory in the sparse checkout. If you remove it, only the files at the top level of the repo stay checked out.")).
+					Cancel()
+
+				t.FileSystem().PathPresent("b/file")
+			}).
+			Press(keys.Files.ViewSparseCheckoutOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Sparse checkout")).
+					Select(Contains("Disable sparse checkout")).
+					Confirm()
+
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Disable sparse checkout")).
+					Content(Contains("Are you sure you want to disable sparse checkout?")).
+					Confirm()
+
+				t.FileSystem().
+					PathPresent("a/file").
+					PathPresent("b/file")
+			})
+	},
+})
@@ -0,0 +1,113 @@
+package filter_by_content
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var PickaxeAndMessage = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Filter commits by the content they change and by their message, combining both filters",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "foo\n").
+			Commit("add foo").
+			CreateFileAndAdd("other", "one\n").
+			Commit("add other").
+			UpdateFileAndAdd("file", "bar\n").
+			Commit("fix: replace foo").
+			UpdateFileAndAdd("other", "two\n").
+			Commit("fix: update other")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Status().
+			Focus().
+			Press(keys.Universal.FilteringMenu)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Filtering")).
+			Select(Contains("Enter text to search for in diffs (-S)")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Enter text:")).
+			Type("foo").
+			Confirm()
+
+		t.Views().Commits().
+			IsFocused().
+			Lines(
+				Contains("fix: replace foo").IsSelected(),
+				Contains("add foo"),
+			)
+
+		t.Views().Information().Content(Contains("Filtering by content 'foo'"))
+
+		t.Views().ReflogCommits().
+			Focus().
+			Lines(
+				Contains("fix: replace foo"),
+				Contains("add foo"),
+			).
+			Press(keys.Universal.FilteringMenu)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Filtering")).
+			Select(Contains("Enter pattern to search for in commit messages (--grep)")).
+			Tooltip(Contains("Note: this will be combined with the existing filter")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Enter pattern:")).
+			Type("^fix").
+			Confirm()
+
+		t.Views().Commits().
+			IsFocused().
+			Lines(
+				Contains("fix: replace foo").IsSelected(),
+			)
+
+		t.Views().Information().Content(Contains("Filtering by content 'foo', message '^fix'"))
+
+		t.Views().Status().
+			Focus().
+			Press(keys.Universal.FilteringMenu)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Filtering")).
+			Select(Contains("Enter regex to search for in diffs (-G)")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Enter regex:")).
+			Type("^(one|two)$").
+			Confirm()
+
+		t.Views().Commits().
+			IsFocused().
+			Lines(
+				Contains("fix: update other").IsSelected(),
+			)
+
+		t.Views().Information().Content(Contains("Filtering by content regex '^(one|two)$', message '^fix'"))
+
+		t.Views().Status().
+			Focus().
+			Press(keys.Universal.FilteringMenu)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Filtering")).
+			Select(Contains("Stop filtering")).
+			Confirm()
+
+		t.Views().Commits().
+			Lines(
+				Contains("fix: update other"),
+				Contains("fix: replace foo"),
+				Contains("add other"),
+				Contains("add foo"),
+			)
+	},
+})
@@ -0,0 +1,78 @@
+package interactive_rebase
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var RebaseSubcommand = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Drop and squash commits with the rebase subcommands, which refuse to rewrite pushed commits or commits on a main branch unless asked to",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateNCommits(1)
+		shell.CloneIntoRemote("origin")
+		shell.SetBranchUpstream("master", "origin/master")
+		shell.NewBranch("feature")
+		shell.CreateNCommitsStartingAt(1, 2)
+		shell.PushBranchAndSetUpstream("origin", "feature")
+		shell.CreateNCommitsStartingAt(2, 3)
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		refresh := func() {
+			t.Views().Files().
+				Focus().
+				Press(keys.Universal.Refresh)
+		}
+
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("commit 04").IsSelected(),
+				Contains("commit 03"),
+				Contains("commit 02"),
+				Contains("commit 01"),
+			)
+
+		t.Shell().RunLazygit("rebase", "drop", "HEAD~1")
+		refresh()
+
+		t.Views().Commits().
+			Lines(
+				Contains("commit 04"),
+				Contains("commit 02"),
+				Contains("commit 01"),
+			)
+
+		// squashing into commit 02 would rewrite it, but it's pushed
+		t.Shell().RunLazygitExpectError("rebase", "squash", "HEAD")
+		refresh()
+
+		t.Views().Commits().
+			Lines(
+				Contains("commit 04"),
+				Contains("commit 02"),
+				Contains("commit 01"),
+			)
+
+		t.Shell().RunLazygit("rebase", "squash", "--allow-pushed", "HEAD")
+		refresh()
+
+		t.Views().Commits().
+			Lines(
+				Contains("commit 02"),
+				Contains("commit 01"),
+			)
+
+		// commit 01 is on the main branch
+		t.Shell().RunLazygitExpectError("rebase", "drop", "HEAD~1")
+		refresh()
+
+		t.Views().Commits().
+			Lines(
+				Contains("commit 02"),
+				Contains("commit 01"),
+			)
+	},
+})
@@ -0,0 +1,50 @@
+package misc
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var ExportSessionLog = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Export the log of the commands run in this session to a file",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().SessionLog.Enabled = true
+		config.GetUserConfig().SessionLog.IncludeOutput = true
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFile("file", "content")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("?? file").IsSelected(),
+			).
+			PressPrimaryAction().
+			Lines(
+				Equals("A  file").IsSelected(),
+			)
+
+		t.GlobalPress(keys.Universal.ExtrasMenu)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Command log")).
+			Select(Contains("Export session log")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Export session log to:")).
+			Clear().
+			Type("session.jsonl").
+			Confirm()
+
+		t.ExpectToast(Equals("Session log exported to session.jsonl"))
+
+		t.FileSystem().FileContent("session.jsonl",
+			Contains(`"command":"git add -- file","dir":`).
+				Contains(`"exitCode":0,"action":"Stage file"`).
+				Contains(`"command":"git status --untracked-files=all --porcelain -z --find-renames=50%"`))
+	},
+})
@@ -0,0 +1,76 @@
+package patch_building
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var LineHistory = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "View the history of the selected lines from the patch building panel",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().Gui.UseHunkModeInStagingView = false
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "a\nb\nc\n").
+			Commit("first").
+			UpdateFileAndAdd("file1", "a\nB\nc\n").
+			Commit("second").
+			UpdateFileAndAdd("file1", "a\nB\nC\n").
+			Commit("third")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("third").IsSelected(),
+				Contains("second"),
+				Contains("first"),
+			).
+			NavigateToLine(Contains("second")).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().PatchBuilding().
+			IsFocused().
+			// a deleted line is traced from the parent commit
+			SelectedLines(Contains("-b")).
+			Press(keys.Main.ViewLineHistory)
+
+		t.Views().SubCommits().
+			IsFocused().
+			Title(Contains("file1:2")).
+			Lines(
+				Contains("first").IsSelected(),
+			).
+			PressEscape()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			PressEnter()
+
+		t.Views().PatchBuilding().
+			IsFocused().
+			SelectNextItem().
+			SelectedLines(Contains("+B")).
+			Press(keys.Main.ViewLineHistory)
+
+		t.Views().SubCommits().
+			IsFocused().
+			Lines(
+				Contains("second").IsSelected(),
+				Contains("first"),
+			).
+			Tap(func() {
+				t.Views().Main().
+					Content(Contains("-b").Contains("+B"))
+			})
+	},
+})
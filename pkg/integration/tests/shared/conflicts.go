@@ -174,3 +174,30 @@ var CreateMergeConflictFileForMergeFileTests = func(shell *Shell, originalFileCo
 		Checkout("current-change-branch").
 		RunCommandExpectError([]string{"git", "merge", "--no-edit", "incoming-change-branch"})
 }
+
+// creates a merge with two conflicting files where rerere has a recorded
+// resolution for 'file' and has already replayed it, but not for 'other'
+var CreateRerereResolvedConflict = func(shell *Shell) {
+	shell.
+		SetConfig("rerere.enabled", "true").
+		CreateFileAndAdd("file", "base\n").
+		CreateFileAndAdd("other", "x\n").
+		Commit("base").
+		NewBranch("feature").
+		UpdateFileAndAdd("file", "feature\n").
+		UpdateFileAndAdd("other", "y\n").
+		Commit("feature change").
+		Checkout("master").
+		UpdateFileAndAdd("file", "master\n").
+		UpdateFileAndAdd("other", "z\n").
+		Commit("master change")
+
+	// record a resolution for one of the two files, then merge again so that
+	// rerere replays it
+	shell.
+		RunCommandExpectError([]string{"git", "merge", "feature"}).
+		UpdateFile("file", "resolved\n").
+		RunCommand([]string{"git", "rerere"}).
+		RunCommand([]string{"git", "merge", "--abort"}).
+		RunCommandExpectError([]string{"git", "merge", "feature"})
+}
@@ -0,0 +1,66 @@
+package staging
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var LineHistory = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "View the history of the selected lines from the staging panel",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().Gui.UseHunkModeInStagingView = false
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "one\ntwo\nthree\n").
+			Commit("first").
+			UpdateFileAndAdd("file1", "one\nTWO\nthree\n").
+			Commit("second").
+			UpdateFileAndAdd("file1", "one\nTWO\nthree\nfour\n").
+			Commit("third").
+			UpdateFile("file1", "one\n2\nthree\nfour\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().Staging().
+			IsFocused().
+			SelectedLines(Contains("-TWO")).
+			SelectNextItem().
+			SelectedLines(Contains("+2")).
+			Press(keys.Main.ViewLineHistory).
+			Tap(func() {
+				t.ExpectToast(Equals("Disabled: The selected lines have no history because they haven't been committed yet."))
+			}).
+			SelectPreviousItem().
+			SelectedLines(Contains("-TWO")).
+			Press(keys.Main.ViewLineHistory)
+
+		t.Views().SubCommits().
+			IsFocused().
+			Title(Contains("file1:2")).
+			Lines(
+				Contains("second").IsSelected(),
+				Contains("first"),
+			).
+			Tap(func() {
+				t.Views().Main().
+					Content(Contains("-two").Contains("+TWO").DoesNotContain("four"))
+			}).
+			SelectNextItem().
+			Tap(func() {
+				t.Views().Main().
+					Content(Contains("+two").DoesNotContain("three"))
+			}).
+			PressEscape()
+
+		t.Views().Files().
+			IsFocused()
+	},
+})
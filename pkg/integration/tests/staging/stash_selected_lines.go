@@ -0,0 +1,105 @@
+package staging
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var StashSelectedLines = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Stash selected lines from the unstaged and the staged changes views, leaving the other changes in place",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().Gui.UseHunkModeInStagingView = false
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "one\ntwo\n")
+		shell.CreateFileAndAdd("file2", "one\ntwo\n")
+		shell.Commit("one")
+
+		shell.UpdateFile("file1", "one\ntwo\nthree\nfour\nfive\nsix\n")
+		shell.UpdateFileAndAdd("file2", "one\ntwo\nthree\nfour\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /"),
+				Equals("   M file1"),
+				Equals("  M  file2"),
+			).
+			NavigateToLine(Contains("file1")).
+			PressEnter()
+
+		t.Views().Staging().
+			IsFocused().
+			SelectedLines(
+				Contains("+three"),
+			).
+			Press(keys.Universal.ToggleRangeSelect).
+			NavigateToLine(Contains("+four")).
+			Press(keys.Files.StashAllChanges)
+
+		t.ExpectPopup().Prompt().Title(Equals("Stash changes")).Type("unstaged lines").Confirm()
+
+		t.Views().Staging().
+			IsFocused().
+			ContainsLines(
+				Contains(" two"),
+				Contains("+five"),
+				Contains("+six"),
+			)
+
+		t.Views().Stash().
+			Lines(
+				Contains("unstaged lines"),
+			)
+
+		t.FileSystem().FileContent("file1", Equals("one\ntwo\nfive\nsix\n"))
+
+		t.Views().Staging().
+			PressEscape()
+
+		t.Views().Files().
+			IsFocused().
+			NavigateToLine(Contains("file2")).
+			PressEnter()
+
+		t.Views().StagingSecondary().
+			IsFocused().
+			SelectedLines(
+				Contains("+three"),
+			).
+			Press(keys.Files.StashAllChanges)
+
+		t.ExpectPopup().Prompt().Title(Equals("Stash changes")).Type("staged line").Confirm()
+
+		t.Views().StagingSecondary().
+			IsFocused().
+			ContainsLines(
+				Contains(" two"),
+				Contains("+four"),
+			)
+
+		t.Views().Stash().
+			Lines(
+				Contains("staged line"),
+				Contains("unstaged lines"),
+			)
+
+		t.FileSystem().FileContent("file2", Equals("one\ntwo\nfour\n"))
+
+		t.Views().Stash().
+			Focus().
+			NavigateToLine(Contains("unstaged lines"))
+
+		t.Views().Main().
+			Content(Contains("+three").Contains("+four").DoesNotContain("+five"))
+
+		t.Views().Stash().
+			NavigateToLine(Contains("On master: staged line"))
+
+		t.Views().Main().
+			Content(Contains("+three").DoesNotContain("+four"))
+	},
+})
@@ -0,0 +1,71 @@
+package stash
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var PredictConflicts = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Show what the selected stash entry is based on, and warn about conflicts before applying it even when stash warnings are turned off",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().Gui.SkipStashWarning = true
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "line\n")
+		shell.CreateFileAndAdd("other", "a\n")
+		shell.Commit("initial commit")
+		shell.UpdateFile("other", "b\n")
+		shell.Stash("clean stash")
+		shell.UpdateFile("file", "stashed\n")
+		shell.Stash("conflicting stash")
+		shell.UpdateFileAndAdd("file", "committed\n")
+		shell.Commit("change file")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Stash().
+			Focus().
+			Lines(
+				Contains("On master: conflicting stash").Contains("(based on master@").Contains(", 1 commit(s) behind HEAD)").IsSelected(),
+				// only counted once selected
+				Contains("On master: clean stash").DoesNotContain("based on"),
+			)
+
+		t.Views().Main().
+			Content(Contains("based on master@").Contains(", 1 commit(s) behind HEAD"))
+
+		t.Views().Stash().
+			NavigateToLine(Contains("clean stash")).
+			Lines(
+				Contains("On master: conflicting stash").Contains(", 1 commit(s) behind HEAD)"),
+				Contains("On master: clean stash").Contains("(based on master@").Contains(", 1 commit(s) behind HEAD)").IsSelected(),
+			).
+			NavigateToLine(Contains("conflicting stash"))
+
+		t.Views().Stash().
+			PressPrimaryAction().
+			Tap(func() {
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Stash apply")).
+					Content(
+						Contains("Applying this stash entry now would cause conflicts in:\n  file").
+							Contains("Are you sure you want to apply this stash entry?"),
+					).
+					Cancel()
+			})
+
+		t.Views().Files().
+			IsEmpty()
+
+		// no confirmation because there are no conflicts and warnings are off
+		t.Views().Stash().
+			NavigateToLine(Contains("clean stash")).
+			PressPrimaryAction()
+
+		t.Views().Files().
+			Lines(
+				Contains("other"),
+			)
+	},
+})
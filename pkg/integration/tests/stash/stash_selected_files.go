@@ -0,0 +1,89 @@
+package stash
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var StashSelectedFiles = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Stash only the files selected in the files panel, including an untracked one",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file-a", "content")
+		shell.CreateFileAndAdd("file-b", "content")
+		shell.CreateFileAndAdd("file-c", "content")
+		shell.EmptyCommit("initial commit")
+		shell.UpdateFileAndAdd("file-a", "new content")
+		shell.UpdateFile("file-b", "new content")
+		shell.UpdateFile("file-c", "new content")
+		shell.CreateFile("file-d", "untracked content")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Stash().
+			IsEmpty()
+
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("  M  file-a"),
+				Equals("   M file-b"),
+				Equals("   M file-c"),
+				Equals("  ?? file-d"),
+			).
+			NavigateToLine(Contains("file-a")).
+			Press(keys.Universal.ToggleRangeSelect).
+			NavigateToLine(Contains("file-b")).
+			Press(keys.Files.ViewStashOptions)
+
+		t.ExpectPopup().Menu().Title(Equals("Stash options")).Select(Contains("Stash selected files")).Confirm()
+
+		t.ExpectPopup().Prompt().Title(Equals("Stash changes")).Type("two files").Confirm()
+
+		t.Views().Stash().
+			Lines(
+				Contains("two files"),
+			)
+
+		t.Views().Files().
+			Lines(
+				Equals("▼ /"),
+				Equals("   M file-c"),
+				Equals("  ?? file-d"),
+			).
+			NavigateToLine(Contains("file-d")).
+			Press(keys.Files.ViewStashOptions)
+
+		t.ExpectPopup().Menu().Title(Equals("Stash options")).Select(Contains("Stash selected files")).Confirm()
+
+		t.ExpectPopup().Prompt().Title(Equals("Stash changes")).Type("untracked file").Confirm()
+
+		t.Views().Stash().
+			Lines(
+				Contains("untracked file"),
+				Contains("two files"),
+			)
+
+		t.Views().Files().
+			Lines(
+				Equals(" M file-c"),
+			)
+
+		t.FileSystem().PathNotPresent("file-d")
+
+		t.Views().Stash().
+			Focus().
+			NavigateToLine(Contains("two files")).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Equals("▼ /"),
+				Contains("file-a"),
+				Contains("file-b"),
+			)
+	},
+})
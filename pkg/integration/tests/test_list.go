@@ -16,6 +16,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/file"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/filter_and_search"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/filter_by_author"
+	"github.com/jesseduffield/lazygit/pkg/integration/tests/filter_by_content"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/filter_by_path"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/interactive_rebase"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/misc"
@@ -42,6 +43,9 @@ var tests = []*components.IntegrationTest{
 	branch.CheckoutAutostash,
 	branch.CheckoutByName,
 	branch.CheckoutPreviousBranch,
+	branch.CheckoutPullRequest,
+	branch.CheckoutPullRequestDivergedLocalBranch,
+	branch.CheckoutPullRequestFromFork,
 	branch.CreateTag,
 	branch.Delete,
 	branch.DeleteMultiple,
@@ -98,6 +102,8 @@ var tests = []*components.IntegrationTest{
 	cherry_pick.CherryPickMerge,
 	cherry_pick.CherryPickRange,
 	cherry_pick.CherryPickRangeAfterPaste,
+	commit.AbsorbStagedChanges,
+	commit.AbsorbStagedChangesAndSquash,
 	commit.AddCoAuthor,
 	commit.AddCoAuthorRange,
 	commit.AddCoAuthorWhileCommitting,
@@ -106,6 +112,7 @@ var tests = []*components.IntegrationTest{
 	commit.AmendWhenThereAreConflictsAndCancel,
 	commit.AmendWhenThereAreConflictsAndContinue,
 	commit.AutoWrapMessage,
+	commit.BlameCommitFile,
 	commit.Checkout,
 	commit.CheckoutFileFromCommit,
 	commit.CheckoutFileFromRangeSelectionOfCommits,
@@ -130,6 +137,7 @@ var tests = []*components.IntegrationTest{
 	commit.DiscardOldFileChanges,
 	commit.DiscardSubmoduleChanges,
 	commit.DoNotShowBranchMarkerForHeadCommit,
+	commit.ExportPatchSeries,
 	commit.FailHooksThenCommitNoHooks,
 	commit.FindBaseCommitForFixup,
 	commit.FindBaseCommitForFixupDisregardFixupsForSameBaseCommit,
@@ -140,6 +148,7 @@ var tests = []*components.IntegrationTest{
 	commit.History,
 	commit.HistoryComplex,
 	commit.NewBranch,
+	commit.Notes,
 	commit.PasteCommitMessage,
 	commit.PasteCommitMessageOverExisting,
 	commit.PreserveCommitMessage,
@@ -154,6 +163,7 @@ var tests = []*components.IntegrationTest{
 	commit.Search,
 	commit.SetAuthor,
 	commit.SetAuthorRange,
+	commit.SignatureStatus,
 	commit.StageRangeOfLines,
 	commit.Staged,
 	commit.StagedWithoutHooks,
@@ -161,15 +171,21 @@ var tests = []*components.IntegrationTest{
 	config.CustomCommandsInPerRepoConfig,
 	config.NegativeRefspec,
 	config.RemoteNamedStar,
+	conflicts.ApplyMailboxWithConflicts,
+	conflicts.CustomMarkerSize,
 	conflicts.Filter,
 	conflicts.MergeFileBoth,
 	conflicts.MergeFileCurrent,
 	conflicts.MergeFileIncoming,
+	conflicts.RerereAutoStage,
+	conflicts.RerereResolved,
 	conflicts.ResolveExternally,
 	conflicts.ResolveMultipleFiles,
 	conflicts.ResolveNoAutoStage,
 	conflicts.ResolveNonTextualConflicts,
 	conflicts.ResolveWithoutTrailingLf,
+	conflicts.ThreeWayMergeEditor,
+	conflicts.ThreeWayMergeEditorAddedOnBothSides,
 	conflicts.UndoChooseHunk,
 	custom_commands.AccessCommitProperties,
 	custom_commands.BasicCommand,
@@ -216,7 +232,9 @@ var tests = []*components.IntegrationTest{
 	diff.DiffCommits,
 	diff.DiffNonStickyRange,
 	diff.IgnoreWhitespace,
+	diff.RangeDiff,
 	diff.RenameSimilarityThresholdChange,
+	file.Blame,
 	file.ClickArrowToCollapse,
 	file.CollapseExpand,
 	file.CopyMenu,
@@ -234,10 +252,14 @@ var tests = []*components.IntegrationTest{
 	file.ExcludeWithoutInfoDir,
 	file.Gitignore,
 	file.GitignoreSpecialCharacters,
+	file.LazyUntrackedFiles,
+	file.LfsLocks,
+	file.LfsPointerDiff,
 	file.RememberCommitMessageAfterFail,
 	file.RenameSimilarityThresholdChange,
 	file.RenamedFiles,
 	file.RenamedFilesNoRootItem,
+	file.SparseCheckout,
 	file.StageChildrenRangeSelect,
 	file.StageDeletedRangeSelect,
 	file.StageRangeSelect,
@@ -264,6 +286,7 @@ var tests = []*components.IntegrationTest{
 	filter_and_search.StagingFolderStagesOnlyTrackedFilesInTrackedOnlyFilter,
 	filter_by_author.SelectAuthor,
 	filter_by_author.TypeAuthor,
+	filter_by_content.PickaxeAndMessage,
 	filter_by_path.CliArg,
 	filter_by_path.DropCommitInFilteringMode,
 	filter_by_path.KeepSameCommitSelectedOnExit,
@@ -311,6 +334,7 @@ var tests = []*components.IntegrationTest{
 	interactive_rebase.QuickStartKeepSelection,
 	interactive_rebase.QuickStartKeepSelectionRange,
 	interactive_rebase.Rebase,
+	interactive_rebase.RebaseSubcommand,
 	interactive_rebase.RebaseWithCommitThatBecomesEmpty,
 	interactive_rebase.RevertDuringRebaseWhenStoppedOnEdit,
 	interactive_rebase.RevertMultipleCommitsInInteractiveRebase,
@@ -338,6 +362,7 @@ var tests = []*components.IntegrationTest{
 	misc.DirenvApprovesEnvrc,
 	misc.DirenvLoadedOnRepoSwitch,
 	misc.DirenvUnloadsOnBlockedEnvrc,
+	misc.ExportSessionLog,
 	misc.InitialOpen,
 	misc.RecentReposOnLaunch,
 	patch_building.Apply,
@@ -347,6 +372,7 @@ var tests = []*components.IntegrationTest{
 	patch_building.ApplyWithModifiedFileNoConflict,
 	patch_building.DiscardLinesFromCommit,
 	patch_building.EditLineInPatchBuildingPanel,
+	patch_building.LineHistory,
 	patch_building.MoveRangeToIndex,
 	patch_building.MoveToEarlierCommit,
 	patch_building.MoveToEarlierCommitFromAddedFile,
@@ -388,6 +414,7 @@ var tests = []*components.IntegrationTest{
 	staging.DiffChangeScreenMode,
 	staging.DiffContextChange,
 	staging.DiscardAllChanges,
+	staging.LineHistory,
 	staging.Search,
 	staging.SelectNextLineAfterStagingInTwoHunkDiff,
 	staging.SelectNextLineAfterStagingIsolatedAddedLine,
@@ -397,6 +424,7 @@ var tests = []*components.IntegrationTest{
 	staging.StagePartialBlockOfChangesLastLines,
 	staging.StagePartialBlockOfChangesMiddleLines,
 	staging.StageRanges,
+	staging.StashSelectedLines,
 	stash.Apply,
 	stash.ApplyPatch,
 	stash.CreateBranch,
@@ -405,6 +433,7 @@ var tests = []*components.IntegrationTest{
 	stash.DropMultipleInFilteredMode,
 	stash.FilterByPath,
 	stash.Pop,
+	stash.PredictConflicts,
 	stash.PreventDiscardingFileChanges,
 	stash.Rename,
 	stash.ShowWithBranchNamedStash,
@@ -412,6 +441,7 @@ var tests = []*components.IntegrationTest{
 	stash.StashAll,
 	stash.StashAndKeepIndex,
 	stash.StashIncludingUntrackedFiles,
+	stash.StashSelectedFiles,
 	stash.StashStaged,
 	stash.StashStagedPartialFile,
 	stash.StashUnstaged,
@@ -477,11 +507,14 @@ var tests = []*components.IntegrationTest{
 	ui.KeybindingSuggestionsWhenSwitchingRepos,
 	ui.ModeSpecificKeybindingSuggestions,
 	ui.OpenLinkFailure,
+	ui.PullRequestsPanel,
+	ui.PullRequestsWithoutGithubRemote,
 	ui.RangeSelect,
 	ui.SwitchTabFromMenu,
 	ui.SwitchTabWithPanelJumpKeys,
 	undo.UndoCheckoutAndDrop,
 	undo.UndoCommit,
+	undo.UndoDiscardAndStashDrop,
 	undo.UndoDrop,
 	worktree.AddFromBranch,
 	worktree.AddFromBranchDetached,
@@ -500,6 +533,7 @@ var tests = []*components.IntegrationTest{
 	worktree.FastForwardWorktreeBranchShouldNotPolluteCurrentWorktree,
 	worktree.ForceRemoveWorktree,
 	worktree.ForceRemoveWorktreeWithSubmodules,
+	worktree.LockMoveAndPrune,
 	worktree.RemoveWorktreeFromBranch,
 	worktree.ResetWindowTabs,
 	worktree.SymlinkIntoRepoSubdir,
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var PullRequestsPanel = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Show the open pull requests of a GitHub repo and their details",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+		shell.CloneIntoRemote("origin")
+		shell.MakeRemoteLookLikeGitHub("origin", "owner/repo")
+	},
+	GitHubPullRequests: []git_commands.PullRequestNode{
+		{
+			Number:         1,
+			Title:          "Add feature",
+			HeadRefName:    "feature",
+			BaseRefName:    "master",
+			State:          "OPEN",
+			Body:           "This adds the feature.",
+			Author:         git_commands.GithubRepositoryOwner{Login: "alice"},
+			ReviewDecision: "APPROVED",
+		},
+		{
+			Number:            2,
+			Title:             "Fix bug",
+			HeadRefName:       "fix",
+			BaseRefName:       "master",
+			State:             "OPEN",
+			Author:            git_commands.GithubRepositoryOwner{Login: "bob"},
+			IsCrossRepository: true,
+		},
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().PullRequests().
+			Focus().
+			Lines(
+				Contains("#1").Contains("Add feature").Contains("alice").Contains("Approved").IsSelected(),
+				Contains("#2").Contains("Fix bug").Contains("bob"),
+			)
+
+		t.Views().Main().
+			Content(Contains("Add feature #1")).
+			Content(Contains("feature → master")).
+			Content(Contains("This adds the feature."))
+
+		t.Views().PullRequests().
+			NavigateToLine(Contains("#2"))
+
+		t.Views().Main().
+			Content(Contains("Fix bug #2")).
+			Content(Contains("No description provided"))
+	},
+})
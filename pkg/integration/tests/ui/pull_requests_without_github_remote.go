@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var PullRequestsWithoutGithubRemote = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Show an empty pull requests panel when the repo has no GitHub remote",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().PullRequests().
+			Focus().
+			IsEmpty()
+
+		t.Views().Main().Content(Contains("No open pull requests"))
+	},
+})
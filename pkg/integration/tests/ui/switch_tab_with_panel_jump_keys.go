@@ -27,6 +27,9 @@ var SwitchTabWithPanelJumpKeys = NewIntegrationTest(NewIntegrationTestArgs{
 		t.Views().Tags().IsFocused().
 			Press(keys.Universal.JumpToBlock[2])
 
+		t.Views().PullRequests().IsFocused().
+			Press(keys.Universal.JumpToBlock[2])
+
 		t.Views().Branches().IsFocused().
 			Press(keys.Universal.JumpToBlock[1])
 
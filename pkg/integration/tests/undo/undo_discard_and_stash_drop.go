@@ -0,0 +1,119 @@
+package undo
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var UndoDiscardAndStashDrop = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Discard changes to a file and drop a stash entry, then undo/redo both actions after a gc",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "original\n")
+		shell.Commit("one")
+		shell.CreateFileAndAdd("stashed", "stashed content\n")
+		shell.Stash("stash one")
+		shell.UpdateFile("file", "modified\n")
+		shell.CreateFile("untracked", "untracked content\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		confirmUndo := func(action string) {
+			t.ExpectPopup().Confirmation().
+				Title(Equals("Undo")).
+				Content(Contains("Are you sure you want to undo '" + action + "'?")).
+				Confirm()
+		}
+
+		confirmRedo := func(action string) {
+			t.ExpectPopup().Confirmation().
+				Title(Equals("Redo")).
+				Content(Contains("Are you sure you want to redo '" + action + "'?")).
+				Confirm()
+		}
+
+		t.Views().Files().
+			Focus().
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("   M file"),
+				Equals("  ?? untracked"),
+			).
+			Press(keys.Universal.Remove).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Discard changes")).
+					Select(Contains("Discard all changes")).
+					Confirm()
+			}).
+			IsEmpty()
+
+		t.FileSystem().
+			FileContent("file", Equals("original\n")).
+			PathNotPresent("untracked")
+
+		t.Views().Stash().
+			Focus().
+			Lines(
+				Contains("stash one").IsSelected(),
+			).
+			Press(keys.Universal.Remove).
+			Tap(func() {
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Stash drop")).
+					Content(Contains("Are you sure you want to drop the selected stash entry(ies)?")).
+					Confirm()
+			}).
+			IsEmpty().
+			Tap(func() {
+				// the discarded contents and the dropped stash are only kept
+				// alive by the journal, so they must survive a gc
+				t.Shell().RunCommand([]string{"git", "gc", "--prune=now", "--quiet"})
+			}).
+			Press(keys.Universal.Undo).
+			Tap(func() { confirmUndo("Drop stash") }).
+			Lines(
+				Contains("stash one"),
+			).
+			Press(keys.Universal.Undo).
+			Tap(func() { confirmUndo("Discard all changes in selected file(s)") })
+
+		t.Views().Files().
+			Lines(
+				Equals("▼ /"),
+				Equals("   M file"),
+				Equals("  ?? untracked"),
+			)
+
+		t.FileSystem().
+			FileContent("file", Equals("modified\n")).
+			FileContent("untracked", Equals("untracked content\n"))
+
+		// the next undo goes back to the reflog
+		t.Views().Stash().
+			Press(keys.Universal.Undo).
+			Tap(func() {
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Undo")).
+					Content(MatchesRegexp(`Are you sure you want to soft reset to '.*'\?`)).
+					Cancel()
+			}).
+			Press(keys.Universal.Redo).
+			Tap(func() { confirmRedo("Discard all changes in selected file(s)") })
+
+		t.Views().Files().IsEmpty()
+
+		t.FileSystem().
+			FileContent("file", Equals("original\n")).
+			PathNotPresent("untracked")
+
+		t.Views().Stash().
+			Lines(
+				Contains("stash one"),
+			).
+			Press(keys.Universal.Redo).
+			Tap(func() { confirmRedo("Drop stash") }).
+			IsEmpty()
+	},
+})
@@ -0,0 +1,130 @@
+package worktree
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var LockMoveAndPrune = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Lock, unlock and move a linked worktree, then prune a worktree whose directory was deleted",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.NewBranch("mybranch")
+		shell.CreateFileAndAdd("README.md", "hello world")
+		shell.Commit("initial commit")
+		shell.AddWorktree("mybranch", "../linked-worktree", "newbranch")
+		shell.AddWorktree("mybranch", "../doomed-worktree", "doomedbranch")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Worktrees().
+			Focus().
+			Lines(
+				Contains("repo").Contains("(main worktree)").IsSelected(),
+				Contains("doomed-worktree"),
+				Contains("linked-worktree"),
+			).
+			Press(keys.Worktrees.ViewWorktreeOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("repo")).
+					Select(Contains("Lock worktree")).
+					Confirm()
+
+				t.ExpectToast(Equals("Disabled: The main worktree cannot be locked"))
+				t.ExpectPopup().Menu().Title(Equals("repo")).Cancel()
+			}).
+			NavigateToLine(Contains("linked-worktree")).
+			Press(keys.Worktrees.ViewWorktreeOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("linked-worktree")).
+					Select(Contains("Lock worktree")).
+					Confirm()
+
+				t.ExpectPopup().Prompt().
+					Title(Equals("Lock reason (optional)")).
+					Type("lives on a usb drive").
+					Confirm()
+			}).
+			Lines(
+				Contains("repo").Contains("(main worktree)"),
+				Contains("doomed-worktree"),
+				Contains("linked-worktree").Contains("(locked)").IsSelected(),
+			)
+
+		t.Views().Main().
+			Content(Contains("Locked:").Contains("lives on a usb drive"))
+
+		t.Views().Worktrees().
+			Press(keys.Worktrees.ViewWorktreeOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("linked-worktree")).
+					Select(Contains("Move worktree")).
+					Confirm()
+
+				t.ExpectToast(Equals("Disabled: Unlock the worktree before moving it"))
+
+				t.ExpectPopup().Menu().
+					Title(Equals("linked-worktree")).
+					Select(Contains("Unlock worktree")).
+					Confirm()
+			}).
+			Lines(
+				Contains("repo").Contains("(main worktree)"),
+				Contains("doomed-worktree"),
+				Contains("linked-worktree").DoesNotContain("(locked)").IsSelected(),
+			).
+			Press(keys.Worktrees.ViewWorktreeOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("linked-worktree")).
+					Select(Contains("Move worktree")).
+					Confirm()
+
+				t.ExpectPopup().Prompt().
+					Title(Equals("New path for worktree 'linked-worktree'")).
+					InitialText(Contains("linked-worktree")).
+					Clear().
+					Type("../moved-worktree").
+					Confirm()
+			}).
+			Lines(
+				Contains("repo").Contains("(main worktree)"),
+				Contains("doomed-worktree"),
+				Contains("moved-worktree"),
+			)
+
+		t.FileSystem().
+			PathPresent("../moved-worktree/README.md").
+			PathNotPresent("../linked-worktree")
+
+		t.Shell().DeleteFile("../doomed-worktree")
+
+		t.Views().Worktrees().
+			Press(keys.Universal.Refresh).
+			Lines(
+				Contains("repo").Contains("(main worktree)"),
+				Contains("doomed-worktree").Contains("(prunable)"),
+				Contains("moved-worktree"),
+			).
+			Press(keys.Worktrees.ViewWorktreeOptions).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Contains("worktree")).
+					Select(Contains("Prune worktrees")).
+					Confirm()
+
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Prune worktrees")).
+					Content(Contains("Locked worktrees will be kept.")).
+					Confirm()
+			}).
+			Lines(
+				Contains("repo").Contains("(main worktree)"),
+				Contains("moved-worktree"),
+			)
+	},
+})
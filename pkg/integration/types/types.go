@@ -18,6 +18,9 @@ type IntegrationTest interface {
 	HeadlessDimensions() (int, int)
 	// If true, we are recording/replaying a demo
 	IsDemo() bool
+	// URL of a fake GitHub API to send requests to instead of the real one;
+	// empty if the test doesn't use it
+	GitHubAPIURL() string
 }
 
 // this is the interface through which our integration tests interact with the lazygit gui
@@ -252,6 +252,24 @@ func TestRenderDisplayStrings(t *testing.T) {
 	}
 }
 
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		size     int64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GiB"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, FormatBytes(test.size))
+	}
+}
+
 func BenchmarkStringWidthAsciiOriginal(b *testing.B) {
 	for b.Loop() {
 		uniseg.StringWidth("some ASCII string")